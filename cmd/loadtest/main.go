@@ -0,0 +1,128 @@
+// Command loadtest replays a distribution of rates requests against the hub,
+// with the configurations pointing at a mock supplier, and reports latency
+// percentiles, grouping roles and supplier call volume. Grouping roles are
+// only reported by hubs in test mode or with trafficlight.exposeRole.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/service-helpers/logger"
+	"bitbucket.org/crgw/supplier-hub/internal/loadtest"
+	"bitbucket.org/crgw/supplier-hub/internal/mocksupplier"
+)
+
+func main() {
+	hubUrl := flag.String("hub", "http://localhost:6156", "hub url")
+	supplierUrl := flag.String("supplier", "http://localhost:8090", "mock supplier url, empty skips supplier call volume")
+	embed := flag.Bool("embed", false, "serve the mock supplier in process on the address of -supplier")
+	fixtures := flag.String("fixtures", "internal/platform/implementations", "directory holding the platform testdata, with -embed")
+	active := flag.String("active", "", "comma separated mock scenarios of -scenarios, with -embed")
+	scenariosPath := flag.String("scenarios", "cmd/mocksupplier/scenarios.yaml", "YAML file with mock scenarios, with -embed")
+	distributionPath := flag.String("distribution", "cmd/loadtest/distribution.yaml", "YAML file with the requests to replay")
+	concurrency := flag.Int("concurrency", 10, "parallel requests")
+	requests := flag.Int("requests", 1000, "requests to send, 0 sends until -duration is over")
+	duration := flag.Duration("duration", 0, "maximum duration of the run")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout of a single request")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed of the request order")
+	asJson := flag.Bool("json", false, "print the report as JSON")
+	logLevel := flag.String("log-level", "info", "log level")
+	flag.Parse()
+
+	log := logger.New(*logLevel)
+
+	distribution, err := loadtest.LoadDistribution(*distributionPath)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid distribution")
+		os.Exit(1)
+	}
+
+	if *embed {
+		scenarios, err := mocksupplier.LoadScenarios(*scenariosPath)
+		if err != nil {
+			log.Error().Err(err).Msg("Invalid scenarios")
+			os.Exit(1)
+		}
+
+		if *active != "" {
+			scenarios.Active = strings.Split(*active, ",")
+		}
+
+		err = serveSupplier(*supplierUrl, mocksupplier.Options{Fixtures: *fixtures, Scenarios: scenarios, Logger: log})
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to start mock supplier")
+			os.Exit(1)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Info().Int("concurrency", *concurrency).Int("requests", *requests).Dur("duration", *duration).Msg("Load test against " + *hubUrl)
+
+	report, err := loadtest.Run(ctx, distribution, loadtest.Options{
+		HubUrl:      *hubUrl,
+		SupplierUrl: *supplierUrl,
+		Concurrency: *concurrency,
+		Requests:    *requests,
+		Duration:    *duration,
+		Seed:        *seed,
+		Client: &http.Client{
+			Timeout: *timeout,
+			Transport: &http.Transport{
+				MaxIdleConnsPerHost: *concurrency,
+			},
+		},
+	})
+	if report == nil {
+		log.Error().Err(err).Msg("Load test failed")
+		os.Exit(1)
+	}
+
+	if err != nil {
+		log.Warn().Err(err).Msg("Load test finished without supplier call volume")
+	}
+
+	if *asJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "\t")
+		_ = encoder.Encode(report)
+		return
+	}
+
+	report.Print(os.Stdout)
+}
+
+// serveSupplier listens before returning, so the run does not race the mock
+func serveSupplier(supplierUrl string, o mocksupplier.Options) error {
+	parsed, err := url.Parse(supplierUrl)
+	if err != nil {
+		return err
+	}
+
+	server, err := mocksupplier.New(o)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", parsed.Host)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := http.Serve(listener, server.Handler())
+		o.Logger.Error().Err(err).Msg("Mock supplier failed")
+	}()
+
+	return nil
+}
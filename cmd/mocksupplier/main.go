@@ -0,0 +1,58 @@
+// Command mocksupplier serves simulated supplier APIs from the platform
+// fixtures, point supplierApiUrl of a configuration at http://<addr>/<platform>.
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+	"strings"
+
+	"bitbucket.org/crgw/service-helpers/logger"
+	"bitbucket.org/crgw/supplier-hub/internal/mocksupplier"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "listen address")
+	fixtures := flag.String("fixtures", "internal/platform/implementations", "directory holding the platform testdata")
+	scenariosPath := flag.String("scenarios", "", "YAML file with scenarios")
+	active := flag.String("active", "", "comma separated scenarios applied to all requests")
+	logLevel := flag.String("log-level", "info", "log level")
+	flag.Parse()
+
+	log := logger.New(*logLevel)
+
+	var scenarios mocksupplier.Scenarios
+
+	if *scenariosPath != "" {
+		var err error
+
+		scenarios, err = mocksupplier.LoadScenarios(*scenariosPath)
+		if err != nil {
+			log.Error().Err(err).Msg("Invalid scenarios")
+			os.Exit(1)
+		}
+	}
+
+	if *active != "" {
+		scenarios.Active = strings.Split(*active, ",")
+	}
+
+	server, err := mocksupplier.New(mocksupplier.Options{
+		Fixtures:  *fixtures,
+		Scenarios: scenarios,
+		Logger:    log,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to start mock supplier")
+		os.Exit(1)
+	}
+
+	log.Info().Strs("platforms", mocksupplier.Platforms()).Msg("Mock supplier listening on address " + *addr)
+
+	err = http.ListenAndServe(*addr, server.Handler())
+	if err != nil {
+		log.Error().Err(err).Msg("Mock supplier failed")
+		os.Exit(1)
+	}
+}
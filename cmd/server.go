@@ -4,68 +4,249 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"bitbucket.org/crgw/service-helpers/logger"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/schema/har"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"bitbucket.org/crgw/supplier-hub/internal/trafficlight/grouping"
 	"bitbucket.org/crgw/supplier-hub/internal/web"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 )
 
-func serverApp(httpServer *http.Server, logger *zerolog.Logger) int {
-	shutdown := false
+const releaseLocksTimeout = 2 * time.Second
+
+// shutdown fails readiness, stops accepting requests and waits for in-flight work.
+// Work still running at the deadline is logged, the background workers are
+// stopped and grouping locks are released.
+func shutdown(
+	httpServer *http.Server,
+	tracker *lifecycle.Tracker,
+	stopWorkers context.CancelFunc,
+	redisFactory *redisfactory.Factory,
+	o config.Shutdown,
+	logger *zerolog.Logger,
+) {
+	tracker.Drain()
+	logger.Info().Msg("Shutting down server, readiness failed")
+
+	time.Sleep(o.ReadinessDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+
+	err := httpServer.Shutdown(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Server did not shut down within the deadline")
+	}
+
+	for _, operation := range tracker.Wait(ctx) {
+		logger.
+			Warn().
+			Str("operation", operation.Name).
+			Str("platform", operation.Platform).
+			Str("correlationId", operation.CorrelationId).
+			Bool("background", operation.Background).
+			Float64("duration", time.Since(operation.Started).Seconds()).
+			Msg("In-flight operation cut off by shutdown")
+	}
+
+	stopWorkers()
+
+	locksCtx, cancelLocks := context.WithTimeout(context.Background(), releaseLocksTimeout)
+	defer cancelLocks()
+
+	var keys []string
+
+	trafficlightClient, err := redisFactory.TrafficlightClient()
+	if err == nil {
+		keys, err = grouping.ReleaseHeldLocks(locksCtx, trafficlightClient)
+	}
+
+	if err != nil {
+		logger.Warn().Err(err).Strs("keys", keys).Msg("Unable to release grouping locks")
+	} else if len(keys) > 0 {
+		logger.Info().Strs("keys", keys).Msg("Released grouping locks")
+	}
+
+	_ = redisFactory.Close()
+}
+
+func serverApp(httpServer *http.Server, stopServer func(), logger *zerolog.Logger) int {
 	done := make(chan error, 1)
-	stop := make(chan os.Signal)
+	stopped := make(chan struct{})
+	stop := make(chan os.Signal, 1)
+
 	go func() {
 		logger.
 			Info().
 			Msg("Listening on address " + httpServer.Addr)
 		done <- httpServer.ListenAndServe()
 	}()
-	go func() {
-		// Wait for stop
-		<-stop
-		shutdown = true
-		logger.Info().Msg("Shutting down server...")
-		_ = httpServer.Shutdown(context.Background())
-	}()
 
 	// Notify stop channel if SIGINT or SIGTERM is received
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
-	err := <-done
-	if err != nil && !shutdown {
+	select {
+	case err := <-done:
 		logger.
 			Error().
 			Err(err).
 			Msg("Server failed")
 		return 1
+	case <-stop:
+	}
+
+	go func() {
+		stopServer()
+		close(stopped)
+	}()
+
+	// a second signal skips waiting
+	select {
+	case <-stopped:
+		logger.Info().Msg("Server stopped")
+	case <-stop:
+		logger.Warn().Msg("Forced shutdown")
 	}
+
+	return 0
+}
+
+// checkRedis reports unreachable redis clients at startup, the service runs
+// degraded without them unless redis is required
+func checkRedis(redisFactory *redisfactory.Factory, required bool, logger *zerolog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	failures := redisFactory.Check(ctx)
+	for name, err := range failures {
+		logger.Warn().Err(err).Str("client", name).Msg("Redis is not reachable, running degraded")
+	}
+
+	if len(failures) > 0 && required {
+		logger.Error().Msg("Redis is required, exiting")
+		os.Exit(1)
+	}
+}
+
+// harCommand converts a platform response, an offloaded history or a
+// supplierRequests array from a file or stdin to HAR on stdout
+func harCommand(args []string) int {
+	input := os.Stdin
+
+	if len(args) > 0 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		defer file.Close()
+		input = file
+	}
+
+	content, err := io.ReadAll(input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	requests, err := har.Parse(content)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	err = encoder.Encode(har.FromSupplierRequests(requests))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
 	return 0
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "har" {
+		os.Exit(harCommand(os.Args[2:]))
+	}
+
 	_ = godotenv.Load(".env")
-	log := logger.New(os.Getenv("LOG_LEVEL"))
 
-	redisFactory := redisfactory.New()
+	cfg, err := config.Load()
+	if err != nil {
+		logger.New("").Error().Err(err).Msg("Invalid configuration")
+		os.Exit(1)
+	}
+
+	log := logger.New(cfg.LogLevel)
+
+	redisFactory, err := redisfactory.New(cfg.Redis.ClientOptions())
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid redis configuration")
+		os.Exit(1)
+	}
+
+	checkRedis(redisFactory, cfg.Redis.Required, log)
 
-	appRouter := web.SetupRouter(log, redisFactory)
+	tracker := lifecycle.NewTracker()
+
+	appRouter, adminRouter, workers, err := web.SetupRouter(log, cfg, redisFactory, tracker)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid server setup")
+		os.Exit(1)
+	}
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	workers.Start(workersCtx)
 
 	var host string
-	if os.Getenv("TEST") == "true" {
+	if cfg.Test {
 		host = "localhost"
 	}
 
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf("%s:%s", host, os.Getenv("PORT")),
+		Addr:    fmt.Sprintf("%s:%d", host, cfg.Server.Port),
 		Handler: appRouter,
 	}
 
-	os.Exit(serverApp(httpServer, log))
+	var adminServer *http.Server
+	if adminRouter != nil {
+		adminServer = &http.Server{
+			Addr:    fmt.Sprintf("%s:%d", host, cfg.Server.AdminPort),
+			Handler: adminRouter,
+		}
+
+		go func() {
+			log.Info().Msg("Admin listening on address " + adminServer.Addr)
+
+			err := adminServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error().Err(err).Msg("Admin server failed")
+			}
+		}()
+	}
+
+	os.Exit(serverApp(httpServer, func() {
+		shutdown(httpServer, tracker, stopWorkers, redisFactory, cfg.Shutdown, log)
+
+		if adminServer != nil {
+			_ = adminServer.Close()
+		}
+	}, log))
 }
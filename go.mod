@@ -4,20 +4,28 @@ go 1.19
 
 require (
 	bitbucket.org/crgw/service-helpers v1.0.3
+	github.com/alicebob/miniredis/v2 v2.30.5
 	github.com/getkin/kin-openapi v0.117.0
 	github.com/gin-contrib/pprof v1.4.0
 	github.com/gin-gonic/gin v1.9.1
 	github.com/go-redis/redismock/v9 v9.0.3
+	github.com/golang-jwt/jwt/v5 v5.0.0
 	github.com/google/go-querystring v1.1.0
 	github.com/google/uuid v1.3.1
 	github.com/joho/godotenv v1.5.1
+	github.com/klauspost/compress v1.16.7
 	github.com/oapi-codegen/runtime v1.0.0
 	github.com/redis/go-redis/v9 v9.0.4
 	github.com/rs/zerolog v1.29.0
 	github.com/stretchr/testify v1.8.4
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require github.com/chenzhuoyu/iasm v0.9.0 // indirect
+require (
+	github.com/alicebob/gopher-json v0.0.0-20200520072559-a9ecdc9d1d3a // indirect
+	github.com/chenzhuoyu/iasm v0.9.0 // indirect
+	github.com/yuin/gopher-lua v1.1.0 // indirect
+)
 
 require (
 	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
@@ -59,5 +67,4 @@ require (
 	golang.org/x/text v0.12.0 // indirect
 	google.golang.org/protobuf v1.31.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
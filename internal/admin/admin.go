@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/audit"
+	"bitbucket.org/crgw/supplier-hub/internal/cancelretry"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/rebook"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ApiKeyHeader string = "x-admin-api-key"
+)
+
+var (
+	errorAdminDisabled = errors.New("admin api key is not configured")
+	errorInvalidApiKey = errors.New("invalid admin api key")
+)
+
+// Authenticate rejects requests without a valid admin api key.
+// When no key is configured the admin routes are disabled.
+func Authenticate(apiKey string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if apiKey == "" {
+			middleware.HandleError(ctx, http.StatusForbidden, "Admin routes are disabled", errorAdminDisabled)
+			ctx.Abort()
+			return
+		}
+
+		given := ctx.GetHeader(ApiKeyHeader)
+
+		if subtle.ConstantTimeCompare([]byte(given), []byte(apiKey)) != 1 {
+			middleware.HandleError(ctx, http.StatusUnauthorized, "Invalid admin api key", errorInvalidApiKey)
+			ctx.Abort()
+			return
+		}
+	}
+}
+
+// Options hold what the admin routes serve, the routes of a nil store are
+// not registered
+type Options struct {
+	// ApiKey protects the admin routes, they are disabled when empty
+	ApiKey       string
+	RedisFactory *redisfactory.Factory
+	Credentials  *credentials.Store
+	Rebook       *rebook.Store
+	CancelRetry  *cancelretry.Store
+	Audit        *audit.Log
+}
+
+func RegisterRoutes(router *gin.Engine, o Options) {
+	group := router.Group(
+		"/admin",
+		Authenticate(o.ApiKey),
+	)
+
+	registerCacheRoutes(group, o.RedisFactory)
+
+	// stored credentials are disabled without a configured backend
+	if o.Credentials != nil {
+		registerCredentialsRoutes(group, o.Credentials)
+	}
+
+	// rebooking is disabled without a store
+	if o.Rebook != nil {
+		registerRebookRoutes(group, o.Rebook)
+	}
+
+	// cancel retries are disabled without a store
+	if o.CancelRetry != nil {
+		registerCancelRetryRoutes(group, o.CancelRetry)
+	}
+
+	// the audit log is disabled without a backend
+	if o.Audit != nil {
+		registerAuditRoutes(group, o.Audit)
+	}
+}
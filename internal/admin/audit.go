@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// registerAuditRoutes looks up audit records by the reservation number or
+// the supplier booking reference
+func registerAuditRoutes(group *gin.RouterGroup, auditLog *audit.Log) {
+	group.GET("/audit", func(ctx *gin.Context) {
+		records, err := auditLog.Find(ctx.Request.Context(), audit.Query{
+			ReservNumber:             ctx.Query("reservNumber"),
+			SupplierBookingReference: ctx.Query("supplierBookingReference"),
+		})
+
+		switch {
+		case err == nil:
+			ctx.JSON(http.StatusOK, records)
+		case errors.Is(err, audit.ErrorEmptyQuery):
+			middleware.HandleError(ctx, http.StatusBadRequest, err.Error(), err)
+		default:
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed searching the audit log", err)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/admin"
+	"bitbucket.org/crgw/supplier-hub/internal/audit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	backend, err := audit.NewFileBackend(t.TempDir(), 1<<20)
+	assert.Nil(t, err)
+
+	auditLog := audit.NewLog(backend)
+
+	router := gin.New()
+	admin.RegisterRoutes(router, admin.Options{ApiKey: testApiKey, Audit: auditLog})
+
+	auditLog.Append(context.Background(), audit.Record{Platform: "hertz", Operation: "booking", ReservNumber: "R1", SupplierBookingReference: "S1"})
+	auditLog.Append(context.Background(), audit.Record{Platform: "hertz", Operation: "cancel", ReservNumber: "R1", SupplierBookingReference: "S1"})
+
+	t.Run("should find records by reservation number or supplier reference", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/audit?reservNumber=R1", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var records []audit.Record
+		json.Unmarshal(response.Body.Bytes(), &records)
+
+		assert.Len(t, records, 2)
+		assert.Equal(t, "cancel", records[1].Operation)
+
+		response = request(router, http.MethodGet, "/admin/audit?supplierBookingReference=UNKNOWN", testApiKey)
+		assert.Equal(t, "[]", response.Body.String())
+	})
+
+	t.Run("should require a reference", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/audit", testApiKey)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
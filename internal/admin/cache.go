@@ -0,0 +1,366 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultScanCount = 100
+	maxScanCount     = 1000
+	purgeBatchSize   = 500
+)
+
+var (
+	errorUnknownNamespace = errors.New("unknown cache namespace")
+	errorForeignKey       = errors.New("key does not belong to the namespace")
+	errorMissingPlatform  = errors.New("platform is required")
+	errorClusterListing   = errors.New("cursor based listing is not possible across cluster nodes")
+)
+
+// namespace describes a family of keys written by the hub.
+// The format contains a single %s placeholder for the platform.
+type namespace struct {
+	format string
+	client func(*redisfactory.Factory) (redis.UniversalClient, error)
+}
+
+var namespaces = map[string]namespace{
+	"extras": {
+		format: "supplier-%s:extras:",
+		client: (*redisfactory.Factory).ResponsesCacheClient,
+	},
+	"auth": {
+		format: "%s-auth-token:",
+		client: (*redisfactory.Factory).ResponsesCacheClient,
+	},
+	"grouping": {
+		format: "res:grouping:supplier-%s:",
+		client: (*redisfactory.Factory).TrafficlightClient,
+	},
+	"grouping-lock": {
+		format: "grouping:supplier-%s:",
+		client: (*redisfactory.Factory).TrafficlightClient,
+	},
+}
+
+// grouping keys do not always use the platform name from the path
+var keyPlatformNames = map[string]string{
+	"bookingcom": "booking-com",
+}
+
+func (n namespace) pattern(platform string, match string) string {
+	if platform == "" {
+		platform = "*"
+	}
+
+	if name, ok := keyPlatformNames[platform]; ok {
+		platform = name
+	}
+
+	if match == "" {
+		match = "*"
+	}
+
+	return fmt.Sprintf(n.format, platform) + match
+}
+
+func (n namespace) owns(key string) bool {
+	pieces := strings.SplitN(n.format, "%s", 2)
+
+	if !strings.HasPrefix(key, pieces[0]) {
+		return false
+	}
+
+	return strings.Contains(key[len(pieces[0]):], pieces[1])
+}
+
+type cacheKey struct {
+	Key string `json:"key"`
+	// Ttl in seconds, -1 when the key does not expire
+	Ttl float64 `json:"ttl"`
+}
+
+type cacheKeysResponse struct {
+	Keys   []cacheKey `json:"keys"`
+	Cursor uint64     `json:"cursor"`
+}
+
+type cacheValueResponse struct {
+	Key   string          `json:"key"`
+	Ttl   float64         `json:"ttl"`
+	Value json.RawMessage `json:"value"`
+}
+
+type cachePurgeResponse struct {
+	Pattern string `json:"pattern"`
+	Deleted int64  `json:"deleted"`
+}
+
+func ttlSeconds(ttl time.Duration) float64 {
+	if ttl < 0 {
+		return -1
+	}
+
+	return ttl.Seconds()
+}
+
+func scanCount(value string) int64 {
+	count, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || count <= 0 {
+		return defaultScanCount
+	}
+
+	if count > maxScanCount {
+		return maxScanCount
+	}
+
+	return count
+}
+
+func keysWithTtl(ctx context.Context, client redis.UniversalClient, keys []string) ([]cacheKey, error) {
+	pipe := client.Pipeline()
+
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		ttls[i] = pipe.TTL(ctx, key)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	result := make([]cacheKey, len(keys))
+	for i, key := range keys {
+		result[i] = cacheKey{
+			Key: key,
+			Ttl: ttlSeconds(ttls[i].Val()),
+		}
+	}
+
+	return result, nil
+}
+
+func purge(ctx context.Context, client redis.UniversalClient, pattern string) (int64, error) {
+	cluster, ok := client.(*redis.ClusterClient)
+	if !ok {
+		return purgeNode(ctx, client, pattern, false)
+	}
+
+	var deleted atomic.Int64
+
+	// scan is node local, every master has to be purged on its own
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		count, err := purgeNode(ctx, node, pattern, true)
+		deleted.Add(count)
+		return err
+	})
+
+	return deleted.Load(), err
+}
+
+// purgeNode unlinks keys one by one when they may belong to different cluster slots
+func purgeNode(ctx context.Context, client redis.UniversalClient, pattern string, perKey bool) (int64, error) {
+	var (
+		cursor  uint64
+		deleted int64
+	)
+
+	for {
+		keys, next, err := client.Scan(ctx, cursor, pattern, purgeBatchSize).Result()
+		if err != nil {
+			return deleted, err
+		}
+
+		if len(keys) > 0 {
+			count, err := unlink(ctx, client, keys, perKey)
+			if err != nil {
+				return deleted, err
+			}
+
+			deleted += count
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
+
+func unlink(ctx context.Context, client redis.UniversalClient, keys []string, perKey bool) (int64, error) {
+	if !perKey {
+		return client.Unlink(ctx, keys...).Result()
+	}
+
+	pipe := client.Pipeline()
+
+	results := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		results[i] = pipe.Unlink(ctx, key)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, result := range results {
+		count += result.Val()
+	}
+
+	return count, nil
+}
+
+func namespaceFromPath(ctx *gin.Context) (namespace, bool) {
+	ns, ok := namespaces[ctx.Params.ByName("namespace")]
+	if !ok {
+		middleware.HandleError(ctx, http.StatusNotFound, "Unknown cache namespace", errorUnknownNamespace)
+		return namespace{}, false
+	}
+
+	return ns, true
+}
+
+func namespaceClient(ctx *gin.Context, ns namespace, redisFactory *redisfactory.Factory) (redis.UniversalClient, bool) {
+	client, err := ns.client(redisFactory)
+	if err != nil {
+		middleware.HandleError(ctx, http.StatusInternalServerError, "Failed creating cache client", err)
+		return nil, false
+	}
+
+	return client, true
+}
+
+func registerCacheRoutes(group *gin.RouterGroup, redisFactory *redisfactory.Factory) {
+	group.GET("/cache/:namespace/keys", func(ctx *gin.Context) {
+		ns, ok := namespaceFromPath(ctx)
+		if !ok {
+			return
+		}
+
+		client, ok := namespaceClient(ctx, ns, redisFactory)
+		if !ok {
+			return
+		}
+
+		if _, ok := client.(*redis.ClusterClient); ok {
+			middleware.HandleError(ctx, http.StatusNotImplemented, "Listing keys is not supported in cluster mode", errorClusterListing)
+			return
+		}
+
+		pattern := ns.pattern(ctx.Query("platform"), ctx.Query("match"))
+		cursor, _ := strconv.ParseUint(ctx.Query("cursor"), 10, 64)
+
+		keys, next, err := client.Scan(ctx.Request.Context(), cursor, pattern, scanCount(ctx.Query("count"))).Result()
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed scanning cache keys", err)
+			return
+		}
+
+		cacheKeys, err := keysWithTtl(ctx.Request.Context(), client, keys)
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed reading cache keys ttl", err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, cacheKeysResponse{
+			Keys:   cacheKeys,
+			Cursor: next,
+		})
+	})
+
+	group.GET("/cache/:namespace/value", func(ctx *gin.Context) {
+		ns, ok := namespaceFromPath(ctx)
+		if !ok {
+			return
+		}
+
+		key := ctx.Query("key")
+		if !ns.owns(key) {
+			middleware.HandleError(ctx, http.StatusBadRequest, "Key does not belong to the namespace", errorForeignKey)
+			return
+		}
+
+		client, ok := namespaceClient(ctx, ns, redisFactory)
+		if !ok {
+			return
+		}
+
+		value, err := client.Get(ctx.Request.Context(), key).Bytes()
+		if err == redis.Nil {
+			middleware.HandleError(ctx, http.StatusNotFound, "Key not found", err)
+			return
+		}
+
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed reading cache value", err)
+			return
+		}
+
+		var decoded json.RawMessage
+		err = caching.Decode(value, &decoded)
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusUnprocessableEntity, "Failed decoding cache value", err)
+			return
+		}
+
+		ttl, err := client.TTL(ctx.Request.Context(), key).Result()
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed reading cache key ttl", err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, cacheValueResponse{
+			Key:   key,
+			Ttl:   ttlSeconds(ttl),
+			Value: decoded,
+		})
+	})
+
+	group.DELETE("/cache/:namespace/keys", func(ctx *gin.Context) {
+		ns, ok := namespaceFromPath(ctx)
+		if !ok {
+			return
+		}
+
+		platform := ctx.Query("platform")
+		if platform == "" {
+			middleware.HandleError(ctx, http.StatusBadRequest, "Platform is required for purging", errorMissingPlatform)
+			return
+		}
+
+		client, ok := namespaceClient(ctx, ns, redisFactory)
+		if !ok {
+			return
+		}
+
+		pattern := ns.pattern(platform, ctx.Query("match"))
+
+		deleted, err := purge(ctx.Request.Context(), client, pattern)
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed purging cache keys", err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, cachePurgeResponse{
+			Pattern: pattern,
+			Deleted: deleted,
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/admin"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+const testApiKey = "secret"
+
+func setupRouter(t *testing.T) (*gin.Engine, *redisfactory.Factory, *miniredis.Miniredis) {
+	out := &bytes.Buffer{}
+	log := zerolog.New(out)
+
+	redisServer := miniredis.RunT(t)
+
+	redisFactory, err := redisfactory.New(map[string]redisfactory.ClientOptions{
+		redisfactory.Trafficlight:   {Uri: "redis://" + redisServer.Addr() + "/2"},
+		redisfactory.ResponsesCache: {Uri: "redis://" + redisServer.Addr() + "/1"},
+	})
+	assert.Nil(t, err)
+
+	router := gin.New()
+	router.Use(middleware.CorrelationId)
+	router.Use(middleware.RegisterLogger(&log))
+
+	admin.RegisterRoutes(router, admin.Options{ApiKey: testApiKey, RedisFactory: redisFactory})
+
+	return router, redisFactory, redisServer
+}
+
+func request(router *gin.Engine, method string, url string, apiKey string) *httptest.ResponseRecorder {
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest(method, url, nil)
+	request.Header.Set(admin.ApiKeyHeader, apiKey)
+
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestAuthenticate(t *testing.T) {
+	router, _, _ := setupRouter(t)
+
+	t.Run("should reject requests without api key", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/extras/keys", "")
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+
+	t.Run("should reject requests with invalid api key", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/extras/keys", "wrong")
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+
+	t.Run("should disable routes when no key is configured", func(t *testing.T) {
+		router := gin.New()
+		admin.RegisterRoutes(router, admin.Options{})
+
+		response := request(router, http.MethodGet, "/admin/cache/extras/keys", "")
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+}
+
+func TestCacheKeys(t *testing.T) {
+	router, redisFactory, redisServer := setupRouter(t)
+
+	responsesCacheClient, err := redisFactory.ResponsesCacheClient()
+	assert.Nil(t, err)
+
+	responsesCache := caching.NewRedisCache(responsesCacheClient)
+	responsesCache.Store(context.Background(), "supplier-hertz:extras:2:ZE:T1:LHR:3", []string{"CSI"}, time.Hour)
+	responsesCache.Store(context.Background(), "anyrent-auth-token:http://anyrent-api", "token", time.Minute)
+
+	redisServer.DB(2).Set("res:grouping:supplier-booking-com:5:man:man", "")
+	redisServer.DB(2).Set("res:grouping:supplier-hertz:5:lhr:lhr", "")
+
+	t.Run("should list keys of a namespace with ttl", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/extras/keys?platform=hertz", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var body struct {
+			Keys []struct {
+				Key string  `json:"key"`
+				Ttl float64 `json:"ttl"`
+			} `json:"keys"`
+			Cursor uint64 `json:"cursor"`
+		}
+		assert.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+
+		assert.Len(t, body.Keys, 1)
+		assert.Equal(t, "supplier-hertz:extras:2:ZE:T1:LHR:3", body.Keys[0].Key)
+		assert.Equal(t, float64(3600), body.Keys[0].Ttl)
+		assert.Equal(t, uint64(0), body.Cursor)
+	})
+
+	t.Run("should map platform names used in grouping keys", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/grouping/keys?platform=bookingcom", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"keys":[{"key":"res:grouping:supplier-booking-com:5:man:man","ttl":-1}],"cursor":0}`, response.Body.String())
+	})
+
+	t.Run("should fail on unknown namespace", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/unknown/keys", testApiKey)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestCacheValue(t *testing.T) {
+	router, redisFactory, redisServer := setupRouter(t)
+
+	responsesCacheClient, err := redisFactory.ResponsesCacheClient()
+	assert.Nil(t, err)
+
+	responsesCache := caching.NewRedisCache(responsesCacheClient)
+	responsesCache.Store(context.Background(), "supplier-hertz:extras:2:ZE:T1:LHR:3", []map[string]string{{"equipType": "CSI"}}, time.Hour)
+
+	redisServer.DB(1).Set("supplier-hertz:extras:2:ZE:T1:LHR:4", "not compressed")
+
+	t.Run("should decode a stored value", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/extras/value?key=supplier-hertz:extras:2:ZE:T1:LHR:3", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"key":"supplier-hertz:extras:2:ZE:T1:LHR:3","ttl":3600,"value":[{"equipType":"CSI"}]}`, response.Body.String())
+	})
+
+	t.Run("should refuse keys from other namespaces", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/extras/value?key=anyrent-auth-token:http://anyrent-api", testApiKey)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("should return not found for missing keys", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/extras/value?key=supplier-hertz:extras:2:missing", testApiKey)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("should fail on values that can not be decoded", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cache/extras/value?key=supplier-hertz:extras:2:ZE:T1:LHR:4", testApiKey)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	})
+}
+
+func TestCachePurge(t *testing.T) {
+	router, _, redisServer := setupRouter(t)
+
+	redisServer.DB(1).Set("supplier-hertz:extras:2:ZE:T1:LHR:3", "")
+	redisServer.DB(1).Set("supplier-hertz:extras:2:ZE:T1:LHR:4", "")
+	redisServer.DB(1).Set("supplier-hertz:extras:2:ZT:T1:LHR:4", "")
+	redisServer.DB(1).Set("rently-auth-token:http://rently-api", "")
+
+	t.Run("should require platform", func(t *testing.T) {
+		response := request(router, http.MethodDelete, "/admin/cache/extras/keys", testApiKey)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("should purge keys matching the pattern", func(t *testing.T) {
+		response := request(router, http.MethodDelete, "/admin/cache/extras/keys?platform=hertz&match=2:ZE:*", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"pattern":"supplier-hertz:extras:2:ZE:*","deleted":2}`, response.Body.String())
+
+		assert.Equal(t, []string{"rently-auth-token:http://rently-api", "supplier-hertz:extras:2:ZT:T1:LHR:4"}, redisServer.DB(1).Keys())
+	})
+}
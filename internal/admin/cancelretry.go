@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/cancelretry"
+	"github.com/gin-gonic/gin"
+)
+
+// registerCancelRetryRoutes reports cancel retries, dead ones need attention
+// and are requeued once the supplier is reachable again
+func registerCancelRetryRoutes(group *gin.RouterGroup, store *cancelretry.Store) {
+	group.GET("/cancel-retry", func(ctx *gin.Context) {
+		items, err := store.Retrying(ctx.Request.Context())
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed loading cancel retries", err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, items)
+	})
+
+	group.GET("/cancel-retry/dead", func(ctx *gin.Context) {
+		items, err := store.Dead(ctx.Request.Context())
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed loading dead cancel retries", err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, items)
+	})
+
+	group.GET("/cancel-retry/:id", func(ctx *gin.Context) {
+		item, err := store.Get(ctx.Request.Context(), ctx.Param("id"))
+		cancelRetryResponse(ctx, item, err)
+	})
+
+	group.POST("/cancel-retry/:id/requeue", func(ctx *gin.Context) {
+		item, err := store.Requeue(ctx.Request.Context(), ctx.Param("id"))
+		cancelRetryResponse(ctx, item, err)
+	})
+}
+
+func cancelRetryResponse(ctx *gin.Context, item cancelretry.Item, err error) {
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, item)
+	case errors.Is(err, cancelretry.ErrorNotFound):
+		middleware.HandleError(ctx, http.StatusNotFound, "Cancel retry not found", err)
+	case errors.Is(err, cancelretry.ErrorNotDead):
+		middleware.HandleError(ctx, http.StatusConflict, err.Error(), err)
+	default:
+		middleware.HandleError(ctx, http.StatusInternalServerError, "Failed loading cancel retry", err)
+	}
+}
@@ -0,0 +1,61 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/admin"
+	"bitbucket.org/crgw/supplier-hub/internal/cancelretry"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelRetry(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	store := cancelretry.NewStore(redis.NewClient(&redis.Options{Addr: redisServer.Addr()}))
+
+	router := gin.New()
+	admin.RegisterRoutes(router, admin.Options{ApiKey: testApiKey, CancelRetry: store})
+
+	params := schema.CancelRequestParams{Contact: schema.Contact{Email: "mock@example.com"}}
+
+	store.Save(context.Background(), cancelretry.Item{Id: "retrying", Platform: "hertz", Params: params, State: cancelretry.StateRetrying})
+	store.Save(context.Background(), cancelretry.Item{Id: "dead", Platform: "hertz", Params: params, State: cancelretry.StateDead})
+
+	t.Run("should list retrying and dead cancel retries", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/cancel-retry", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var items []cancelretry.Item
+		json.Unmarshal(response.Body.Bytes(), &items)
+
+		assert.Len(t, items, 1)
+		assert.Equal(t, "retrying", items[0].Id)
+
+		response = request(router, http.MethodGet, "/admin/cancel-retry/dead", testApiKey)
+		json.Unmarshal(response.Body.Bytes(), &items)
+
+		assert.Len(t, items, 1)
+		assert.Equal(t, "dead", items[0].Id)
+	})
+
+	t.Run("should requeue dead cancel retries", func(t *testing.T) {
+		response := request(router, http.MethodPost, "/admin/cancel-retry/retrying/requeue", testApiKey)
+		assert.Equal(t, http.StatusConflict, response.Code)
+
+		response = request(router, http.MethodPost, "/admin/cancel-retry/dead/requeue", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"state":"retrying"`)
+
+		response = request(router, http.MethodGet, "/admin/cancel-retry/dead", testApiKey)
+		assert.Equal(t, "[]", response.Body.String())
+
+		response = request(router, http.MethodGet, "/admin/cancel-retry/unknown", testApiKey)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
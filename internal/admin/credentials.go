@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"github.com/gin-gonic/gin"
+)
+
+var errorInvalidRecord = errors.New("platform and configuration object are required")
+
+// credentialsResponse never contains stored values, only the field names
+type credentialsResponse struct {
+	Ref      string   `json:"ref"`
+	Platform string   `json:"platform"`
+	ClientId string   `json:"clientId,omitempty"`
+	Fields   []string `json:"fields"`
+}
+
+func credentialsError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, credentials.ErrorInvalidRef):
+		middleware.HandleError(ctx, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, credentials.ErrorNotFound):
+		middleware.HandleError(ctx, http.StatusNotFound, "Configuration reference not found", err)
+	default:
+		middleware.HandleError(ctx, http.StatusInternalServerError, "Failed accessing stored credentials", err)
+	}
+}
+
+func registerCredentialsRoutes(group *gin.RouterGroup, store *credentials.Store) {
+	group.GET("/credentials/:ref", func(ctx *gin.Context) {
+		ref := ctx.Params.ByName("ref")
+
+		record, err := store.Get(ctx.Request.Context(), ref)
+		if err != nil {
+			credentialsError(ctx, err)
+			return
+		}
+
+		var configuration map[string]json.RawMessage
+		_ = json.Unmarshal(record.Configuration, &configuration)
+
+		fields := make([]string, 0, len(configuration))
+		for field := range configuration {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		ctx.JSON(http.StatusOK, credentialsResponse{
+			Ref:      ref,
+			Platform: record.Platform,
+			ClientId: record.ClientId,
+			Fields:   fields,
+		})
+	})
+
+	group.PUT("/credentials/:ref", func(ctx *gin.Context) {
+		var record credentials.Record
+
+		err := ctx.ShouldBindJSON(&record)
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusBadRequest, "Failed to bind credentials", err)
+			return
+		}
+
+		var configuration map[string]json.RawMessage
+		if record.Platform == "" || json.Unmarshal(record.Configuration, &configuration) != nil || configuration == nil {
+			middleware.HandleError(ctx, http.StatusBadRequest, errorInvalidRecord.Error(), errorInvalidRecord)
+			return
+		}
+
+		err = store.Put(ctx.Request.Context(), ctx.Params.ByName("ref"), record)
+		if err != nil {
+			credentialsError(ctx, err)
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	})
+
+	group.DELETE("/credentials/:ref", func(ctx *gin.Context) {
+		err := store.Delete(ctx.Request.Context(), ctx.Params.ByName("ref"))
+		if err != nil {
+			credentialsError(ctx, err)
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	})
+}
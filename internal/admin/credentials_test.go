@@ -0,0 +1,60 @@
+package admin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/admin"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentials(t *testing.T) {
+	backend, _ := credentials.NewFileBackend(t.TempDir())
+	store, _ := credentials.NewStore(backend, []byte("0123456789abcdef0123456789abcdef"))
+
+	router := gin.New()
+	admin.RegisterRoutes(router, admin.Options{ApiKey: testApiKey, Credentials: store})
+
+	put := func(ref string, body string) int {
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest(http.MethodPut, "/admin/credentials/"+ref, strings.NewReader(body))
+		request.Header.Set(admin.ApiKeyHeader, testApiKey)
+		router.ServeHTTP(response, request)
+
+		return response.Code
+	}
+
+	t.Run("should store credentials and only expose field names", func(t *testing.T) {
+		code := put("rently-main", `{"platform":"rently","configuration":{"username":"hub","password":"secret"}}`)
+		assert.Equal(t, http.StatusNoContent, code)
+
+		response := request(router, http.MethodGet, "/admin/credentials/rently-main", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"ref":"rently-main","platform":"rently","fields":["password","username"]}`, response.Body.String())
+	})
+
+	t.Run("should store the owning client", func(t *testing.T) {
+		code := put("rently-broker", `{"platform":"rently","clientId":"broker","configuration":{"username":"hub"}}`)
+		assert.Equal(t, http.StatusNoContent, code)
+
+		response := request(router, http.MethodGet, "/admin/credentials/rently-broker", testApiKey)
+		assert.JSONEq(t, `{"ref":"rently-broker","platform":"rently","clientId":"broker","fields":["username"]}`, response.Body.String())
+	})
+
+	t.Run("should reject records without platform", func(t *testing.T) {
+		code := put("rently-main", `{"configuration":{"username":"hub"}}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("should delete credentials", func(t *testing.T) {
+		response := request(router, http.MethodDelete, "/admin/credentials/rently-main", testApiKey)
+		assert.Equal(t, http.StatusNoContent, response.Code)
+
+		response = request(router, http.MethodGet, "/admin/credentials/rently-main", testApiKey)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
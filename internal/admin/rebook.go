@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/rebook"
+	"github.com/gin-gonic/gin"
+)
+
+// registerRebookRoutes reports rebookings, open ones need attention when
+// they are not continued by the caller
+func registerRebookRoutes(group *gin.RouterGroup, store *rebook.Store) {
+	group.GET("/rebook", func(ctx *gin.Context) {
+		sagas, err := store.Open(ctx.Request.Context())
+		if err != nil {
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed loading rebookings", err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, sagas)
+	})
+
+	group.GET("/rebook/:platform/:reference", func(ctx *gin.Context) {
+		saga, err := store.Get(ctx.Request.Context(), rebook.SagaId(ctx.Param("platform"), ctx.Param("reference")))
+
+		switch {
+		case err == nil:
+			ctx.JSON(http.StatusOK, saga)
+		case errors.Is(err, rebook.ErrorNotFound):
+			middleware.HandleError(ctx, http.StatusNotFound, "Rebooking not found", err)
+		default:
+			middleware.HandleError(ctx, http.StatusInternalServerError, "Failed loading rebooking", err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/admin"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/rebook"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebook(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+	store := rebook.NewStore(redis.NewClient(&redis.Options{Addr: redisServer.Addr()}), time.Hour)
+
+	router := gin.New()
+	admin.RegisterRoutes(router, admin.Options{ApiKey: testApiKey, Rebook: store})
+
+	store.Save(context.Background(), rebook.Saga{Platform: "rently", OldReference: "OLD", NewReference: "NEW", State: rebook.StateRollingBack})
+	store.Save(context.Background(), rebook.Saga{Platform: "rently", OldReference: "DONE", State: rebook.StateCompleted})
+
+	t.Run("should list open rebookings", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/rebook", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var sagas []rebook.Saga
+		json.Unmarshal(response.Body.Bytes(), &sagas)
+
+		assert.Len(t, sagas, 1)
+		assert.Equal(t, "NEW", sagas[0].NewReference)
+	})
+
+	t.Run("should return rebookings by platform and old reference", func(t *testing.T) {
+		response := request(router, http.MethodGet, "/admin/rebook/rently/DONE", testApiKey)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"state":"completed"`)
+
+		response = request(router, http.MethodGet, "/admin/rebook/rently/UNKNOWN", testApiKey)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
@@ -0,0 +1,76 @@
+// Package audit keeps an append-only log of booking, modify and cancel
+// requests and responses. Records are never changed or removed by the hub,
+// backends only append and look them up by booking references.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrorUnknownBackend = errors.New("unknown audit backend")
+	ErrorEmptyQuery     = errors.New("reservNumber or supplierBookingReference is required")
+)
+
+// Record is a platform request and its response. Request and Response are
+// redacted JSON, see Redact.
+type Record struct {
+	Id                       string          `json:"id"`
+	Platform                 string          `json:"platform"`
+	Operation                string          `json:"operation"`
+	ClientId                 string          `json:"clientId,omitempty"`
+	CorrelationId            string          `json:"correlationId,omitempty"`
+	ReservNumber             string          `json:"reservNumber,omitempty"`
+	SupplierBookingReference string          `json:"supplierBookingReference,omitempty"`
+	StatusCode               int             `json:"statusCode"`
+	Request                  json.RawMessage `json:"request,omitempty"`
+	Response                 json.RawMessage `json:"response,omitempty"`
+	StartedAt                time.Time       `json:"startedAt"`
+	// Duration is in milliseconds like the duration of supplier requests
+	Duration int64 `json:"duration"`
+}
+
+// Query matches records by any of the given references
+type Query struct {
+	ReservNumber             string
+	SupplierBookingReference string
+}
+
+func (q Query) Matches(record Record) bool {
+	return (q.ReservNumber != "" && q.ReservNumber == record.ReservNumber) ||
+		(q.SupplierBookingReference != "" && q.SupplierBookingReference == record.SupplierBookingReference)
+}
+
+// Backend appends records and returns matching ones oldest first
+type Backend interface {
+	Append(ctx context.Context, record Record) error
+	Find(ctx context.Context, query Query) ([]Record, error)
+}
+
+type Log struct {
+	backend Backend
+}
+
+func NewLog(backend Backend) *Log {
+	return &Log{backend: backend}
+}
+
+// Append assigns the record id
+func (l *Log) Append(ctx context.Context, record Record) error {
+	record.Id = uuid.New().String()
+
+	return l.backend.Append(ctx, record)
+}
+
+func (l *Log) Find(ctx context.Context, query Query) ([]Record, error) {
+	if query.ReservNumber == "" && query.SupplierBookingReference == "" {
+		return nil, ErrorEmptyQuery
+	}
+
+	return l.backend.Find(ctx, query)
+}
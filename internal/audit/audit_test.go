@@ -0,0 +1,187 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func record(reservNumber string, reference string) audit.Record {
+	return audit.Record{
+		Platform:                 "hertz",
+		Operation:                "booking",
+		ReservNumber:             reservNumber,
+		SupplierBookingReference: reference,
+		StatusCode:               200,
+		Request:                  audit.Redact([]byte(`{"reservNumber":"` + reservNumber + `"}`)),
+		StartedAt:                time.Now().UTC(),
+	}
+}
+
+func TestFileBackend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should find appended records by reservation number and supplier reference", func(t *testing.T) {
+		backend, err := audit.NewFileBackend(t.TempDir(), 1<<20)
+		assert.Nil(t, err)
+
+		log := audit.NewLog(backend)
+
+		assert.Nil(t, log.Append(ctx, record("R1", "S1")))
+		assert.Nil(t, log.Append(ctx, record("R2", "S2")))
+		assert.Nil(t, log.Append(ctx, record("R1", "S3")))
+
+		records, err := log.Find(ctx, audit.Query{ReservNumber: "R1"})
+		assert.Nil(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "S1", records[0].SupplierBookingReference)
+		assert.Equal(t, "S3", records[1].SupplierBookingReference)
+		assert.NotEqual(t, records[0].Id, records[1].Id)
+		assert.JSONEq(t, `{"reservNumber":"R1"}`, string(records[0].Request))
+
+		records, _ = log.Find(ctx, audit.Query{SupplierBookingReference: "S2"})
+		assert.Len(t, records, 1)
+
+		records, _ = log.Find(ctx, audit.Query{ReservNumber: "UNKNOWN"})
+		assert.Empty(t, records)
+
+		_, err = log.Find(ctx, audit.Query{})
+		assert.ErrorIs(t, err, audit.ErrorEmptyQuery)
+	})
+
+	t.Run("should rotate files and keep finding records in order", func(t *testing.T) {
+		dir := t.TempDir()
+
+		backend, err := audit.NewFileBackend(dir, 200)
+		assert.Nil(t, err)
+
+		log := audit.NewLog(backend)
+
+		for _, reference := range []string{"S1", "S2", "S3"} {
+			assert.Nil(t, log.Append(ctx, record("R1", reference)))
+		}
+
+		files, _ := filepath.Glob(filepath.Join(dir, "audit*.jsonl"))
+		assert.Len(t, files, 3)
+
+		records, err := log.Find(ctx, audit.Query{ReservNumber: "R1"})
+		assert.Nil(t, err)
+		assert.Len(t, records, 3)
+		assert.Equal(t, "S3", records[2].SupplierBookingReference)
+	})
+
+	t.Run("should append to existing files and skip partial lines", func(t *testing.T) {
+		dir := t.TempDir()
+
+		backend, _ := audit.NewFileBackend(dir, 1<<20)
+		audit.NewLog(backend).Append(ctx, record("R1", "S1"))
+
+		file, _ := os.OpenFile(filepath.Join(dir, "audit.jsonl"), os.O_APPEND|os.O_WRONLY, 0o600)
+		file.WriteString(`{"reservNumber":"R1"`)
+		file.Close()
+
+		records, err := audit.NewLog(backend).Find(ctx, audit.Query{ReservNumber: "R1"})
+		assert.Nil(t, err)
+		assert.Len(t, records, 1)
+	})
+}
+
+func TestRedact(t *testing.T) {
+	t.Run("should redact secrets at any depth", func(t *testing.T) {
+		redacted := audit.Redact([]byte(`{
+			"reservNumber": "R1",
+			"configuration": {"username": "user", "password": "secret", "apiKey": "key"},
+			"supplierRequests": [{"requestContent": {"headers": {"Authorization": ["Bearer token"], "Accept": ["*/*"]}}}],
+			"rentalDays": 12345678901234567890
+		}`))
+
+		assert.JSONEq(t, `{
+			"reservNumber": "R1",
+			"configuration": {"username": "user", "password": "<redacted>", "apiKey": "<redacted>"},
+			"supplierRequests": [{"requestContent": {"headers": {"Authorization": "<redacted>", "Accept": ["*/*"]}}}],
+			"rentalDays": 12345678901234567890
+		}`, string(redacted))
+	})
+
+	t.Run("should redact credentials in supplier request bodies of a Booking.com booking", func(t *testing.T) {
+		request := `<MakeBookingRQ version="1.1" correlationId="R1"><Credentials username="test-user" password="test-password"></Credentials></MakeBookingRQ>`
+		response := `<MakeBookingRS version="1.1"><Booking id="123456789"/></MakeBookingRS>`
+
+		body, _ := json.Marshal(map[string]any{
+			"supplierBookingReference": "123456789",
+			"supplierRequests": []any{
+				map[string]any{
+					"name": "auth",
+					"requestContent": map[string]any{
+						"body": `{"username":"test-user","password":"test-password"}`,
+					},
+					"responseContent": map[string]any{"body": `{"userAccessToken":"eyJhbGciOiJFUzI1NiJ9"}`},
+				},
+				map[string]any{
+					"name": "booking",
+					"requestContent": map[string]any{
+						"method":  "POST",
+						"body":    request,
+						"headers": map[string]any{"Authorization": []string{"Bearer eyJhbGciOiJFUzI1NiJ9"}},
+					},
+					"responseContent": map[string]any{"statusCode": 200, "body": response},
+				},
+			},
+		})
+
+		redacted := audit.Redact(body)
+
+		assert.NotContains(t, string(redacted), "test-password")
+		assert.NotContains(t, string(redacted), "eyJhbGciOiJFUzI1NiJ9")
+
+		var decoded struct {
+			SupplierRequests []struct {
+				RequestContent  map[string]any `json:"requestContent"`
+				ResponseContent map[string]any `json:"responseContent"`
+			} `json:"supplierRequests"`
+		}
+		assert.Nil(t, json.Unmarshal(redacted, &decoded))
+
+		booking := decoded.SupplierRequests[1]
+		assert.Equal(t, `<MakeBookingRQ version="1.1" correlationId="R1"><Credentials username="test-user" password="<redacted>"></Credentials></MakeBookingRQ>`, booking.RequestContent["body"])
+		assert.Equal(t, "POST", booking.RequestContent["method"])
+		assert.Equal(t, response, booking.ResponseContent["body"])
+
+		auth := decoded.SupplierRequests[0]
+		assert.JSONEq(t, `{"username":"test-user","password":"<redacted>"}`, auth.RequestContent["body"].(string))
+		assert.JSONEq(t, `{"userAccessToken":"<redacted>"}`, auth.ResponseContent["body"].(string))
+	})
+
+	t.Run("should redact credentials in the supplier formats", func(t *testing.T) {
+		bodies := map[string]string{
+			`<OTA_VehResRQ><POS><Source ISOCountry="DE"><RequestorID Type="4" ID="T123" MessagePassword="secret"/></Source></POS><VehResRQCore Status="Available"/></OTA_VehResRQ>`: `<OTA_VehResRQ><POS><Source ISOCountry="DE"><RequestorID Type="4" ID="<redacted>" MessagePassword="<redacted>"/></Source></POS><VehResRQCore Status="Available"/></OTA_VehResRQ>`,
+			`<ns:credentials><ns:userID ns:encodingType="xsd:string">user</ns:userID><ns:password ns:encodingType="xsd:string">secret</ns:password></ns:credentials>`:               `<ns:credentials><ns:userID ns:encodingType="xsd:string">user</ns:userID><ns:password ns:encodingType="xsd:string"><redacted></ns:password></ns:credentials>`,
+			`client_id=RentlyAPI&grant_type=password&password=secret&username=user`:                                                                                                 `client_id=RentlyAPI&grant_type=password&password=%3Credacted%3E&username=user`,
+			`plain text`: `plain text`,
+		}
+
+		for body, expected := range bodies {
+			content, _ := json.Marshal(map[string]any{
+				"supplierRequests": []any{map[string]any{"requestContent": map[string]any{"body": body}}},
+			})
+
+			var decoded struct {
+				SupplierRequests []struct {
+					RequestContent map[string]any `json:"requestContent"`
+				} `json:"supplierRequests"`
+			}
+			assert.Nil(t, json.Unmarshal(audit.Redact(content), &decoded))
+			assert.Equal(t, expected, decoded.SupplierRequests[0].RequestContent["body"])
+		}
+	})
+
+	t.Run("should leave out bodies that are not json", func(t *testing.T) {
+		assert.Nil(t, audit.Redact([]byte("<Response/>")))
+	})
+}
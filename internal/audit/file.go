@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// currentFile is appended to, rotated files are named by their rotation
+	// time and sort before it
+	currentFile   = "audit.jsonl"
+	rotatedPrefix = "audit-"
+	rotatedLayout = "20060102T150405.000000000"
+)
+
+// FileBackend appends one JSON record per line. The file is rotated when a
+// record would grow it beyond the max size, rotated files are kept. The log is
+// local to the instance, instances must not share the directory as they
+// rotate the same files, and lookups only find the records of the instance
+// serving them.
+type FileBackend struct {
+	dir     string
+	maxSize int64
+	file    *os.File
+	size    int64
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+func NewFileBackend(dir string, maxSize int64) (*FileBackend, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &FileBackend{
+		dir:     dir,
+		maxSize: maxSize,
+		now:     time.Now,
+	}
+
+	return f, f.open()
+}
+
+func (f *FileBackend) open() error {
+	file, err := os.OpenFile(filepath.Join(f.dir, currentFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+func (f *FileBackend) rotate() error {
+	err := f.file.Close()
+	if err != nil {
+		return err
+	}
+
+	rotated := rotatedPrefix + f.now().UTC().Format(rotatedLayout) + ".jsonl"
+
+	err = os.Rename(filepath.Join(f.dir, currentFile), filepath.Join(f.dir, rotated))
+	if err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// Append writes the record with a single write so readers see whole lines
+func (f *FileBackend) Append(ctx context.Context, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.size > 0 && f.size+int64(len(line)) > f.maxSize {
+		err = f.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+
+	return err
+}
+
+// Find scans rotated files and the current file, it does not block appends
+func (f *FileBackend) Find(ctx context.Context, query Query) ([]Record, error) {
+	names, err := filepath.Glob(filepath.Join(f.dir, "audit*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+
+	records := []Record{}
+
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		records, err = findInFile(name, query, records)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
+
+// findInFile skips a trailing line without newline, it is still being written
+func findInFile(name string, query Query, records []Record) ([]Record, error) {
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var record Record
+		if json.Unmarshal(line, &record) != nil {
+			continue
+		}
+
+		if query.Matches(record) {
+			records = append(records, record)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redacted = "<redacted>"
+
+// sensitiveKeys are matched case-insensitively as part of object keys, they
+// cover supplier configuration and the headers of supplier requests
+var sensitiveKeys = []string{"authorization", "cookie", "key", "token", "secret", "password"}
+
+// Redact replaces the values of sensitive keys at any depth and the
+// credentials in the bodies of supplier requests, see redactBody. Bodies that
+// are not JSON are left out.
+func Redact(body []byte) json.RawMessage {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if decoder.Decode(&value) != nil {
+		return nil
+	}
+
+	result, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func redactValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, nested := range typed {
+			if key == "supplierRequests" {
+				redactSupplierRequests(nested)
+			}
+
+			if sensitive(key) {
+				typed[key] = redacted
+			} else {
+				typed[key] = redactValue(nested)
+			}
+		}
+	case []any:
+		for i, nested := range typed {
+			typed[i] = redactValue(nested)
+		}
+	}
+
+	return value
+}
+
+func sensitive(key string) bool {
+	lower := strings.ToLower(key)
+
+	for _, s := range sensitiveKeys {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func redactSupplierRequests(value any) {
+	requests, _ := value.([]any)
+
+	for _, request := range requests {
+		if typed, ok := request.(map[string]any); ok {
+			redactSupplierRequest(typed)
+		}
+	}
+}
+
+// redactSupplierRequest redacts request and response bodies, responses of
+// auth requests carry session tokens. Headers are left to key matching.
+func redactSupplierRequest(request map[string]any) {
+	redactBody(request["requestContent"])
+	redactBody(request["responseContent"])
+}
+
+func redactBody(content any) {
+	typed, ok := content.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if body, ok := typed["body"].(string); ok {
+		typed["body"] = redactText(body)
+	}
+}
+
+// redactText redacts credentials in the format of the body, JSON keys, XML
+// attributes and elements or form fields, and keeps everything else
+func redactText(body string) string {
+	trimmed := strings.TrimSpace(body)
+
+	switch {
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		if result := Redact([]byte(trimmed)); result != nil {
+			return string(result)
+		}
+
+		return redacted
+	case strings.HasPrefix(trimmed, "<"):
+		return redactXml(body)
+	}
+
+	return redactForm(body)
+}
+
+var (
+	xmlStartTag    = regexp.MustCompile(`<([\w.-]+:)?([\w.-]+)(\s[^<>]*)?>`)
+	xmlAttribute   = regexp.MustCompile(`(\s)([\w.-]+:)?([\w.-]+)(\s*=\s*)("[^"]*"|'[^']*')`)
+	xmlTextElement = regexp.MustCompile(`(<([\w.-]+:)?([\w.-]+)(\s[^<>]*)?>)([^<]+)(</)`)
+
+	// credentialAttributes are not found by key matching, the requestor id of
+	// OTA POS elements identifies the account of the broker
+	credentialAttributes = map[string]string{"RequestorID": "ID"}
+)
+
+func redactXml(body string) string {
+	body = xmlStartTag.ReplaceAllStringFunc(body, func(tag string) string {
+		element := xmlStartTag.FindStringSubmatch(tag)[2]
+
+		return xmlAttribute.ReplaceAllStringFunc(tag, func(attribute string) string {
+			groups := xmlAttribute.FindStringSubmatch(attribute)
+			name := groups[3]
+
+			if !sensitive(name) && credentialAttributes[element] != name {
+				return attribute
+			}
+
+			return groups[1] + groups[2] + name + groups[4] + `"` + redacted + `"`
+		})
+	})
+
+	return xmlTextElement.ReplaceAllStringFunc(body, func(element string) string {
+		groups := xmlTextElement.FindStringSubmatch(element)
+
+		if strings.HasSuffix(groups[1], "/>") || !sensitive(groups[3]) {
+			return element
+		}
+
+		return groups[1] + redacted + groups[6]
+	})
+}
+
+// redactForm only changes url encoded forms with sensitive fields, e.g.
+// password grants
+func redactForm(body string) string {
+	values, err := url.ParseQuery(body)
+	if err != nil {
+		return body
+	}
+
+	changed := false
+
+	for key := range values {
+		if sensitive(key) {
+			values.Set(key, redacted)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return body
+	}
+
+	return values.Encode()
+}
@@ -0,0 +1,147 @@
+// Package cancelretry retries cancellations that failed by timeouts or
+// connection errors in the background. Items are kept in redis, any
+// instance continues them after restarts, exhausted items are moved to a
+// dead-letter list for inspection and requeueing. Only cancellations
+// referencing stored configuration are retried, supplier credentials are not
+// kept in redis.
+package cancelretry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/jobqueue"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrorNotFound = errors.New("cancel retry not found")
+	ErrorNotDead  = errors.New("only dead cancel retries can be requeued")
+	// ErrorConfigurationRefRequired keeps supplier credentials out of redis,
+	// only cancellations referencing stored configuration are retried
+	ErrorConfigurationRefRequired = errors.New("cancel retries require a configuration reference")
+)
+
+// backoff doubles the wait per attempt up to the max backoff
+func backoff(o config.CancelRetry, attempts int) time.Duration {
+	return jobqueue.Backoff(o.Backoff, o.MaxBackoff, attempts)
+}
+
+type State string
+
+const (
+	// StateRetrying waits for the next attempt
+	StateRetrying State = "retrying"
+	// StateSucceeded is done, the supplier confirmed the cancellation
+	StateSucceeded State = "succeeded"
+	// StateDead gave up, the item is kept in the dead-letter list
+	StateDead State = "dead"
+)
+
+// Attempt is a cancel request to the supplier, the first one is the
+// request of the client
+type Attempt struct {
+	At     time.Time                     `json:"at"`
+	Status schema.CancelResponseStatus   `json:"status"`
+	Errors schema.SupplierResponseErrors `json:"errors,omitempty"`
+	// HistoryId is the offloaded supplier requests, see GET /history/{id}
+	HistoryId string `json:"historyId,omitempty"`
+}
+
+// Item retries a cancellation. Params keep the configuration reference, the
+// configuration is resolved for each attempt and never stored.
+type Item struct {
+	Id            string                     `json:"id"`
+	Platform      string                     `json:"platform"`
+	ClientId      string                     `json:"clientId,omitempty"`
+	CorrelationId string                     `json:"correlationId,omitempty"`
+	Params        schema.CancelRequestParams `json:"params"`
+	State         State                      `json:"state"`
+	Attempts      []Attempt                  `json:"attempts"`
+	// Retries counts the attempts since the item was queued or requeued
+	Retries   int    `json:"retries"`
+	LastError string `json:"lastError,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	NextAt    time.Time `json:"nextAt"`
+}
+
+// Retryable reports failures by timeouts and connection errors only, the
+// supplier may not have received the cancellation
+func Retryable(response schema.CancelResponse) bool {
+	errs := converting.Unwrap(response.Errors)
+	if converting.Unwrap(response.Status) != schema.CancelResponseStatusFAILED || len(errs) == 0 {
+		return false
+	}
+
+	for _, e := range errs {
+		if e.Code != schema.TimeoutError && e.Code != schema.ConnectionError {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Enqueue schedules the first retry of the failed cancellation, params
+// without a configuration reference are rejected
+func (r *Retrier) Enqueue(ctx context.Context, platform string, clientId string, correlationId string, params schema.CancelRequestParams, response schema.CancelResponse) (Item, error) {
+	if params.ConfigurationRef == nil {
+		return Item{}, ErrorConfigurationRefRequired
+	}
+
+	params.Configuration = schema.CancelRequestParams_Configuration{}
+
+	now := r.now().UTC()
+	item := Item{
+		Id:            uuid.New().String(),
+		Platform:      platform,
+		ClientId:      clientId,
+		CorrelationId: correlationId,
+		Params:        params,
+		State:         StateRetrying,
+		Attempts: []Attempt{{
+			At:        now,
+			Status:    converting.Unwrap(response.Status),
+			Errors:    converting.Unwrap(response.Errors),
+			HistoryId: converting.Unwrap(response.HistoryId),
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
+		NextAt:    now.Add(r.options.Backoff),
+	}
+
+	return item, r.store.Save(ctx, item)
+}
+
+// RetryFailed queues retryable failures of cancellations referencing stored
+// configuration and returns the response with the retry id, nil retriers
+// retry nothing
+func (r *Retrier) RetryFailed(ctx context.Context, platform string, clientId string, correlationId string, params schema.CancelRequestParams, response schema.CancelResponse, logger *zerolog.Logger) schema.CancelResponse {
+	if r == nil || !Retryable(response) {
+		return response
+	}
+
+	item, err := r.Enqueue(ctx, platform, clientId, correlationId, params, response)
+	if errors.Is(err, ErrorConfigurationRefRequired) {
+		logger.Warn().Str("reservNumber", params.ReservNumber).Msg("Not retrying cancellation with inline configuration")
+		return response
+	}
+
+	if err != nil {
+		logger.Err(err).Str("reservNumber", params.ReservNumber).Msg("Failed queueing cancel retry")
+		return response
+	}
+
+	logger.Info().Str("cancelRetryId", item.Id).Str("reservNumber", params.ReservNumber).Msg("Queued cancel retry")
+
+	response.RetryId = &item.Id
+
+	return response
+}
@@ -0,0 +1,270 @@
+package cancelretry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/cancelretry"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"bitbucket.org/crgw/supplier-hub/internal/history"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+var log = zerolog.Nop()
+
+// platform answers the responses in order, the last one repeatedly
+type platform struct {
+	responses []schema.CancelResponse
+	cancels   int
+	params    schema.CancelRequestParams
+}
+
+func (p *platform) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
+	p.params = params
+
+	response := p.responses[len(p.responses)-1]
+	if p.cancels < len(p.responses) {
+		response = p.responses[p.cancels]
+	}
+
+	p.cancels++
+
+	return response, nil
+}
+
+type platforms map[string]any
+
+func (p platforms) GetPlatform(name string) (any, error) {
+	if platform, ok := p[name]; ok {
+		return platform, nil
+	}
+
+	return nil, errors.New("unknown platform")
+}
+
+func options() config.CancelRetry {
+	return config.CancelRetry{
+		Enabled:     true,
+		Backoff:     time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+		MaxAttempts: 3,
+	}
+}
+
+func response(status schema.CancelResponseStatus, errs ...schema.SupplierResponseError) schema.CancelResponse {
+	return schema.CancelResponse{
+		Status:           &status,
+		Errors:           &errs,
+		SupplierRequests: &schema.SupplierRequests{{}},
+	}
+}
+
+var params = schema.CancelRequestParams{
+	ReservNumber:             "R123",
+	SupplierBookingReference: "S123",
+	Contact:                  schema.Contact{Email: "mock@example.com"},
+	ConfigurationRef:         converting.PointerToValue("hertz-main"),
+}
+
+// resolved params carry the stored configuration, the request body had it
+// merged by the credentials middleware
+func resolved() schema.CancelRequestParams {
+	resolved := params
+	resolved.Configuration.UnmarshalJSON([]byte(`{"password":"secret"}`))
+
+	return resolved
+}
+
+// runUntil processes due items until the retrier is idle
+func runUntil(t *testing.T, retrier *cancelretry.Retrier) {
+	for i := 0; i < 20; i++ {
+		time.Sleep(2 * time.Millisecond)
+		retrier.RunDue(context.Background(), &log)
+	}
+}
+
+func setup(t *testing.T, p *platform) (*cancelretry.Retrier, *cancelretry.Store, *history.Store) {
+	redisServer := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+
+	credentialStore := newCredentialStore(t, client)
+	store := cancelretry.NewStore(client)
+	historyStore := history.NewStore(history.NewRedisBackend(client, time.Hour))
+
+	return cancelretry.New(store, options(), platforms{"hertz": p}, credentialStore, historyStore), store, historyStore
+}
+
+func newCredentialStore(t *testing.T, client redis.UniversalClient) *credentials.Store {
+	store, err := credentials.NewStore(credentials.NewRedisBackend(client), []byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, err)
+
+	err = store.Put(context.Background(), "hertz-main", credentials.Record{
+		Platform:      "hertz",
+		ClientId:      "client",
+		Configuration: json.RawMessage(`{"password":"secret"}`),
+	})
+	assert.Nil(t, err)
+
+	return store
+}
+
+func TestRetrier(t *testing.T) {
+	ctx := context.Background()
+	timeout := response(schema.CancelResponseStatusFAILED, schema.NewTimeoutError("timed out"))
+
+	t.Run("should retry timeouts and connection errors until cancelled", func(t *testing.T) {
+		p := &platform{responses: []schema.CancelResponse{
+			response(schema.CancelResponseStatusFAILED, schema.NewConnectionError("connection refused")),
+			response(schema.CancelResponseStatusOK),
+		}}
+		retrier, store, historyStore := setup(t, p)
+
+		answered := retrier.RetryFailed(ctx, "hertz", "client", "correlation", resolved(), timeout, &log)
+		assert.NotNil(t, answered.RetryId)
+
+		runUntil(t, retrier)
+
+		assert.Equal(t, 2, p.cancels)
+
+		configuration, _ := p.params.Configuration.MarshalJSON()
+		assert.JSONEq(t, `{"password":"secret"}`, string(configuration))
+
+		item, err := store.Get(ctx, *answered.RetryId)
+		assert.Nil(t, err)
+		assert.Equal(t, cancelretry.StateSucceeded, item.State)
+		assert.Len(t, item.Attempts, 3)
+		assert.Equal(t, schema.TimeoutError, item.Attempts[0].Errors[0].Code)
+		assert.Equal(t, schema.CancelResponseStatusOK, item.Attempts[2].Status)
+
+		record, err := historyStore.Get(ctx, item.Attempts[2].HistoryId)
+		assert.Nil(t, err)
+		assert.Equal(t, "client", record.ClientId)
+		assert.Equal(t, "cancel", record.History.Operation)
+		assert.Equal(t, "correlation", converting.Unwrap(record.History.CorrelationId))
+	})
+
+	t.Run("should only queue failures by timeouts and connection errors", func(t *testing.T) {
+		retrier, _, _ := setup(t, &platform{})
+
+		answered := retrier.RetryFailed(ctx, "hertz", "client", "", params, response(schema.CancelResponseStatusFAILED, schema.NewSupplierError("not found")), &log)
+		assert.Nil(t, answered.RetryId)
+
+		answered = retrier.RetryFailed(ctx, "hertz", "client", "", params, response(schema.CancelResponseStatusOK), &log)
+		assert.Nil(t, answered.RetryId)
+
+		var disabled *cancelretry.Retrier
+		answered = disabled.RetryFailed(ctx, "hertz", "client", "", params, timeout, &log)
+		assert.Nil(t, answered.RetryId)
+
+		assert.Equal(t, 0, retrier.RunDue(ctx, &log))
+	})
+
+	t.Run("should not keep supplier configuration", func(t *testing.T) {
+		retrier, store, _ := setup(t, &platform{})
+
+		inline := resolved()
+		inline.ConfigurationRef = nil
+
+		answered := retrier.RetryFailed(ctx, "hertz", "client", "", inline, timeout, &log)
+		assert.Nil(t, answered.RetryId)
+
+		_, err := retrier.Enqueue(ctx, "hertz", "client", "", inline, timeout)
+		assert.ErrorIs(t, err, cancelretry.ErrorConfigurationRefRequired)
+
+		item, err := retrier.Enqueue(ctx, "hertz", "client", "", resolved(), timeout)
+		assert.Nil(t, err)
+
+		item, _ = store.Get(ctx, item.Id)
+		content, _ := json.Marshal(item)
+		assert.NotContains(t, string(content), "secret")
+		assert.Equal(t, "hertz-main", converting.Unwrap(item.Params.ConfigurationRef))
+	})
+
+	t.Run("should move exhausted items to the dead-letter list and requeue them", func(t *testing.T) {
+		p := &platform{responses: []schema.CancelResponse{timeout}}
+		retrier, store, _ := setup(t, p)
+
+		item, err := retrier.Enqueue(ctx, "hertz", "client", "", params, timeout)
+		assert.Nil(t, err)
+
+		runUntil(t, retrier)
+
+		assert.Equal(t, 3, p.cancels)
+
+		dead, err := store.Dead(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, dead, 1)
+		assert.Equal(t, cancelretry.StateDead, dead[0].State)
+		assert.Equal(t, "timed out", dead[0].LastError)
+
+		_, err = store.Requeue(ctx, "unknown")
+		assert.ErrorIs(t, err, cancelretry.ErrorNotFound)
+
+		p.responses = []schema.CancelResponse{response(schema.CancelResponseStatusOK)}
+
+		_, err = store.Requeue(ctx, item.Id)
+		assert.Nil(t, err)
+
+		runUntil(t, retrier)
+
+		item, _ = store.Get(ctx, item.Id)
+		assert.Equal(t, cancelretry.StateSucceeded, item.State)
+		assert.Len(t, item.Attempts, 5)
+
+		dead, _ = store.Dead(ctx)
+		assert.Empty(t, dead)
+
+		_, err = store.Requeue(ctx, item.Id)
+		assert.ErrorIs(t, err, cancelretry.ErrorNotDead)
+	})
+
+	t.Run("should give up on supplier errors", func(t *testing.T) {
+		p := &platform{responses: []schema.CancelResponse{
+			response(schema.CancelResponseStatusFAILED, schema.NewSupplierError("booking not found")),
+		}}
+		retrier, store, _ := setup(t, p)
+
+		item, _ := retrier.Enqueue(ctx, "hertz", "client", "", params, timeout)
+
+		runUntil(t, retrier)
+
+		assert.Equal(t, 1, p.cancels)
+
+		item, _ = store.Get(ctx, item.Id)
+		assert.Equal(t, cancelretry.StateDead, item.State)
+		assert.Equal(t, "booking not found", item.LastError)
+	})
+
+	t.Run("should continue persisted items with another retrier", func(t *testing.T) {
+		p := &platform{responses: []schema.CancelResponse{response(schema.CancelResponseStatusOK)}}
+		client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+		credentialStore := newCredentialStore(t, client)
+		store := cancelretry.NewStore(client)
+
+		retrier := cancelretry.New(store, options(), platforms{"hertz": p}, credentialStore, nil)
+		retrying, _ := retrier.Enqueue(ctx, "hertz", "client", "", params, timeout)
+
+		items, err := store.Retrying(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, items, 1)
+
+		restarted := cancelretry.New(store, options(), platforms{"hertz": p}, credentialStore, nil)
+		runUntil(t, restarted)
+
+		item, _ := store.Get(ctx, retrying.Id)
+		assert.Equal(t, cancelretry.StateSucceeded, item.State)
+		assert.Empty(t, item.Attempts[1].HistoryId)
+
+		items, _ = store.Retrying(ctx)
+		assert.Empty(t, items)
+	})
+}
@@ -0,0 +1,96 @@
+package cancelretry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/jobqueue"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyPrefix = "cancelretry:"
+	// deadKey lists the ids of dead items, newest first
+	deadKey = "dead"
+
+	// itemTtl keeps items for inspection and requeueing
+	itemTtl = 30 * 24 * time.Hour
+)
+
+// Store keeps items in redis so they survive restarts
+type Store struct {
+	client redis.UniversalClient
+	queue  *jobqueue.Queue[Item]
+	now    func() time.Time
+}
+
+func NewStore(client redis.UniversalClient) *Store {
+	return &Store{
+		client: client,
+		queue:  jobqueue.New[Item](client, "cancel-retry", redisKeyPrefix, itemTtl),
+		now:    time.Now,
+	}
+}
+
+// Get returns ErrorNotFound for unknown and expired items
+func (s *Store) Get(ctx context.Context, id string) (Item, error) {
+	item, err := s.queue.Get(ctx, id)
+	if errors.Is(err, jobqueue.ErrorNotFound) {
+		return item, ErrorNotFound
+	}
+
+	return item, err
+}
+
+// Save schedules retrying items at NextAt and lists dead items
+func (s *Store) Save(ctx context.Context, item Item) error {
+	listDead := func(pipe redis.Pipeliner) {
+		pipe.LRem(ctx, s.queue.Key(deadKey), 0, item.Id)
+
+		if item.State == StateDead {
+			pipe.LPush(ctx, s.queue.Key(deadKey), item.Id)
+		}
+	}
+
+	if item.State == StateRetrying {
+		return s.queue.Schedule(ctx, item.Id, item, item.NextAt, listDead)
+	}
+
+	return s.queue.Finish(ctx, item.Id, item, listDead)
+}
+
+// Retrying returns the items waiting for a retry, soonest first
+func (s *Store) Retrying(ctx context.Context) ([]Item, error) {
+	return s.queue.Scheduled(ctx)
+}
+
+// Dead returns the dead-letter items, newest first
+func (s *Store) Dead(ctx context.Context) ([]Item, error) {
+	ids, err := s.client.LRange(ctx, s.queue.Key(deadKey), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queue.Load(ctx, ids)
+}
+
+// Requeue retries a dead item right away with a new budget of attempts
+func (s *Store) Requeue(ctx context.Context, id string) (Item, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+
+	if item.State != StateDead {
+		return item, ErrorNotDead
+	}
+
+	now := s.now().UTC()
+	item.State = StateRetrying
+	item.Retries = 0
+	item.UpdatedAt = now
+	item.NextAt = now
+
+	return item, s.Save(ctx, item)
+}
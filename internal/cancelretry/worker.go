@@ -0,0 +1,189 @@
+package cancelretry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"bitbucket.org/crgw/supplier-hub/internal/history"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/interfaces"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"github.com/rs/zerolog"
+)
+
+const historyOperation = "cancel"
+
+type platforms interface {
+	GetPlatform(string) (any, error)
+}
+
+type Retrier struct {
+	store       *Store
+	options     config.CancelRetry
+	platforms   platforms
+	credentials *credentials.Store
+	history     *history.Store
+	now         func() time.Time
+}
+
+// New retries with platforms of the factory, configuration references are
+// resolved with the credential store for each attempt. Supplier requests of
+// the attempts are offloaded to the history store when it is configured.
+func New(store *Store, o config.CancelRetry, platforms platforms, credentialStore *credentials.Store, historyStore *history.Store) *Retrier {
+	return &Retrier{
+		store:       store,
+		options:     o,
+		platforms:   platforms,
+		credentials: credentialStore,
+		history:     historyStore,
+		now:         time.Now,
+	}
+}
+
+// Run processes due items until the context ends or shutdown starts, items
+// are registered with the lifecycle tracker so shutdown waits for them
+func (r *Retrier) Run(ctx context.Context, lifecycleTracker *lifecycle.Tracker, logger *zerolog.Logger) {
+	r.store.queue.Run(ctx, r.process, lifecycleTracker, logger)
+}
+
+// RunDue processes the items due now and returns how many
+func (r *Retrier) RunDue(ctx context.Context, logger *zerolog.Logger) int {
+	return r.store.queue.RunDue(ctx, r.process, logger)
+}
+
+func (r *Retrier) process(ctx context.Context, item Item, logger *zerolog.Logger) {
+	itemLogger := logger.With().
+		Str("cancelRetryId", item.Id).
+		Str("platform", item.Platform).
+		Str("reservNumber", item.Params.ReservNumber).
+		Logger()
+
+	if item.State == StateRetrying {
+		r.attempt(ctx, &item, &itemLogger)
+	}
+
+	item.UpdatedAt = r.now().UTC()
+
+	err := r.store.Save(ctx, item)
+	if err != nil {
+		itemLogger.Err(err).Msg("Failed saving cancel retry")
+	}
+}
+
+// attempt cancels again, cancellations the supplier reports as already done
+// are answered with OK by the platforms and succeed
+func (r *Retrier) attempt(ctx context.Context, item *Item, logger *zerolog.Logger) {
+	now := r.now().UTC()
+	item.Retries++
+
+	response, err := r.cancel(ctx, *item, logger)
+
+	attempt := Attempt{
+		At:     now,
+		Status: converting.Unwrap(response.Status),
+		Errors: converting.Unwrap(response.Errors),
+	}
+
+	if err == nil {
+		attempt.HistoryId = r.offload(ctx, *item, response, logger)
+	}
+
+	item.Attempts = append(item.Attempts, attempt)
+
+	switch {
+	case err != nil:
+		item.LastError = err.Error()
+	case attempt.Status == schema.CancelResponseStatusOK:
+		item.State = StateSucceeded
+		item.LastError = ""
+		logger.Info().Int("retries", item.Retries).Msg("Cancelled booking on retry")
+		return
+	case !Retryable(response):
+		item.State = StateDead
+		item.LastError = lastError(attempt.Errors)
+		logger.Error().Str("error", item.LastError).Msg("Cancel retry failed by the supplier")
+		return
+	default:
+		item.LastError = lastError(attempt.Errors)
+	}
+
+	if item.Retries >= r.options.MaxAttempts {
+		item.State = StateDead
+		logger.Error().Str("error", item.LastError).Int("retries", item.Retries).Msg("Gave up retrying cancellation")
+		return
+	}
+
+	item.NextAt = now.Add(backoff(r.options, item.Retries))
+}
+
+func (r *Retrier) cancel(ctx context.Context, item Item, logger *zerolog.Logger) (schema.CancelResponse, error) {
+	platform, err := r.platforms.GetPlatform(item.Platform)
+	if err != nil {
+		return schema.CancelResponse{}, err
+	}
+
+	platformWithCancel, ok := platform.(interfaces.WithCancelBooking)
+	if !ok {
+		return schema.CancelResponse{}, fmt.Errorf("%s: cancel not implemented", item.Platform)
+	}
+
+	body, err := json.Marshal(item.Params)
+	if err != nil {
+		return schema.CancelResponse{}, err
+	}
+
+	body, err = r.credentials.Resolve(ctx, item.Platform, item.ClientId, body)
+	if err != nil {
+		return schema.CancelResponse{}, err
+	}
+
+	var params schema.CancelRequestParams
+
+	err = json.Unmarshal(body, &params)
+	if err != nil {
+		return schema.CancelResponse{}, err
+	}
+
+	return platformWithCancel.CancelBooking(ctx, params, logger)
+}
+
+// offload records the supplier requests of the attempt for the client of
+// the cancellation, failures only lose the history
+func (r *Retrier) offload(ctx context.Context, item Item, response schema.CancelResponse, logger *zerolog.Logger) string {
+	if r.history == nil || response.SupplierRequests == nil {
+		return ""
+	}
+
+	record := history.Record{
+		ClientId: item.ClientId,
+		History: schema.SupplierHistory{
+			Platform:         item.Platform,
+			Operation:        historyOperation,
+			SupplierRequests: *response.SupplierRequests,
+		},
+	}
+
+	if item.CorrelationId != "" {
+		record.History.CorrelationId = &item.CorrelationId
+	}
+
+	id, err := r.history.Put(ctx, record)
+	if err != nil {
+		logger.Err(err).Msg("Failed offloading cancel retry history")
+	}
+
+	return id
+}
+
+func lastError(errs schema.SupplierResponseErrors) string {
+	if len(errs) == 0 {
+		return "cancellation failed without errors"
+	}
+
+	return errs[len(errs)-1].Message
+}
@@ -0,0 +1,813 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/health"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/client"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	EnvProduction = "production"
+
+	// BackendFile and BackendRedis name the backends of credentials, history
+	// and the audit log, the audit log only has a file backend
+	BackendFile  = "file"
+	BackendRedis = "redis"
+
+	// ScopeAdmin grants pprof and admin routes, it is never matched by wildcards
+	ScopeAdmin = "admin"
+
+	defaultPort             = 6156
+	defaultOpenApiLocation  = "./api/openapi.json"
+	defaultShutdownTimeout  = 30 * time.Second
+	defaultHistoryRetention = 24 * time.Hour
+	defaultRebookRetention  = 30 * 24 * time.Hour
+
+	defaultPollInterval    = 30 * time.Second
+	defaultMaxPollInterval = 30 * time.Minute
+	defaultMaxAge          = 48 * time.Hour
+	defaultMaxDeliveries   = 10
+
+	defaultBackoff     = time.Minute
+	defaultMaxBackoff  = time.Hour
+	defaultMaxAttempts = 8
+
+	defaultAuditMaxFileSize = 100 << 20
+
+	// keySize is the size of AES-256 keys
+	keySize = 32
+)
+
+var (
+	ErrorInvalidConfig = errors.New("invalid configuration")
+	ErrorInvalidValue  = errors.New("invalid configuration value")
+	ErrorInvalidScope  = errors.New("scope must be admin or <platform>:<operation>")
+	ErrorInvalidKey    = errors.New("credentials key must be 32 base64 encoded bytes")
+)
+
+// platformName is a single path segment, platforms are routed by /:platform
+var platformName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+type Config struct {
+	// Env is "production" in production, other values only matter to logging
+	Env string `yaml:"env"`
+	// Test makes the output deterministic and listens on localhost only
+	Test         bool         `yaml:"test"`
+	LogLevel     string       `yaml:"logLevel"`
+	Server       Server       `yaml:"server"`
+	Admin        Admin        `yaml:"admin"`
+	Auth         Auth         `yaml:"auth"`
+	Quota        Quota        `yaml:"quota"`
+	Cache        Cache        `yaml:"cache"`
+	Redis        Redis        `yaml:"redis"`
+	Services     Services     `yaml:"services"`
+	Credentials  Credentials  `yaml:"credentials"`
+	History      History      `yaml:"history"`
+	Health       Health       `yaml:"health"`
+	Shutdown     Shutdown     `yaml:"shutdown"`
+	Faults       Faults       `yaml:"faults"`
+	Trafficlight Trafficlight `yaml:"trafficlight"`
+	Remote       Remote       `yaml:"remote"`
+	Rebook       Rebook       `yaml:"rebook"`
+	Tracking     Tracking     `yaml:"tracking"`
+	CancelRetry  CancelRetry  `yaml:"cancelRetry"`
+	Audit        Audit        `yaml:"audit"`
+}
+
+type Server struct {
+	Port int `yaml:"port"`
+	// AdminPort serves pprof and admin routes on a separate listener when set,
+	// on the public listener they need the admin api key
+	AdminPort       int    `yaml:"adminPort"`
+	OpenApiLocation string `yaml:"openApiLocation"`
+}
+
+type Admin struct {
+	// ApiKey protects the admin routes, they are disabled when empty
+	ApiKey string `yaml:"apiKey"`
+}
+
+// Auth protects all routes except status and health, pprof and admin routes
+// need the admin scope unless they are served on the admin port
+type Auth struct {
+	Enabled bool         `yaml:"enabled"`
+	Clients []AuthClient `yaml:"clients"`
+	Jwt     Jwt          `yaml:"jwt"`
+	// HmacMaxSkew bounds the age of signed requests
+	HmacMaxSkew time.Duration `yaml:"hmacMaxSkew"`
+}
+
+// AuthClient is a caller known to the hub, the api keys are sha256 hex digests
+// so the configuration does not hold usable secrets
+type AuthClient struct {
+	Id           string   `yaml:"id"`
+	ApiKeySha256 []string `yaml:"apiKeySha256"`
+	HmacSecret   string   `yaml:"hmacSecret"`
+	Scopes       []string `yaml:"scopes"`
+}
+
+// Jwt configures validation of bearer tokens against a local JWKS file
+type Jwt struct {
+	JwksPath string `yaml:"jwksPath"`
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+}
+
+// QuotaLimit bounds every client matching Client on every scope matching Scope,
+// each client and scope pair gets its own slots and bucket. Zero values are unlimited.
+type QuotaLimit struct {
+	// Client is a client id, empty or * matches all clients
+	Client string `yaml:"client"`
+	// Scope is <platform>:<operation>, both may be *, empty matches all
+	Scope       string `yaml:"scope"`
+	Concurrency int    `yaml:"concurrency"`
+	// Rate is the number of requests per second, Burst defaults to the rate
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// Quota bounds platform requests per client, see the quota package
+type Quota struct {
+	Enabled bool `yaml:"enabled"`
+	// Capacity bounds concurrent platform requests of all clients together
+	Capacity int `yaml:"capacity"`
+	// Reserved slots of the capacity are only used by reserved operations
+	Reserved           int      `yaml:"reserved"`
+	ReservedOperations []string `yaml:"reservedOperations"`
+	// Limits are matched in order, the first match applies
+	Limits []QuotaLimit `yaml:"limits"`
+}
+
+// Cache configures the responses cache, see caching.NewFromOptions
+type Cache struct {
+	// Engine one of redis, memory or tiered, defaults to redis
+	Engine string `yaml:"engine"`
+	// Codec one of deflate, zstd or snappy, defaults to deflate
+	Codec string `yaml:"codec"`
+	// LocalTtl caps how long the tiered engine keeps values in memory
+	LocalTtl time.Duration `yaml:"localTtl"`
+}
+
+// Options converts the cache to the options of the caching package
+func (c Cache) Options() caching.Options {
+	return caching.Options(c)
+}
+
+type Redis struct {
+	// Required exits at startup when a client is not reachable
+	Required bool `yaml:"required"`
+	// Clients by name, see redisfactory for the names in use
+	Clients map[string]RedisClient `yaml:"clients"`
+}
+
+// RedisClient configures a named client, see redisfactory.ClientOptions
+type RedisClient struct {
+	Mode             string        `yaml:"mode"`
+	Uri              string        `yaml:"uri"`
+	MasterName       string        `yaml:"masterName"`
+	SentinelPassword string        `yaml:"sentinelPassword"`
+	Tls              bool          `yaml:"tls"`
+	PoolSize         int           `yaml:"poolSize"`
+	DialTimeout      time.Duration `yaml:"dialTimeout"`
+	ReadTimeout      time.Duration `yaml:"readTimeout"`
+	WriteTimeout     time.Duration `yaml:"writeTimeout"`
+}
+
+// Options converts the client to the options of the redisfactory
+func (r RedisClient) Options() redisfactory.ClientOptions {
+	return redisfactory.ClientOptions(r)
+}
+
+// ClientOptions are the options of all clients for redisfactory.New
+func (r Redis) ClientOptions() map[string]redisfactory.ClientOptions {
+	options := make(map[string]redisfactory.ClientOptions, len(r.Clients))
+	for name, client := range r.Clients {
+		options[name] = client.Options()
+	}
+
+	return options
+}
+
+// Services are the internal services bookings are paid through
+type Services struct {
+	// Domain is used for services without an explicit url, e.g. spit.<domain>
+	Domain         string `yaml:"domain"`
+	UserServiceUrl string `yaml:"userServiceUrl"`
+	SpitUrl        string `yaml:"spitUrl"`
+	Username       string `yaml:"username"`
+	Password       string `yaml:"password"`
+}
+
+// Credentials configure the store of supplier configuration referenced by configurationRef
+type Credentials struct {
+	// Backend is file or redis, references are rejected when empty
+	Backend string `yaml:"backend"`
+	// Path is the directory of the file backend
+	Path string `yaml:"path"`
+	// Key is the base64 encoded AES-256 key, prefer setting it in the environment
+	Key string `yaml:"key"`
+}
+
+// History configures how supplier requests are returned by platform routes
+type History struct {
+	// DefaultMode applies when the caller does not choose one, see schema.HistoryMode
+	DefaultMode string `yaml:"defaultMode"`
+	// Backend is file or redis, offloading is rejected when empty
+	Backend string `yaml:"backend"`
+	// Path is the directory of the file backend
+	Path string `yaml:"path"`
+	// Retention bounds how long offloaded history can be fetched
+	Retention time.Duration `yaml:"retention"`
+}
+
+type Health struct {
+	// Optional dependencies do not fail readiness
+	Optional []string `yaml:"optional"`
+	// SupplierProbes lists platform=url pairs probed on demand
+	SupplierProbes string `yaml:"supplierProbes"`
+}
+
+type Shutdown struct {
+	// ReadinessDelay gives load balancers time to notice failing readiness
+	ReadinessDelay time.Duration `yaml:"readinessDelay"`
+	// Timeout bounds waiting for in-flight requests and background work
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Faults break supplier exchanges for resilience testing, they are not
+// available in production
+type Faults struct {
+	// Catalog adds to requesting.DefaultFaults, entries of the same name replace them
+	Catalog []requesting.Fault `yaml:"catalog"`
+	// Active faults apply to every request, callers select others with the
+	// requesting.FaultHeader header
+	Active []string `yaml:"active"`
+}
+
+// Remote platforms are served by adapters of other services speaking the
+// api/openapi.json contract, operations are posted to <url>/<operation>
+type Remote struct {
+	// Platforms are the base urls of the adapters by platform name, e.g.
+	// http://adapter.local/partner for an adapter serving /partner/rates
+	Platforms map[string]string `yaml:"platforms"`
+}
+
+// Trafficlight groups identical rates requests, see the grouping package
+type Trafficlight struct {
+	// ExposeRole answers rates with the grouping role header for load tests,
+	// it is always exposed in test mode
+	ExposeRole bool `yaml:"exposeRole"`
+}
+
+// Rebook modifies bookings of platforms without a modify operation by
+// booking again and cancelling the old booking, see the rebook package
+type Rebook struct {
+	Enabled bool `yaml:"enabled"`
+	// Retention bounds how long rebookings can be continued and reported
+	Retention time.Duration `yaml:"retention"`
+}
+
+// Tracking polls PENDING bookings and delivers their final status to the
+// callback url of the booking request, see the tracking package
+type Tracking struct {
+	Enabled bool `yaml:"enabled"`
+	// CallbackSecret signs callbacks like HMAC authenticated requests to the
+	// hub, see signing.Sign
+	CallbackSecret string `yaml:"callbackSecret"`
+	// PollInterval is the first wait for a status, waits double up to
+	// MaxPollInterval. Callback retries back off the same way.
+	PollInterval    time.Duration `yaml:"pollInterval"`
+	MaxPollInterval time.Duration `yaml:"maxPollInterval"`
+	// MaxAge stops polling, the last status is delivered as not final
+	MaxAge time.Duration `yaml:"maxAge"`
+	// MaxDeliveries bounds the attempts to deliver a callback
+	MaxDeliveries int `yaml:"maxDeliveries"`
+	// AllowPrivateCallbacks delivers callbacks to private, loopback and
+	// link-local addresses, they are refused when connecting otherwise
+	AllowPrivateCallbacks bool `yaml:"allowPrivateCallbacks"`
+}
+
+// CancelRetry retries cancellations that failed by timeouts or connection
+// errors, see the cancelretry package
+type CancelRetry struct {
+	Enabled bool `yaml:"enabled"`
+	// Backoff is the first wait before a retry, waits double up to MaxBackoff
+	Backoff    time.Duration `yaml:"backoff"`
+	MaxBackoff time.Duration `yaml:"maxBackoff"`
+	// MaxAttempts bounds the retries before an item is dead, requeueing
+	// starts over
+	MaxAttempts int `yaml:"maxAttempts"`
+}
+
+// Audit configures the append-only log of booking, modify and cancel requests
+type Audit struct {
+	// Backend is file, the audit log is disabled when empty
+	Backend string `yaml:"backend"`
+	// Path is the directory of the file backend. It is per instance, the
+	// admin audit endpoint of an instance only finds the records it appended.
+	Path string `yaml:"path"`
+	// MaxFileSize rotates the file backend, in bytes
+	MaxFileSize int `yaml:"maxFileSize"`
+}
+
+// Names lists the remote platforms sorted
+func (r Remote) Names() []string {
+	names := make([]string, 0, len(r.Platforms))
+	for name := range r.Platforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (r Remote) validate() []string {
+	var problems []string
+
+	for _, name := range r.Names() {
+		if !platformName.MatchString(name) {
+			problems = append(problems, fmt.Sprintf("remote platform name %q is invalid", name))
+		}
+
+		if builtinPlatform(name) {
+			problems = append(problems, fmt.Sprintf("remote platform %s is built in", name))
+		}
+
+		if u, err := url.Parse(r.Platforms[name]); err != nil || u.Scheme == "" || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("remote platform %s needs an absolute url", name))
+		}
+	}
+
+	return problems
+}
+
+// builtinPlatform tells whether the platform is compiled into the service
+func builtinPlatform(name string) bool {
+	switch schema.RequiredPlatformInPath(name) {
+	case schema.RequiredPlatformInPathAnyrent,
+		schema.RequiredPlatformInPathBookingcom,
+		schema.RequiredPlatformInPathHertz,
+		schema.RequiredPlatformInPathOta,
+		schema.RequiredPlatformInPathProfitmaxdht,
+		schema.RequiredPlatformInPathRently,
+		schema.RequiredPlatformInPathSandbox:
+		return true
+	}
+
+	return false
+}
+
+// Available lists the faults by name
+func (f Faults) Available() map[string]requesting.Fault {
+	available := make(map[string]requesting.Fault)
+
+	for _, fault := range append(requesting.DefaultFaults(), f.Catalog...) {
+		available[fault.Name] = fault
+	}
+
+	return available
+}
+
+func (f Faults) validate(production bool) []string {
+	var problems []string
+
+	for _, fault := range f.Catalog {
+		if err := fault.Validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	available := f.Available()
+
+	for _, name := range f.Active {
+		if _, ok := available[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s %q", requesting.ErrorUnknownFault, name))
+		}
+	}
+
+	if production && len(f.Active) > 0 {
+		problems = append(problems, "faults must not be active in production")
+	}
+
+	return problems
+}
+
+func (c *Config) Production() bool {
+	return c.Env == EnvProduction
+}
+
+func (s Services) UserServiceOptions() []client.OptionFunc {
+	return s.options(s.UserServiceUrl)
+}
+
+func (s Services) SpitOptions() []client.OptionFunc {
+	return s.options(s.SpitUrl)
+}
+
+func (s Services) options(baseURL string) []client.OptionFunc {
+	return []client.OptionFunc{
+		client.WithServiceDomain(s.Domain),
+		client.WithBaseURL(baseURL),
+	}
+}
+
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Port:            defaultPort,
+			OpenApiLocation: defaultOpenApiLocation,
+		},
+		Cache: Cache{
+			Engine: caching.EngineRedis,
+			Codec:  caching.Deflate.Name(),
+		},
+		Redis: Redis{
+			Clients: make(map[string]RedisClient),
+		},
+		History: History{
+			DefaultMode: string(schema.HistoryModeFull),
+			Retention:   defaultHistoryRetention,
+		},
+		Shutdown: Shutdown{
+			Timeout: defaultShutdownTimeout,
+		},
+		Rebook: Rebook{
+			Retention: defaultRebookRetention,
+		},
+		Tracking: Tracking{
+			PollInterval:    defaultPollInterval,
+			MaxPollInterval: defaultMaxPollInterval,
+			MaxAge:          defaultMaxAge,
+			MaxDeliveries:   defaultMaxDeliveries,
+		},
+		CancelRetry: CancelRetry{
+			Backoff:     defaultBackoff,
+			MaxBackoff:  defaultMaxBackoff,
+			MaxAttempts: defaultMaxAttempts,
+		},
+		Audit: Audit{
+			MaxFileSize: defaultAuditMaxFileSize,
+		},
+	}
+}
+
+// Load reads the YAML file named by CONFIG_FILE, if any, and the environment
+func Load() (*Config, error) {
+	return LoadFrom(os.Getenv("CONFIG_FILE"), os.Getenv)
+}
+
+// LoadFrom applies defaults, the YAML file and then environment variables that are set,
+// the result is validated
+func LoadFrom(path string, getenv func(string) string) (*Config, error) {
+	c := Default()
+
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrorInvalidConfig, err)
+		}
+
+		err = yaml.Unmarshal(content, c)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrorInvalidConfig, path, err)
+		}
+	}
+
+	err := c.applyEnv(getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (a Auth) validate() []string {
+	if !a.Enabled {
+		return nil
+	}
+
+	var problems []string
+
+	if len(a.Clients) == 0 && a.Jwt.JwksPath == "" {
+		problems = append(problems, "auth needs clients or a jwks path")
+	}
+
+	ids := make(map[string]bool)
+
+	for _, client := range a.Clients {
+		if client.Id == "" || ids[client.Id] {
+			problems = append(problems, fmt.Sprintf("auth client id %q is empty or duplicated", client.Id))
+		}
+		ids[client.Id] = true
+
+		for _, scope := range client.Scopes {
+			if err := ValidateScope(scope); err != nil {
+				problems = append(problems, fmt.Sprintf("auth client %s: %s: %s", client.Id, err, scope))
+			}
+		}
+	}
+
+	return problems
+}
+
+// ValidateScope accepts the admin scope and <platform>:<operation> scopes
+func ValidateScope(scope string) error {
+	if scope == ScopeAdmin {
+		return nil
+	}
+
+	platform, operation, ok := strings.Cut(scope, ":")
+	if !ok || platform == "" || operation == "" || strings.Contains(operation, ":") {
+		return ErrorInvalidScope
+	}
+
+	return nil
+}
+
+func (q Quota) validate() []string {
+	var problems []string
+
+	if q.Capacity < 0 || q.Reserved < 0 {
+		problems = append(problems, "quota capacity and reserved must not be negative")
+	}
+
+	if q.Reserved > 0 && q.Reserved >= q.Capacity {
+		problems = append(problems, fmt.Sprintf("quota reserved %d must be less than the capacity %d", q.Reserved, q.Capacity))
+	}
+
+	for _, limit := range q.Limits {
+		if limit.Concurrency < 0 || limit.Rate < 0 || limit.Burst < 0 {
+			problems = append(problems, fmt.Sprintf("quota limit %s %s must not be negative", limit.Client, limit.Scope))
+		}
+
+		if limit.Scope != "" {
+			platform, operation, ok := strings.Cut(limit.Scope, ":")
+			if !ok || platform == "" || operation == "" {
+				problems = append(problems, fmt.Sprintf("quota limit scope %s must be <platform>:<operation>", limit.Scope))
+			}
+		}
+	}
+
+	return problems
+}
+
+// DecodeKey decodes the base64 encoded key
+func (c Credentials) DecodeKey() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(c.Key)
+	if err != nil || len(key) != keySize {
+		return nil, ErrorInvalidKey
+	}
+
+	return key, nil
+}
+
+func (c Credentials) validate() []string {
+	var problems []string
+
+	switch c.Backend {
+	case "", BackendRedis:
+	case BackendFile:
+		if c.Path == "" {
+			problems = append(problems, "credentials path is required by the file backend")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown credentials backend: %s", c.Backend))
+	}
+
+	if c.Backend != "" {
+		if _, err := c.DecodeKey(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return problems
+}
+
+func (h History) validate() []string {
+	var problems []string
+
+	mode := schema.HistoryMode(h.DefaultMode)
+
+	switch mode {
+	case "", schema.HistoryModeNone, schema.HistoryModeMetadata, schema.HistoryModeFull, schema.HistoryModeOffload:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown history mode: %q", h.DefaultMode))
+	}
+
+	switch h.Backend {
+	case "", BackendRedis:
+	case BackendFile:
+		if h.Path == "" {
+			problems = append(problems, "history path is required by the file backend")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown history backend: %s", h.Backend))
+	}
+
+	if mode == schema.HistoryModeOffload && h.Backend == "" {
+		problems = append(problems, "history offload mode needs a backend")
+	}
+
+	if h.Retention < 0 {
+		problems = append(problems, "history retention must not be negative")
+	}
+
+	return problems
+}
+
+func (t Tracking) validate() []string {
+	if !t.Enabled {
+		return nil
+	}
+
+	var problems []string
+
+	if t.CallbackSecret == "" {
+		problems = append(problems, "tracking callback secret is required")
+	}
+
+	if t.PollInterval <= 0 || t.MaxPollInterval < t.PollInterval {
+		problems = append(problems, fmt.Sprintf("tracking poll interval %s must be positive and not above the max poll interval %s", t.PollInterval, t.MaxPollInterval))
+	}
+
+	if t.MaxAge <= 0 || t.MaxDeliveries <= 0 {
+		problems = append(problems, "tracking max age and max deliveries must be positive")
+	}
+
+	return problems
+}
+
+func (r CancelRetry) validate() []string {
+	if !r.Enabled {
+		return nil
+	}
+
+	var problems []string
+
+	if r.Backoff <= 0 || r.MaxBackoff < r.Backoff {
+		problems = append(problems, fmt.Sprintf("cancel retry backoff %s must be positive and not above the max backoff %s", r.Backoff, r.MaxBackoff))
+	}
+
+	if r.MaxAttempts <= 0 {
+		problems = append(problems, "cancel retry max attempts must be positive")
+	}
+
+	return problems
+}
+
+func (a Audit) validate() []string {
+	switch a.Backend {
+	case "":
+		return nil
+	case BackendFile:
+	default:
+		return []string{fmt.Sprintf("unknown audit backend: %q", a.Backend)}
+	}
+
+	var problems []string
+
+	if a.Path == "" {
+		problems = append(problems, "audit path is required for the file backend")
+	}
+
+	if a.MaxFileSize <= 0 {
+		problems = append(problems, "audit max file size must be positive")
+	}
+
+	return problems
+}
+
+// requiredRedisClients are validated at startup, other clients are created on first use
+func (c *Config) requiredRedisClients() []string {
+	names := []string{redisfactory.Trafficlight, redisfactory.ResponsesCache}
+
+	if c.Credentials.Backend == BackendRedis {
+		names = append(names, redisfactory.Credentials)
+	}
+
+	if c.History.Backend == BackendRedis {
+		names = append(names, redisfactory.History)
+	}
+
+	if c.Rebook.Enabled {
+		names = append(names, redisfactory.Rebook)
+	}
+
+	if c.Tracking.Enabled {
+		names = append(names, redisfactory.Tracking)
+	}
+
+	if c.CancelRetry.Enabled {
+		names = append(names, redisfactory.CancelRetry)
+	}
+
+	return names
+}
+
+// Validate reports all missing and invalid values at once
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("server port %d is out of range", c.Server.Port))
+	}
+
+	if c.Server.AdminPort < 0 || c.Server.AdminPort > 65535 || (c.Server.AdminPort != 0 && c.Server.AdminPort == c.Server.Port) {
+		problems = append(problems, fmt.Sprintf("server admin port %d is invalid", c.Server.AdminPort))
+	}
+
+	problems = append(problems, c.Auth.validate()...)
+
+	problems = append(problems, c.Quota.validate()...)
+
+	switch c.Cache.Engine {
+	case "", caching.EngineRedis, caching.EngineMemory, caching.EngineTiered:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown cache engine %s", c.Cache.Engine))
+	}
+
+	if _, err := caching.CodecByName(c.Cache.Codec); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	for _, name := range c.requiredRedisClients() {
+		if _, ok := c.Redis.Clients[name]; !ok {
+			problems = append(problems, fmt.Sprintf("redis %s: %s", name, redisfactory.ErrorMissingUri))
+		}
+	}
+
+	names := make([]string, 0, len(c.Redis.Clients))
+	for name := range c.Redis.Clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := c.Redis.Clients[name].Options().Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("redis %s: %s", name, err))
+		}
+	}
+
+	if c.Services.Username == "" || c.Services.Password == "" {
+		problems = append(problems, "services username and password are required")
+	}
+
+	if c.Services.Domain == "" && c.Services.UserServiceUrl == "" {
+		problems = append(problems, "services domain or user service url is required")
+	}
+
+	if c.Services.Domain == "" && c.Services.SpitUrl == "" {
+		problems = append(problems, "services domain or spit url is required")
+	}
+
+	problems = append(problems, c.Credentials.validate()...)
+
+	problems = append(problems, c.History.validate()...)
+
+	if c.Rebook.Retention < 0 {
+		problems = append(problems, "rebook retention must not be negative")
+	}
+
+	problems = append(problems, c.Tracking.validate()...)
+
+	problems = append(problems, c.CancelRetry.validate()...)
+
+	problems = append(problems, c.Audit.validate()...)
+
+	if _, err := health.SupplierChecks(c.Health.SupplierProbes); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	problems = append(problems, c.Faults.validate(c.Production())...)
+
+	problems = append(problems, c.Remote.validate()...)
+
+	if c.Shutdown.ReadinessDelay < 0 || c.Shutdown.Timeout < 0 {
+		problems = append(problems, "shutdown durations must not be negative")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrorInvalidConfig, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,429 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/client"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/stretchr/testify/assert"
+)
+
+func lookup(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func requiredEnv() map[string]string {
+	return map[string]string{
+		"TRAFFICLIGHT_REDIS_URI":    "redis://localhost/2",
+		"RESPONSES_CACHE_REDIS_URI": "redis://localhost/1",
+		"CRG_URL_USER_SERVICE":      "http://user-service.local",
+		"CRG_URL_SPIT":              "http://spit.local",
+		"CRG_USERNAME":              "service.supplier-hub",
+		"CRG_PASSWORD":              "secret",
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should apply defaults", func(t *testing.T) {
+		cfg, err := config.LoadFrom("", lookup(requiredEnv()))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 6156, cfg.Server.Port)
+		assert.Equal(t, "./api/openapi.json", cfg.Server.OpenApiLocation)
+		assert.Equal(t, "redis", cfg.Cache.Engine)
+		assert.Equal(t, "deflate", cfg.Cache.Codec)
+		assert.Equal(t, 30*time.Second, cfg.Shutdown.Timeout)
+		assert.False(t, cfg.Production())
+		assert.Equal(t, "redis://localhost/2", cfg.Redis.Clients[redisfactory.Trafficlight].Uri)
+	})
+
+	t.Run("should read the yaml file", func(t *testing.T) {
+		cfg, err := config.LoadFrom("./testdata/config.yaml", lookup(map[string]string{}))
+
+		assert.Nil(t, err)
+		assert.True(t, cfg.Production())
+		assert.Equal(t, 8080, cfg.Server.Port)
+		assert.Equal(t, "tiered", cfg.Cache.Engine)
+		assert.Equal(t, 30*time.Second, cfg.Cache.LocalTtl)
+		assert.True(t, cfg.Redis.Required)
+		assert.Equal(t, 20, cfg.Redis.Clients[redisfactory.Trafficlight].PoolSize)
+		assert.Equal(t, []string{"spit"}, cfg.Health.Optional)
+		assert.Equal(t, time.Minute, cfg.Shutdown.Timeout)
+		// defaults are kept for values missing in the file
+		assert.Equal(t, "./api/openapi.json", cfg.Server.OpenApiLocation)
+	})
+
+	t.Run("should override the yaml file with the environment", func(t *testing.T) {
+		cfg, err := config.LoadFrom("./testdata/config.yaml", lookup(map[string]string{
+			"PORT":                            "9090",
+			"TRAFFICLIGHT_REDIS_URI":          "redis://trafficlight/2",
+			"RESPONSES_CACHE_REDIS_POOL_SIZE": "5",
+			"HEALTH_OPTIONAL":                 "spit, userservice",
+		}))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 9090, cfg.Server.Port)
+		assert.Equal(t, "redis://trafficlight/2", cfg.Redis.Clients[redisfactory.Trafficlight].Uri)
+		assert.Equal(t, 20, cfg.Redis.Clients[redisfactory.Trafficlight].PoolSize)
+		assert.Equal(t, 5, cfg.Redis.Clients[redisfactory.ResponsesCache].PoolSize)
+		assert.Equal(t, []string{"spit", "userservice"}, cfg.Health.Optional)
+	})
+
+	t.Run("should fail on unparsable values", func(t *testing.T) {
+		env := requiredEnv()
+		env["SHUTDOWN_TIMEOUT"] = "soon"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidValue)
+	})
+
+	t.Run("should fail on a missing file", func(t *testing.T) {
+		_, err := config.LoadFrom("./testdata/missing.yaml", lookup(requiredEnv()))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+	})
+
+	t.Run("should report all missing values", func(t *testing.T) {
+		_, err := config.LoadFrom("", lookup(map[string]string{
+			"CACHE_CODEC": "lz4",
+		}))
+
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "redis trafficlight")
+		assert.Contains(t, err.Error(), "redis responses-cache")
+		assert.Contains(t, err.Error(), "username and password")
+		assert.Contains(t, err.Error(), "spit url")
+		assert.Contains(t, err.Error(), "lz4")
+	})
+}
+
+func TestCredentials(t *testing.T) {
+	t.Run("should require a key for the credentials backend", func(t *testing.T) {
+		env := requiredEnv()
+		env["CREDENTIALS_BACKEND"] = "file"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "credentials path")
+		assert.Contains(t, err.Error(), "32 base64 encoded bytes")
+	})
+
+	t.Run("should require the redis client of the redis backend", func(t *testing.T) {
+		env := requiredEnv()
+		env["CREDENTIALS_BACKEND"] = "redis"
+		env["CREDENTIALS_KEY"] = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "redis credentials")
+
+		env["CREDENTIALS_REDIS_URI"] = "redis://localhost/3"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+		assert.Nil(t, err)
+		assert.Equal(t, "redis://localhost/3", cfg.Redis.Clients[redisfactory.Credentials].Uri)
+	})
+
+	t.Run("should decode base64 keys", func(t *testing.T) {
+		key, err := config.Credentials{Key: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="}.DecodeKey()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("0123456789abcdef0123456789abcdef"), key)
+
+		_, err = config.Credentials{Key: "c2hvcnQ="}.DecodeKey()
+		assert.ErrorIs(t, err, config.ErrorInvalidKey)
+	})
+}
+
+func TestAuth(t *testing.T) {
+	t.Run("should require clients or a jwks path when enabled", func(t *testing.T) {
+		env := requiredEnv()
+		env["AUTH_ENABLED"] = "true"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "auth needs clients")
+
+		env["AUTH_JWKS_PATH"] = "/etc/supplier-hub/jwks.json"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+		assert.Nil(t, err)
+		assert.True(t, cfg.Auth.Enabled)
+	})
+
+	t.Run("should reject an admin port equal to the server port", func(t *testing.T) {
+		env := requiredEnv()
+		env["ADMIN_PORT"] = "6156"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "admin port")
+	})
+
+	t.Run("should validate scopes", func(t *testing.T) {
+		assert.Nil(t, config.ValidateScope("hertz:rates"))
+		assert.Nil(t, config.ValidateScope(config.ScopeAdmin))
+		assert.ErrorIs(t, config.ValidateScope("hertz"), config.ErrorInvalidScope)
+		assert.ErrorIs(t, config.ValidateScope("hertz:rates:all"), config.ErrorInvalidScope)
+	})
+}
+
+func TestQuota(t *testing.T) {
+	t.Run("should keep capacity for other operations", func(t *testing.T) {
+		cfg, err := config.LoadFrom("", lookup(requiredEnv()))
+		assert.Nil(t, err)
+
+		cfg.Quota = config.Quota{Capacity: 2, Reserved: 2}
+		assert.ErrorContains(t, cfg.Validate(), "quota reserved 2 must be less than the capacity 2")
+
+		cfg.Quota = config.Quota{Reserved: 1}
+		assert.NotNil(t, cfg.Validate())
+
+		cfg.Quota = config.Quota{Capacity: 2, Reserved: 1}
+		assert.Nil(t, cfg.Validate())
+	})
+
+	t.Run("should reject invalid limits", func(t *testing.T) {
+		cfg, err := config.LoadFrom("", lookup(requiredEnv()))
+		assert.Nil(t, err)
+
+		cfg.Quota = config.Quota{Limits: []config.QuotaLimit{{Scope: "rates"}}}
+		assert.ErrorContains(t, cfg.Validate(), "quota limit scope rates")
+
+		cfg.Quota = config.Quota{Limits: []config.QuotaLimit{{Rate: -1}}}
+		assert.ErrorContains(t, cfg.Validate(), "must not be negative")
+
+		cfg.Quota = config.Quota{Limits: []config.QuotaLimit{{Scope: "*:rates", Rate: 10}}}
+		assert.Nil(t, cfg.Validate())
+	})
+}
+
+func TestHistory(t *testing.T) {
+	t.Run("should require a backend to offload by default", func(t *testing.T) {
+		env := requiredEnv()
+		env["HISTORY_DEFAULT_MODE"] = "offload"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "history offload mode needs a backend")
+
+		env["HISTORY_BACKEND"] = "file"
+		env["HISTORY_PATH"] = "/var/lib/supplier-hub/history"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+		assert.Nil(t, err)
+		assert.Equal(t, 24*time.Hour, cfg.History.Retention)
+	})
+
+	t.Run("should reject unknown modes", func(t *testing.T) {
+		env := requiredEnv()
+		env["HISTORY_DEFAULT_MODE"] = "bodies"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "unknown history mode")
+	})
+}
+
+func TestFaults(t *testing.T) {
+	t.Run("should activate default and configured faults", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.Faults = config.Faults{
+			Catalog: []requesting.Fault{{Name: "reset", Kind: requesting.FaultStatus, Status: 502}},
+			Active:  []string{"reset", "invalid"},
+		}
+
+		assert.Equal(t, requesting.FaultStatus, cfg.Faults.Available()["reset"].Kind)
+		assert.Equal(t, requesting.FaultInvalid, cfg.Faults.Available()["invalid"].Kind)
+
+		env := requiredEnv()
+		env["FAULTS_ACTIVE"] = "latency, truncated"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"latency", "truncated"}, cfg.Faults.Active)
+	})
+
+	t.Run("should reject unknown and production faults", func(t *testing.T) {
+		env := requiredEnv()
+		env["ENV"] = "production"
+		env["FAULTS_ACTIVE"] = "reset,flood"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), `unknown fault "flood"`)
+		assert.Contains(t, err.Error(), "faults must not be active in production")
+	})
+}
+
+func TestServices(t *testing.T) {
+	t.Run("should prefer explicit urls over the domain", func(t *testing.T) {
+		services := config.Services{
+			Domain:  "services.internal",
+			SpitUrl: "http://spit.local",
+		}
+
+		spit, _ := client.NewOptions(services.SpitOptions()...)
+		assert.Equal(t, "http://spit.local", spit.BaseURL("spit", ""))
+
+		userService, _ := client.NewOptions(services.UserServiceOptions()...)
+		assert.Equal(t, "http://user-service.services.internal", userService.BaseURL("user-service", ""))
+	})
+}
+
+func TestRemote(t *testing.T) {
+	t.Run("should read remote platforms from the environment", func(t *testing.T) {
+		env := requiredEnv()
+		env["REMOTE_PLATFORMS"] = "partner=http://adapter.local/partner, other=https://other.local"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"other", "partner"}, cfg.Remote.Names())
+		assert.Equal(t, "http://adapter.local/partner", cfg.Remote.Platforms["partner"])
+	})
+
+	t.Run("should fail on unparsable pairs", func(t *testing.T) {
+		env := requiredEnv()
+		env["REMOTE_PLATFORMS"] = "http://adapter.local"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidValue)
+	})
+
+	t.Run("should reject built in platforms, invalid names and relative urls", func(t *testing.T) {
+		env := requiredEnv()
+		env["REMOTE_PLATFORMS"] = "hertz=http://adapter.local,Partner/1=http://adapter.local,partner=/partner"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "remote platform hertz is built in")
+		assert.Contains(t, err.Error(), `remote platform name "Partner/1" is invalid`)
+		assert.Contains(t, err.Error(), "remote platform partner needs an absolute url")
+	})
+}
+
+func TestRebook(t *testing.T) {
+	t.Run("should require the rebook redis client when enabled", func(t *testing.T) {
+		env := requiredEnv()
+		env["REBOOK_ENABLED"] = "true"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "redis rebook")
+
+		env["REBOOK_REDIS_URI"] = "redis://localhost/3"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+		assert.Nil(t, err)
+		assert.Equal(t, 30*24*time.Hour, cfg.Rebook.Retention)
+	})
+}
+
+func TestTracking(t *testing.T) {
+	t.Run("should require a callback secret and the tracking redis client when enabled", func(t *testing.T) {
+		env := requiredEnv()
+		env["TRACKING_ENABLED"] = "true"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "redis tracking")
+		assert.Contains(t, err.Error(), "tracking callback secret is required")
+
+		env["TRACKING_REDIS_URI"] = "redis://localhost/4"
+		env["TRACKING_CALLBACK_SECRET"] = "secret"
+		env["TRACKING_POLL_INTERVAL"] = "10s"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+		assert.Nil(t, err)
+		assert.Equal(t, 10*time.Second, cfg.Tracking.PollInterval)
+		assert.Equal(t, 30*time.Minute, cfg.Tracking.MaxPollInterval)
+		assert.Equal(t, 10, cfg.Tracking.MaxDeliveries)
+	})
+
+	t.Run("should reject poll intervals above the max poll interval", func(t *testing.T) {
+		env := requiredEnv()
+		env["TRACKING_ENABLED"] = "true"
+		env["TRACKING_REDIS_URI"] = "redis://localhost/4"
+		env["TRACKING_CALLBACK_SECRET"] = "secret"
+		env["TRACKING_POLL_INTERVAL"] = "1h"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "tracking poll interval")
+	})
+}
+
+func TestCancelRetry(t *testing.T) {
+	t.Run("should require the cancel retry redis client when enabled", func(t *testing.T) {
+		env := requiredEnv()
+		env["CANCEL_RETRY_ENABLED"] = "true"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "redis cancel-retry")
+
+		env["CANCEL_RETRY_REDIS_URI"] = "redis://localhost/5"
+		env["CANCEL_RETRY_MAX_ATTEMPTS"] = "3"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+		assert.Nil(t, err)
+		assert.Equal(t, time.Minute, cfg.CancelRetry.Backoff)
+		assert.Equal(t, time.Hour, cfg.CancelRetry.MaxBackoff)
+		assert.Equal(t, 3, cfg.CancelRetry.MaxAttempts)
+	})
+
+	t.Run("should reject backoffs above the max backoff", func(t *testing.T) {
+		env := requiredEnv()
+		env["CANCEL_RETRY_ENABLED"] = "true"
+		env["CANCEL_RETRY_REDIS_URI"] = "redis://localhost/5"
+		env["CANCEL_RETRY_BACKOFF"] = "2h"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "cancel retry backoff")
+	})
+}
+
+func TestAudit(t *testing.T) {
+	t.Run("should require a path for the file backend", func(t *testing.T) {
+		env := requiredEnv()
+		env["AUDIT_BACKEND"] = "file"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "audit path is required")
+
+		env["AUDIT_PATH"] = "/var/lib/supplier-hub/audit"
+
+		cfg, err := config.LoadFrom("", lookup(env))
+		assert.Nil(t, err)
+		assert.Equal(t, 100<<20, cfg.Audit.MaxFileSize)
+	})
+
+	t.Run("should reject unknown backends", func(t *testing.T) {
+		env := requiredEnv()
+		env["AUDIT_BACKEND"] = "sqlite"
+
+		_, err := config.LoadFrom("", lookup(env))
+		assert.ErrorIs(t, err, config.ErrorInvalidConfig)
+		assert.Contains(t, err.Error(), "unknown audit backend")
+	})
+
+	t.Run("should validate the backend", func(t *testing.T) {
+		cfg, err := config.LoadFrom("", lookup(requiredEnv()))
+		assert.Nil(t, err)
+
+		cfg.Audit = config.Audit{Backend: "sqlite"}
+		assert.ErrorContains(t, cfg.Validate(), `unknown audit backend: "sqlite"`)
+
+		cfg.Audit = config.Audit{Backend: config.BackendFile, MaxFileSize: 1}
+		assert.ErrorContains(t, cfg.Validate(), "audit path is required")
+
+		cfg.Audit = config.Audit{Backend: config.BackendFile, Path: t.TempDir()}
+		assert.ErrorContains(t, cfg.Validate(), "audit max file size must be positive")
+	})
+}
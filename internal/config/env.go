@@ -0,0 +1,223 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+)
+
+// env overrides values with environment variables that are set, the first
+// parse failure is kept in err
+type env struct {
+	getenv func(string) string
+	err    error
+}
+
+func (e *env) lookup(key string) (string, bool) {
+	value := e.getenv(key)
+	return value, value != "" && e.err == nil
+}
+
+func (e *env) invalid(key string, err error) {
+	e.err = fmt.Errorf("%w: %s: %s", ErrorInvalidValue, key, err)
+}
+
+func (e *env) string(key string, target *string) {
+	if value, ok := e.lookup(key); ok {
+		*target = value
+	}
+}
+
+func (e *env) bool(key string, target *bool) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.invalid(key, err)
+		return
+	}
+
+	*target = parsed
+}
+
+func (e *env) int(key string, target *int) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.invalid(key, err)
+		return
+	}
+
+	*target = parsed
+}
+
+func (e *env) duration(key string, target *time.Duration) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.invalid(key, err)
+		return
+	}
+
+	*target = parsed
+}
+
+// list reads comma separated values
+func (e *env) list(key string, target *[]string) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+
+	*target = values
+}
+
+// pairs reads comma separated name=value pairs, they replace the map
+func (e *env) pairs(key string, target *map[string]string) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+
+	values := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(item, "=")
+		if !ok || name == "" {
+			e.invalid(key, fmt.Errorf("expected name=value: %s", item))
+			return
+		}
+
+		values[name] = value
+	}
+
+	*target = values
+}
+
+func (c *Config) applyEnv(getenv func(string) string) error {
+	e := &env{getenv: getenv}
+
+	e.string("ENV", &c.Env)
+	e.bool("TEST", &c.Test)
+	e.string("LOG_LEVEL", &c.LogLevel)
+
+	e.int("PORT", &c.Server.Port)
+	e.int("ADMIN_PORT", &c.Server.AdminPort)
+	e.string("OPENAPI_LOCATION", &c.Server.OpenApiLocation)
+
+	e.string("ADMIN_API_KEY", &c.Admin.ApiKey)
+
+	e.bool("AUTH_ENABLED", &c.Auth.Enabled)
+	e.string("AUTH_JWKS_PATH", &c.Auth.Jwt.JwksPath)
+	e.string("AUTH_JWT_ISSUER", &c.Auth.Jwt.Issuer)
+	e.string("AUTH_JWT_AUDIENCE", &c.Auth.Jwt.Audience)
+	e.duration("AUTH_HMAC_MAX_SKEW", &c.Auth.HmacMaxSkew)
+
+	e.bool("QUOTA_ENABLED", &c.Quota.Enabled)
+	e.int("QUOTA_CAPACITY", &c.Quota.Capacity)
+	e.int("QUOTA_RESERVED", &c.Quota.Reserved)
+	e.list("QUOTA_RESERVED_OPERATIONS", &c.Quota.ReservedOperations)
+
+	e.string("CACHE_ENGINE", &c.Cache.Engine)
+	e.string("CACHE_CODEC", &c.Cache.Codec)
+	e.duration("CACHE_LOCAL_TTL", &c.Cache.LocalTtl)
+
+	e.bool("REDIS_REQUIRED", &c.Redis.Required)
+
+	e.string("CRG_SERVICE_DOMAIN", &c.Services.Domain)
+	e.string("CRG_URL_USER_SERVICE", &c.Services.UserServiceUrl)
+	e.string("CRG_URL_SPIT", &c.Services.SpitUrl)
+	e.string("CRG_USERNAME", &c.Services.Username)
+	e.string("CRG_PASSWORD", &c.Services.Password)
+
+	e.string("CREDENTIALS_BACKEND", &c.Credentials.Backend)
+	e.string("CREDENTIALS_PATH", &c.Credentials.Path)
+	e.string("CREDENTIALS_KEY", &c.Credentials.Key)
+
+	e.string("HISTORY_DEFAULT_MODE", &c.History.DefaultMode)
+	e.string("HISTORY_BACKEND", &c.History.Backend)
+	e.string("HISTORY_PATH", &c.History.Path)
+	e.duration("HISTORY_RETENTION", &c.History.Retention)
+
+	e.list("HEALTH_OPTIONAL", &c.Health.Optional)
+	e.string("HEALTH_SUPPLIER_PROBES", &c.Health.SupplierProbes)
+
+	e.duration("SHUTDOWN_READINESS_DELAY", &c.Shutdown.ReadinessDelay)
+	e.duration("SHUTDOWN_TIMEOUT", &c.Shutdown.Timeout)
+
+	e.list("FAULTS_ACTIVE", &c.Faults.Active)
+
+	e.pairs("REMOTE_PLATFORMS", &c.Remote.Platforms)
+
+	e.bool("TRAFFICLIGHT_EXPOSE_ROLE", &c.Trafficlight.ExposeRole)
+
+	e.bool("REBOOK_ENABLED", &c.Rebook.Enabled)
+	e.duration("REBOOK_RETENTION", &c.Rebook.Retention)
+
+	e.bool("TRACKING_ENABLED", &c.Tracking.Enabled)
+	e.string("TRACKING_CALLBACK_SECRET", &c.Tracking.CallbackSecret)
+	e.duration("TRACKING_POLL_INTERVAL", &c.Tracking.PollInterval)
+	e.duration("TRACKING_MAX_POLL_INTERVAL", &c.Tracking.MaxPollInterval)
+	e.duration("TRACKING_MAX_AGE", &c.Tracking.MaxAge)
+	e.int("TRACKING_MAX_DELIVERIES", &c.Tracking.MaxDeliveries)
+	e.bool("TRACKING_ALLOW_PRIVATE_CALLBACKS", &c.Tracking.AllowPrivateCallbacks)
+
+	e.bool("CANCEL_RETRY_ENABLED", &c.CancelRetry.Enabled)
+	e.duration("CANCEL_RETRY_BACKOFF", &c.CancelRetry.Backoff)
+	e.duration("CANCEL_RETRY_MAX_BACKOFF", &c.CancelRetry.MaxBackoff)
+	e.int("CANCEL_RETRY_MAX_ATTEMPTS", &c.CancelRetry.MaxAttempts)
+
+	e.string("AUDIT_BACKEND", &c.Audit.Backend)
+	e.string("AUDIT_PATH", &c.Audit.Path)
+	e.int("AUDIT_MAX_FILE_SIZE", &c.Audit.MaxFileSize)
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if c.Redis.Clients == nil {
+		c.Redis.Clients = make(map[string]RedisClient)
+	}
+
+	// required clients may be configured by the environment alone
+	for _, name := range c.requiredRedisClients() {
+		if _, ok := c.Redis.Clients[name]; !ok && getenv(redisfactory.EnvPrefix(name)+"URI") != "" {
+			c.Redis.Clients[name] = RedisClient{}
+		}
+	}
+
+	for name, client := range c.Redis.Clients {
+		o, err := redisfactory.ApplyEnv(name, client.Options(), getenv)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrorInvalidValue, err)
+		}
+
+		c.Redis.Clients[name] = RedisClient(o)
+	}
+
+	return nil
+}
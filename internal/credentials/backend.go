@@ -0,0 +1,103 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "credentials:"
+
+// FileBackend keeps one file per reference in a directory
+type FileBackend struct {
+	dir string
+}
+
+func NewFileBackend(dir string) (*FileBackend, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileBackend{dir: dir}, nil
+}
+
+func (f *FileBackend) path(ref string) string {
+	return filepath.Join(f.dir, ref+".enc")
+}
+
+func (f *FileBackend) Load(ctx context.Context, ref string) ([]byte, error) {
+	value, err := os.ReadFile(f.path(ref))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrorNotFound
+	}
+
+	return value, err
+}
+
+// Save writes to a temporary file first so readers never see a partial record
+func (f *FileBackend) Save(ctx context.Context, ref string, value []byte) error {
+	file, err := os.CreateTemp(f.dir, ref+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(value)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), f.path(ref))
+}
+
+func (f *FileBackend) Delete(ctx context.Context, ref string) error {
+	err := os.Remove(f.path(ref))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrorNotFound
+	}
+
+	return err
+}
+
+type RedisBackend struct {
+	client redis.UniversalClient
+}
+
+func NewRedisBackend(client redis.UniversalClient) *RedisBackend {
+	return &RedisBackend{client: client}
+}
+
+func (r *RedisBackend) Load(ctx context.Context, ref string) ([]byte, error) {
+	value, err := r.client.Get(ctx, redisKeyPrefix+ref).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrorNotFound
+	}
+
+	return value, err
+}
+
+func (r *RedisBackend) Save(ctx context.Context, ref string, value []byte) error {
+	return r.client.Set(ctx, redisKeyPrefix+ref, value, 0).Err()
+}
+
+func (r *RedisBackend) Delete(ctx context.Context, ref string) error {
+	deleted, err := r.client.Del(ctx, redisKeyPrefix+ref).Result()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return ErrorNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package credentials
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// keySize is the size of AES-256 keys
+const keySize = 32
+
+var (
+	ErrorNotFound       = errors.New("configuration reference not found")
+	ErrorInvalidRef     = errors.New("invalid configuration reference")
+	ErrorInvalidKey     = errors.New("credentials key must be 32 bytes")
+	ErrorUnknownBackend = errors.New("unknown credentials backend")
+	errorCorrupted      = errors.New("stored credentials can not be decrypted")
+)
+
+// refPattern keeps references usable as file names and redis keys
+var refPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// Record is a supplier configuration stored under a reference, it can only
+// be used by requests of the owning client to the same platform. Records
+// without a client are used by unauthenticated requests.
+type Record struct {
+	Platform      string          `json:"platform"`
+	ClientId      string          `json:"clientId,omitempty"`
+	Configuration json.RawMessage `json:"configuration"`
+}
+
+// Backend keeps encrypted records, Load returns ErrorNotFound for unknown references
+type Backend interface {
+	Load(ctx context.Context, ref string) ([]byte, error)
+	Save(ctx context.Context, ref string, value []byte) error
+	Delete(ctx context.Context, ref string) error
+}
+
+// Store encrypts records with AES-GCM, the reference is authenticated
+// so a record copied to another reference can not be decrypted
+type Store struct {
+	backend Backend
+	aead    cipher.AEAD
+}
+
+func NewStore(backend Backend, key []byte) (*Store, error) {
+	if len(key) != keySize {
+		return nil, ErrorInvalidKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		backend: backend,
+		aead:    aead,
+	}, nil
+}
+
+func ValidateRef(ref string) error {
+	if !refPattern.MatchString(ref) {
+		return fmt.Errorf("%w: %q", ErrorInvalidRef, ref)
+	}
+
+	return nil
+}
+
+func (s *Store) Get(ctx context.Context, ref string) (Record, error) {
+	var record Record
+
+	err := ValidateRef(ref)
+	if err != nil {
+		return record, err
+	}
+
+	sealed, err := s.backend.Load(ctx, ref)
+	if err != nil {
+		return record, err
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return record, errorCorrupted
+	}
+
+	plain, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(ref))
+	if err != nil {
+		return record, errorCorrupted
+	}
+
+	err = json.Unmarshal(plain, &record)
+
+	return record, err
+}
+
+func (s *Store) Put(ctx context.Context, ref string, record Record) error {
+	err := ValidateRef(ref)
+	if err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return err
+	}
+
+	return s.backend.Save(ctx, ref, s.aead.Seal(nonce, nonce, plain, []byte(ref)))
+}
+
+func (s *Store) Delete(ctx context.Context, ref string) error {
+	err := ValidateRef(ref)
+	if err != nil {
+		return err
+	}
+
+	return s.backend.Delete(ctx, ref)
+}
@@ -0,0 +1,191 @@
+package credentials_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func newStore(t *testing.T, backend credentials.Backend) *credentials.Store {
+	store, err := credentials.NewStore(backend, testKey)
+	assert.Nil(t, err)
+
+	return store
+}
+
+func anyRentRecord() credentials.Record {
+	return credentials.Record{
+		Platform:      "anyrent",
+		Configuration: json.RawMessage(`{"supplierApiUrl":"https://anyrent.example","apiKey":"secret"}`),
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store encrypted records in files", func(t *testing.T) {
+		dir := t.TempDir()
+
+		backend, err := credentials.NewFileBackend(dir)
+		assert.Nil(t, err)
+
+		store := newStore(t, backend)
+		assert.Nil(t, store.Put(ctx, "anyrent-main", anyRentRecord()))
+
+		content, err := os.ReadFile(filepath.Join(dir, "anyrent-main.enc"))
+		assert.Nil(t, err)
+		assert.NotContains(t, string(content), "secret")
+
+		record, err := store.Get(ctx, "anyrent-main")
+		assert.Nil(t, err)
+		assert.Equal(t, "anyrent", record.Platform)
+		assert.JSONEq(t, string(anyRentRecord().Configuration), string(record.Configuration))
+
+		assert.Nil(t, store.Delete(ctx, "anyrent-main"))
+
+		_, err = store.Get(ctx, "anyrent-main")
+		assert.ErrorIs(t, err, credentials.ErrorNotFound)
+	})
+
+	t.Run("should store encrypted records in redis", func(t *testing.T) {
+		redisServer := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+
+		store := newStore(t, credentials.NewRedisBackend(client))
+		assert.Nil(t, store.Put(ctx, "anyrent-main", anyRentRecord()))
+
+		value, err := redisServer.Get("credentials:anyrent-main")
+		assert.Nil(t, err)
+		assert.NotContains(t, value, "secret")
+
+		record, err := store.Get(ctx, "anyrent-main")
+		assert.Nil(t, err)
+		assert.Equal(t, "anyrent", record.Platform)
+
+		assert.ErrorIs(t, store.Delete(ctx, "unknown"), credentials.ErrorNotFound)
+	})
+
+	t.Run("should not decrypt records moved to another reference", func(t *testing.T) {
+		dir := t.TempDir()
+		backend, _ := credentials.NewFileBackend(dir)
+		store := newStore(t, backend)
+
+		assert.Nil(t, store.Put(ctx, "anyrent-main", anyRentRecord()))
+		assert.Nil(t, os.Rename(filepath.Join(dir, "anyrent-main.enc"), filepath.Join(dir, "anyrent-other.enc")))
+
+		_, err := store.Get(ctx, "anyrent-other")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should reject references unsafe as file names", func(t *testing.T) {
+		backend, _ := credentials.NewFileBackend(t.TempDir())
+		store := newStore(t, backend)
+
+		assert.ErrorIs(t, store.Put(ctx, "../escape", anyRentRecord()), credentials.ErrorInvalidRef)
+	})
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+
+	backend, _ := credentials.NewFileBackend(t.TempDir())
+	store := newStore(t, backend)
+	assert.Nil(t, store.Put(ctx, "anyrent-main", anyRentRecord()))
+
+	t.Run("should merge stored configuration over inline values", func(t *testing.T) {
+		body, err := store.Resolve(ctx, "anyrent", "", []byte(`{
+			"configurationRef": "anyrent-main",
+			"configuration": {"supplierApiUrl": "https://attacker.example", "extra": true},
+			"moduleId": 1
+		}`))
+		assert.Nil(t, err)
+
+		var params struct {
+			Configuration map[string]any `json:"configuration"`
+			ModuleId      int            `json:"moduleId"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &params))
+
+		assert.Equal(t, "https://anyrent.example", params.Configuration["supplierApiUrl"])
+		assert.Equal(t, "secret", params.Configuration["apiKey"])
+		assert.Equal(t, true, params.Configuration["extra"])
+		assert.Equal(t, 1, params.ModuleId)
+	})
+
+	t.Run("should leave bodies without reference unchanged", func(t *testing.T) {
+		original := []byte(`{"configuration": {"apiKey": "inline"}}`)
+
+		body, err := store.Resolve(ctx, "anyrent", "", original)
+		assert.Nil(t, err)
+		assert.Equal(t, original, body)
+	})
+
+	t.Run("should reject references of another platform", func(t *testing.T) {
+		_, err := store.Resolve(ctx, "rently", "", []byte(`{"configurationRef": "anyrent-main"}`))
+		assert.ErrorIs(t, err, credentials.ErrorPlatformMismatch)
+	})
+
+	t.Run("should only resolve references of the owning client", func(t *testing.T) {
+		owned := anyRentRecord()
+		owned.ClientId = "broker"
+		assert.Nil(t, store.Put(ctx, "anyrent-broker", owned))
+
+		_, err := store.Resolve(ctx, "anyrent", "", []byte(`{"configurationRef": "anyrent-broker"}`))
+		assert.ErrorIs(t, err, credentials.ErrorClientMismatch)
+
+		_, err = store.Resolve(ctx, "anyrent", "crawler", []byte(`{"configurationRef": "anyrent-broker"}`))
+		assert.ErrorIs(t, err, credentials.ErrorClientMismatch)
+
+		_, err = store.ResolveItems(ctx, "anyrent", "crawler", []byte(`{"items": [{"configurationRef": "anyrent-broker"}]}`))
+		assert.ErrorIs(t, err, credentials.ErrorClientMismatch)
+
+		_, err = store.Resolve(ctx, "anyrent", "broker", []byte(`{"configurationRef": "anyrent-broker"}`))
+		assert.Nil(t, err)
+
+		_, err = store.Resolve(ctx, "anyrent", "broker", []byte(`{"configurationRef": "anyrent-main"}`))
+		assert.ErrorIs(t, err, credentials.ErrorClientMismatch)
+	})
+
+	t.Run("should reject unknown references", func(t *testing.T) {
+		_, err := store.Resolve(ctx, "anyrent", "", []byte(`{"configurationRef": "missing"}`))
+		assert.ErrorIs(t, err, credentials.ErrorNotFound)
+	})
+
+	t.Run("should resolve every item of a batch", func(t *testing.T) {
+		body, err := store.ResolveItems(ctx, "anyrent", "", []byte(`{"items": [
+			{"configurationRef": "anyrent-main"},
+			{"configuration": {"apiKey": "inline"}}
+		]}`))
+		assert.Nil(t, err)
+
+		var params struct {
+			Items []struct {
+				Configuration map[string]any `json:"configuration"`
+			} `json:"items"`
+		}
+		assert.Nil(t, json.Unmarshal(body, &params))
+
+		assert.Equal(t, "secret", params.Items[0].Configuration["apiKey"])
+		assert.Equal(t, "inline", params.Items[1].Configuration["apiKey"])
+
+		_, err = store.ResolveItems(ctx, "anyrent", "", []byte(`{"items": [{}, {"configurationRef": "missing"}]}`))
+		assert.ErrorIs(t, err, credentials.ErrorNotFound)
+		assert.Contains(t, err.Error(), "items[1]")
+	})
+
+	t.Run("should reject references when disabled", func(t *testing.T) {
+		var disabled *credentials.Store
+
+		_, err := disabled.Resolve(ctx, "anyrent", "", []byte(`{"configurationRef": "anyrent-main"}`))
+		assert.ErrorIs(t, err, credentials.ErrorDisabled)
+	})
+}
@@ -0,0 +1,122 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	configurationField    = "configuration"
+	configurationRefField = "configurationRef"
+	itemsField            = "items"
+)
+
+var (
+	ErrorDisabled         = errors.New("configuration references are not enabled")
+	ErrorPlatformMismatch = errors.New("configuration reference belongs to another platform")
+	ErrorClientMismatch   = errors.New("configuration reference belongs to another client")
+	ErrorNotAnObject      = errors.New("configuration must be an object")
+)
+
+// Resolve merges the configuration stored under configurationRef into the configuration
+// of the request body. Stored values take precedence, so inline configuration can not
+// redirect stored credentials to another supplier url. Bodies without a reference are
+// returned unchanged, a nil store rejects references. Only records owned by clientId
+// are resolved, it is empty for unauthenticated requests.
+func (s *Store) Resolve(ctx context.Context, platform string, clientId string, body []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+
+	// malformed bodies are left to the request validation
+	if json.Unmarshal(body, &fields) != nil {
+		return body, nil
+	}
+
+	rawRef, ok := fields[configurationRefField]
+	if !ok {
+		return body, nil
+	}
+
+	if s == nil {
+		return nil, ErrorDisabled
+	}
+
+	var ref string
+	err := json.Unmarshal(rawRef, &ref)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorInvalidRef, err)
+	}
+
+	record, err := s.Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.Platform != platform {
+		return nil, ErrorPlatformMismatch
+	}
+
+	if record.ClientId != clientId {
+		return nil, ErrorClientMismatch
+	}
+
+	merged := make(map[string]json.RawMessage)
+
+	if inline, ok := fields[configurationField]; ok && string(inline) != "null" {
+		err = json.Unmarshal(inline, &merged)
+		if err != nil {
+			return nil, ErrorNotAnObject
+		}
+	}
+
+	var stored map[string]json.RawMessage
+	err = json.Unmarshal(record.Configuration, &stored)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range stored {
+		merged[key] = value
+	}
+
+	fields[configurationField], err = json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// ResolveItems resolves each element of the items of a batch request body
+// like Resolve, errors name the index of the item
+func (s *Store) ResolveItems(ctx context.Context, platform string, clientId string, body []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+
+	if json.Unmarshal(body, &fields) != nil {
+		return body, nil
+	}
+
+	var items []json.RawMessage
+	if json.Unmarshal(fields[itemsField], &items) != nil {
+		return body, nil
+	}
+
+	for i, item := range items {
+		resolved, err := s.Resolve(ctx, platform, clientId, item)
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %w", i, err)
+		}
+
+		items[i] = resolved
+	}
+
+	var err error
+
+	fields[itemsField], err = json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
@@ -0,0 +1,158 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultTimeout = 2 * time.Second
+
+// Check probes a single dependency, a nil error means the dependency is up
+type Check struct {
+	Name     string
+	Required bool
+	Probe    func(ctx context.Context) error
+}
+
+type Checker struct {
+	checks         []Check
+	supplierChecks []Check
+	draining       func() bool
+	timeout        time.Duration
+}
+
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Checker{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// WithSupplierChecks adds optional probes, they only run when explicitly requested
+func (c *Checker) WithSupplierChecks(checks ...Check) *Checker {
+	c.supplierChecks = append(c.supplierChecks, checks...)
+	return c
+}
+
+// WithDraining fails readiness without probing while draining reports true
+func (c *Checker) WithDraining(draining func() bool) *Checker {
+	c.draining = draining
+	return c
+}
+
+func (c *Checker) run(ctx context.Context, check Check) schema.HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Probe(ctx)
+
+	result := schema.HealthCheckResult{
+		Status:   schema.HealthCheckResultStatusUp,
+		Required: check.Required,
+		Latency:  float32(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		message := err.Error()
+		result.Status = schema.HealthCheckResultStatusDown
+		result.Error = &message
+	}
+
+	return result
+}
+
+// Run probes all dependencies concurrently, the report is down
+// when any required dependency is down
+func (c *Checker) Run(ctx context.Context, withSuppliers bool) schema.HealthReport {
+	if c.draining != nil && c.draining() {
+		message := "server is shutting down"
+
+		return schema.HealthReport{
+			Status: schema.HealthReportStatusDown,
+			Checks: map[string]schema.HealthCheckResult{
+				"shutdown": {
+					Status:   schema.HealthCheckResultStatusDown,
+					Required: true,
+					Error:    &message,
+				},
+			},
+		}
+	}
+
+	checks := c.checks
+	if withSuppliers {
+		checks = append(append([]Check{}, c.checks...), c.supplierChecks...)
+	}
+
+	report := schema.HealthReport{
+		Status: schema.HealthReportStatusUp,
+		Checks: make(map[string]schema.HealthCheckResult, len(checks)),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for _, check := range checks {
+		wg.Add(1)
+
+		go func(check Check) {
+			defer wg.Done()
+
+			result := c.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[check.Name] = result
+			if result.Required && result.Status == schema.HealthCheckResultStatusDown {
+				report.Status = schema.HealthReportStatusDown
+			}
+		}(check)
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+func RegisterRoutes(router *gin.Engine, checker *Checker) {
+	startTime := time.Now()
+
+	router.GET("/health/live", func(ctx *gin.Context) {
+		response := struct {
+			Status string  `json:"status"`
+			Uptime float64 `json:"uptime"`
+		}{
+			Status: "up",
+			Uptime: time.Since(startTime).Seconds(),
+		}
+
+		ctx.JSON(http.StatusOK, response)
+	})
+
+	router.GET("/health/ready", func(ctx *gin.Context) {
+		var params schema.GetHealthReadyParams
+		_ = ctx.ShouldBindQuery(&params)
+
+		report := checker.Run(ctx.Request.Context(), params.Suppliers != nil && *params.Suppliers)
+
+		code := http.StatusOK
+		if report.Status != schema.HealthReportStatusUp {
+			code = http.StatusServiceUnavailable
+		}
+
+		ctx.JSON(code, report)
+	})
+}
@@ -0,0 +1,163 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/health"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func up(ctx context.Context) error {
+	return nil
+}
+
+func down(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func ready(router *gin.Engine, query string) (int, schema.HealthReport) {
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest(http.MethodGet, "/health/ready"+query, nil)
+	router.ServeHTTP(response, request)
+
+	var report schema.HealthReport
+	json.Unmarshal(response.Body.Bytes(), &report)
+
+	return response.Code, report
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("should be ready when required dependencies are up", func(t *testing.T) {
+		router := gin.New()
+		health.RegisterRoutes(router, health.NewChecker(0,
+			health.Check{Name: "redis", Required: true, Probe: up},
+			health.Check{Name: "spit", Required: false, Probe: down},
+		))
+
+		code, report := ready(router, "")
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, schema.HealthReportStatusUp, report.Status)
+		assert.Equal(t, schema.HealthCheckResultStatusUp, report.Checks["redis"].Status)
+		assert.Equal(t, schema.HealthCheckResultStatusDown, report.Checks["spit"].Status)
+		assert.Equal(t, "connection refused", *report.Checks["spit"].Error)
+	})
+
+	t.Run("should not be ready when a required dependency is down", func(t *testing.T) {
+		router := gin.New()
+		health.RegisterRoutes(router, health.NewChecker(0,
+			health.Check{Name: "redis", Required: true, Probe: down},
+		))
+
+		code, report := ready(router, "")
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, schema.HealthReportStatusDown, report.Status)
+	})
+
+	t.Run("should probe suppliers only on demand", func(t *testing.T) {
+		router := gin.New()
+		health.RegisterRoutes(router, health.NewChecker(0,
+			health.Check{Name: "redis", Required: true, Probe: up},
+		).WithSupplierChecks(
+			health.Check{Name: "supplier:hertz", Probe: down},
+		))
+
+		code, report := ready(router, "")
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, report.Checks, "supplier:hertz")
+
+		code, report = ready(router, "?suppliers=true")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, schema.HealthCheckResultStatusDown, report.Checks["supplier:hertz"].Status)
+	})
+
+	t.Run("should not be ready while draining", func(t *testing.T) {
+		draining := false
+
+		router := gin.New()
+		health.RegisterRoutes(router, health.NewChecker(0,
+			health.Check{Name: "redis", Required: true, Probe: up},
+		).WithDraining(func() bool {
+			return draining
+		}))
+
+		code, _ := ready(router, "")
+		assert.Equal(t, http.StatusOK, code)
+
+		draining = true
+
+		code, report := ready(router, "")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, schema.HealthCheckResultStatusDown, report.Checks["shutdown"].Status)
+		assert.NotContains(t, report.Checks, "redis")
+	})
+
+	t.Run("should always be live", func(t *testing.T) {
+		router := gin.New()
+		health.RegisterRoutes(router, health.NewChecker(0,
+			health.Check{Name: "redis", Required: true, Probe: down},
+		))
+
+		response := httptest.NewRecorder()
+		request, _ := http.NewRequest(http.MethodGet, "/health/live", nil)
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+}
+
+func TestProbes(t *testing.T) {
+	t.Run("should treat server errors as down", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/broken" {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		assert.Nil(t, health.HttpProbe(server.URL)(context.Background()))
+		assert.NotNil(t, health.HttpProbe(server.URL+"/broken")(context.Background()))
+		assert.NotNil(t, health.HttpProbe("")(context.Background()))
+	})
+
+	t.Run("should ping redis clients", func(t *testing.T) {
+		redisServer := miniredis.RunT(t)
+
+		redisFactory, err := redisfactory.New(map[string]redisfactory.ClientOptions{
+			redisfactory.Trafficlight:   {Uri: "redis://" + redisServer.Addr() + "/2"},
+			redisfactory.ResponsesCache: {Uri: "redis://" + redisServer.Addr() + "/1"},
+		})
+		assert.Nil(t, err)
+		defer redisFactory.Close()
+
+		probe := health.RedisProbe(redisFactory, redisfactory.Trafficlight)
+		assert.Nil(t, probe(context.Background()))
+
+		redisServer.Close()
+		assert.NotNil(t, probe(context.Background()))
+	})
+
+	t.Run("should parse supplier probes", func(t *testing.T) {
+		checks, err := health.SupplierChecks("hertz=https://hertz.example, anyrent=https://anyrent.example")
+		assert.Nil(t, err)
+		assert.Len(t, checks, 2)
+		assert.Equal(t, "supplier:anyrent", checks[1].Name)
+		assert.False(t, checks[1].Required)
+
+		_, err = health.SupplierChecks("hertz")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+)
+
+var (
+	errorMissingUrl      = errors.New("url is not configured")
+	errorInvalidProbe    = errors.New("invalid supplier probe, expected platform=url")
+	errorUnhealthyStatus = errors.New("unhealthy status code")
+)
+
+var probeClient = &http.Client{
+	// reaching the server is enough, redirects are not followed
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// RedisProbe pings the named client of the factory
+func RedisProbe(redisFactory *redisfactory.Factory, name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		client, err := redisFactory.Client(name)
+		if err != nil {
+			return err
+		}
+
+		return client.Ping(ctx).Err()
+	}
+}
+
+// HttpProbe requests the url, any response below 500 counts as reachable
+func HttpProbe(url string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if url == "" {
+			return errorMissingUrl
+		}
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		response, err := probeClient.Do(request)
+		if err != nil {
+			return err
+		}
+		response.Body.Close()
+
+		if response.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("%w: %d", errorUnhealthyStatus, response.StatusCode)
+		}
+
+		return nil
+	}
+}
+
+// SupplierChecks parses comma separated platform=url pairs,
+// supplier probes are never required
+func SupplierChecks(value string) ([]Check, error) {
+	var checks []Check
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		platform, url, ok := strings.Cut(pair, "=")
+		if !ok || platform == "" || url == "" {
+			return nil, fmt.Errorf("%w: %s", errorInvalidProbe, pair)
+		}
+
+		checks = append(checks, Check{
+			Name:  "supplier:" + platform,
+			Probe: HttpProbe(url),
+		})
+	}
+
+	return checks, nil
+}
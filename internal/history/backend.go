@@ -0,0 +1,153 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyPrefix = "history:"
+
+	// pruneInterval bounds how often saving scans the directory for expired files
+	pruneInterval = time.Minute
+)
+
+// FileBackend keeps one file per history in a directory, files older than
+// the retention are not returned and removed while saving
+type FileBackend struct {
+	dir        string
+	retention  time.Duration
+	lastPruned time.Time
+	now        func() time.Time
+	mu         sync.Mutex
+}
+
+func NewFileBackend(dir string, retention time.Duration) (*FileBackend, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileBackend{
+		dir:       dir,
+		retention: retention,
+		now:       time.Now,
+	}, nil
+}
+
+func (f *FileBackend) path(id string) string {
+	return filepath.Join(f.dir, id+".json")
+}
+
+func (f *FileBackend) expired(info os.FileInfo) bool {
+	return f.retention > 0 && f.now().Sub(info.ModTime()) > f.retention
+}
+
+func (f *FileBackend) Load(ctx context.Context, id string) ([]byte, error) {
+	info, err := os.Stat(f.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrorNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if f.expired(info) {
+		return nil, ErrorNotFound
+	}
+
+	return os.ReadFile(f.path(id))
+}
+
+// Save writes to a temporary file first so readers never see a partial history
+func (f *FileBackend) Save(ctx context.Context, id string, value []byte) error {
+	file, err := os.CreateTemp(f.dir, id+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(value)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(file.Name(), f.path(id))
+	if err != nil {
+		return err
+	}
+
+	f.prune()
+
+	return nil
+}
+
+// prune removes expired files, at most once per pruneInterval
+func (f *FileBackend) prune() {
+	if f.retention <= 0 {
+		return
+	}
+
+	f.mu.Lock()
+	if f.now().Sub(f.lastPruned) < pruneInterval {
+		f.mu.Unlock()
+		return
+	}
+	f.lastPruned = f.now()
+	f.mu.Unlock()
+
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err == nil && f.expired(info) {
+			os.Remove(filepath.Join(f.dir, entry.Name()))
+		}
+	}
+}
+
+// RedisBackend expires histories with the retention, zero keeps them
+type RedisBackend struct {
+	client    redis.UniversalClient
+	retention time.Duration
+}
+
+func NewRedisBackend(client redis.UniversalClient, retention time.Duration) *RedisBackend {
+	return &RedisBackend{
+		client:    client,
+		retention: retention,
+	}
+}
+
+func (r *RedisBackend) Load(ctx context.Context, id string) ([]byte, error) {
+	value, err := r.client.Get(ctx, redisKeyPrefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrorNotFound
+	}
+
+	return value, err
+}
+
+func (r *RedisBackend) Save(ctx context.Context, id string, value []byte) error {
+	return r.client.Set(ctx, redisKeyPrefix+id, value, r.retention).Err()
+}
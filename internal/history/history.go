@@ -0,0 +1,142 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/google/uuid"
+)
+
+const (
+	// ModeHeader and ModeQuery select the history mode of a platform request,
+	// the header wins when both are sent
+	ModeHeader = "x-supplier-history"
+	ModeQuery  = "history"
+)
+
+var (
+	ErrorNotFound       = errors.New("history not found")
+	ErrorInvalidId      = errors.New("invalid history id")
+	ErrorUnknownMode    = errors.New("unknown history mode")
+	ErrorUnknownBackend = errors.New("unknown history backend")
+	ErrorDisabled       = errors.New("history offloading is not configured")
+)
+
+var idPattern = regexp.MustCompile(`^[a-f0-9-]{36}$`)
+
+// Record is the offloaded history, ClientId limits reading to the caller
+// that made the platform request
+type Record struct {
+	ClientId string                 `json:"clientId,omitempty"`
+	History  schema.SupplierHistory `json:"history"`
+}
+
+// Backend keeps serialized records, Load returns ErrorNotFound for unknown
+// and expired ids
+type Backend interface {
+	Load(ctx context.Context, id string) ([]byte, error)
+	Save(ctx context.Context, id string, value []byte) error
+}
+
+type Store struct {
+	backend Backend
+	now     func() time.Time
+}
+
+func NewStore(backend Backend) *Store {
+	return &Store{
+		backend: backend,
+		now:     time.Now,
+	}
+}
+
+// ParseMode accepts the HistoryMode values, an empty value returns the fallback
+func ParseMode(value string, fallback schema.HistoryMode) (schema.HistoryMode, error) {
+	switch mode := schema.HistoryMode(value); mode {
+	case "":
+		return fallback, nil
+	case schema.HistoryModeNone, schema.HistoryModeMetadata, schema.HistoryModeFull, schema.HistoryModeOffload:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrorUnknownMode, value)
+	}
+}
+
+// Metadata keeps name, url, method, status and timings of the requests,
+// bodies and headers are left out
+func Metadata(requests schema.SupplierRequests) schema.SupplierRequests {
+	metadata := make(schema.SupplierRequests, 0, len(requests))
+
+	for _, request := range requests {
+		entry := schema.SupplierRequest{
+			Name:          request.Name,
+			Duration:      request.Duration,
+			StartDateTime: request.StartDateTime,
+		}
+
+		if request.RequestContent != nil {
+			entry.RequestContent = &schema.RequestContent{
+				Url:    request.RequestContent.Url,
+				Method: request.RequestContent.Method,
+			}
+		}
+
+		if request.ResponseContent != nil {
+			entry.ResponseContent = &schema.ResponseContent{
+				StatusCode: request.ResponseContent.StatusCode,
+			}
+		}
+
+		metadata = append(metadata, entry)
+	}
+
+	return metadata
+}
+
+// Put stores the history under a new id, a nil store returns ErrorDisabled
+func (s *Store) Put(ctx context.Context, record Record) (string, error) {
+	if s == nil {
+		return "", ErrorDisabled
+	}
+
+	record.History.Id = uuid.New().String()
+	record.History.CreatedAt = s.now().UTC()
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.backend.Save(ctx, record.History.Id, value)
+	if err != nil {
+		return "", err
+	}
+
+	return record.History.Id, nil
+}
+
+func (s *Store) Get(ctx context.Context, id string) (Record, error) {
+	var record Record
+
+	if s == nil {
+		return record, ErrorDisabled
+	}
+
+	if !idPattern.MatchString(id) {
+		return record, ErrorInvalidId
+	}
+
+	value, err := s.backend.Load(ctx, id)
+	if err != nil {
+		return record, err
+	}
+
+	err = json.Unmarshal(value, &record)
+
+	return record, err
+}
@@ -0,0 +1,156 @@
+package history_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/history"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func supplierRequests() schema.SupplierRequests {
+	name := schema.SupplierRequestName("rates")
+	url := "https://supplier.example/rates"
+	method := "POST"
+	body := "<request/>"
+	responseBody := "<response/>"
+	statusCode := 200
+	duration := 120
+
+	return schema.SupplierRequests{{
+		Name:     &name,
+		Duration: &duration,
+		RequestContent: &schema.RequestContent{
+			Url:     &url,
+			Method:  &method,
+			Body:    &body,
+			Headers: &map[string]interface{}{"authorization": "secret"},
+		},
+		ResponseContent: &schema.ResponseContent{
+			StatusCode: &statusCode,
+			Body:       &responseBody,
+		},
+	}}
+}
+
+func TestParseMode(t *testing.T) {
+	t.Run("should fall back when no mode is sent", func(t *testing.T) {
+		mode, err := history.ParseMode("", schema.HistoryModeFull)
+		assert.Nil(t, err)
+		assert.Equal(t, schema.HistoryModeFull, mode)
+
+		mode, err = history.ParseMode("metadata", schema.HistoryModeFull)
+		assert.Nil(t, err)
+		assert.Equal(t, schema.HistoryModeMetadata, mode)
+	})
+
+	t.Run("should reject unknown modes", func(t *testing.T) {
+		_, err := history.ParseMode("bodies", schema.HistoryModeFull)
+		assert.ErrorIs(t, err, history.ErrorUnknownMode)
+	})
+}
+
+func TestMetadata(t *testing.T) {
+	t.Run("should drop bodies and headers", func(t *testing.T) {
+		metadata := history.Metadata(supplierRequests())
+
+		assert.Len(t, metadata, 1)
+		assert.Equal(t, "https://supplier.example/rates", *metadata[0].RequestContent.Url)
+		assert.Equal(t, "POST", *metadata[0].RequestContent.Method)
+		assert.Equal(t, 200, *metadata[0].ResponseContent.StatusCode)
+		assert.Equal(t, 120, *metadata[0].Duration)
+		assert.Nil(t, metadata[0].RequestContent.Body)
+		assert.Nil(t, metadata[0].RequestContent.Headers)
+		assert.Nil(t, metadata[0].ResponseContent.Body)
+	})
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	record := history.Record{
+		ClientId: "broker",
+		History: schema.SupplierHistory{
+			Platform:         "hertz",
+			Operation:        "rates",
+			SupplierRequests: supplierRequests(),
+		},
+	}
+
+	t.Run("should store history on disk", func(t *testing.T) {
+		backend, err := history.NewFileBackend(t.TempDir(), time.Hour)
+		assert.Nil(t, err)
+
+		store := history.NewStore(backend)
+
+		id, err := store.Put(ctx, record)
+		assert.Nil(t, err)
+
+		stored, err := store.Get(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, "broker", stored.ClientId)
+		assert.Equal(t, id, stored.History.Id)
+		assert.Equal(t, "hertz", stored.History.Platform)
+		assert.Equal(t, "<response/>", *stored.History.SupplierRequests[0].ResponseContent.Body)
+		assert.False(t, stored.History.CreatedAt.IsZero())
+	})
+
+	t.Run("should not return expired history", func(t *testing.T) {
+		dir := t.TempDir()
+
+		backend, err := history.NewFileBackend(dir, time.Hour)
+		assert.Nil(t, err)
+
+		store := history.NewStore(backend)
+
+		id, err := store.Put(ctx, record)
+		assert.Nil(t, err)
+
+		old := time.Now().Add(-2 * time.Hour)
+		assert.Nil(t, os.Chtimes(filepath.Join(dir, id+".json"), old, old))
+
+		_, err = store.Get(ctx, id)
+		assert.ErrorIs(t, err, history.ErrorNotFound)
+	})
+
+	t.Run("should store history in redis with the retention", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+		store := history.NewStore(history.NewRedisBackend(client, time.Hour))
+
+		id, err := store.Put(ctx, record)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Hour, server.TTL("history:"+id))
+
+		stored, err := store.Get(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, "rates", stored.History.Operation)
+	})
+
+	t.Run("should reject invalid and unknown ids", func(t *testing.T) {
+		backend, err := history.NewFileBackend(t.TempDir(), 0)
+		assert.Nil(t, err)
+
+		store := history.NewStore(backend)
+
+		_, err = store.Get(ctx, "../credentials")
+		assert.ErrorIs(t, err, history.ErrorInvalidId)
+
+		_, err = store.Get(ctx, "7a7fe2f4-2a56-4f7e-9a54-9f8a4a3f0b6d")
+		assert.ErrorIs(t, err, history.ErrorNotFound)
+	})
+
+	t.Run("should be disabled without a store", func(t *testing.T) {
+		var store *history.Store
+
+		_, err := store.Put(ctx, record)
+		assert.ErrorIs(t, err, history.ErrorDisabled)
+	})
+}
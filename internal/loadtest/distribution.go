@@ -0,0 +1,182 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/mocksupplier"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultPickUpInDays = 30
+
+var ErrorEmptyDistribution = errors.New("distribution has no requests")
+
+// Request is one kind of rates request of the distribution, it is replayed
+// Weight times as often as a request of weight one
+type Request struct {
+	Name     string `yaml:"name" json:"name"`
+	Platform string `yaml:"platform" json:"platform"`
+	Weight   int    `yaml:"weight" json:"weight,omitempty"`
+	// PickUpInDays sets missing pick up date times relative to the start of
+	// the run, drop off follows after rentalDays
+	PickUpInDays int `yaml:"pickUpInDays" json:"pickUpInDays,omitempty"`
+	// Params are sent as RatesRequestParams, configuration defaults to the
+	// mock supplier configuration of the platform
+	Params map[string]interface{} `yaml:"params" json:"params"`
+}
+
+type Distribution struct {
+	Requests []Request `yaml:"requests" json:"requests"`
+}
+
+func (r Request) weight() int {
+	if r.Weight == 0 {
+		return 1
+	}
+
+	return r.Weight
+}
+
+func (r Request) validate() error {
+	var problems []string
+
+	if r.Name == "" {
+		problems = append(problems, "name is required")
+	}
+
+	if r.Platform == "" {
+		problems = append(problems, "platform is required")
+	}
+
+	if _, ok := r.Params["configuration"]; !ok && r.Platform != "" && mocksupplier.Configuration(r.Platform, "") == nil {
+		problems = append(problems, "configuration is required, the mock supplier does not serve "+r.Platform)
+	}
+
+	if r.Weight < 0 {
+		problems = append(problems, "weight must not be negative")
+	}
+
+	if r.PickUpInDays < 0 {
+		problems = append(problems, "pickUpInDays must not be negative")
+	}
+
+	_, err := r.body(time.Now(), "http://localhost")
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("request %s: %s", r.Name, strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// body renders the params with dates and configuration filled in
+func (r Request) body(start time.Time, supplierUrl string) ([]byte, error) {
+	params := make(map[string]interface{}, len(r.Params)+1)
+	for key, value := range r.Params {
+		params[key] = value
+	}
+
+	if _, ok := params["configuration"]; !ok {
+		params["configuration"] = mocksupplier.Configuration(r.Platform, supplierUrl)
+	}
+
+	pickUpInDays := r.PickUpInDays
+	if pickUpInDays == 0 {
+		pickUpInDays = defaultPickUpInDays
+	}
+
+	var rentalDays int
+
+	switch days := params["rentalDays"].(type) {
+	case int:
+		rentalDays = days
+	case float64:
+		rentalDays = int(days)
+	}
+
+	pickUp := start.UTC().Truncate(24*time.Hour).AddDate(0, 0, pickUpInDays).Add(10 * time.Hour)
+	withDateTime(params, "pickUp", pickUp)
+	withDateTime(params, "dropOff", pickUp.AddDate(0, 0, rentalDays))
+
+	content, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// the hub binds the same struct, anything it can not decode fails here already
+	var decoded schema.RatesRequestParams
+
+	err = json.Unmarshal(content, &decoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid params: %w", err)
+	}
+
+	return content, nil
+}
+
+func withDateTime(params map[string]interface{}, key string, dateTime time.Time) {
+	branch, ok := params[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if _, ok := branch["dateTime"]; ok {
+		return
+	}
+
+	copied := make(map[string]interface{}, len(branch)+1)
+	for key, value := range branch {
+		copied[key] = value
+	}
+
+	copied["dateTime"] = dateTime.Format(time.RFC3339)
+	params[key] = copied
+}
+
+// Validate checks every request of the distribution
+func (d Distribution) Validate() error {
+	if len(d.Requests) == 0 {
+		return ErrorEmptyDistribution
+	}
+
+	var problems []string
+
+	for _, request := range d.Requests {
+		err := request.validate()
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// LoadDistribution reads a distribution from a YAML file
+func LoadDistribution(path string) (Distribution, error) {
+	var distribution Distribution
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return distribution, err
+	}
+
+	err = yaml.Unmarshal(content, &distribution)
+	if err != nil {
+		return distribution, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return distribution, distribution.Validate()
+}
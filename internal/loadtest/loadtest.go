@@ -0,0 +1,257 @@
+// Package loadtest replays a distribution of rates requests against the hub
+// and reports latency, grouping roles and the calls the mock supplier received.
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/trafficlight/grouping"
+)
+
+const callsPath = "/_mock/calls"
+
+var ErrorNoLimit = errors.New("either requests or duration is required")
+
+type Options struct {
+	HubUrl string
+	// SupplierUrl of the mock supplier, its call counts are reset before and
+	// read after the run, empty skips supplier call volume
+	SupplierUrl string
+	Concurrency int
+	// Requests stops the run after sending that many requests, Duration
+	// after that time, whichever comes first
+	Requests int
+	Duration time.Duration
+	Seed     int64
+	Client   *http.Client
+}
+
+type job struct {
+	name string
+	url  string
+	body []byte
+}
+
+// Run replays the distribution and reports on it
+func Run(ctx context.Context, d Distribution, o Options) (*Report, error) {
+	if o.Requests <= 0 && o.Duration <= 0 {
+		return nil, ErrorNoLimit
+	}
+
+	err := d.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	concurrency := o.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	jobs, weights, err := prepare(d, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.SupplierUrl != "" {
+		err = resetCalls(ctx, client, o.SupplierUrl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if o.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Duration)
+		defer cancel()
+	}
+
+	start := time.Now()
+
+	queue := make(chan job)
+	results := make(chan sample)
+
+	go func() {
+		defer close(queue)
+
+		random := rand.New(rand.NewSource(o.Seed))
+
+		for sent := 0; o.Requests <= 0 || sent < o.Requests; sent++ {
+			next := jobs[pick(random, weights)]
+
+			select {
+			case queue <- next:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var workers sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		workers.Add(1)
+
+		go func() {
+			defer workers.Done()
+
+			for next := range queue {
+				results <- send(ctx, client, next)
+			}
+		}()
+	}
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	var samples []sample
+	for result := range results {
+		// requests cut by the end of the run are not part of it
+		if result.err != nil && ctx.Err() != nil && errors.Is(result.err, ctx.Err()) {
+			continue
+		}
+
+		samples = append(samples, result)
+	}
+
+	report := newReport(samples, time.Since(start))
+
+	if o.SupplierUrl != "" {
+		// the run context may be over already, the counts are still wanted
+		report.SupplierCalls, err = readCalls(context.Background(), client, o.SupplierUrl)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// prepare renders the request bodies once, so equal requests group in the hub
+func prepare(d Distribution, o Options) ([]job, []int, error) {
+	start := time.Now()
+
+	jobs := make([]job, 0, len(d.Requests))
+	weights := make([]int, 0, len(d.Requests))
+	total := 0
+
+	for _, request := range d.Requests {
+		body, err := request.body(start, o.SupplierUrl)
+		if err != nil {
+			return nil, nil, fmt.Errorf("request %s: %w", request.Name, err)
+		}
+
+		jobs = append(jobs, job{
+			name: request.Name,
+			url:  strings.TrimSuffix(o.HubUrl, "/") + "/" + request.Platform + "/rates",
+			body: body,
+		})
+
+		total += request.weight()
+		weights = append(weights, total)
+	}
+
+	return jobs, weights, nil
+}
+
+// pick chooses an index by the cumulative weights
+func pick(random *rand.Rand, weights []int) int {
+	return sort.SearchInts(weights, random.Intn(weights[len(weights)-1])+1)
+}
+
+func send(ctx context.Context, client *http.Client, j job) sample {
+	result := sample{request: j.name}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, j.url, bytes.NewReader(j.body))
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+
+	response, err := client.Do(request)
+	if err != nil {
+		result.latency = time.Since(start)
+		result.err = err
+		return result
+	}
+
+	defer response.Body.Close()
+
+	_, err = io.Copy(io.Discard, response.Body)
+	result.latency = time.Since(start)
+	result.err = err
+	result.status = response.StatusCode
+	result.role = response.Header.Get(grouping.RoleHeader)
+
+	return result
+}
+
+func resetCalls(ctx context.Context, client *http.Client, supplierUrl string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, strings.TrimSuffix(supplierUrl, "/")+callsPath, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to reset mock supplier calls: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unable to reset mock supplier calls: status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+func readCalls(ctx context.Context, client *http.Client, supplierUrl string) (map[string]map[schema.SupplierRequestName]int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(supplierUrl, "/")+callsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read mock supplier calls: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read mock supplier calls: status %d", response.StatusCode)
+	}
+
+	calls := make(map[string]map[schema.SupplierRequestName]int)
+
+	err = json.NewDecoder(response.Body).Decode(&calls)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read mock supplier calls: %w", err)
+	}
+
+	return calls, nil
+}
@@ -0,0 +1,188 @@
+package loadtest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/loadtest"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/trafficlight/grouping"
+	"github.com/stretchr/testify/assert"
+)
+
+func params() map[string]interface{} {
+	return map[string]interface{}{
+		"pickUp":           map[string]interface{}{"code": "MUC", "country": "DE"},
+		"dropOff":          map[string]interface{}{"code": "MUC", "country": "DE"},
+		"rentalDays":       7,
+		"contract":         map[string]interface{}{"currency": "EUR", "supplierId": 1, "paymentType": 0},
+		"taxRate":          19,
+		"residenceCountry": "DE",
+		"age":              30,
+		"moduleId":         1,
+	}
+}
+
+// hub answers the first request of each body as lead and the others as hit
+func hub(t *testing.T, delay time.Duration) (*httptest.Server, *sync.Map) {
+	bodies := &sync.Map{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		_, _ = body.ReadFrom(r.Body)
+
+		role := grouping.RoleHit
+		if _, loaded := bodies.LoadOrStore(r.URL.Path+body.String(), true); !loaded {
+			role = grouping.RoleLead
+		}
+
+		time.Sleep(delay)
+
+		w.Header().Set(grouping.RoleHeader, role)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, bodies
+}
+
+func supplier(t *testing.T, resets *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_mock/calls", r.URL.Path)
+
+		if r.Method == http.MethodDelete {
+			atomic.AddInt32(resets, 1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]map[schema.SupplierRequestName]int{
+			"hertz": {schema.Rates: 2},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestDistribution(t *testing.T) {
+	t.Run("should load the example distribution", func(t *testing.T) {
+		distribution, err := loadtest.LoadDistribution("../../cmd/loadtest/distribution.yaml")
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, distribution.Requests)
+	})
+
+	t.Run("should report every invalid request", func(t *testing.T) {
+		invalid := params()
+		invalid["age"] = "thirty"
+
+		err := loadtest.Distribution{Requests: []loadtest.Request{
+			{Platform: "hertz", Params: params()},
+			{Name: "unknown", Platform: "unknown", Params: params()},
+			{Name: "invalid", Platform: "hertz", Weight: -1, Params: invalid},
+		}}.Validate()
+
+		assert.ErrorContains(t, err, "request : name is required")
+		assert.ErrorContains(t, err, "configuration is required, the mock supplier does not serve unknown")
+		assert.ErrorContains(t, err, "weight must not be negative")
+		assert.ErrorContains(t, err, "invalid params")
+	})
+
+	t.Run("should fail without requests", func(t *testing.T) {
+		assert.ErrorIs(t, loadtest.Distribution{}.Validate(), loadtest.ErrorEmptyDistribution)
+	})
+}
+
+func TestRun(t *testing.T) {
+	distribution := loadtest.Distribution{Requests: []loadtest.Request{
+		{Name: "hertz", Platform: "hertz", Weight: 3, Params: params()},
+		{Name: "anyrent", Platform: "anyrent", Params: params()},
+	}}
+
+	t.Run("should report latency, grouping roles and supplier calls", func(t *testing.T) {
+		var resets int32
+
+		service, bodies := hub(t, 0)
+		mock := supplier(t, &resets)
+
+		report, err := loadtest.Run(context.Background(), distribution, loadtest.Options{
+			HubUrl:      service.URL,
+			SupplierUrl: mock.URL,
+			Concurrency: 4,
+			Requests:    40,
+			Seed:        1,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), resets)
+		assert.Equal(t, 40, report.Requests)
+		assert.Equal(t, 0, report.Failures)
+		assert.Equal(t, map[int]int{http.StatusOK: 40}, report.Statuses)
+		assert.Equal(t, 2, report.Grouping[grouping.RoleLead])
+		assert.Equal(t, 38, report.Grouping[grouping.RoleHit])
+		assert.InDelta(t, 0.95, report.Ratio(grouping.RoleHit), 0.001)
+		assert.Equal(t, 2, report.SupplierCallsTotal())
+		assert.Contains(t, report.ByName, "hertz")
+		assert.Contains(t, report.ByName, "anyrent")
+		assert.LessOrEqual(t, report.Latency.P50, report.Latency.P99)
+
+		bodies.Range(func(key, value any) bool {
+			body := key.(string)
+			assert.Contains(t, body, `"dateTime"`)
+			assert.Contains(t, body, mock.URL)
+
+			return true
+		})
+
+		output := &strings.Builder{}
+		report.Print(output)
+
+		assert.Contains(t, output.String(), "supplier calls  2")
+		assert.Contains(t, output.String(), "hertz rates")
+	})
+
+	t.Run("should stop after the duration", func(t *testing.T) {
+		service, _ := hub(t, 20*time.Millisecond)
+
+		start := time.Now()
+
+		report, err := loadtest.Run(context.Background(), distribution, loadtest.Options{
+			HubUrl:      service.URL,
+			Concurrency: 2,
+			Duration:    100 * time.Millisecond,
+		})
+
+		assert.Nil(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Greater(t, report.Requests, 0)
+		assert.Equal(t, 0, report.Failures)
+		assert.Nil(t, report.SupplierCalls)
+	})
+
+	t.Run("should count requests without response as failures", func(t *testing.T) {
+		report, err := loadtest.Run(context.Background(), distribution, loadtest.Options{
+			HubUrl:   "http://127.0.0.1:1",
+			Requests: 3,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, report.Failures)
+		assert.Empty(t, report.Statuses)
+		assert.Equal(t, float64(0), report.Ratio(grouping.RoleLead))
+	})
+
+	t.Run("should require a limit", func(t *testing.T) {
+		_, err := loadtest.Run(context.Background(), distribution, loadtest.Options{HubUrl: "http://localhost"})
+
+		assert.ErrorIs(t, err, loadtest.ErrorNoLimit)
+	})
+}
@@ -0,0 +1,217 @@
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/trafficlight/grouping"
+)
+
+// RoleNone counts responses without grouping role, e.g. answered from the
+// responses cache or with grouping skipped
+const RoleNone = "none"
+
+// sample is the outcome of one replayed request
+type sample struct {
+	request string
+	latency time.Duration
+	status  int
+	role    string
+	err     error
+}
+
+type Latency struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+type Report struct {
+	Requests int           `json:"requests"`
+	Elapsed  time.Duration `json:"elapsed"`
+	// Failures are requests without response, e.g. timeouts or resets
+	Failures int `json:"failures"`
+	// Latency covers requests with response only
+	Latency  Latency            `json:"latency"`
+	ByName   map[string]Latency `json:"byName"`
+	Statuses map[int]int        `json:"statuses"`
+	// Grouping counts responses by grouping role, see grouping.RoleHeader
+	Grouping      map[string]int                                `json:"grouping"`
+	SupplierCalls map[string]map[schema.SupplierRequestName]int `json:"supplierCalls,omitempty"`
+}
+
+// Ratio is the share of responses answered in the grouping role
+func (r *Report) Ratio(role string) float64 {
+	responses := r.Requests - r.Failures
+	if responses == 0 {
+		return 0
+	}
+
+	return float64(r.Grouping[role]) / float64(responses)
+}
+
+// SupplierCallsTotal sums the supplier calls of all platforms
+func (r *Report) SupplierCallsTotal() int {
+	total := 0
+
+	for _, calls := range r.SupplierCalls {
+		for _, count := range calls {
+			total += count
+		}
+	}
+
+	return total
+}
+
+// Throughput is the number of requests per second
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(r.Requests) / r.Elapsed.Seconds()
+}
+
+func newReport(samples []sample, elapsed time.Duration) *Report {
+	report := &Report{
+		Requests: len(samples),
+		Elapsed:  elapsed,
+		ByName:   make(map[string]Latency),
+		Statuses: make(map[int]int),
+		Grouping: make(map[string]int),
+	}
+
+	all := make([]time.Duration, 0, len(samples))
+	byName := make(map[string][]time.Duration)
+
+	for _, s := range samples {
+		if s.err != nil {
+			report.Failures++
+			continue
+		}
+
+		all = append(all, s.latency)
+		byName[s.request] = append(byName[s.request], s.latency)
+
+		report.Statuses[s.status]++
+
+		role := s.role
+		if role == "" {
+			role = RoleNone
+		}
+
+		report.Grouping[role]++
+	}
+
+	report.Latency = latency(all)
+
+	for name, latencies := range byName {
+		report.ByName[name] = latency(latencies)
+	}
+
+	return report
+}
+
+func latency(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	return Latency{
+		P50: percentile(latencies, 50),
+		P90: percentile(latencies, 90),
+		P95: percentile(latencies, 95),
+		P99: percentile(latencies, 99),
+		Max: latencies[len(latencies)-1],
+	}
+}
+
+// percentile uses the nearest rank of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+// Print writes the report as aligned text
+func (r *Report) Print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "requests\t%d in %s (%.1f/s), %d failed\n", r.Requests, r.Elapsed.Round(time.Millisecond), r.Throughput(), r.Failures)
+
+	fmt.Fprintln(tw, "\nlatency\tp50\tp90\tp95\tp99\tmax")
+	printLatency(tw, "all", r.Latency)
+
+	for _, name := range sortedKeys(r.ByName) {
+		printLatency(tw, name, r.ByName[name])
+	}
+
+	fmt.Fprintln(tw, "\nstatus\tresponses")
+
+	statuses := make([]int, 0, len(r.Statuses))
+	for status := range r.Statuses {
+		statuses = append(statuses, status)
+	}
+
+	sort.Ints(statuses)
+
+	for _, status := range statuses {
+		fmt.Fprintf(tw, "%d\t%d\n", status, r.Statuses[status])
+	}
+
+	fmt.Fprintln(tw, "\ngrouping\tresponses\tratio")
+
+	for _, role := range []string{grouping.RoleLead, grouping.RoleHit, grouping.RoleWait, RoleNone} {
+		fmt.Fprintf(tw, "%s\t%d\t%.3f\n", role, r.Grouping[role], r.Ratio(role))
+	}
+
+	if r.SupplierCalls != nil {
+		fmt.Fprintf(tw, "\nsupplier calls\t%d\n", r.SupplierCallsTotal())
+
+		for _, platform := range sortedKeys(r.SupplierCalls) {
+			calls := r.SupplierCalls[platform]
+
+			names := make([]string, 0, len(calls))
+			for name := range calls {
+				names = append(names, string(name))
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				fmt.Fprintf(tw, "%s %s\t%d\n", platform, name, calls[schema.SupplierRequestName(name)])
+			}
+		}
+	}
+
+	tw.Flush()
+}
+
+func printLatency(w io.Writer, name string, l Latency) {
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", name,
+		l.P50.Round(time.Millisecond), l.P90.Round(time.Millisecond), l.P95.Round(time.Millisecond),
+		l.P99.Round(time.Millisecond), l.Max.Round(time.Millisecond))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,538 @@
+//go:build integration
+
+package mocksupplier_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/admin"
+	"bitbucket.org/crgw/supplier-hub/internal/audit"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/loadtest"
+	"bitbucket.org/crgw/supplier-hub/internal/mocksupplier"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"bitbucket.org/crgw/supplier-hub/internal/trafficlight/grouping"
+	"bitbucket.org/crgw/supplier-hub/internal/web"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// hub runs the service in memory against miniredis, configure changes the
+// defaults of the tests
+func hub(t *testing.T, configure ...func(*config.Config)) *httptest.Server {
+	redisServer := miniredis.RunT(t)
+	uri := "redis://" + redisServer.Addr()
+
+	cfg := config.Default()
+	cfg.Test = true
+	cfg.Cache.Engine = caching.EngineMemory
+	cfg.Server.OpenApiLocation = "../../api/openapi.json"
+	cfg.Redis.Clients[redisfactory.Trafficlight] = config.RedisClient{Uri: uri}
+	cfg.Redis.Clients[redisfactory.ResponsesCache] = config.RedisClient{Uri: uri}
+
+	for _, c := range configure {
+		c(cfg)
+	}
+
+	redisFactory, err := redisfactory.New(cfg.Redis.ClientOptions())
+	assert.Nil(t, err)
+
+	log := zerolog.Nop()
+	router, _, _, err := web.SetupRouter(&log, cfg, redisFactory, lifecycle.NewTracker())
+	assert.Nil(t, err)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func post(t *testing.T, url string, body interface{}) map[string]interface{} {
+	content, _ := json.Marshal(body)
+
+	response, err := http.Post(url, "application/json", bytes.NewReader(content))
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	answer, _ := io.ReadAll(response.Body)
+	assert.Equal(t, http.StatusOK, response.StatusCode, string(answer))
+
+	var decoded map[string]interface{}
+	assert.Nil(t, json.Unmarshal(answer, &decoded))
+
+	return decoded
+}
+
+func TestEndToEnd(t *testing.T) {
+	mock := supplier(t, mocksupplier.Scenarios{})
+	service := hub(t)
+
+	pickUp := time.Now().UTC().AddDate(0, 1, 0).Truncate(24 * time.Hour).Add(10 * time.Hour)
+	branch := map[string]interface{}{"code": "MUC", "country": "DE", "dateTime": pickUp.Format(time.RFC3339)}
+
+	for _, platform := range mocksupplier.Platforms() {
+		t.Run("should search rates of "+platform, func(t *testing.T) {
+			response := post(t, service.URL+"/"+platform+"/rates", map[string]interface{}{
+				"pickUp":           branch,
+				"dropOff":          map[string]interface{}{"code": "MUC", "country": "DE", "dateTime": pickUp.AddDate(0, 0, 7).Format(time.RFC3339)},
+				"rentalDays":       7,
+				"contract":         map[string]interface{}{"currency": "EUR", "supplierId": 1, "paymentType": 0},
+				"taxRate":          19,
+				"residenceCountry": "DE",
+				"age":              30,
+				"moduleId":         1,
+				"timeouts":         map[string]interface{}{"default": 5000},
+				"configuration":    mocksupplier.Configuration(platform, mock.URL),
+			})
+
+			assert.Empty(t, response["errors"])
+			assert.NotEmpty(t, response["vehicles"])
+		})
+
+		t.Run("should cancel bookings of "+platform, func(t *testing.T) {
+			response := post(t, service.URL+"/"+platform+"/cancel", map[string]interface{}{
+				"pickUp":                   branch,
+				"supplierBookingReference": "K48730916F3",
+				"brokerReference":          "B123",
+				"reservNumber":             "R123",
+				"moduleId":                 1,
+				"contact":                  map[string]interface{}{"email": "mock@example.com"},
+				"timeouts":                 map[string]interface{}{"default": 5000},
+				"configuration":            mocksupplier.Configuration(platform, mock.URL),
+			})
+
+			assert.Equal(t, "OK", response["status"])
+		})
+	}
+
+	for _, platform := range []string{"anyrent", "bookingcom", "rently"} {
+		t.Run("should import locations of "+platform, func(t *testing.T) {
+			response := post(t, service.URL+"/"+platform+"/locations", map[string]interface{}{
+				"timeouts":      map[string]interface{}{"default": 5000},
+				"configuration": mocksupplier.Configuration(platform, mock.URL),
+			})
+
+			assert.Empty(t, response["errors"])
+			assert.NotEmpty(t, response["locations"])
+		})
+	}
+}
+
+func TestRemote(t *testing.T) {
+	mock := supplier(t, mocksupplier.Scenarios{})
+	adapter := hub(t)
+	// the hub serving hertz is the adapter of the remote platform
+	service := hub(t, func(cfg *config.Config) {
+		cfg.Remote.Platforms = map[string]string{"partner": adapter.URL + "/hertz"}
+	})
+
+	pickUp := time.Now().UTC().AddDate(0, 1, 0).Truncate(24 * time.Hour).Add(10 * time.Hour)
+	branch := map[string]interface{}{"code": "MUC", "country": "DE", "dateTime": pickUp.Format(time.RFC3339)}
+
+	t.Run("should forward rates of remote platforms to their adapter", func(t *testing.T) {
+		response := post(t, service.URL+"/partner/rates", map[string]interface{}{
+			"pickUp":           branch,
+			"dropOff":          map[string]interface{}{"code": "MUC", "country": "DE", "dateTime": pickUp.AddDate(0, 0, 7).Format(time.RFC3339)},
+			"rentalDays":       7,
+			"contract":         map[string]interface{}{"currency": "EUR", "supplierId": 1, "paymentType": 0},
+			"taxRate":          19,
+			"residenceCountry": "DE",
+			"age":              30,
+			"moduleId":         1,
+			"timeouts":         map[string]interface{}{"default": 5000},
+			"configuration":    mocksupplier.Configuration("hertz", mock.URL),
+		})
+
+		assert.Empty(t, response["errors"])
+		assert.NotEmpty(t, response["vehicles"])
+		assert.NotEmpty(t, response["supplierRequests"])
+	})
+
+	t.Run("should reject platforms neither built in nor remote", func(t *testing.T) {
+		response, err := http.Post(service.URL+"/unknown/rates", "application/json", bytes.NewReader([]byte("{}")))
+
+		assert.Nil(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
+func TestSandbox(t *testing.T) {
+	service := hub(t)
+
+	pickUp := time.Now().UTC().AddDate(0, 1, 0).Truncate(24 * time.Hour).Add(10 * time.Hour)
+	branch := map[string]interface{}{"code": "MUCT01", "country": "DE", "dateTime": pickUp.Format(time.RFC3339)}
+	configuration := map[string]interface{}{"seed": 7}
+	timeouts := map[string]interface{}{"default": 5000}
+
+	rates := post(t, service.URL+"/sandbox/rates", map[string]interface{}{
+		"pickUp":           branch,
+		"dropOff":          branch,
+		"rentalDays":       7,
+		"contract":         map[string]interface{}{"currency": "EUR", "supplierId": 1, "paymentType": 0},
+		"taxRate":          19,
+		"residenceCountry": "DE",
+		"age":              30,
+		"moduleId":         1,
+		"timeouts":         timeouts,
+		"configuration":    configuration,
+	})
+	vehicle := rates["vehicles"].([]interface{})[0].(map[string]interface{})
+
+	reference := func(reservNumber string) string {
+		booking := post(t, service.URL+"/sandbox/booking", map[string]interface{}{
+			"pickUp":                branch,
+			"dropOff":               branch,
+			"contract":              map[string]interface{}{"currency": "EUR", "supplierId": 1, "paymentType": 0},
+			"vehicleClass":          vehicle["class"],
+			"supplierRateReference": vehicle["supplierRateReference"],
+			"reservNumber":          reservNumber,
+			"brokerReference":       "B123",
+			"customer": map[string]interface{}{
+				"firstName": "Max", "lastName": "Mustermann", "residenceCountry": "DE",
+				"phone": "+49301234567", "email": "mock@example.com", "age": 30,
+			},
+			"moduleId":      1,
+			"timeouts":      timeouts,
+			"configuration": configuration,
+		})
+
+		assert.NotEmpty(t, booking["supplierBookingReference"])
+		return booking["supplierBookingReference"].(string)
+	}
+
+	status := func(reservNumber string, supplierBookingReference string) interface{} {
+		return post(t, service.URL+"/sandbox/booking-status", map[string]interface{}{
+			"supplierBookingReference": supplierBookingReference,
+			"reservNumber":             reservNumber,
+			"brokerReference":          "B123",
+			"bookingDateTime":          pickUp.AddDate(0, 0, -7).Format(time.RFC3339),
+			"moduleId":                 1,
+			"timeouts":                 timeouts,
+			"configuration":            configuration,
+		})["status"]
+	}
+
+	t.Run("should cancel sandbox bookings", func(t *testing.T) {
+		supplierBookingReference := reference("R123")
+		assert.Equal(t, "OK", status("R123", supplierBookingReference))
+
+		response := post(t, service.URL+"/sandbox/cancel", map[string]interface{}{
+			"pickUp":                   branch,
+			"supplierBookingReference": supplierBookingReference,
+			"brokerReference":          "B123",
+			"reservNumber":             "R123",
+			"moduleId":                 1,
+			"contact":                  map[string]interface{}{"email": "mock@example.com"},
+			"timeouts":                 timeouts,
+			"configuration":            configuration,
+		})
+
+		assert.Equal(t, "OK", response["status"])
+		assert.Equal(t, "CANCELLED", status("R123", supplierBookingReference))
+	})
+
+	t.Run("should confirm pending sandbox bookings", func(t *testing.T) {
+		supplierBookingReference := reference("SANDBOX-PENDING-1")
+
+		assert.Equal(t, "PENDING", status("SANDBOX-PENDING-1", supplierBookingReference))
+		assert.Equal(t, "OK", status("SANDBOX-PENDING-1", supplierBookingReference))
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should group concurrent equal rates requests", func(t *testing.T) {
+		mock := supplier(t, mocksupplier.Scenarios{
+			Scenarios: []mocksupplier.Scenario{{Name: "slow", Latency: 300 * time.Millisecond}},
+			Active:    []string{"slow"},
+		})
+		service := hub(t)
+
+		distribution, err := loadtest.LoadDistribution("../../cmd/loadtest/distribution.yaml")
+		assert.Nil(t, err)
+
+		report, err := loadtest.Run(context.Background(), distribution, loadtest.Options{
+			HubUrl:      service.URL,
+			SupplierUrl: mock.URL,
+			Concurrency: 12,
+			Requests:    60,
+			Seed:        1,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[int]int{http.StatusOK: 60}, report.Statuses)
+		// platforms without rates grouping answer without role
+		assert.Greater(t, report.Grouping[grouping.RoleLead], 0)
+		assert.Greater(t, report.Grouping[grouping.RoleHit]+report.Grouping[grouping.RoleWait], report.Grouping[grouping.RoleLead])
+		assert.Less(t, report.SupplierCallsTotal(), 60)
+	})
+}
+
+func TestBookingStatusBatch(t *testing.T) {
+	mock := supplier(t, mocksupplier.Scenarios{})
+	service := hub(t)
+
+	for _, platform := range []string{"anyrent", "rently"} {
+		t.Run("should stream booking statuses of "+platform+" fetching the auth token once", func(t *testing.T) {
+			items := []map[string]interface{}{}
+			for _, reference := range []string{"S1", "S2", "S3", "S4", "S5", "S6"} {
+				items = append(items, map[string]interface{}{
+					"supplierBookingReference": reference,
+					"reservNumber":             "R" + reference,
+					"brokerReference":          "B123",
+					"bookingDateTime":          time.Now().UTC().Format(time.RFC3339),
+					"moduleId":                 1,
+					"timeouts":                 map[string]interface{}{"default": 5000},
+					"configuration":            mocksupplier.Configuration(platform, mock.URL),
+				})
+			}
+
+			content, _ := json.Marshal(map[string]interface{}{"items": items})
+
+			response, err := http.Post(service.URL+"/"+platform+"/booking-status/batch", "application/json", bytes.NewReader(content))
+			assert.Nil(t, err)
+			defer response.Body.Close()
+
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, "application/x-ndjson", response.Header.Get("Content-Type"))
+
+			indexes := map[int]bool{}
+			decoder := json.NewDecoder(response.Body)
+
+			for decoder.More() {
+				var result schema.BookingStatusBatchResult
+				assert.Nil(t, decoder.Decode(&result))
+
+				assert.Equal(t, http.StatusOK, result.Code)
+				assert.Equal(t, "R"+items[result.Index]["supplierBookingReference"].(string), result.ReservNumber)
+				assert.Equal(t, schema.BookingStatusResponseStatusOK, result.Response.Status)
+				indexes[result.Index] = true
+			}
+
+			assert.Len(t, indexes, len(items))
+
+			calls, err := http.Get(mock.URL + "/_mock/calls")
+			assert.Nil(t, err)
+			defer calls.Body.Close()
+
+			var counted map[string]map[schema.SupplierRequestName]int
+			assert.Nil(t, json.NewDecoder(calls.Body).Decode(&counted))
+
+			assert.Equal(t, 1, counted[platform][schema.Auth])
+			assert.Equal(t, len(items), counted[platform][schema.BookingStatus])
+		})
+	}
+
+	t.Run("should run items within the quota of the client", func(t *testing.T) {
+		limited := hub(t, func(cfg *config.Config) {
+			cfg.Quota = config.Quota{
+				Enabled: true,
+				Limits:  []config.QuotaLimit{{Scope: "*:booking-status", Concurrency: 1, Rate: 50}},
+			}
+		})
+
+		items := []map[string]interface{}{}
+		for _, reference := range []string{"S1", "S2", "S3"} {
+			items = append(items, map[string]interface{}{
+				"supplierBookingReference": reference,
+				"reservNumber":             "R" + reference,
+				"brokerReference":          "B123",
+				"bookingDateTime":          time.Now().UTC().Format(time.RFC3339),
+				"moduleId":                 1,
+				"timeouts":                 map[string]interface{}{"default": 5000},
+				"configuration":            mocksupplier.Configuration("anyrent", mock.URL),
+			})
+		}
+
+		content, _ := json.Marshal(map[string]interface{}{"items": items})
+
+		response, err := http.Post(limited.URL+"/anyrent/booking-status/batch", "application/json", bytes.NewReader(content))
+		assert.Nil(t, err)
+		defer response.Body.Close()
+
+		decoder := json.NewDecoder(response.Body)
+		results := 0
+
+		for decoder.More() {
+			var result schema.BookingStatusBatchResult
+			assert.Nil(t, decoder.Decode(&result))
+			assert.Equal(t, http.StatusOK, result.Code)
+			results++
+		}
+
+		assert.Equal(t, len(items), results)
+	})
+
+	t.Run("should reject empty batches", func(t *testing.T) {
+		response, err := http.Post(service.URL+"/anyrent/booking-status/batch", "application/json", bytes.NewReader([]byte(`{"items":[]}`)))
+		assert.Nil(t, err)
+		defer response.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
+func TestQuota(t *testing.T) {
+	mock := supplier(t, mocksupplier.Scenarios{})
+	service := hub(t, func(cfg *config.Config) {
+		cfg.Quota = config.Quota{
+			Enabled: true,
+			Limits:  []config.QuotaLimit{{Scope: "*:locations", Rate: 0.001, Burst: 1}},
+		}
+		cfg.Admin.ApiKey = "admin-key"
+	})
+
+	calls := func() int {
+		response, err := http.Get(mock.URL + "/_mock/calls")
+		assert.Nil(t, err)
+		defer response.Body.Close()
+
+		var counted map[string]map[schema.SupplierRequestName]int
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&counted))
+
+		total := 0
+		for _, count := range counted["anyrent"] {
+			total += count
+		}
+
+		return total
+	}
+
+	t.Run("should reject requests over the quota without calling the supplier", func(t *testing.T) {
+		body := map[string]interface{}{
+			"timeouts":      map[string]interface{}{"default": 5000},
+			"configuration": mocksupplier.Configuration("anyrent", mock.URL),
+		}
+
+		post(t, service.URL+"/anyrent/locations", body)
+		before := calls()
+
+		content, _ := json.Marshal(body)
+		code, answer := send(t, http.MethodPost, service.URL+"/anyrent/locations", string(content))
+
+		assert.Equal(t, http.StatusTooManyRequests, code)
+		assert.Equal(t, `{"message":"Quota exceeded"}`, answer)
+		assert.Equal(t, before, calls())
+	})
+
+	t.Run("should not keep quota state of unknown platforms", func(t *testing.T) {
+		code, _ := send(t, http.MethodPost, service.URL+"/unknown-platform/locations", `{}`)
+		assert.NotEqual(t, http.StatusOK, code)
+
+		code, _ = send(t, http.MethodGet, service.URL+"/metrics", "")
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		_, metrics := send(t, http.MethodGet, service.URL+"/metrics", "", admin.ApiKeyHeader, "admin-key")
+		assert.Contains(t, metrics, `scope="anyrent:locations"`)
+		assert.NotContains(t, metrics, "unknown-platform")
+	})
+}
+
+// TestRejections checks that requests rejected by a middleware answer with
+// the error only and never reach the supplier
+func TestRejections(t *testing.T) {
+	mock := supplier(t, mocksupplier.Scenarios{})
+	service := hub(t)
+
+	locations, _ := json.Marshal(map[string]interface{}{
+		"timeouts":      map[string]interface{}{"default": 5000},
+		"configuration": mocksupplier.Configuration("anyrent", mock.URL),
+	})
+
+	reject := func(t *testing.T, url string, body string, headers ...string) string {
+		headers = append(headers, "Content-Type", "application/json")
+
+		code, answer := send(t, http.MethodPost, url, body, headers...)
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		var decoded map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(answer), &decoded), answer)
+		assert.Len(t, decoded, 1, answer)
+
+		return answer
+	}
+
+	t.Run("should reject configuration references when credentials are disabled", func(t *testing.T) {
+		answer := reject(t, service.URL+"/anyrent/locations", `{"timeouts":{"default":5000},"configurationRef":"stored"}`)
+		assert.Contains(t, answer, "configuration references are not enabled")
+	})
+
+	t.Run("should reject unknown history modes", func(t *testing.T) {
+		answer := reject(t, service.URL+"/anyrent/locations?history=everything", string(locations))
+		assert.Contains(t, answer, "unknown history mode")
+	})
+
+	t.Run("should reject offloading when history offloading is not configured", func(t *testing.T) {
+		answer := reject(t, service.URL+"/anyrent/locations", string(locations), "x-supplier-history", "offload")
+		assert.Contains(t, answer, "history offloading is not configured")
+	})
+
+	t.Run("should reject unknown faults", func(t *testing.T) {
+		answer := reject(t, service.URL+"/anyrent/locations", string(locations), "x-fault-injection", "earthquake")
+		assert.Contains(t, answer, `unknown fault \"earthquake\"`)
+	})
+}
+
+func TestAuditLog(t *testing.T) {
+	mock := supplier(t, mocksupplier.Scenarios{})
+	service := hub(t, func(cfg *config.Config) {
+		cfg.Admin.ApiKey = "admin-key"
+		cfg.Audit.Backend = config.BackendFile
+		cfg.Audit.Path = t.TempDir()
+	})
+
+	t.Run("should record cancellations with redacted secrets", func(t *testing.T) {
+		pickUp := time.Now().UTC().AddDate(0, 1, 0).Truncate(24 * time.Hour)
+
+		post(t, service.URL+"/anyrent/cancel?history=metadata", map[string]interface{}{
+			"pickUp":                   map[string]interface{}{"code": "MUC", "country": "DE", "dateTime": pickUp.Format(time.RFC3339)},
+			"supplierBookingReference": "K48730916F3",
+			"brokerReference":          "B123",
+			"reservNumber":             "R-AUDIT",
+			"moduleId":                 1,
+			"contact":                  map[string]interface{}{"email": "mock@example.com"},
+			"timeouts":                 map[string]interface{}{"default": 5000},
+			"configuration":            mocksupplier.Configuration("anyrent", mock.URL),
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, service.URL+"/admin/audit?supplierBookingReference=K48730916F3", nil)
+		request.Header.Set(admin.ApiKeyHeader, "admin-key")
+
+		response, err := http.DefaultClient.Do(request)
+		assert.Nil(t, err)
+		defer response.Body.Close()
+
+		var records []audit.Record
+		assert.Nil(t, json.NewDecoder(response.Body).Decode(&records))
+		assert.Len(t, records, 1)
+
+		record := records[0]
+		assert.Equal(t, "cancel", record.Operation)
+		assert.Equal(t, "R-AUDIT", record.ReservNumber)
+		assert.Equal(t, http.StatusOK, record.StatusCode)
+		assert.NotEmpty(t, record.CorrelationId)
+
+		var cancel schema.CancelRequestParams
+		assert.Nil(t, json.Unmarshal(record.Request, &cancel))
+
+		configuration, _ := cancel.Configuration.AsAnyRentConfiguration()
+		assert.Equal(t, "<redacted>", configuration.ApiKey)
+
+		var cancelled schema.CancelResponse
+		assert.Nil(t, json.Unmarshal(record.Response, &cancelled))
+		assert.Equal(t, schema.CancelResponseStatusOK, *cancelled.Status)
+		assert.NotEmpty(t, (*cancelled.SupplierRequests)[0].ResponseContent.Body)
+	})
+}
@@ -0,0 +1,313 @@
+// Package mocksupplier simulates the supplier APIs with the fixtures of the
+// platform tests. Platforms are served below /<platform>, scenarios add
+// latency, errors, broken bodies or partial availability.
+package mocksupplier
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// ScenarioHeader selects scenarios per request, comma separated
+	ScenarioHeader = "x-mock-scenario"
+
+	// scenarioPrefix selects scenarios in the supplier url, e.g.
+	// /scenario/slow,broken/hertz, as the hub does not forward headers
+	scenarioPrefix = "/scenario/"
+	adminPrefix    = "/_mock"
+)
+
+var ErrorFixtures = errors.New("fixtures directory not found")
+
+type Options struct {
+	// Fixtures is the platform implementations directory holding the testdata
+	Fixtures  string
+	Scenarios Scenarios
+	Logger    *zerolog.Logger
+}
+
+type Server struct {
+	fixtures  string
+	logger    *zerolog.Logger
+	scenarios []Scenario
+	active    []string
+	calls     map[string]map[schema.SupplierRequestName]int
+	random    *rand.Rand
+	mu        sync.Mutex
+}
+
+func New(o Options) (*Server, error) {
+	info, err := os.Stat(o.Fixtures)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrorFixtures, o.Fixtures)
+	}
+
+	err = o.Scenarios.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	logger := o.Logger
+	if logger == nil {
+		nop := zerolog.Nop()
+		logger = &nop
+	}
+
+	return &Server{
+		fixtures:  o.Fixtures,
+		logger:    logger,
+		scenarios: o.Scenarios.Scenarios,
+		active:    o.Scenarios.Active,
+		calls:     make(map[string]map[schema.SupplierRequestName]int),
+		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// Handler serves the suppliers and the /_mock endpoints to change active
+// scenarios and read call counts
+func (s *Server) Handler() http.Handler {
+	gin.SetMode(gin.ReleaseMode)
+
+	router := gin.New()
+
+	router.GET(adminPrefix+"/scenarios", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"scenarios": s.scenarios, "active": s.Active()})
+	})
+
+	router.PUT(adminPrefix+"/scenarios", func(c *gin.Context) {
+		var body struct {
+			Active []string `json:"active"`
+		}
+
+		err := c.BindJSON(&body)
+		if err != nil {
+			return
+		}
+
+		err = s.Activate(body.Active...)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"active": s.Active()})
+	})
+
+	router.GET(adminPrefix+"/calls", func(c *gin.Context) {
+		c.JSON(http.StatusOK, s.Calls())
+	})
+
+	router.DELETE(adminPrefix+"/calls", func(c *gin.Context) {
+		s.ResetCalls()
+		c.Status(http.StatusNoContent)
+	})
+
+	router.NoRoute(s.supplier)
+
+	return router
+}
+
+// Activate replaces the scenarios applied to all requests
+func (s *Server) Activate(names ...string) error {
+	err := Scenarios{Scenarios: s.scenarios, Active: names}.Validate()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.active = append([]string(nil), names...)
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *Server) Active() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.active...)
+}
+
+// Calls counts the answered requests by platform and request name
+func (s *Server) Calls() map[string]map[schema.SupplierRequestName]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make(map[string]map[schema.SupplierRequestName]int, len(s.calls))
+	for platform, requests := range s.calls {
+		calls[platform] = make(map[schema.SupplierRequestName]int, len(requests))
+		for request, count := range requests {
+			calls[platform][request] = count
+		}
+	}
+
+	return calls
+}
+
+func (s *Server) ResetCalls() {
+	s.mu.Lock()
+	s.calls = make(map[string]map[schema.SupplierRequestName]int)
+	s.mu.Unlock()
+}
+
+func (s *Server) count(platform string, request schema.SupplierRequestName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.calls[platform] == nil {
+		s.calls[platform] = make(map[schema.SupplierRequestName]int)
+	}
+
+	s.calls[platform][request]++
+}
+
+// splitPath separates selected scenarios, platform and the supplier path
+func splitPath(path string) (selected []string, platform string, rest string) {
+	if strings.HasPrefix(path, scenarioPrefix) {
+		names, remaining, _ := strings.Cut(strings.TrimPrefix(path, scenarioPrefix), "/")
+		selected = strings.Split(names, ",")
+		path = "/" + remaining
+	}
+
+	platform, rest, _ = strings.Cut(strings.TrimPrefix(path, "/"), "/")
+
+	return selected, platform, "/" + rest
+}
+
+// scenario picks the first applying scenario, selected ones before active ones
+func (s *Server) scenario(names []string, platform string, request schema.SupplierRequestName) (Scenario, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names = append(names, s.active...)
+
+	for _, name := range names {
+		for _, scenario := range s.scenarios {
+			if scenario.Name != strings.TrimSpace(name) || !scenario.matches(platform, request) {
+				continue
+			}
+
+			if scenario.Probability == 0 || s.random.Float64() < scenario.Probability {
+				return scenario, true
+			}
+		}
+	}
+
+	return Scenario{}, false
+}
+
+func (s *Server) fixture(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.fixtures, filepath.FromSlash(name)))
+}
+
+func (s *Server) supplier(c *gin.Context) {
+	selected, platformName, path := splitPath(c.Request.URL.Path)
+
+	if header := c.GetHeader(ScenarioHeader); header != "" {
+		selected = append(strings.Split(header, ","), selected...)
+	}
+
+	p, ok := platforms[platformName]
+	if !ok {
+		c.String(http.StatusNotFound, "unknown platform %q, simulated are %s", platformName, strings.Join(Platforms(), ", "))
+		return
+	}
+
+	body, _ := io.ReadAll(c.Request.Body)
+
+	r, ok := p.match(c.Request.Method, path, body)
+	if !ok {
+		c.String(http.StatusNotFound, "no %s route for %s %s", platformName, c.Request.Method, path)
+		return
+	}
+
+	s.count(platformName, r.request)
+
+	scenario, _ := s.scenario(selected, platformName, r.request)
+
+	log := s.logger.With().
+		Str("platform", platformName).
+		Str("request", string(r.request)).
+		Str("scenario", scenario.Name).
+		Logger()
+
+	if scenario.Latency > 0 {
+		select {
+		case <-time.After(scenario.Latency):
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+
+	if scenario.Fault == FaultReset {
+		log.Debug().Msg("Resetting connection")
+		reset(c)
+		return
+	}
+
+	content, err := s.content(r, scenario)
+	if err != nil {
+		log.Err(err).Msg("Unable to read fixture")
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if scenario.Availability != nil && (r.request == schema.Rates || r.request == schema.Extras) {
+		content, err = p.vehicles(content, *scenario.Availability)
+		if err != nil {
+			log.Err(err).Msg("Unable to reduce availability")
+		}
+	}
+
+	status := http.StatusOK
+	if scenario.Status != 0 {
+		status = scenario.Status
+	}
+
+	log.Debug().Int("status", status).Msg("Answering supplier request")
+
+	c.Data(status, r.contentType(), malform(scenario.Fault, content))
+}
+
+func (s *Server) content(r route, scenario Scenario) ([]byte, error) {
+	switch {
+	case scenario.Body != "":
+		return []byte(scenario.Body), nil
+	case scenario.Fixture != "":
+		return s.fixture(scenario.Fixture)
+	case r.fixture != "":
+		return s.fixture(r.fixture)
+	default:
+		return []byte(r.body), nil
+	}
+}
+
+// reset drops the connection, clients see an EOF or connection reset
+func reset(c *gin.Context) {
+	hijacker, ok := c.Writer.(http.Hijacker)
+	if !ok {
+		c.AbortWithStatus(http.StatusBadGateway)
+		return
+	}
+
+	connection, _, err := hijacker.Hijack()
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadGateway)
+		return
+	}
+
+	_ = connection.Close()
+}
@@ -0,0 +1,183 @@
+package mocksupplier_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/mocksupplier"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const fixtures = "../platform/implementations"
+
+func supplier(t *testing.T, scenarios mocksupplier.Scenarios) *httptest.Server {
+	server, err := mocksupplier.New(mocksupplier.Options{Fixtures: fixtures, Scenarios: scenarios})
+	assert.Nil(t, err)
+
+	mock := httptest.NewServer(server.Handler())
+	t.Cleanup(mock.Close)
+
+	return mock
+}
+
+func send(t *testing.T, method string, url string, body string, headers ...string) (int, string) {
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	assert.Nil(t, err)
+
+	for i := 0; i+1 < len(headers); i += 2 {
+		request.Header.Set(headers[i], headers[i+1])
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer response.Body.Close()
+
+	content, _ := io.ReadAll(response.Body)
+
+	return response.StatusCode, string(content)
+}
+
+const soapRates = `<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/"><SOAP-ENV:Header/><SOAP-ENV:Body><OTA_VehAvailRateRQ/></SOAP-ENV:Body></SOAP-ENV:Envelope>`
+
+func TestServer(t *testing.T) {
+	half := 0.5
+	none := 0.0
+
+	scenarios := mocksupplier.Scenarios{
+		Scenarios: []mocksupplier.Scenario{
+			{Name: "down", Status: http.StatusServiceUnavailable, Body: "unavailable"},
+			{Name: "broken", Request: schema.Rates, Fault: mocksupplier.FaultTruncated},
+			{Name: "half", Availability: &half},
+			{Name: "sold-out", Platform: "rently", Availability: &none},
+			{Name: "reset", Fault: mocksupplier.FaultReset},
+			{Name: "slow", Latency: 50 * time.Millisecond},
+		},
+	}
+
+	mock := supplier(t, scenarios)
+
+	t.Run("should answer requests by root element and path", func(t *testing.T) {
+		status, body := send(t, http.MethodPost, mock.URL+"/hertz", "<OTA_VehCancelRQ/>")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, `CancelStatus="Cancelled"`)
+
+		status, body = send(t, http.MethodPost, mock.URL+"/profitmaxdht", soapRates)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, "<OTA_VehAvailRateRS>")
+
+		status, body = send(t, http.MethodDelete, mock.URL+"/anyrent/v1/bookings/123", "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "{}", body)
+
+		status, _ = send(t, http.MethodGet, mock.URL+"/rently/api/Unknown", "")
+		assert.Equal(t, http.StatusNotFound, status)
+
+		status, _ = send(t, http.MethodGet, mock.URL+"/avis/rates", "")
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("should apply scenarios selected in the url or header", func(t *testing.T) {
+		status, body := send(t, http.MethodPost, mock.URL+"/scenario/down/hertz", "<OTA_VehCancelRQ/>")
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, "unavailable", body)
+
+		status, body = send(t, http.MethodPost, mock.URL+"/bookingcom", "<SearchRQ/>", mocksupplier.ScenarioHeader, "broken")
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotContains(t, body, "</SearchRS>")
+
+		// broken only matches rates
+		_, body = send(t, http.MethodPost, mock.URL+"/scenario/broken/bookingcom", "<CancelBookingRQ/>")
+		assert.Contains(t, body, "</CancelBookingRS>")
+
+		started := time.Now()
+		send(t, http.MethodPost, mock.URL+"/scenario/slow/hertz", "<OTA_VehCancelRQ/>")
+		assert.GreaterOrEqual(t, time.Since(started), 50*time.Millisecond)
+
+		status, _ = send(t, http.MethodPost, mock.URL+"/scenario/reset/hertz", "<OTA_VehCancelRQ/>")
+		assert.Equal(t, 0, status)
+	})
+
+	t.Run("should reduce availability of rates", func(t *testing.T) {
+		_, body := send(t, http.MethodGet, mock.URL+"/scenario/sold-out/rently/api/AvailabilityByPlace", "")
+		assert.Equal(t, "[]", body)
+
+		_, full := send(t, http.MethodPost, mock.URL+"/hertz", "<OTA_VehAvailRateRQ/>")
+		_, reduced := send(t, http.MethodPost, mock.URL+"/scenario/half/hertz", "<OTA_VehAvailRateRQ/>")
+		assert.Equal(t, 1, strings.Count(full, "<VehAvail>"))
+		assert.Equal(t, 0, strings.Count(reduced, "<VehAvail>"))
+		assert.Contains(t, reduced, "</OTA_VehAvailRateRS>")
+	})
+
+	t.Run("should switch active scenarios and count calls", func(t *testing.T) {
+		send(t, http.MethodDelete, mock.URL+"/_mock/calls", "")
+
+		status, _ := send(t, http.MethodPut, mock.URL+"/_mock/scenarios", `{"active":["down"]}`)
+		assert.Equal(t, http.StatusOK, status)
+
+		status, _ = send(t, http.MethodGet, mock.URL+"/anyrent/v1/prices", "")
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+
+		status, _ = send(t, http.MethodPut, mock.URL+"/_mock/scenarios", `{"active":["missing"]}`)
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		send(t, http.MethodPut, mock.URL+"/_mock/scenarios", `{"active":[]}`)
+
+		status, _ = send(t, http.MethodGet, mock.URL+"/anyrent/v1/prices", "")
+		assert.Equal(t, http.StatusOK, status)
+
+		_, calls := send(t, http.MethodGet, mock.URL+"/_mock/calls", "")
+		assert.JSONEq(t, `{"anyrent":{"rates":2}}`, calls)
+	})
+}
+
+func TestScenarios(t *testing.T) {
+	t.Run("should load scenarios from YAML", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "scenarios.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte(`
+scenarios:
+  - name: flaky
+    platform: hertz
+    request: rates
+    probability: 0.1
+    status: 500
+  - name: slow
+    latency: 2s
+active: [slow]
+`), 0o644))
+
+		scenarios, err := mocksupplier.LoadScenarios(path)
+		assert.Nil(t, err)
+		assert.Len(t, scenarios.Scenarios, 2)
+		assert.Equal(t, 2*time.Second, scenarios.Scenarios[1].Latency)
+		assert.Equal(t, []string{"slow"}, scenarios.Active)
+	})
+
+	t.Run("should report every invalid scenario", func(t *testing.T) {
+		err := mocksupplier.Scenarios{
+			Scenarios: []mocksupplier.Scenario{
+				{Name: "a", Platform: "avis"},
+				{Name: "b", Fault: "smoke", Probability: 2},
+			},
+			Active: []string{"c"},
+		}.Validate()
+
+		assert.ErrorContains(t, err, `unknown platform "avis"`)
+		assert.ErrorContains(t, err, `unknown fault "smoke"`)
+		assert.ErrorContains(t, err, "probability")
+		assert.ErrorContains(t, err, `unknown scenario "c"`)
+	})
+
+	t.Run("should require the fixtures", func(t *testing.T) {
+		_, err := mocksupplier.New(mocksupplier.Options{Fixtures: "missing"})
+		assert.ErrorIs(t, err, mocksupplier.ErrorFixtures)
+	})
+}
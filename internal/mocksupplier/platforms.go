@@ -0,0 +1,229 @@
+package mocksupplier
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+)
+
+// route answers one kind of supplier request. XML suppliers post everything
+// to a single url and are told apart by the root request element, JSON
+// suppliers by method and path.
+type route struct {
+	request schema.SupplierRequestName
+	method  string
+	path    *regexp.Regexp
+	element string
+	// contains narrows down requests sharing the root element
+	contains string
+	// fixture is relative to the fixtures directory, body is used when
+	// the suppliers answer without content worth a file
+	fixture string
+	body    string
+}
+
+type platform struct {
+	routes []route
+	// vehicles locates the vehicles of rates responses for partial availability
+	vehicles vehicles
+	// configuration holds dummy credentials, the url is added per server
+	configuration map[string]interface{}
+}
+
+const (
+	contentTypeXml  = "application/xml; charset=utf-8"
+	contentTypeJson = "application/json; charset=utf-8"
+)
+
+var platforms = map[string]platform{
+	"hertz": {
+		configuration: map[string]interface{}{
+			"vendorCode":       "ZE",
+			"taco":             "91266313",
+			"vc":               "5E24X16P9IA",
+			"vn":               "T744",
+			"cp":               "3X93",
+			"lastName":         "MOCK",
+			"residenceCountry": "GB",
+		},
+		vehicles: xmlVehicles("VehAvail"),
+		routes: []route{
+			{request: schema.Extras, element: "OTA_VehAvailRateRQ", contains: "SpecialEquipPrefs", fixture: "hertz/testdata/rates/supplier_extras_response_default.xml"},
+			{request: schema.Rates, element: "OTA_VehAvailRateRQ", fixture: "hertz/testdata/rates/supplier_response_default.xml"},
+			{request: schema.Booking, element: "OTA_VehResRQ", fixture: "hertz/testdata/booking/supplier_response_default.xml"},
+			{request: schema.Quote, element: "OTA_VehModifyRQ", contains: `ModifyType="Quote"`, fixture: "hertz/testdata/quote/quote_general_valid_response.xml"},
+			{request: schema.Modify, element: "OTA_VehModifyRQ", fixture: "hertz/testdata/quote/quote_general_valid_response.xml"},
+			{request: schema.Cancel, element: "OTA_VehCancelRQ", fixture: "hertz/testdata/cancel/cancel_supplier_response_1.xml"},
+		},
+	},
+	"profitmaxdht": {
+		configuration: map[string]interface{}{
+			"username":         "mock",
+			"password":         "mock",
+			"client":           "mock",
+			"destination":      "mock",
+			"vendorCode":       "ZT",
+			"lastName":         "MOCK",
+			"residenceCountry": "GB",
+		},
+		vehicles: xmlVehicles("VehAvail"),
+		routes: []route{
+			{request: schema.Rates, element: "OTA_VehAvailRateRQ", fixture: "profitmaxdht/testdata/rates/supplier_response_default.xml"},
+			{request: schema.Booking, element: "OTA_VehResRQ", fixture: "profitmaxdht/testdata/booking/supplier_response_default.xml"},
+			{request: schema.BookingStatus, element: "OTA_VehRetResRQ", fixture: "profitmaxdht/testdata/bookingstatus/supplier_response_default.xml"},
+			{request: schema.Cancel, element: "OTA_VehCancelRQ", fixture: "profitmaxdht/testdata/cancel/supplier_response_default.xml"},
+		},
+	},
+	"bookingcom": {
+		configuration: map[string]interface{}{
+			"username":      "mock",
+			"password":      "mock",
+			"affiliateCode": "mock",
+			"supplierName":  "test-supplier-name",
+		},
+		vehicles: xmlVehicles("Match"),
+		routes: []route{
+			{request: schema.Rates, element: "SearchRQ", fixture: "bookingcom/testdata/rates/supplier_response_default.xml"},
+			{request: schema.Booking, element: "MakeBookingRQ", fixture: "bookingcom/testdata/booking/successful_response.xml"},
+			{request: schema.BookingStatus, element: "BookingStatusRQ", fixture: "bookingcom/testdata/bookingstatus/confirmed_response.xml"},
+			{request: schema.Cancel, element: "CancelBookingRQ", fixture: "bookingcom/testdata/cancel/success_response.xml"},
+			{request: schema.Locations, element: "LocationSearchRQ", fixture: "bookingcom/testdata/locations/supplier_search_response_default.xml"},
+			{request: schema.Locations, element: "DepotInfoRQ", fixture: "bookingcom/testdata/locations/supplier_depot_response_1001.xml"},
+		},
+	},
+	"anyrent": {
+		configuration: map[string]interface{}{
+			"apiKey": "mock",
+		},
+		vehicles: jsonVehicles("fleets.*.groups"),
+		routes: []route{
+			{request: schema.Auth, method: http.MethodPost, path: regexp.MustCompile(`^/v1/authorize$`), fixture: "anyrent/testdata/common/auth_response.json"},
+			{request: schema.Rates, method: http.MethodGet, path: regexp.MustCompile(`^/v1/prices$`), fixture: "anyrent/testdata/rates/supplier_response_default.json"},
+			{request: schema.Booking, method: http.MethodPost, path: regexp.MustCompile(`^/v1/bookings$`), fixture: "anyrent/testdata/booking/supplier_response_default.json"},
+			{request: schema.BookingStatus, method: http.MethodGet, path: regexp.MustCompile(`^/v1/bookings/[^/]+$`), fixture: "anyrent/testdata/bookingstatus/supplier_response_default.json"},
+			{request: schema.Cancel, method: http.MethodDelete, path: regexp.MustCompile(`^/v1/bookings/[^/]+$`), body: "{}"},
+			{request: schema.Locations, method: http.MethodGet, path: regexp.MustCompile(`^/v1/stations$`), fixture: "anyrent/testdata/locations/supplier_response_default.json"},
+		},
+	},
+	"rently": {
+		configuration: map[string]interface{}{
+			"username":                "mock",
+			"password":                "mock",
+			"commercialAgreementCode": "Prepaid",
+		},
+		vehicles: jsonVehicles(""),
+		routes: []route{
+			{request: schema.Auth, method: http.MethodPost, path: regexp.MustCompile(`^/connect/token$`), fixture: "rently/testdata/common/auth_response.json"},
+			{request: schema.Rates, method: http.MethodGet, path: regexp.MustCompile(`^/api/AvailabilityByPlace$`), fixture: "rently/testdata/rates/supplier_response_default.json"},
+			{request: schema.Booking, method: http.MethodPost, path: regexp.MustCompile(`^/api/Booking$`), fixture: "rently/testdata/booking/supplier_response_default.json"},
+			{request: schema.Cancel, method: http.MethodDelete, path: regexp.MustCompile(`^/api/Booking/[^/]+$`), body: "{}"},
+			{request: schema.BookingStatus, method: http.MethodGet, path: regexp.MustCompile(`^/api/Booking/[^/]+$`), fixture: "rently/testdata/booking/supplier_response_default.json"},
+			{request: schema.Locations, method: http.MethodGet, path: regexp.MustCompile(`^/api/Places$`), fixture: "rently/testdata/locations/supplier_response_default.json"},
+		},
+	},
+	"ota": {
+		configuration: map[string]interface{}{
+			"envelope":   "soap",
+			"soapHeader": "<Credentials><UserName>mock</UserName><Password>mock</Password></Credentials>",
+			"requestorIds": []interface{}{
+				map[string]interface{}{"type": "4", "id": "MOCK", "companyName": "MOCK"},
+			},
+			"vendorCodes": []interface{}{"ZE"},
+			"lastName":    "MOCK",
+		},
+		vehicles: xmlVehicles("VehAvail"),
+		routes: []route{
+			{request: schema.Rates, element: "OTA_VehAvailRateRQ", fixture: "ota/testdata/rates/supplier_response_default.xml"},
+			{request: schema.Booking, element: "OTA_VehResRQ", fixture: "ota/testdata/booking/supplier_response_default.xml"},
+			{request: schema.BookingStatus, element: "OTA_VehRetResRQ", fixture: "ota/testdata/bookingstatus/supplier_response_default.xml"},
+			{request: schema.Cancel, element: "OTA_VehCancelRQ", fixture: "ota/testdata/cancel/supplier_response_default.xml"},
+		},
+	},
+}
+
+// Platforms lists the simulated suppliers
+func Platforms() []string {
+	names := make([]string, 0, len(platforms))
+	for name := range platforms {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Configuration is a supplier configuration for the platform pointing at
+// the server on baseUrl, nil for unknown platforms
+func Configuration(name string, baseUrl string) map[string]interface{} {
+	p, ok := platforms[name]
+	if !ok {
+		return nil
+	}
+
+	configuration := map[string]interface{}{
+		"supplierApiUrl": strings.TrimSuffix(baseUrl, "/") + "/" + name,
+	}
+
+	for key, value := range p.configuration {
+		configuration[key] = value
+	}
+
+	return configuration
+}
+
+// requestElement is the first element named like a request, SOAP envelopes
+// wrap the OTA request in their body
+func requestElement(body []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF || err != nil {
+			return ""
+		}
+
+		if start, ok := token.(xml.StartElement); ok && strings.HasSuffix(start.Name.Local, "RQ") {
+			return start.Name.Local
+		}
+	}
+}
+
+func (r route) contentType() string {
+	if r.element != "" {
+		return contentTypeXml
+	}
+
+	return contentTypeJson
+}
+
+// match finds the route of a request, path is relative to the platform
+func (p platform) match(method string, path string, body []byte) (route, bool) {
+	var element string
+
+	for _, r := range p.routes {
+		if r.element != "" {
+			if element == "" {
+				element = requestElement(body)
+			}
+
+			if r.element == element && (r.contains == "" || bytes.Contains(body, []byte(r.contains))) {
+				return r, true
+			}
+
+			continue
+		}
+
+		if r.method == method && r.path.MatchString(path) {
+			return r, true
+		}
+	}
+
+	return route{}, false
+}
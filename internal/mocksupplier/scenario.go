@@ -0,0 +1,147 @@
+package mocksupplier
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FaultMalformed replaces the body with content that does not parse
+	FaultMalformed = "malformed"
+	// FaultTruncated cuts the body in half
+	FaultTruncated = "truncated"
+	// FaultEmpty answers without body
+	FaultEmpty = "empty"
+	// FaultReset closes the connection without answering
+	FaultReset = "reset"
+)
+
+var (
+	ErrorUnknownScenario = errors.New("unknown scenario")
+	errorUnknownFault    = errors.New("unknown fault")
+)
+
+// Scenario changes the answers to matching supplier requests. Empty
+// platform or request match all of them.
+type Scenario struct {
+	Name     string                     `yaml:"name" json:"name"`
+	Platform string                     `yaml:"platform" json:"platform,omitempty"`
+	Request  schema.SupplierRequestName `yaml:"request" json:"request,omitempty"`
+	// Probability of the scenario applying to a matching request, 0 means always
+	Probability float64       `yaml:"probability" json:"probability,omitempty"`
+	Latency     time.Duration `yaml:"latency" json:"latency,omitempty"`
+	Status      int           `yaml:"status" json:"status,omitempty"`
+	// Fixture replaces the default fixture, Body the whole content
+	Fixture string `yaml:"fixture" json:"fixture,omitempty"`
+	Body    string `yaml:"body" json:"body,omitempty"`
+	Fault   string `yaml:"fault" json:"fault,omitempty"`
+	// Availability is the share of vehicles kept in rates responses
+	Availability *float64 `yaml:"availability" json:"availability,omitempty"`
+}
+
+type Scenarios struct {
+	Scenarios []Scenario `yaml:"scenarios"`
+	// Active scenarios apply without being selected by the request
+	Active []string `yaml:"active"`
+}
+
+func (s Scenario) matches(platform string, request schema.SupplierRequestName) bool {
+	return (s.Platform == "" || s.Platform == platform) && (s.Request == "" || s.Request == request)
+}
+
+func (s Scenario) validate() error {
+	var problems []string
+
+	if s.Name == "" {
+		problems = append(problems, "name is required")
+	}
+
+	if _, ok := platforms[s.Platform]; s.Platform != "" && !ok {
+		problems = append(problems, fmt.Sprintf("unknown platform %q", s.Platform))
+	}
+
+	switch s.Fault {
+	case "", FaultMalformed, FaultTruncated, FaultEmpty, FaultReset:
+	default:
+		problems = append(problems, fmt.Sprintf("%s %q", errorUnknownFault, s.Fault))
+	}
+
+	if s.Probability < 0 || s.Probability > 1 {
+		problems = append(problems, "probability must be between 0 and 1")
+	}
+
+	if s.Availability != nil && (*s.Availability < 0 || *s.Availability > 1) {
+		problems = append(problems, "availability must be between 0 and 1")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("scenario %s: %s", s.Name, strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// Validate checks every scenario and that active scenarios exist
+func (s Scenarios) Validate() error {
+	var problems []string
+
+	names := make(map[string]bool, len(s.Scenarios))
+
+	for _, scenario := range s.Scenarios {
+		err := scenario.validate()
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+
+		names[scenario.Name] = true
+	}
+
+	for _, name := range s.Active {
+		if !names[name] {
+			problems = append(problems, fmt.Sprintf("%s %q", ErrorUnknownScenario, name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// LoadScenarios reads scenarios from a YAML file
+func LoadScenarios(path string) (Scenarios, error) {
+	var scenarios Scenarios
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return scenarios, err
+	}
+
+	err = yaml.Unmarshal(content, &scenarios)
+	if err != nil {
+		return scenarios, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return scenarios, scenarios.Validate()
+}
+
+// malform breaks a body in the requested way
+func malform(fault string, body []byte) []byte {
+	switch fault {
+	case FaultMalformed:
+		return append(body[:len(body)/2:len(body)/2], []byte("<<{{ not a supplier response")...)
+	case FaultTruncated:
+		return body[:len(body)/2]
+	case FaultEmpty:
+		return nil
+	default:
+		return body
+	}
+}
@@ -0,0 +1,87 @@
+package mocksupplier
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// vehicles keeps the given share of the vehicles in a rates response
+type vehicles func(body []byte, availability float64) ([]byte, error)
+
+func keep(count int, availability float64) int {
+	return int(math.Floor(float64(count) * availability))
+}
+
+// xmlVehicles drops trailing vehicle elements, the element must not contain
+// itself so the first closing tag ends it
+func xmlVehicles(element string) vehicles {
+	pattern := regexp.MustCompile(fmt.Sprintf(`(?s)<%s[\s>].*?</%s>\s*`, element, element))
+
+	return func(body []byte, availability float64) ([]byte, error) {
+		matches := pattern.FindAllIndex(body, -1)
+		removed := matches[keep(len(matches), availability):]
+
+		if len(removed) == 0 {
+			return body, nil
+		}
+
+		trimmed := make([]byte, 0, len(body))
+		offset := 0
+
+		for _, match := range removed {
+			trimmed = append(trimmed, body[offset:match[0]]...)
+			offset = match[1]
+		}
+
+		return append(trimmed, body[offset:]...), nil
+	}
+}
+
+// jsonVehicles truncates the arrays at path, * walks every item of an array
+// and an empty path stands for a response that is the array itself
+func jsonVehicles(path string) vehicles {
+	var segments []string
+	if path != "" {
+		segments = strings.Split(path, ".")
+	}
+
+	return func(body []byte, availability float64) ([]byte, error) {
+		var document interface{}
+
+		err := json.Unmarshal(body, &document)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(truncate(document, segments, availability))
+	}
+}
+
+func truncate(value interface{}, segments []string, availability float64) interface{} {
+	if len(segments) == 0 {
+		items, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+
+		return items[:keep(len(items), availability)]
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if child, ok := v[segments[0]]; ok {
+			v[segments[0]] = truncate(child, segments[1:], availability)
+		}
+	case []interface{}:
+		if segments[0] == "*" {
+			for i := range v {
+				v[i] = truncate(v[i], segments[1:], availability)
+			}
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,137 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/errors"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/interfaces"
+	platformMiddleware "bitbucket.org/crgw/supplier-hub/internal/platform/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/quota"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// batchConcurrency bounds the items of a batch requested at once, the
+	// quota of the client may allow less
+	batchConcurrency = 5
+
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// bookingStatusBatch streams a result per item as soon as it is done, the
+// items share auth tokens of the supplier, see requesting.WithBatch
+func bookingStatusBatch(ctx *gin.Context) {
+	platformWithBookingStatus, ok := ctx.MustGet(platformMiddleware.PlatformKey).(interfaces.WithBookingStatus)
+	if !ok {
+		middleware.HandleError(ctx, http.StatusBadRequest, "Booking status not implemented", errors.ErrorNotImplemented)
+		return
+	}
+
+	params, ok := ctx.MustGet(platformMiddleware.ParamsKey).(*schema.BookingStatusBatchRequestParams)
+	if !ok {
+		middleware.HandleError(ctx, http.StatusInternalServerError, "Bad request params", nil)
+		return
+	}
+
+	logger := ctx.MustGet("logger").(*zerolog.Logger)
+	quotaValue, _ := ctx.Get(quota.ContextKey)
+	acquire, _ := quotaValue.(quota.Acquire)
+	scope := ctx.Params.ByName("platform") + ":booking-status"
+
+	requestCtx := requesting.WithBatch(ctx.Request.Context())
+	results := make(chan schema.BookingStatusBatchResult)
+
+	go func() {
+		var wait sync.WaitGroup
+		semaphore := make(chan struct{}, batchConcurrency)
+
+		for i, item := range params.Items {
+			semaphore <- struct{}{}
+			wait.Add(1)
+
+			go func(i int, item schema.BookingStatusRequestParams) {
+				defer func() {
+					<-semaphore
+					wait.Done()
+				}()
+
+				results <- bookingStatusItem(requestCtx, platformWithBookingStatus, acquire, scope, i, item, logger)
+			}(i, item)
+		}
+
+		wait.Wait()
+		close(results)
+	}()
+
+	ctx.Header("Content-Type", ndjsonContentType)
+	ctx.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(ctx.Writer)
+
+	// results are drained after write errors so no item is left blocked
+	for result := range results {
+		if err := encoder.Encode(result); err != nil {
+			logger.Warn().Err(err).Int("index", result.Index).Msg("Failed writing booking status result")
+			continue
+		}
+
+		ctx.Writer.Flush()
+	}
+}
+
+func bookingStatusItem(
+	ctx context.Context,
+	platform interfaces.WithBookingStatus,
+	acquire quota.Acquire,
+	scope string,
+	index int,
+	params schema.BookingStatusRequestParams,
+	logger *zerolog.Logger,
+) (result schema.BookingStatusBatchResult) {
+	result = schema.BookingStatusBatchResult{
+		Index:                    index,
+		ReservNumber:             params.ReservNumber,
+		SupplierBookingReference: params.SupplierBookingReference,
+	}
+
+	fail := func(code int, message string) schema.BookingStatusBatchResult {
+		result.Code = code
+		result.Error = &message
+
+		return result
+	}
+
+	// a panicking item must not take the stream down
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.Error().Interface("panic", recovered).Int("index", index).Msg("Booking status of batch item panicked")
+			result = fail(http.StatusInternalServerError, "Failed requesting booking status")
+		}
+	}()
+
+	release, err := acquire.Wait(ctx, scope)
+	if err != nil {
+		return fail(http.StatusTooManyRequests, fmt.Sprintf("Quota exceeded: %s", err))
+	}
+
+	defer release()
+
+	response, err := platform.GetBookingStatus(ctx, params, logger)
+	if err != nil {
+		logger.Err(err).Int("index", index).Msg("Failed requesting booking status of batch item")
+		return fail(http.StatusInternalServerError, "Failed requesting booking status")
+	}
+
+	result.Code = http.StatusOK
+	result.Response = &response
+
+	return result
+}
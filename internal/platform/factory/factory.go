@@ -3,17 +3,25 @@ package factory
 import (
 	"fmt"
 
+	"bitbucket.org/crgw/supplier-hub/internal/config"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/anyrent"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/remote"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/rently"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/sandbox"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"github.com/redis/go-redis/v9"
 )
 
 type Factory struct {
-	redisFactory *redisfactory.Factory
-	platforms    map[string]any
+	config         *config.Config
+	redisFactory   *redisfactory.Factory
+	responsesCache *caching.Cacher
+	platforms      map[string]any
 }
 
 func (f *Factory) GetPlatform(name string) (any, error) {
@@ -24,26 +32,58 @@ func (f *Factory) GetPlatform(name string) (any, error) {
 
 		// Register all platforms here
 		case "hertz":
-			f.platforms[name] = hertz.New(f.redisFactory.ResponsesCacheClient())
+			f.platforms[name] = hertz.New(f.responsesCache)
 		case "profitmaxdht":
-			f.platforms[name] = profitmaxdht.New(f.redisFactory.ResponsesCacheClient())
+			f.platforms[name] = profitmaxdht.New(f.responsesCache)
 		case "bookingcom":
-			f.platforms[name] = bookingcom.New(f.redisFactory.ResponsesCacheClient())
+			f.platforms[name] = bookingcom.New(f.responsesCache, bookingcom.Services{
+				UserService: f.config.Services.UserServiceOptions(),
+				Spit:        f.config.Services.SpitOptions(),
+				Username:    f.config.Services.Username,
+				Password:    f.config.Services.Password,
+			})
 		case "anyrent":
-			f.platforms[name] = anyrent.New(f.redisFactory.ResponsesCacheClient())
+			f.platforms[name] = anyrent.New(f.responsesCache)
 		case "rently":
-			f.platforms[name] = rently.New(f.redisFactory.ResponsesCacheClient())
+			f.platforms[name] = rently.New(f.responsesCache)
+		case "ota":
+			f.platforms[name] = ota.New(f.responsesCache)
+		case "sandbox":
+			client, err := f.sandboxClient()
+			if err != nil {
+				return nil, err
+			}
+
+			f.platforms[name] = sandbox.New(client)
 		default:
-			return nil, fmt.Errorf("platform %s not found", name)
+			// platforms of other services, forwarded to their adapters
+			baseUrl, ok := f.config.Remote.Platforms[name]
+			if !ok {
+				return nil, fmt.Errorf("platform %s not found", name)
+			}
+
+			f.platforms[name] = remote.New(name, baseUrl)
 		}
 	}
 
 	return f.platforms[name], nil
 }
 
-func NewFactory(redisFactory *redisfactory.Factory) *Factory {
+// sandboxClient keeps sandbox bookings apart when a sandbox client is
+// configured, they share the responses cache otherwise
+func (f *Factory) sandboxClient() (redis.UniversalClient, error) {
+	if client, err := f.redisFactory.Client(redisfactory.Sandbox); err == nil {
+		return client, nil
+	}
+
+	return f.redisFactory.ResponsesCacheClient()
+}
+
+func NewFactory(cfg *config.Config, redisFactory *redisfactory.Factory, responsesCache *caching.Cacher) *Factory {
 	return &Factory{
-		redisFactory: redisFactory,
-		platforms:    make(map[string]any),
+		config:         cfg,
+		redisFactory:   redisFactory,
+		responsesCache: responsesCache,
+		platforms:      make(map[string]any),
 	}
 }
@@ -0,0 +1,148 @@
+package platform_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/mocksupplier"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/factory"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/interfaces"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+const faultTimeout = 300
+
+func decode[P any](t *testing.T, values map[string]interface{}) P {
+	var params P
+
+	content, _ := json.Marshal(values)
+	assert.Nil(t, json.Unmarshal(content, &params))
+
+	return params
+}
+
+func ratesParams(t *testing.T, configuration map[string]interface{}) schema.RatesRequestParams {
+	pickUp := time.Now().UTC().AddDate(0, 1, 0).Truncate(24 * time.Hour).Add(10 * time.Hour)
+
+	return decode[schema.RatesRequestParams](t, map[string]interface{}{
+		"pickUp":           map[string]interface{}{"code": "MUC", "country": "DE", "dateTime": pickUp.Format(time.RFC3339)},
+		"dropOff":          map[string]interface{}{"code": "MUC", "country": "DE", "dateTime": pickUp.AddDate(0, 0, 7).Format(time.RFC3339)},
+		"rentalDays":       7,
+		"contract":         map[string]interface{}{"currency": "EUR", "supplierId": 1, "paymentType": 0},
+		"taxRate":          19,
+		"residenceCountry": "DE",
+		"age":              30,
+		"moduleId":         1,
+		"timeouts":         map[string]interface{}{"default": faultTimeout},
+		"configuration":    configuration,
+	})
+}
+
+func cancelParams(t *testing.T, configuration map[string]interface{}) schema.CancelRequestParams {
+	pickUp := time.Now().UTC().AddDate(0, 1, 0).Truncate(24 * time.Hour).Add(10 * time.Hour)
+
+	return decode[schema.CancelRequestParams](t, map[string]interface{}{
+		"pickUp":                   map[string]interface{}{"code": "MUC", "country": "DE", "dateTime": pickUp.Format(time.RFC3339)},
+		"supplierBookingReference": "K48730916F3",
+		"brokerReference":          "B123",
+		"reservNumber":             "R123",
+		"moduleId":                 1,
+		"contact":                  map[string]interface{}{"email": "mock@example.com"},
+		"timeouts":                 map[string]interface{}{"default": faultTimeout},
+		"configuration":            configuration,
+	})
+}
+
+// platform is created per case, so cached auth tokens and extras do not
+// hide faults of earlier requests
+func platform(t *testing.T, name string) any {
+	p, err := factory.NewFactory(&config.Config{}, nil, caching.NewMemoryCache()).GetPlatform(name)
+	assert.Nil(t, err)
+
+	return p
+}
+
+// survive fails the test instead of the run when the platform panics
+func survive(t *testing.T, call func()) {
+	defer func() {
+		if err := recover(); err != nil {
+			assert.Fail(t, fmt.Sprintf("platform panicked: %v", err))
+		}
+	}()
+
+	start := time.Now()
+	call()
+
+	// the latency fault outlasts the timeout, the platform must not wait for it
+	assert.Less(t, time.Since(start), 5*faultTimeout*time.Millisecond)
+}
+
+func faultErrors(response *schema.SupplierResponseErrors, err error) int {
+	if err != nil {
+		return 1
+	}
+
+	if response == nil {
+		return 0
+	}
+
+	return len(*response)
+}
+
+// TestFaults runs every platform through every fault kind, the platforms
+// must answer with supplier errors instead of failing, hanging or panicking
+func TestFaults(t *testing.T) {
+	server, err := mocksupplier.New(mocksupplier.Options{Fixtures: "implementations"})
+	assert.Nil(t, err)
+
+	mock := httptest.NewServer(server.Handler())
+	defer mock.Close()
+
+	logger := zerolog.Nop()
+
+	for _, name := range mocksupplier.Platforms() {
+		configuration := mocksupplier.Configuration(name, mock.URL)
+
+		t.Run("should search rates of "+name+" without faults", func(t *testing.T) {
+			rates, err := platform(t, name).(interfaces.WithGetRates).GetRates(context.Background(), ratesParams(t, configuration), &logger)
+
+			assert.Nil(t, err)
+			assert.Equal(t, 0, faultErrors(rates.Errors, err))
+			assert.NotEmpty(t, rates.Vehicles)
+		})
+
+		for _, fault := range requesting.DefaultFaults() {
+			ctx := requesting.WithFaults(context.Background(), name, []requesting.Fault{fault})
+
+			t.Run("should report "+fault.Name+" faults of "+name+" rates", func(t *testing.T) {
+				survive(t, func() {
+					rates, err := platform(t, name).(interfaces.WithGetRates).GetRates(ctx, ratesParams(t, configuration), &logger)
+
+					assert.Greater(t, faultErrors(rates.Errors, err), 0)
+					assert.Empty(t, rates.Vehicles)
+				})
+			})
+
+			t.Run("should report "+fault.Name+" faults of "+name+" cancellations", func(t *testing.T) {
+				survive(t, func() {
+					cancel, err := platform(t, name).(interfaces.WithCancelBooking).CancelBooking(ctx, cancelParams(t, configuration), &logger)
+
+					assert.Greater(t, faultErrors(cancel.Errors, err), 0)
+
+					if cancel.Status != nil {
+						assert.NotEqual(t, schema.CancelResponseStatus("OK"), *cancel.Status)
+					}
+				})
+			})
+		}
+	}
+}
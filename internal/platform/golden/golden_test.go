@@ -0,0 +1,44 @@
+// Package golden_test replays recorded supplier exchanges through the
+// platforms and compares their responses to golden files. Cases live in
+// implementations/<platform>/testdata/golden/<operation>/<case>/ with
+// request.json, response.json and a cassettes directory holding the recorded
+// exchanges.
+package golden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/factory"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+)
+
+const implementations = "../implementations"
+
+func TestPlatforms(t *testing.T) {
+	platforms, err := os.ReadDir(implementations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := factory.NewFactory(&config.Config{}, nil, caching.NewMemoryCache())
+
+	for _, entry := range platforms {
+		dir := filepath.Join(implementations, entry.Name(), "testdata", "golden")
+
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+
+		t.Run(entry.Name(), func(t *testing.T) {
+			platform, err := f.GetPlatform(entry.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			run(t, platform, dir)
+		})
+	}
+}
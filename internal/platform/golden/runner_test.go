@@ -0,0 +1,199 @@
+package golden_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/interfaces"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	requestFile  = "request.json"
+	responseFile = "response.json"
+	cassetteDir  = "cassettes"
+
+	// modeEnv switches to recording against the suppliers in request.json
+	modeEnv = "CASSETTE_MODE"
+	// updateEnv rewrites response.json with the current platform output
+	updateEnv = "GOLDEN_UPDATE"
+)
+
+var errorUnsupportedOperation = errors.New("platform does not support operation")
+
+// call decodes the request and calls the matching platform method
+func call[P any, R any](ctx context.Context, content []byte, method func(context.Context, P, *zerolog.Logger) (R, error)) (any, error) {
+	var params P
+
+	err := json.Unmarshal(content, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := zerolog.Nop()
+
+	return method(ctx, params, &logger)
+}
+
+func dispatch(ctx context.Context, platform any, operation string, content []byte) (any, error) {
+	switch operation {
+	case "rates":
+		if p, ok := platform.(interfaces.WithGetRates); ok {
+			return call(ctx, content, p.GetRates)
+		}
+	case "booking":
+		if p, ok := platform.(interfaces.WithCreateBooking); ok {
+			return call(ctx, content, p.CreateBooking)
+		}
+	case "booking-status":
+		if p, ok := platform.(interfaces.WithBookingStatus); ok {
+			return call(ctx, content, p.GetBookingStatus)
+		}
+	case "modify":
+		if p, ok := platform.(interfaces.WithModifyBooking); ok {
+			return call(ctx, content, p.ModifyBooking)
+		}
+	case "cancel":
+		if p, ok := platform.(interfaces.WithCancelBooking); ok {
+			return call(ctx, content, p.CancelBooking)
+		}
+	case "locations":
+		if p, ok := platform.(interfaces.WithLocations); ok {
+			return call(ctx, content, p.GetLocations)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errorUnsupportedOperation, operation)
+}
+
+// sortSupplierRequests orders the supplier requests of a response by name and
+// url, platforms send requests in parallel and record them as they finish
+func sortSupplierRequests(response any) {
+	value := reflect.Indirect(reflect.ValueOf(response))
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	field := value.FieldByName("SupplierRequests")
+	if !field.IsValid() {
+		return
+	}
+
+	requests, ok := field.Interface().(*schema.SupplierRequests)
+	if !ok || requests == nil {
+		return
+	}
+
+	key := func(request schema.SupplierRequest) string {
+		var url string
+		if request.RequestContent != nil {
+			url = converting.Unwrap(request.RequestContent.Url)
+		}
+
+		return string(converting.Unwrap(request.Name)) + " " + url
+	}
+
+	sort.SliceStable(*requests, func(i, j int) bool {
+		return key((*requests)[i]) < key((*requests)[j])
+	})
+}
+
+// run executes every case below dir as a subtest. Cases replay strictly, a
+// request without recording fails the case. Setting CASSETTE_MODE=record
+// records the exchanges again and GOLDEN_UPDATE=1 rewrites the golden files.
+func run(t *testing.T, platform any, dir string) {
+	operations, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, operation := range operations {
+		if !operation.IsDir() {
+			continue
+		}
+
+		cases, err := os.ReadDir(filepath.Join(dir, operation.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range cases {
+			if !c.IsDir() {
+				continue
+			}
+
+			path := filepath.Join(dir, operation.Name(), c.Name())
+
+			t.Run(operation.Name()+"/"+c.Name(), func(t *testing.T) {
+				runCase(t, platform, operation.Name(), path)
+			})
+		}
+	}
+}
+
+func runCase(t *testing.T, platform any, operation string, path string) {
+	mode := os.Getenv(modeEnv)
+	if mode == "" {
+		mode = requesting.CassetteReplay
+	}
+
+	cassette, err := requesting.NewCassette(filepath.Join(path, cassetteDir), mode, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := requesting.WithMiddlewares(schema.WithoutTimings(context.Background()), cassette.Middleware())
+
+	request, err := os.ReadFile(filepath.Join(path, requestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := dispatch(ctx, platform, operation, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, cassette.Misses(), "requests without recording")
+
+	sortSupplierRequests(response)
+
+	var actual bytes.Buffer
+
+	encoder := json.NewEncoder(&actual)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+
+	err = encoder.Encode(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if os.Getenv(updateEnv) != "" {
+		err = os.WriteFile(filepath.Join(path, responseFile), actual.Bytes(), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	expected, err := os.ReadFile(filepath.Join(path, responseFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, string(expected), actual.String())
+}
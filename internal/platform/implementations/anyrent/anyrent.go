@@ -10,12 +10,11 @@ import (
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
-	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 )
 
 type anyRent struct {
-	redis         *redis.Client
+	cache         *caching.Cacher
 	httpTransport *http.Transport
 }
 
@@ -24,7 +23,7 @@ func (a *anyRent) GetLocations(ctx context.Context, params schema.LocationsReque
 	slowLogger := slowlog.CreateLogger(logger)
 
 	locationsRequest := locationsRequest{
-		cache:         caching.NewRedisCache(a.redis),
+		cache:         a.cache,
 		params:        params,
 		configuration: configuration,
 		logger:        logger,
@@ -44,7 +43,7 @@ func (a *anyRent) GetRates(ctx context.Context, params schema.RatesRequestParams
 	slowLogger := slowlog.CreateLogger(logger)
 
 	ratesRequest := ratesRequest{
-		cache:         caching.NewRedisCache(a.redis),
+		cache:         a.cache,
 		params:        params,
 		configuration: configuration,
 		logger:        logger,
@@ -69,49 +68,49 @@ func (a *anyRent) CreateBooking(ctx context.Context, params schema.BookingReques
 	}
 
 	bookingRequest := bookingRequest{
-		cache:                 caching.NewRedisCache(a.redis),
+		cache:                 a.cache,
 		params:                params,
 		configuration:         configuration,
 		supplierRateReference: supplierRateReference,
 		logger:                logger,
 	}
 
-	return bookingRequest.Execute(a.httpTransport)
+	return bookingRequest.Execute(ctx, a.httpTransport)
 }
 
 func (a *anyRent) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
 	configuration, _ := params.Configuration.AsAnyRentConfiguration()
 
 	bookingStatusRequest := bookingStatusRequest{
-		cache:         caching.NewRedisCache(a.redis),
+		cache:         a.cache,
 		params:        params,
 		configuration: configuration,
 		logger:        logger,
 	}
 
-	return bookingStatusRequest.Execute(a.httpTransport)
+	return bookingStatusRequest.Execute(ctx, a.httpTransport)
 }
 
 func (a *anyRent) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
 	configuration, _ := params.Configuration.AsAnyRentConfiguration()
 
 	bookingCancel := cancelRequest{
-		cache:         caching.NewRedisCache(a.redis),
+		cache:         a.cache,
 		params:        params,
 		configuration: configuration,
 		logger:        logger,
 	}
 
-	return bookingCancel.Execute(a.httpTransport)
+	return bookingCancel.Execute(ctx, a.httpTransport)
 }
 
-func New(redisClient *redis.Client) *anyRent {
+func New(cache *caching.Cacher) *anyRent {
 	transport := http.DefaultTransport.(*http.Transport)
 	// improves durations a lot
 	transport.DisableKeepAlives = true
 
 	return &anyRent{
-		redis:         redisClient,
+		cache:         cache,
 		httpTransport: transport,
 	}
 }
@@ -23,7 +23,7 @@ type bookingStatusRequest struct {
 	logger        *zerolog.Logger
 }
 
-func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.BookingStatusResponse, error) {
+func (b *bookingStatusRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingStatusResponse, error) {
 	bookingStatus := schema.BookingStatusResponse{}
 	bookingStatus.Status = schema.BookingStatusResponseStatusFAILED
 
@@ -41,7 +41,7 @@ func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.Bo
 		cache:         b.cache,
 	}
 
-	auth, err := authRequest.Execute(httpTransport)
+	auth, err := authRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*auth.SupplierRequests)
 	errorsBucket.AddErrors(*auth.Errors)
 
@@ -67,7 +67,7 @@ func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.Bo
 		},
 	}
 
-	response, err := b.makeRequest(client, *auth.Token)
+	response, err := b.makeRequest(ctx, client, *auth.Token)
 
 	if err != nil {
 		errorsBucket.AddError(schema.NewSupplierError(err.Error()))
@@ -80,11 +80,12 @@ func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.Bo
 }
 
 func (b *bookingStatusRequest) makeRequest(
+	ctx context.Context,
 	client *http.Client,
 	token string,
 ) (json.BookingStatusRS, error) {
 	url := fmt.Sprintf("%v/v1/bookings/%v", b.configuration.SupplierApiUrl, b.params.SupplierBookingReference)
-	c := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.BookingStatus)
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.BookingStatus)
 
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodGet, url, http.NoBody)
 	httpRequest.Header.Set("Authorization", "Bearer "+token)
@@ -13,6 +13,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/anyrent"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -177,7 +178,7 @@ func TestBookingStatusRequest(t *testing.T) {
 				mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 				mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-				service := anyrent.New(redisClient)
+				service := anyrent.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 				bookingStatus, err := service.GetBookingStatus(ctx, params, &log)
 				assert.Nil(t, err)
@@ -230,7 +231,7 @@ func TestBookingStatusRequest(t *testing.T) {
 		mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 		mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-		service := anyrent.New(redisClient)
+		service := anyrent.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.BookingStatusResponse, 1)
 
@@ -336,7 +337,7 @@ func bookingStatusParamsTemplate(configuration schema.AnyRentConfiguration) sche
 }
 
 func getBookingStatus(params schema.BookingStatusRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.BookingStatusResponse, error) {
-	service := anyrent.New(redisClient)
+	service := anyrent.New(caching.NewRedisCache(redisClient))
 	ctx := context.Background()
 	return service.GetBookingStatus(ctx, params, log)
 }
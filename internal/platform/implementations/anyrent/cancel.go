@@ -23,7 +23,7 @@ type cancelRequest struct {
 	logger        *zerolog.Logger
 }
 
-func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelResponse, error) {
+func (c *cancelRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.CancelResponse, error) {
 	cancel := schema.CancelResponse{}
 
 	status := schema.CancelResponseStatusFAILED
@@ -43,7 +43,7 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 		cache:         c.cache,
 	}
 
-	auth, err := authRequest.Execute(httpTransport)
+	auth, err := authRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*auth.SupplierRequests)
 	errorsBucket.AddErrors(*auth.Errors)
 
@@ -72,7 +72,7 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 		},
 	}
 
-	_, err = c.makeRequest(client, *auth.Token)
+	_, err = c.makeRequest(ctx, client, *auth.Token)
 
 	if err != nil {
 		errorsBucket.AddError(schema.NewSupplierError(err.Error()))
@@ -86,11 +86,12 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 }
 
 func (ca *cancelRequest) makeRequest(
+	ctx context.Context,
 	client *http.Client,
 	token string,
 ) (json.CancelBookingRS, error) {
 	url := fmt.Sprintf("%v/v1/bookings/%v", ca.configuration.SupplierApiUrl, ca.params.SupplierBookingReference)
-	c := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Cancel)
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Cancel)
 
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodDelete, url, http.NoBody)
 	httpRequest.Header.Set("Authorization", "Bearer "+token)
@@ -13,6 +13,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/anyrent"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -139,7 +140,7 @@ func TestCancelRequest(t *testing.T) {
 				mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 				mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-				service := anyrent.New(redisClient)
+				service := anyrent.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 				cancel, err := service.CancelBooking(ctx, params, &log)
 				assert.Nil(t, err)
@@ -192,7 +193,7 @@ func TestCancelRequest(t *testing.T) {
 		mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 		mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-		service := anyrent.New(redisClient)
+		service := anyrent.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.CancelResponse, 1)
 
@@ -300,7 +301,7 @@ func cancelParamsTemplate(configuration schema.AnyRentConfiguration) schema.Canc
 }
 
 func cancelBooking(params schema.CancelRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.CancelResponse, error) {
-	service := anyrent.New(redisClient)
+	service := anyrent.New(caching.NewRedisCache(redisClient))
 	ctx := context.Background()
 	return service.CancelBooking(ctx, params, log)
 }
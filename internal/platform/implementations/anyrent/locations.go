@@ -47,7 +47,7 @@ func (l *locationsRequest) Execute(ctx context.Context, httpTransport *http.Tran
 		cache:         l.cache,
 	}
 
-	auth, err := authRequest.Execute(httpTransport)
+	auth, err := authRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*auth.SupplierRequests)
 	errorsBucket.AddErrors(*auth.Errors)
 	l.slowLogger.Stop("anyrent:locations:execute:auth")
@@ -79,7 +79,7 @@ func (l *locationsRequest) Execute(ctx context.Context, httpTransport *http.Tran
 
 	// fetch the first page
 	l.slowLogger.Start("anyrent:locations:execute:requests")
-	response, err := l.makeRequest(client, 1, *auth.Token)
+	response, err := l.makeRequest(ctx, client, 1, *auth.Token)
 	l.slowLogger.Stop("anyrent:locations:execute:requests")
 
 	if err != nil {
@@ -112,7 +112,7 @@ func (l *locationsRequest) Execute(ctx context.Context, httpTransport *http.Tran
 		restOfPagesCount := response.Meta.Pagination.TotalPages - 1
 
 		for page := 2; page <= restOfPagesCount+1; page++ {
-			go l.makeExtraRequest(client, page, *auth.Token, locationResultChannel, locationsErrChannel, locationsDoneResultChannel)
+			go l.makeExtraRequest(ctx, client, page, *auth.Token, locationResultChannel, locationsErrChannel, locationsDoneResultChannel)
 		}
 
 		finished := 0
@@ -143,6 +143,7 @@ func (l *locationsRequest) Execute(ctx context.Context, httpTransport *http.Tran
 }
 
 func (l *locationsRequest) makeExtraRequest(
+	ctx context.Context,
 	client *http.Client,
 	pageNumber int,
 	token string,
@@ -150,7 +151,7 @@ func (l *locationsRequest) makeExtraRequest(
 	locationsErrChannel chan<- schema.SupplierResponseError,
 	locationsDoneResultChannel chan<- bool,
 ) {
-	response, err := l.makeRequest(client, pageNumber, token)
+	response, err := l.makeRequest(ctx, client, pageNumber, token)
 
 	if err != nil {
 		locationsErrChannel <- schema.NewSupplierError(err.Error())
@@ -175,6 +176,7 @@ func (l *locationsRequest) makeExtraRequest(
 }
 
 func (l *locationsRequest) makeRequest(
+	ctx context.Context,
 	client *http.Client,
 	pageNumber int,
 	token string,
@@ -185,7 +187,7 @@ func (l *locationsRequest) makeRequest(
 	v, _ := query.Values(opt)
 
 	url := fmt.Sprintf("%v/v1/stations?%v", l.configuration.SupplierApiUrl, v.Encode())
-	c := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Locations)
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Locations)
 
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodGet, url, http.NoBody)
 	httpRequest.Header.Set("Authorization", "Bearer "+token)
@@ -2,7 +2,6 @@ package anyrent_test
 
 import (
 	"bytes"
-	"compress/flate"
 	"context"
 	jsonEncoding "encoding/json"
 	"fmt"
@@ -15,6 +14,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/anyrent"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
@@ -161,7 +161,7 @@ func TestLocationsRequest(t *testing.T) {
 				mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 				mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-				service := anyrent.New(redisClient)
+				service := anyrent.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 				locations, err := service.GetLocations(ctx, params, &log)
 				assert.Nil(t, err)
@@ -215,7 +215,7 @@ func TestLocationsRequest(t *testing.T) {
 		mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 		mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-		service := anyrent.New(redisClient)
+		service := anyrent.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.LocationsResponse, 1)
 
@@ -321,7 +321,7 @@ func locationsParamsTemplate(configuration schema.AnyRentConfiguration) schema.L
 }
 
 func getLocations(params schema.LocationsRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.LocationsResponse, error) {
-	service := anyrent.New(redisClient)
+	service := anyrent.New(caching.NewRedisCache(redisClient))
 	ctx := context.Background()
 	return service.GetLocations(ctx, params, log)
 }
@@ -373,23 +373,5 @@ func defaultAuthRedisCacheKey(supplierApiUrl string) string {
 }
 
 func getCachedAndCompressedAuthKey() ([]byte, error) {
-	uncompressed, err := jsonEncoding.Marshal("test-token")
-	if err != nil {
-		return nil, err
-	}
-
-	var buffer bytes.Buffer
-	writer, _ := flate.NewWriter(&buffer, flate.BestSpeed)
-
-	_, err = writer.Write(uncompressed)
-	if err != nil {
-		return nil, err
-	}
-
-	err = writer.Close()
-	if err != nil {
-		return nil, err
-	}
-
-	return buffer.Bytes(), nil
+	return caching.Encode(caching.Deflate, "test-token")
 }
@@ -48,7 +48,7 @@ func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 		cache:         r.cache,
 	}
 
-	auth, err := authRequest.Execute(httpTransport)
+	auth, err := authRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*auth.SupplierRequests)
 	errorsBucket.AddErrors(*auth.Errors)
 
@@ -76,7 +76,7 @@ func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 		},
 	}
 
-	response, err := r.makeRequest(client, *auth.Token)
+	response, err := r.makeRequest(ctx, client, *auth.Token)
 
 	if err != nil {
 		errorsBucket.AddError(schema.NewSupplierError(err.Error()))
@@ -104,6 +104,7 @@ func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 }
 
 func (r *ratesRequest) makeRequest(
+	ctx context.Context,
 	client *http.Client,
 	token string,
 ) (json.PricesAndAvailabilityRS, error) {
@@ -123,7 +124,7 @@ func (r *ratesRequest) makeRequest(
 	v, _ := query.Values(opt)
 
 	url := fmt.Sprintf("%v/v1/prices?%v", r.configuration.SupplierApiUrl, v.Encode())
-	c := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Rates)
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Rates)
 
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodGet, url, http.NoBody)
 	httpRequest.Header.Set("Authorization", "Bearer "+token)
@@ -13,6 +13,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/anyrent"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -135,7 +136,7 @@ func TestRatesRequest(t *testing.T) {
 				mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 				mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-				service := anyrent.New(redisClient)
+				service := anyrent.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 				vehicles, err := service.GetRates(ctx, params, &log)
 				assert.Nil(t, err)
@@ -188,7 +189,7 @@ func TestRatesRequest(t *testing.T) {
 		mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 		mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-		service := anyrent.New(redisClient)
+		service := anyrent.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.RatesResponse, 1)
 
@@ -311,7 +312,7 @@ func ratesParamsTemplate(configuration schema.AnyRentConfiguration) schema.Rates
 }
 
 func getRates(params schema.RatesRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.RatesResponse, error) {
-	service := anyrent.New(redisClient)
+	service := anyrent.New(caching.NewRedisCache(redisClient))
 	ctx := context.Background()
 	return service.GetRates(ctx, params, log)
 }
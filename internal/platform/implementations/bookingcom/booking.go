@@ -6,7 +6,6 @@ import (
 	"encoding/xml"
 	"io"
 	"net/http"
-	"os"
 	"time"
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom/mapping"
@@ -23,14 +22,15 @@ type bookingRequest struct {
 	params                schema.BookingRequestParams
 	configuration         schema.BookingComConfiguration
 	supplierRateReference mapping.SupplierRateReference
+	services              Services
 	logger                *zerolog.Logger
 }
 
-func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingResponse, error) {
+func (b *bookingRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingResponse, error) {
 	booking := schema.BookingResponse{}
 	booking.Status = schema.BookingResponseStatusFAILED
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Booking)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Booking)
 
 	// fetch auth token
 	user, err := b.requestUatToken(&ctx)
@@ -106,7 +106,7 @@ func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingR
 
 	bookingStatusRequest.params.ReservNumber = *booking.SupplierBookingReference
 
-	bookingStatus, err = bookingStatusRequest.Execute(httpTransport)
+	bookingStatus, err = bookingStatusRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*bookingStatus.SupplierRequests)
 	errorsBucket.AddErrors(*bookingStatus.Errors)
 
@@ -238,12 +238,12 @@ func (b *bookingRequest) requestBody(cardInfo *spit.CardInfo) string {
 }
 
 func (b *bookingRequest) requestUatToken(ctx *context.Context) (*userservice.User, error) {
-	userServiceClient, err := userservice.NewClient(b.logger)
+	userServiceClient, err := userservice.NewClient(b.logger, b.services.UserService...)
 	if err != nil {
 		return &userservice.User{}, err
 	}
 
-	result, err := userServiceClient.AuthUserViaPassword(*ctx, os.Getenv("CRG_USERNAME"), os.Getenv("CRG_PASSWORD"))
+	result, err := userServiceClient.AuthUserViaPassword(*ctx, b.services.Username, b.services.Password)
 	if err != nil {
 		return &userservice.User{}, err
 	}
@@ -252,7 +252,7 @@ func (b *bookingRequest) requestUatToken(ctx *context.Context) (*userservice.Use
 }
 
 func (b *bookingRequest) requestCardInfo(ctx *context.Context, uatToken string) (*spit.CardInfo, error) {
-	spitClient, err := spit.NewClient(b.logger)
+	spitClient, err := spit.NewClient(b.logger, b.services.Spit...)
 	if err != nil {
 		return &spit.CardInfo{}, err
 	}
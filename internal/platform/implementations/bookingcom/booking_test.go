@@ -14,6 +14,8 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/client"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -207,7 +209,7 @@ func TestBookingRequest(t *testing.T) {
 		params := bookingParamsTemplate(configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := bookingcom.New(redisClient)
+		service := bookingcom.New(caching.NewRedisCache(redisClient), testServices)
 
 		channel := make(chan schema.BookingResponse, 1)
 
@@ -359,11 +361,14 @@ func bookingParamsTemplate(configuration schema.BookingComConfiguration) schema.
 }
 
 func createBooking(params schema.BookingRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.BookingResponse, error) {
-	service := bookingcom.New(redisClient)
+	service := bookingcom.New(caching.NewRedisCache(redisClient), testServices)
 	ctx := context.Background()
 	return service.CreateBooking(ctx, params, log)
 }
 
+// testServices point to the services mocked by mockApiServices
+var testServices bookingcom.Services
+
 func mockApiServices() (testUserServiceServer *httptest.Server, testSpitServiceServer *httptest.Server) {
 	// mock the user service
 	testUserServiceServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -373,7 +378,6 @@ func mockApiServices() (testUserServiceServer *httptest.Server, testSpitServiceS
 		w.Write([]byte(uatBody))
 	}))
 	// defer testUserServiceServer.Close()
-	os.Setenv("CRG_URL_USER_SERVICE", testUserServiceServer.URL)
 
 	// mock the spit service
 	testSpitServiceServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -383,7 +387,12 @@ func mockApiServices() (testUserServiceServer *httptest.Server, testSpitServiceS
 		w.Write([]byte(spitBody))
 	}))
 	// defer testSpitServiceServer.Close()
-	os.Setenv("CRG_URL_SPIT", testSpitServiceServer.URL)
+	testServices = bookingcom.Services{
+		UserService: []client.OptionFunc{client.WithBaseURL(testUserServiceServer.URL)},
+		Spit:        []client.OptionFunc{client.WithBaseURL(testSpitServiceServer.URL)},
+		Username:    "service.supplier-hub",
+		Password:    "secret",
+	}
 
 	return testUserServiceServer, testSpitServiceServer
 }
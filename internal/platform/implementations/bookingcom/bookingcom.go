@@ -12,19 +12,35 @@ import (
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom/mapping"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/client"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
-	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 )
 
+// Services are the internal services bookings are paid through
+type Services struct {
+	UserService []client.OptionFunc
+	Spit        []client.OptionFunc
+	Username    string
+	Password    string
+}
+
 type bookingCom struct {
-	redis         *redis.Client
+	cache         *caching.Cacher
+	searches      *searches
+	services      Services
 	httpTransport *http.Transport
 }
 
 func (h *bookingCom) TrafficLightGroupingCacheKey(ctx context.Context, params schema.RatesRequestParams, logger *zerolog.Logger) string {
 	configuration, _ := params.Configuration.AsBookingComConfiguration()
 
+	// the supplier search itself is shared by all suppliers, see searches
+	supplierName := configuration.SupplierName
+	if configuration.AllSuppliers != nil && *configuration.AllSuppliers {
+		supplierName = "*"
+	}
+
 	pickUpDateTime := params.PickUp.DateTime
 	dropOffDateTime := params.DropOff.DateTime
 
@@ -39,7 +55,7 @@ func (h *bookingCom) TrafficLightGroupingCacheKey(ctx context.Context, params sc
 		params.DropOff.Code,
 		pickUpDate,
 		fmt.Sprintf("%.0f", duration),
-		configuration.SupplierName,
+		supplierName,
 	}
 
 	return strings.ToLower(strings.Join(keyPieces[:], ":"))
@@ -50,7 +66,8 @@ func (h *bookingCom) GetRates(ctx context.Context, params schema.RatesRequestPar
 	slowLogger := slowlog.CreateLogger(logger)
 
 	ratesRequest := RatesRequest{
-		cache:         caching.NewRedisCache(h.redis),
+		cache:         h.cache,
+		searches:      h.searches,
 		params:        params,
 		configuration: configuration,
 		logger:        logger,
@@ -65,6 +82,19 @@ func (h *bookingCom) GetRates(ctx context.Context, params schema.RatesRequestPar
 	return rates, nil
 }
 
+func (h *bookingCom) GetLocations(ctx context.Context, params schema.LocationsRequestParams, logger *zerolog.Logger) (schema.LocationsResponse, error) {
+	configuration, _ := params.Configuration.AsBookingComConfiguration()
+
+	locationsRequest := locationsRequest{
+		params:        params,
+		configuration: configuration,
+		logger:        logger,
+		slowLogger:    slowlog.CreateLogger(logger),
+	}
+
+	return locationsRequest.Execute(ctx, h.httpTransport)
+}
+
 func (h *bookingCom) CreateBooking(ctx context.Context, params schema.BookingRequestParams, logger *zerolog.Logger) (schema.BookingResponse, error) {
 	configuration, _ := params.Configuration.AsBookingComConfiguration()
 
@@ -82,10 +112,11 @@ func (h *bookingCom) CreateBooking(ctx context.Context, params schema.BookingReq
 		params:                params,
 		configuration:         configuration,
 		supplierRateReference: supplierRateReference,
+		services:              h.services,
 		logger:                logger,
 	}
 
-	return bookingRequest.Execute(h.httpTransport)
+	return bookingRequest.Execute(ctx, h.httpTransport)
 }
 
 func (h *bookingCom) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
@@ -97,7 +128,7 @@ func (h *bookingCom) GetBookingStatus(ctx context.Context, params schema.Booking
 		logger:        logger,
 	}
 
-	return bookingStatusRequest.Execute(h.httpTransport)
+	return bookingStatusRequest.Execute(ctx, h.httpTransport)
 }
 
 func (h *bookingCom) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
@@ -109,16 +140,18 @@ func (h *bookingCom) CancelBooking(ctx context.Context, params schema.CancelRequ
 		logger:        logger,
 	}
 
-	return bookingCancel.Execute(h.httpTransport)
+	return bookingCancel.Execute(ctx, h.httpTransport)
 }
 
-func New(redisClient *redis.Client) *bookingCom {
+func New(cache *caching.Cacher, services Services) *bookingCom {
 	transport := http.DefaultTransport.(*http.Transport)
 	// improves durations a lot
 	transport.DisableKeepAlives = true
 
 	return &bookingCom{
-		redis:         redisClient,
+		cache:         cache,
+		searches:      newSearches(cache),
+		services:      services,
 		httpTransport: transport,
 	}
 }
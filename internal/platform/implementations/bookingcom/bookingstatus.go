@@ -21,7 +21,7 @@ type bookingStatusRequest struct {
 	otaBookingStatusResponse ota.BookingStatusRS
 }
 
-func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.BookingStatusResponse, error) {
+func (b *bookingStatusRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingStatusResponse, error) {
 	bookingStatus := schema.BookingStatusResponse{}
 	bookingStatus.Status = schema.BookingStatusResponseStatusFAILED
 
@@ -45,7 +45,7 @@ func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.Bo
 		},
 	}
 
-	response, e := requesting.RequestErrors(b.makeRequest(client))
+	response, e := requesting.RequestErrors(b.makeRequest(ctx, client))
 
 	// handle response
 	if e != nil {
@@ -75,12 +75,12 @@ func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.Bo
 	return bookingStatus, nil
 }
 
-func (b *bookingStatusRequest) makeRequest(client *http.Client) (*http.Response, error) {
+func (b *bookingStatusRequest) makeRequest(ctx context.Context, client *http.Client) (*http.Response, error) {
 	body := bytes.NewBuffer(b.requestBody())
 
 	url := b.configuration.SupplierApiUrl
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.BookingStatus)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.BookingStatus)
 
 	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -202,7 +203,7 @@ func TestBookingStatusRequest(t *testing.T) {
 		params := bookingStatusParamsTemplate(configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := bookingcom.New(redisClient)
+		service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 
 		channel := make(chan schema.BookingStatusResponse, 1)
 
@@ -292,7 +293,7 @@ func bookingStatusParamsTemplate(configuration schema.BookingComConfiguration) s
 }
 
 func bookingStatus(params schema.BookingStatusRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.BookingStatusResponse, error) {
-	service := bookingcom.New(redisClient)
+	service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 	ctx := context.Background()
 	return service.GetBookingStatus(ctx, params, log)
 }
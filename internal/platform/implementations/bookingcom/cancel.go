@@ -23,7 +23,7 @@ type cancelRequest struct {
 	otaCancelBookingResponse ota.CancelBookingRS
 }
 
-func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelResponse, error) {
+func (c *cancelRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.CancelResponse, error) {
 	cancel := schema.CancelResponse{}
 
 	status := schema.CancelResponseStatusFAILED
@@ -52,7 +52,7 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 		},
 	}
 
-	response, e := requesting.RequestErrors(c.makeRequest(client))
+	response, e := requesting.RequestErrors(c.makeRequest(ctx, client))
 
 	// handle response
 	if e != nil {
@@ -85,12 +85,12 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 	return cancel, nil
 }
 
-func (c *cancelRequest) makeRequest(client *http.Client) (*http.Response, error) {
+func (c *cancelRequest) makeRequest(ctx context.Context, client *http.Client) (*http.Response, error) {
 	body := bytes.NewBuffer(c.requestBody())
 
 	url := c.configuration.SupplierApiUrl
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Cancel)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Cancel)
 
 	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -166,7 +167,7 @@ func TestCancelRequest(t *testing.T) {
 		params := cancelParamsTemplate(configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := bookingcom.New(redisClient)
+		service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 
 		channel := make(chan schema.CancelResponse, 1)
 
@@ -258,7 +259,7 @@ func cancelParamsTemplate(configuration schema.BookingComConfiguration) schema.C
 }
 
 func cancelBooking(params schema.CancelRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.CancelResponse, error) {
-	service := bookingcom.New(redisClient)
+	service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 	ctx := context.Background()
 	return service.CancelBooking(ctx, params, log)
 }
@@ -0,0 +1,233 @@
+package bookingcom
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/rs/zerolog"
+)
+
+// depotInfoConcurrency limits the depot detail requests running at once,
+// airports list dozens of depots
+const depotInfoConcurrency = 5
+
+type otaResponse interface {
+	ErrorMessage() string
+}
+
+type locationsRequest struct {
+	params        schema.LocationsRequestParams
+	configuration schema.BookingComConfiguration
+	logger        *zerolog.Logger
+	slowLogger    slowlog.Logger
+}
+
+func (l *locationsRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.LocationsResponse, error) {
+	locations := schema.LocationsResponse{
+		Locations: &[]schema.Location{},
+	}
+
+	requestsBucket := schema.NewSupplierRequestsBucket()
+	errorsBucket := schema.NewErrorsBucket()
+
+	locations.SupplierRequests = requestsBucket.SupplierRequests()
+	locations.Errors = errorsBucket.Errors()
+
+	timeout := l.params.Timeouts.Default
+	if l.params.Timeouts.Locations != nil {
+		timeout = *l.params.Timeouts.Locations
+	}
+
+	// prepare client
+	client := &http.Client{
+		Timeout: time.Duration(timeout) * time.Millisecond,
+		Transport: &requesting.InterceptorTransport{
+			Transport: httpTransport,
+			Middlewares: []requesting.TransportMiddleware{
+				requesting.NewLoggingTransportMiddleware(l.logger),
+				requesting.NewBucketTransportMiddleware(&requestsBucket),
+			},
+		},
+	}
+
+	// search the locations, the response lists the depots of all suppliers
+	l.slowLogger.Start("booking-com:locations:execute:search")
+	var search ota.LocationSearchRS
+	err := l.makeRequest(ctx, client, l.searchRequestBody(), &search)
+	l.slowLogger.Stop("booking-com:locations:execute:search")
+
+	if err != nil {
+		errorsBucket.AddError(*err)
+		return locations, nil
+	}
+
+	supplierLocations := []ota.LocationSearchRSLocation{}
+	for _, location := range search.LocationList.Location {
+		if location.BelongsTo(l.configuration.SupplierName) {
+			supplierLocations = append(supplierLocations, location)
+		}
+	}
+
+	// fetch the depot details, the order of the search is kept
+	l.slowLogger.Start("booking-com:locations:execute:depots")
+	parsed := make([]schema.Location, len(supplierLocations))
+	semaphore := make(chan struct{}, depotInfoConcurrency)
+
+	var wg sync.WaitGroup
+	for i, location := range supplierLocations {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int, location ota.LocationSearchRSLocation) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			var depot ota.DepotInfoRS
+			err := l.makeRequest(ctx, client, l.depotRequestBody(location.Id), &depot)
+			if err != nil {
+				errorsBucket.AddError(*err)
+				return
+			}
+
+			parsed[i] = l.parseLocation(location, depot.Depot)
+		}(i, location)
+	}
+	wg.Wait()
+	l.slowLogger.Stop("booking-com:locations:execute:depots")
+
+	// cleanup collected locations in case of errors, an incomplete import
+	// must not be taken for the full list
+	if len(*errorsBucket.Errors()) > 0 {
+		return locations, nil
+	}
+
+	locations.Locations = &parsed
+
+	return locations, nil
+}
+
+func (l *locationsRequest) makeRequest(ctx context.Context, client *http.Client, body []byte, response otaResponse) *schema.SupplierResponseError {
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Locations)
+
+	httpRequest, _ := http.NewRequestWithContext(c, http.MethodPost, l.configuration.SupplierApiUrl, bytes.NewBuffer(body))
+	httpRequest.Header.Set("Content-Type", "application/xml")
+	httpRequest.Header.Add("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.54 Safari/537.36")
+
+	rs, e := requesting.RequestErrors(client.Do(httpRequest))
+	if e != nil {
+		return e
+	}
+	defer rs.Body.Close()
+
+	// bind the response body to the xml
+	bodyBytes, _ := io.ReadAll(rs.Body)
+	rs.Body.Close()
+
+	if err := xml.Unmarshal(bodyBytes, response); err != nil {
+		e := schema.NewSupplierError("unable to parse the body")
+		return &e
+	}
+
+	if message := response.ErrorMessage(); message != "" {
+		e := schema.NewSupplierError(message)
+		return &e
+	}
+
+	return nil
+}
+
+func (l *locationsRequest) credentials() ota.Credentials {
+	return ota.Credentials{
+		Credentials: ota.CredentialsInfo{
+			Username: l.configuration.Username,
+			Password: l.configuration.Password,
+		},
+	}
+}
+
+func (l *locationsRequest) searchRequestBody() []byte {
+	xmlBytes, _ := xml.MarshalIndent(ota.LocationSearchRQ{
+		Version: ota.Version{
+			Version: "1.1",
+		},
+		SupplierInfo: true,
+		Credentials:  l.credentials(),
+		Supplier: ota.LocationSearchRQSupplier{
+			SupplierName: l.configuration.SupplierName,
+		},
+	}, "", "    ")
+
+	return xmlBytes
+}
+
+func (l *locationsRequest) depotRequestBody(id string) []byte {
+	xmlBytes, _ := xml.MarshalIndent(ota.DepotInfoRQ{
+		Version: ota.Version{
+			Version: "1.1",
+		},
+		Credentials: l.credentials(),
+		Depot: ota.DepotInfoRQDepot{
+			Id: id,
+		},
+	}, "", "    ")
+
+	return xmlBytes
+}
+
+// parseLocation codes locations by depot id, the id rates return as route location
+func (l *locationsRequest) parseLocation(location ota.LocationSearchRSLocation, depot ota.DepotInfoRSDepot) schema.Location {
+	name := depot.Name
+	if name == "" {
+		name = location.Name
+	}
+
+	city := depot.Address.City
+	if city == "" {
+		city = location.City
+	}
+
+	country := depot.Address.Country
+	if country == "" {
+		country = location.Country
+	}
+
+	parsed := schema.Location{
+		Code:         location.Id,
+		Name:         name,
+		Country:      country,
+		City:         converting.PointerToValue(city),
+		Address:      converting.PointerToValue(depot.Address.Street),
+		PostalCode:   converting.PointerToValue(depot.Address.Postcode),
+		Phone:        converting.PointerToValue(depot.Phone),
+		OpeningHours: converting.PointerToValue(depot.GetOpeningTime()),
+		RawData:      depot.GetRawData(),
+	}
+
+	if depot.Coordinates != nil {
+		parsed.Latitude = converting.PointerToValue(depot.Coordinates.Latitude)
+		parsed.Longitude = converting.PointerToValue(depot.Coordinates.Longitude)
+	}
+
+	if depot.Email != "" {
+		parsed.Email = converting.PointerToValue(openapi_types.Email(depot.Email))
+	}
+
+	if location.Iata != "" {
+		parsed.Iata = converting.PointerToValue(location.Iata)
+	}
+
+	return parsed
+}
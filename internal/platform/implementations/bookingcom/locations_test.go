@@ -0,0 +1,167 @@
+package bookingcom_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"github.com/go-redis/redismock/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// locationsSupplier answers location searches with search and depot details
+// by the depot id of the request
+func locationsSupplier(t *testing.T, search string, depots map[string]string) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	requested := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "application/xml", r.Header.Get("Content-Type"))
+
+		if bytes.HasPrefix(body, []byte("<LocationSearchRQ")) {
+			var rq ota.LocationSearchRQ
+			assert.Nil(t, xml.Unmarshal(body, &rq))
+			assert.Equal(t, "test-user", rq.Credentials.Credentials.Username)
+			assert.Equal(t, "test-supplier-name", rq.Supplier.SupplierName)
+
+			w.Write(locationsFixture(search))
+			return
+		}
+
+		var rq ota.DepotInfoRQ
+		assert.Nil(t, xml.Unmarshal(body, &rq))
+
+		mu.Lock()
+		requested = append(requested, rq.Depot.Id)
+		mu.Unlock()
+
+		w.Write(locationsFixture(depots[rq.Depot.Id]))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requested
+}
+
+func locationsFixture(name string) []byte {
+	content, _ := os.ReadFile("./testdata/locations/" + name)
+	return content
+}
+
+func locationsParams(url string) schema.LocationsRequestParams {
+	configuration := ratesDefaultConfiguration()
+	configuration.SupplierApiUrl = url
+
+	b, _ := json.Marshal(configuration)
+
+	var cp schema.LocationsRequestParams_Configuration
+	json.Unmarshal(b, &cp)
+
+	return schema.LocationsRequestParams{
+		Timeouts:      schema.Timeouts{Default: 8000},
+		Configuration: cp,
+	}
+}
+
+func getLocations(params schema.LocationsRequestParams) (schema.LocationsResponse, error) {
+	log := zerolog.Nop()
+	redisClient, _ := redismock.NewClientMock()
+	service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
+
+	return service.GetLocations(context.Background(), params, &log)
+}
+
+func TestLocationsRequest(t *testing.T) {
+	defaultDepots := map[string]string{
+		"1001": "supplier_depot_response_1001.xml",
+		"1002": "supplier_depot_response_1002.xml",
+	}
+
+	t.Run("should map the depots of the supplier", func(t *testing.T) {
+		server, requested := locationsSupplier(t, "supplier_search_response_default.xml", defaultDepots)
+
+		locations, err := getLocations(locationsParams(server.URL))
+
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"1001", "1002"}, *requested)
+		assert.Len(t, *locations.SupplierRequests, 3)
+
+		locations.SupplierRequests = nil
+		actual, _ := json.MarshalIndent(locations, "", "\t")
+
+		assert.Equal(t, strings.TrimSpace(string(locationsFixture("response_default.json"))), string(actual))
+	})
+
+	t.Run("should return supplier errors of the search", func(t *testing.T) {
+		server, requested := locationsSupplier(t, "supplier_search_response_failed.xml", defaultDepots)
+
+		locations, err := getLocations(locationsParams(server.URL))
+
+		assert.Nil(t, err)
+		assert.Empty(t, *requested)
+		assert.Empty(t, *locations.Locations)
+		assert.Len(t, *locations.Errors, 1)
+		assert.Equal(t, schema.SupplierError, (*locations.Errors)[0].Code)
+		assert.Equal(t, "AUTHENTICATION FAILED", (*locations.Errors)[0].Message)
+	})
+
+	t.Run("should not return a partial import when a depot fails", func(t *testing.T) {
+		server, _ := locationsSupplier(t, "supplier_search_response_default.xml", map[string]string{
+			"1001": "supplier_depot_response_1001.xml",
+			"1002": "supplier_depot_response_failed.xml",
+		})
+
+		locations, err := getLocations(locationsParams(server.URL))
+
+		assert.Nil(t, err)
+		assert.Empty(t, *locations.Locations)
+		assert.Len(t, *locations.Errors, 1)
+		assert.Equal(t, "DEPOT NOT FOUND", (*locations.Errors)[0].Message)
+	})
+
+	t.Run("should handle timeouts", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(10 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		params := locationsParams(server.URL)
+		params.Timeouts.Locations = converting.PointerToValue(1)
+
+		locations, err := getLocations(params)
+
+		assert.Nil(t, err)
+		assert.Len(t, *locations.Errors, 1)
+		assert.Equal(t, schema.TimeoutError, (*locations.Errors)[0].Code)
+	})
+
+	t.Run("should handle status != 200 error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		locations, _ := getLocations(locationsParams(server.URL))
+
+		assert.Len(t, *locations.Errors, 1)
+		assert.Equal(t, schema.SupplierError, (*locations.Errors)[0].Code)
+		assert.Equal(t, "supplier returned status code 404", (*locations.Errors)[0].Message)
+	})
+}
@@ -0,0 +1,11 @@
+package ota
+
+type DepotInfoRQ struct {
+	Version
+	Credentials
+	Depot DepotInfoRQDepot `xml:"Depot"`
+}
+
+type DepotInfoRQDepot struct {
+	Id string `xml:"id,attr"`
+}
@@ -0,0 +1,104 @@
+package ota
+
+import (
+	"encoding/xml"
+	"sort"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+)
+
+type DepotInfoRS struct {
+	Depot DepotInfoRSDepot `xml:"Depot"`
+	Errors
+}
+
+type DepotInfoRSDepot struct {
+	XMLName      xml.Name                `xml:"Depot"`
+	Id           string                  `xml:"id,attr"`
+	Name         string                  `xml:"Name"`
+	Address      DepotInfoRSAddress      `xml:"Address"`
+	Coordinates  *DepotInfoRSCoordinates `xml:"Coordinates"`
+	Phone        string                  `xml:"Phone"`
+	Email        string                  `xml:"Email"`
+	OpeningHours DepotInfoRSOpeningHours `xml:"OpeningHours"`
+}
+
+type DepotInfoRSAddress struct {
+	Street   string `xml:"street,attr"`
+	City     string `xml:"city,attr"`
+	Postcode string `xml:"postcode,attr"`
+	Country  string `xml:"country,attr"`
+}
+
+type DepotInfoRSCoordinates struct {
+	Latitude  float32 `xml:"latitude,attr"`
+	Longitude float32 `xml:"longitude,attr"`
+}
+
+type DepotInfoRSOpeningHours struct {
+	Day []DepotInfoRSDay `xml:"Day"`
+}
+
+// DepotInfoRSDay weekday 1 is monday and 7 sunday, outOfHours marks the
+// times the depot serves on request and with a fee
+type DepotInfoRSDay struct {
+	Weekday    int    `xml:"weekday,attr"`
+	Open       string `xml:"open,attr"`
+	Close      string `xml:"close,attr"`
+	Closed     bool   `xml:"closed,attr"`
+	OutOfHours bool   `xml:"outOfHours,attr"`
+}
+
+func (d *DepotInfoRSDepot) GetOpeningTime() []schema.OpeningTime {
+	days := make([]DepotInfoRSDay, len(d.OpeningHours.Day))
+	copy(days, d.OpeningHours.Day)
+
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Weekday < days[j].Weekday
+	})
+
+	openingTime := []schema.OpeningTime{}
+
+	for _, day := range days {
+		// closed days are left out like the suppliers returning schedules do
+		if day.Closed {
+			continue
+		}
+
+		opening := schema.OpeningTime{
+			Open:    true,
+			Weekday: day.Weekday,
+			Start:   day.Open,
+			End:     day.Close,
+		}
+
+		if day.OutOfHours {
+			ooh := true
+			opening.Ooh = &ooh
+		}
+
+		openingTime = append(openingTime, opening)
+	}
+
+	return openingTime
+}
+
+func (d *DepotInfoRSDepot) GetRawData() struct {
+	Content     string `json:"content"`
+	ContentType string `json:"contentType"`
+} {
+	rawData := ""
+
+	xmlData, err := xml.Marshal(d)
+	if err == nil {
+		rawData = string(xmlData)
+	}
+
+	return struct {
+		Content     string `json:"content"`
+		ContentType string `json:"contentType"`
+	}{
+		Content:     rawData,
+		ContentType: "application/xml",
+	}
+}
@@ -0,0 +1,12 @@
+package ota
+
+type LocationSearchRQ struct {
+	Version
+	SupplierInfo bool `xml:"supplierInfo,attr"`
+	Credentials
+	Supplier LocationSearchRQSupplier `xml:"Supplier"`
+}
+
+type LocationSearchRQSupplier struct {
+	SupplierName string `xml:"supplierName,attr"`
+}
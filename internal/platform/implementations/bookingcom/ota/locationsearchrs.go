@@ -0,0 +1,27 @@
+package ota
+
+import "strings"
+
+type LocationSearchRS struct {
+	LocationList LocationSearchRSLocationList `xml:"LocationList"`
+	Errors
+}
+
+type LocationSearchRSLocationList struct {
+	Location []LocationSearchRSLocation `xml:"Location"`
+}
+
+type LocationSearchRSLocation struct {
+	Id        string           `xml:"id,attr"`
+	Country   string           `xml:"country,attr"`
+	City      string           `xml:"city,attr"`
+	Iata      string           `xml:"iata,attr"`
+	OnAirport string           `xml:"onAirport,attr"`
+	Name      string           `xml:"Name"`
+	Supplier  SearchRSSupplier `xml:"Supplier"`
+}
+
+// BelongsTo compares supplier names case insensitively like the rates do
+func (l *LocationSearchRSLocation) BelongsTo(supplierName string) bool {
+	return strings.EqualFold(l.Supplier.SupplierName, supplierName)
+}
@@ -17,6 +17,7 @@ import (
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
 	"github.com/rs/zerolog"
@@ -24,6 +25,7 @@ import (
 
 type RatesRequest struct {
 	cache         *caching.Cacher
+	searches      *searches
 	params        schema.RatesRequestParams
 	configuration schema.BookingComConfiguration
 	logger        *zerolog.Logger
@@ -63,21 +65,20 @@ func (r *RatesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 
 	r.slowLogger.Start("booking-com:rates:execute:requests")
 
-	ratesResChannel := make(chan ota.SearchRS, 1)
-	ratesErrChannel := make(chan schema.SupplierResponseError, 1)
-	defer close(ratesResChannel)
-	defer close(ratesErrChannel)
-
-	r.ratesRequest(ctx, client, ratesResChannel, ratesErrChannel)
+	requestBody := r.requestBody()
 
-	var vehAvailRateRS ota.SearchRS
+	search, shared := r.searches.do(ctx, searchKey(r.configuration.SupplierApiUrl, requestBody), client.Timeout, func(ctx context.Context) searchResult {
+		return r.search(ctx, client, requestBody)
+	})
 
-	select {
-	case vehAvailRateRS = <-ratesResChannel:
-		break
+	// the search of another supplier is reported by the request running it
+	if shared {
+		rates.SupplierRequests = &schema.SupplierRequests{}
+		r.logger.Debug().Msg("Reusing the search of another supplier")
+	}
 
-	case ratesErr := <-ratesErrChannel:
-		errorsBucket.AddError(ratesErr)
+	if search.err != nil {
+		errorsBucket.AddError(*search.err)
 		return rates, nil
 	}
 
@@ -85,9 +86,11 @@ func (r *RatesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 
 	r.slowLogger.Start("booking-com:rates:execute:mapVehicles")
 
-	for _, vehAvail := range vehAvailRateRS.MatchList.Match {
-		// skip not checked vehicles and filter be the supplier name
-		if !vehAvail.Vehicle.AvailabilityCheck || strings.ToLower(vehAvail.Supplier.SupplierName) != strings.ToLower(r.configuration.SupplierName) {
+	allSuppliers := r.configuration.AllSuppliers != nil && *r.configuration.AllSuppliers
+
+	for _, vehAvail := range search.response.MatchList.Match {
+		// skip not checked vehicles and filter by the supplier name, the search is shared by all suppliers
+		if !vehAvail.Vehicle.AvailabilityCheck || (!allSuppliers && !strings.EqualFold(vehAvail.Supplier.SupplierName, r.configuration.SupplierName)) {
 			continue
 		}
 
@@ -97,6 +100,11 @@ func (r *RatesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 			continue
 		}
 
+		if allSuppliers {
+			supplier := vehAvail.Supplier.SupplierName
+			vehicle.Supplier = &supplier
+		}
+
 		rates.Vehicles = append(rates.Vehicles, vehicle)
 	}
 
@@ -105,21 +113,37 @@ func (r *RatesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 	return rates, nil
 }
 
+func (r *RatesRequest) search(ctx context.Context, client *http.Client, requestBody string) searchResult {
+	ratesResChannel := make(chan ota.SearchRS, 1)
+	ratesErrChannel := make(chan schema.SupplierResponseError, 1)
+	defer close(ratesResChannel)
+	defer close(ratesErrChannel)
+
+	r.ratesRequest(ctx, client, requestBody, ratesResChannel, ratesErrChannel)
+
+	select {
+	case vehAvailRateRS := <-ratesResChannel:
+		return searchResult{response: vehAvailRateRS}
+
+	case ratesErr := <-ratesErrChannel:
+		return searchResult{err: &ratesErr}
+	}
+}
+
 func (r *RatesRequest) ratesRequest(
 	ctx context.Context,
 	client *http.Client,
+	requestBody string,
 	resChannel chan<- ota.SearchRS,
 	errChannel chan<- schema.SupplierResponseError,
 ) {
-	requestBody := r.requestBody()
-
 	c := context.WithValue(ctx, schema.RequestingTypeKey, schema.Rates)
 
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodPost, r.configuration.SupplierApiUrl, bytes.NewBuffer([]byte(requestBody)))
 	httpRequest.Header.Set("Content-Type", "application/xml")
 	httpRequest.Header.Add("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.54 Safari/537.36")
 
-	go func() {
+	lifecycle.Go(ctx, "bookingcom:rates", func() {
 		defer r.recoverPanic(errChannel)
 
 		rs, e := requesting.RequestErrors(client.Do(httpRequest))
@@ -146,7 +170,7 @@ func (r *RatesRequest) ratesRequest(
 		}
 
 		resChannel <- ratesResponse
-	}()
+	})
 }
 
 func (r *RatesRequest) requestBody() string {
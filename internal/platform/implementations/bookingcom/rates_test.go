@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom/mapping"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
 	"github.com/go-redis/redismock/v9"
 	"github.com/rs/zerolog"
@@ -73,7 +76,7 @@ func TestRatesRequestRR(t *testing.T) {
 				params := mergeRatesParamsAndConfiguration(test.requestParams, test.configuration)
 
 				redisClient, _ := redismock.NewClientMock()
-				service := bookingcom.New(redisClient)
+				service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 				ctx := context.Background()
 				service.GetRates(ctx, params, &log)
 			})
@@ -134,7 +137,7 @@ func TestRatesRequestRR(t *testing.T) {
 				params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), test.configuration)
 
 				redisClient, _ := redismock.NewClientMock()
-				service := bookingcom.New(redisClient)
+				service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 				ctx := context.Background()
 				rates, err := service.GetRates(ctx, params, &log)
 
@@ -170,7 +173,7 @@ func TestRatesRequestRR(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(p, configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := bookingcom.New(redisClient)
+		service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 		ctx := context.Background()
 
 		ratesResponse, _ := service.GetRates(ctx, params, &log)
@@ -191,7 +194,7 @@ func TestRatesRequestRR(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := bookingcom.New(redisClient)
+		service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 		ctx := context.Background()
 
 		ratesResponse, _ := service.GetRates(ctx, params, &log)
@@ -221,7 +224,7 @@ func TestRatesRequestRR(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := bookingcom.New(redisClient)
+		service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 		ctx := context.Background()
 
 		ratesResponse, _ := service.GetRates(ctx, params, &log)
@@ -251,7 +254,7 @@ func TestRatesRequestRR(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := bookingcom.New(redisClient)
+		service := bookingcom.New(caching.NewRedisCache(redisClient), bookingcom.Services{})
 		ctx := context.Background()
 
 		ratesResponse, _ := service.GetRates(ctx, params, &log)
@@ -268,6 +271,178 @@ func TestRatesRequestRR(t *testing.T) {
 	})
 }
 
+func TestSharedSearch(t *testing.T) {
+	log := zerolog.Nop()
+
+	// two suppliers at the same route, the second one listed in the response
+	response := defaultSupplierRatesResponse()
+	other := response.MatchList.Match[0]
+	other.Vehicle.Id = "200"
+	other.Supplier.SupplierName = "other-supplier-name"
+	response.MatchList.Match = append(response.MatchList.Match, other)
+
+	body, _ := xml.Marshal(response)
+
+	var calls int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer testServer.Close()
+
+	paramsOf := func(supplierName string, allSuppliers bool) schema.RatesRequestParams {
+		configuration := ratesDefaultConfiguration()
+		configuration.SupplierApiUrl = testServer.URL
+		configuration.SupplierName = supplierName
+		if allSuppliers {
+			configuration.AllSuppliers = converting.PointerToValue(true)
+		}
+
+		return mergeRatesParamsAndConfiguration(ratesDefaultParams(), configuration)
+	}
+
+	t.Run("should search once for concurrent suppliers and split the vehicles", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		service := bookingcom.New(caching.NewMemoryCache(), bookingcom.Services{})
+
+		suppliers := []string{"test-supplier-name", "other-supplier-name", "TEST-SUPPLIER-NAME"}
+		responses := make([]schema.RatesResponse, len(suppliers))
+
+		var wg sync.WaitGroup
+		for i, supplier := range suppliers {
+			wg.Add(1)
+			go func(i int, supplier string) {
+				defer wg.Done()
+				responses[i], _ = service.GetRates(context.Background(), paramsOf(supplier, false), &log)
+			}(i, supplier)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		supplierRequests := 0
+		for i, response := range responses {
+			assert.Empty(t, *response.Errors)
+			assert.Len(t, response.Vehicles, 1)
+			assert.Nil(t, response.Vehicles[0].Supplier)
+
+			supplierRequests += len(*response.SupplierRequests)
+
+			var reference mapping.SupplierRateReference
+			json.Unmarshal([]byte(*response.Vehicles[0].SupplierRateReference), &reference)
+
+			expected := "100"
+			if suppliers[i] == "other-supplier-name" {
+				expected = "200"
+			}
+
+			assert.Equal(t, expected, reference.VehicleId)
+		}
+
+		// only the supplier running the search reports its supplier request
+		assert.Equal(t, 1, supplierRequests)
+	})
+
+	t.Run("should finish the search for waiting suppliers when the first caller goes away", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		service := bookingcom.New(caching.NewMemoryCache(), bookingcom.Services{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			service.GetRates(ctx, paramsOf("test-supplier-name", false), &log)
+		}()
+
+		time.Sleep(5 * time.Millisecond)
+
+		waiting := make(chan schema.RatesResponse)
+		go func() {
+			response, _ := service.GetRates(context.Background(), paramsOf("other-supplier-name", false), &log)
+			waiting <- response
+		}()
+
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+
+		response := <-waiting
+		<-done
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Empty(t, *response.Errors)
+		assert.Len(t, response.Vehicles, 1)
+		assert.Empty(t, *response.SupplierRequests)
+	})
+
+	t.Run("should serve later suppliers from the cached search", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		service := bookingcom.New(caching.NewMemoryCache(), bookingcom.Services{})
+
+		first, _ := service.GetRates(context.Background(), paramsOf("test-supplier-name", false), &log)
+		second, _ := service.GetRates(context.Background(), paramsOf("other-supplier-name", false), &log)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Len(t, first.Vehicles, 1)
+		assert.Len(t, second.Vehicles, 1)
+		assert.Empty(t, *second.SupplierRequests)
+
+		params := paramsOf("test-supplier-name", false)
+		params.DropOff.DateTime = params.DropOff.DateTime.Add(24 * time.Hour)
+
+		service.GetRates(context.Background(), params, &log)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("should return the vehicles of all suppliers tagged with their supplier", func(t *testing.T) {
+		service := bookingcom.New(caching.NewMemoryCache(), bookingcom.Services{})
+
+		rates, err := service.GetRates(context.Background(), paramsOf("", true), &log)
+
+		assert.Nil(t, err)
+		assert.Len(t, rates.Vehicles, 2)
+		assert.Equal(t, "test-supplier-name", *rates.Vehicles[0].Supplier)
+		assert.Equal(t, "other-supplier-name", *rates.Vehicles[1].Supplier)
+	})
+
+	t.Run("should group all suppliers apart from single suppliers", func(t *testing.T) {
+		service := bookingcom.New(caching.NewMemoryCache(), bookingcom.Services{})
+
+		single := service.TrafficLightGroupingCacheKey(context.Background(), paramsOf("test-supplier-name", false), &log)
+		all := service.TrafficLightGroupingCacheKey(context.Background(), paramsOf("test-supplier-name", true), &log)
+
+		assert.True(t, strings.HasSuffix(single, ":test-supplier-name"))
+		assert.True(t, strings.HasSuffix(all, ":*"))
+	})
+
+	t.Run("should not cache failed searches", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+
+		atomic.StoreInt32(&calls, 0)
+		service := bookingcom.New(caching.NewMemoryCache(), bookingcom.Services{})
+
+		params := paramsOf("test-supplier-name", false)
+		configuration, _ := params.Configuration.AsBookingComConfiguration()
+		configuration.SupplierApiUrl = failing.URL
+		params = mergeRatesParamsAndConfiguration(params, configuration)
+
+		for i := 0; i < 2; i++ {
+			rates, _ := service.GetRates(context.Background(), params, &log)
+			assert.Len(t, *rates.Errors, 1)
+		}
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
 func rateReference(ref mapping.SupplierRateReference) string {
 	bytes, _ := json.Marshal(ref)
 	return string(bytes)
@@ -0,0 +1,112 @@
+package bookingcom
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/bookingcom/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+)
+
+// searchCacheTtl keeps a search for the suppliers asking for the same route
+// and dates shortly after, prices change too often to keep it longer
+const searchCacheTtl = time.Minute
+
+type searchResult struct {
+	response ota.SearchRS
+	err      *schema.SupplierResponseError
+}
+
+type searchFlight struct {
+	done   chan struct{}
+	result searchResult
+}
+
+// searches runs a single SearchRQ per route, dates and account at a time. The
+// SearchRS lists the matches of all suppliers, so the rates of every supplier
+// configured on the route are split from it instead of searching again.
+type searches struct {
+	cache   *caching.Cacher
+	flights map[string]*searchFlight
+	mu      sync.Mutex
+}
+
+func newSearches(cache *caching.Cacher) *searches {
+	return &searches{
+		cache:   cache,
+		flights: make(map[string]*searchFlight),
+	}
+}
+
+// searchKey is built from the request body, which holds no supplier name, the
+// credentials in it are hashed
+func searchKey(supplierApiUrl string, requestBody string) string {
+	sum := sha256.Sum256([]byte(supplierApiUrl + "\n" + requestBody))
+
+	return "booking-com:search:" + hex.EncodeToString(sum[:])
+}
+
+// do returns the cached search of the key, joins the search of the key running
+// already or runs search. The search runs detached from the caller, callers
+// going away do not fail it for the others. Only the caller running search
+// gets the supplier requests, shared is true for the others, they wait at most
+// timeout for it.
+func (s *searches) do(ctx context.Context, key string, timeout time.Duration, search func(ctx context.Context) searchResult) (result searchResult, shared bool) {
+	var cached ota.SearchRS
+
+	// a failing cache is a miss, the supplier is asked instead
+	if ok, err := s.cache.Fetch(ctx, key, &cached); err == nil && ok {
+		return searchResult{response: cached}, true
+	}
+
+	s.mu.Lock()
+	flight, running := s.flights[key]
+	if !running {
+		flight = &searchFlight{done: make(chan struct{})}
+		s.flights[key] = flight
+	}
+	s.mu.Unlock()
+
+	if running {
+		return s.wait(ctx, flight, timeout), true
+	}
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.flights, key)
+		s.mu.Unlock()
+
+		close(flight.done)
+	}()
+
+	detached := requesting.Detach(ctx)
+
+	flight.result = search(detached)
+
+	if flight.result.err == nil {
+		_ = s.cache.Store(detached, key, flight.result.response, searchCacheTtl)
+	}
+
+	return flight.result, false
+}
+
+func (s *searches) wait(ctx context.Context, flight *searchFlight, timeout time.Duration) searchResult {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-flight.done:
+		return flight.result
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	err := schema.NewTimeoutError("waiting for the search of another supplier timed out")
+
+	return searchResult{err: &err}
+}
@@ -11,9 +11,9 @@ import (
 	"time"
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/mapping"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"github.com/rs/zerolog"
 )
@@ -40,7 +40,7 @@ func (b *bookingRequest) requestBody(qualifier mapping.SupplierRateReference) st
 	vehRentalCore := ota.VehRentalCore{
 		PickUpDateTime: b.params.PickUp.DateTime.Format(schema.DateTimeFormat),
 		ReturnDateTime: b.params.DropOff.DateTime.Format(schema.DateTimeFormat),
-		PickUpLocation: ota.Location{
+		PickUpLocation: &ota.Location{
 			LocationCode: b.params.PickUp.Code,
 		},
 		ReturnLocation: &ota.Location{
@@ -161,7 +161,7 @@ func (b *bookingRequest) requestBody(qualifier mapping.SupplierRateReference) st
 		XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehResRS.xsd",
 		Version:           "1.008",
 		MaxResponses:      maxResponses,
-		POS:               ota.NewPOS(mapping.MappedResidenceCountry(b.configuration, b.params.Customer.ResidenceCountry), b.configuration, b.params.BrokerReference),
+		POS:               mapping.NewPOS(mapping.MappedResidenceCountry(b.configuration, b.params.Customer.ResidenceCountry), b.configuration, b.params.BrokerReference),
 		VehResRQInfo: ota.VehResRQInfo{
 			SpecialReqPref:    converting.LatinCharacters(comments),
 			ArrivalDetails:    arrivalDetails,
@@ -193,10 +193,10 @@ func (b *bookingRequest) requestBody(qualifier mapping.SupplierRateReference) st
 	return string(xmlString)
 }
 
-func (b *bookingRequest) bookingRequest(client *http.Client) (*http.Response, error) {
+func (b *bookingRequest) bookingRequest(ctx context.Context, client *http.Client) (*http.Response, error) {
 	requestBody := b.requestBody(b.supplierRateReference)
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Booking)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Booking)
 	httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodPost, b.configuration.SupplierApiUrl, bytes.NewBuffer([]byte(requestBody)))
 	httpRequest.Header.Set("Content-Type", "application/xml; charset=utf-8")
 
@@ -208,7 +208,7 @@ func (b *bookingRequest) bookingRequest(client *http.Client) (*http.Response, er
 	return response, nil
 }
 
-func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingResponse, error) {
+func (b *bookingRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingResponse, error) {
 	booking := schema.BookingResponse{}
 
 	requestsBucket := schema.NewSupplierRequestsBucket()
@@ -234,7 +234,7 @@ func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingR
 		},
 	}
 
-	response, e := requesting.RequestErrors(b.bookingRequest(client))
+	response, e := requesting.RequestErrors(b.bookingRequest(ctx, client))
 	if e != nil {
 		errorsBucket.AddError(*e)
 		return booking, nil
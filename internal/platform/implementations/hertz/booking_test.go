@@ -14,9 +14,10 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/mapping"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"github.com/go-redis/redismock/v9"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -120,7 +121,7 @@ func defaultSupplierBookingRequest() ota.VehResRQ {
 			VehRentalCore: ota.VehRentalCore{
 				PickUpDateTime: "2023-07-10T10:00:00",
 				ReturnDateTime: "2023-07-17T10:00:00",
-				PickUpLocation: ota.Location{
+				PickUpLocation: &ota.Location{
 					LocationCode: "QRY",
 				},
 				ReturnLocation: &ota.Location{
@@ -458,7 +459,7 @@ func TestBookingRequest(t *testing.T) {
 				params := mergeBookingParamsAndConfiguration(test.requestParams, test.configuration)
 
 				redisClient, _ := redismock.NewClientMock()
-				service := hertz.New(redisClient)
+				service := hertz.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 
 				service.CreateBooking(ctx, params, &log)
@@ -501,7 +502,7 @@ func TestBookingRequest(t *testing.T) {
 		params.SupplierRateReference = string(reference)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		bookingResponse, _ := service.CreateBooking(ctx, params, &log)
@@ -534,7 +535,7 @@ func TestBookingRequest(t *testing.T) {
 		params := mergeBookingParamsAndConfiguration(p, configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		bookingResponse, _ := service.CreateBooking(ctx, params, &log)
@@ -559,7 +560,7 @@ func TestBookingRequest(t *testing.T) {
 		params := mergeBookingParamsAndConfiguration(bookingDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.BookingResponse, 1)
 
@@ -591,7 +592,7 @@ func TestBookingRequest(t *testing.T) {
 		params := mergeBookingParamsAndConfiguration(bookingDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		bookingResponse, _ := service.CreateBooking(ctx, params, &log)
@@ -631,7 +632,7 @@ func TestBookingRequest(t *testing.T) {
 		params := mergeBookingParamsAndConfiguration(bookingDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		bookingResponse, _ := service.CreateBooking(ctx, params, &log)
@@ -671,7 +672,7 @@ func TestBookingRequest(t *testing.T) {
 		params := mergeBookingParamsAndConfiguration(bookingDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		bookingResponse, _ := service.CreateBooking(ctx, params, &log)
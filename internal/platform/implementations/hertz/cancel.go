@@ -8,8 +8,9 @@ import (
 	"net/http"
 	"time"
 
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/mapping"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"github.com/rs/zerolog"
 )
@@ -22,7 +23,7 @@ type cancelRequest struct {
 }
 
 func (c *cancelRequest) requestBody() []byte {
-	var pos ota.POS = ota.NewPOS(*c.configuration.ResidenceCountry, c.configuration, c.params.BrokerReference)
+	var pos ota.POS = mapping.NewPOS(*c.configuration.ResidenceCountry, c.configuration, c.params.BrokerReference)
 	var core ota.VehCancelRQCore = ota.VehCancelRQCore{
 		CancelType: "Book",
 		UniqueID: &ota.UniqueID{
@@ -47,11 +48,11 @@ func (c *cancelRequest) requestBody() []byte {
 	return xml
 }
 
-func (c *cancelRequest) makeRequest(client *http.Client) (*http.Response, error) {
+func (c *cancelRequest) makeRequest(ctx context.Context, client *http.Client) (*http.Response, error) {
 	body := bytes.NewBuffer(c.requestBody())
 	url := c.configuration.SupplierApiUrl
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Cancel)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Cancel)
 
 	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
@@ -81,7 +82,7 @@ func (c *cancelRequest) successfullyCancelled(cancelStatus ota.CoreCancelStatus)
 	return cancelStatus == ota.CoreCancelStatusCancelled
 }
 
-func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelResponse, error) {
+func (c *cancelRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.CancelResponse, error) {
 	cancel := schema.CancelResponse{}
 
 	requestsBucket := schema.NewSupplierRequestsBucket()
@@ -107,7 +108,7 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 		},
 	}
 
-	response, e := requesting.RequestErrors(c.makeRequest(client))
+	response, e := requesting.RequestErrors(c.makeRequest(ctx, client))
 
 	// handle response
 	if e != nil {
@@ -14,6 +14,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
@@ -174,7 +175,7 @@ func TestCancelRequest(t *testing.T) {
 		params := cancelParamsTemplate(configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.CancelResponse, 1)
 
@@ -265,7 +266,7 @@ func cancelParamsTemplate(configuration schema.HertzConfiguration) schema.Cancel
 }
 
 func cancelBooking(params schema.CancelRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.CancelResponse, error) {
-	service := hertz.New(redisClient)
+	service := hertz.New(caching.NewRedisCache(redisClient))
 	ctx := context.Background()
 	return service.CancelBooking(ctx, params, log)
 }
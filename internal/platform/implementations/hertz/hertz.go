@@ -14,7 +14,6 @@ import (
 	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
-	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 )
 
@@ -23,7 +22,7 @@ const (
 )
 
 type hertz struct {
-	redis         *redis.Client
+	cache         *caching.Cacher
 	httpTransport *http.Transport
 }
 
@@ -66,7 +65,7 @@ func (h *hertz) GetRates(ctx context.Context, params schema.RatesRequestParams,
 	slowLogger := slowlog.CreateLogger(logger)
 
 	ratesRequest := ratesRequest{
-		cache:         caching.NewRedisCache(h.redis),
+		cache:         h.cache,
 		params:        params,
 		configuration: configuration,
 		logger:        logger,
@@ -110,7 +109,7 @@ func (h *hertz) CreateBooking(ctx context.Context, params schema.BookingRequestP
 		logger:                logger,
 	}
 
-	return bookingRequest.Execute(h.httpTransport)
+	return bookingRequest.Execute(ctx, h.httpTransport)
 }
 
 func (h *hertz) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
@@ -122,7 +121,7 @@ func (h *hertz) CancelBooking(ctx context.Context, params schema.CancelRequestPa
 		logger:        logger,
 	}
 
-	return bookingCancel.Execute(h.httpTransport)
+	return bookingCancel.Execute(ctx, h.httpTransport)
 }
 
 func (h *hertz) ModifyBooking(ctx context.Context, params schema.ModifyRequestParams, logger *zerolog.Logger) (schema.ModifyResponse, error) {
@@ -143,16 +142,16 @@ func (h *hertz) ModifyBooking(ctx context.Context, params schema.ModifyRequestPa
 		logger:                      logger,
 	}
 
-	return modifyRequest.Execute(h.httpTransport)
+	return modifyRequest.Execute(ctx, h.httpTransport)
 }
 
-func New(redisClient *redis.Client) *hertz {
+func New(cache *caching.Cacher) *hertz {
 	transport := http.DefaultTransport.(*http.Transport)
 	// improves durations a lot
 	transport.DisableKeepAlives = true
 
 	return &hertz{
-		redis:         redisClient,
+		cache:         cache,
 		httpTransport: transport,
 	}
 }
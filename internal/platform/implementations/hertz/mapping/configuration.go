@@ -1,6 +1,10 @@
 package mapping
 
-import "bitbucket.org/crgw/supplier-hub/internal/schema"
+import (
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+)
 
 func MappedResidenceCountry(c schema.HertzConfiguration, requestCountry string) string {
 	if c.ResidenceCountryMapping == nil {
@@ -19,3 +23,71 @@ func MappedResidenceCountry(c schema.HertzConfiguration, requestCountry string)
 
 	return mappedCountry
 }
+
+func NewPOS(residenceCountry string, configuration schema.HertzConfiguration, brokerReference string) ota.POS {
+	sources := make([]ota.Source, 0)
+
+	sources = append(sources, ota.Source{
+		ISOCountry:    residenceCountry,
+		AgentDutyCode: converting.Unwrap(configuration.Vc),
+		RequestorID: ota.RequestorID{
+			Type: "4",
+			ID:   converting.Unwrap(configuration.Vn),
+			CompanyName: &ota.CompanyName{
+				Code:        "CP",
+				CodeContext: converting.Unwrap(configuration.Cp),
+			},
+		},
+	})
+
+	sources = append(sources, ota.Source{
+		RequestorID: ota.RequestorID{
+			Type: "8",
+			ID:   configuration.VendorCode,
+		},
+	})
+
+	if configuration.Taco != nil {
+		sources = append(sources, ota.Source{
+			RequestorID: ota.RequestorID{
+				Type: "5",
+				ID:   *configuration.Taco,
+			},
+		})
+	}
+
+	if configuration.BookingAgent != nil {
+		sources = append(sources, ota.Source{
+			RequestorID: ota.RequestorID{
+				Type: "29",
+				ID:   *configuration.BookingAgent,
+			},
+		})
+	}
+
+	if brokerReference != "" {
+		sources = append(sources, ota.Source{
+			RequestorID: ota.RequestorID{
+				Type: "16",
+				ID:   brokerReference,
+			},
+		})
+	}
+
+	return ota.POS{
+		Source: sources,
+	}
+}
+
+// Pricing maps the configuration deciding how fees, taxes and coverages are paid
+func Pricing(c schema.HertzConfiguration) ota.Pricing {
+	return ota.Pricing{
+		FeePayableLocally:           converting.Unwrap(c.FeePayableLocally),
+		FpPayFeesLocally:            converting.Unwrap(c.FpPayFeesLocally),
+		FpPaynowVehiclePriceWithTax: converting.Unwrap(c.FpPaynowVehiclePriceWithTax),
+		PayNowCoverages:             converting.Unwrap(c.PayNowCoverages),
+		AddTaxToCoverages:           converting.Unwrap(c.AddTaxToCoverages),
+		TaxExclCoverageCountries:    converting.Unwrap(c.TaxExclCoverageCountries),
+		IncludeCoveragesInRate:      converting.Unwrap(c.IncludeCoveragesInRate),
+	}
+}
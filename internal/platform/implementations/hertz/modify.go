@@ -14,7 +14,7 @@ import (
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/mapping"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"github.com/rs/zerolog"
 )
 
@@ -31,7 +31,7 @@ func (m *modifyRequest) requestBody(qualifier mapping.SupplierRateReference) []b
 	vehRentalCore := ota.VehRentalCore{
 		PickUpDateTime: m.params.PickUp.DateTime.Format(schema.DateTimeFormat),
 		ReturnDateTime: m.params.DropOff.DateTime.Format(schema.DateTimeFormat),
-		PickUpLocation: ota.Location{
+		PickUpLocation: &ota.Location{
 			LocationCode: m.params.PickUp.Code,
 		},
 	}
@@ -141,7 +141,7 @@ func (m *modifyRequest) requestBody(qualifier mapping.SupplierRateReference) []b
 		XmlnsXsi:          "http://www.w3.org/2001/XMLSchema-instance",
 		XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehModifyRQ.xsd",
 		Version:           "1.008",
-		POS:               ota.NewPOS(mapping.MappedResidenceCountry(m.configuration, m.params.Customer.ResidenceCountry), m.configuration, m.params.BrokerReference),
+		POS:               mapping.NewPOS(mapping.MappedResidenceCountry(m.configuration, m.params.Customer.ResidenceCountry), m.configuration, m.params.BrokerReference),
 		VehModifyRQCore: ota.VehModifyRQCore{
 			Status:     "Confirmed",
 			ModifyType: "Book",
@@ -177,10 +177,10 @@ func (m *modifyRequest) requestBody(qualifier mapping.SupplierRateReference) []b
 	return xmlString
 }
 
-func (m *modifyRequest) modifyRequest(client *http.Client) (*http.Response, error) {
+func (m *modifyRequest) modifyRequest(ctx context.Context, client *http.Client) (*http.Response, error) {
 	requestBody := m.requestBody(m.supplierRateReference)
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Modify)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Modify)
 	httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodPost, m.configuration.SupplierApiUrl, bytes.NewBuffer(requestBody))
 	httpRequest.Header.Set("Content-Type", "application/xml; charset=utf-8")
 
@@ -192,7 +192,7 @@ func (m *modifyRequest) modifyRequest(client *http.Client) (*http.Response, erro
 	return response, nil
 }
 
-func (m *modifyRequest) Execute(httpTransport *http.Transport) (schema.ModifyResponse, error) {
+func (m *modifyRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.ModifyResponse, error) {
 	modify := schema.ModifyResponse{}
 
 	requestsBucket := schema.NewSupplierRequestsBucket()
@@ -217,7 +217,7 @@ func (m *modifyRequest) Execute(httpTransport *http.Transport) (schema.ModifyRes
 		},
 	}
 
-	response, e := requesting.RequestErrors(m.modifyRequest(client))
+	response, e := requesting.RequestErrors(m.modifyRequest(ctx, client))
 	if e != nil {
 		errorsBucket.AddError(*e)
 		return modify, nil
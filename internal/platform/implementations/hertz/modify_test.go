@@ -13,6 +13,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
 	"github.com/go-redis/redismock/v9"
 	"github.com/rs/zerolog"
@@ -69,7 +70,7 @@ func TestModifyRequest(t *testing.T) {
 				params := modifyParams(test.requestFile, testServer.URL)
 
 				redisClient, _ := redismock.NewClientMock()
-				service := hertz.New(redisClient)
+				service := hertz.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 
 				_, _ = service.ModifyBooking(ctx, params, &log)
@@ -89,7 +90,7 @@ func TestModifyRequest(t *testing.T) {
 		params := modifyParams("./testdata/modify/modify_request_connection.json", testServer.URL)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		modifyResponse, _ := service.ModifyBooking(ctx, params, &log)
@@ -111,7 +112,7 @@ func TestModifyRequest(t *testing.T) {
 		params := modifyParams("./testdata/modify/modify_request_connection_valid.json", testServer.URL)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.ModifyResponse, 1)
 
@@ -141,7 +142,7 @@ func TestModifyRequest(t *testing.T) {
 		params := modifyParams("./testdata/modify/modify_request_connection_valid.json", testServer.URL)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		modifyResponse, _ := service.ModifyBooking(ctx, params, &log)
@@ -165,7 +166,7 @@ func TestModifyRequest(t *testing.T) {
 		params := modifyParams("./testdata/modify/modify_request_connection_valid.json", testServer.URL)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		modifyResponse, _ := service.ModifyBooking(ctx, params, &log)
@@ -189,7 +190,7 @@ func TestModifyRequest(t *testing.T) {
 		params := modifyParams("./testdata/modify/modify_request_connection_valid.json", testServer.URL)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		modifyResponse, _ := service.ModifyBooking(ctx, params, &log)
@@ -13,9 +13,9 @@ import (
 	"time"
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/mapping"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
 
@@ -64,7 +64,7 @@ func (q *quoteRequest) requestBody(vehicleClass string) []byte {
 		XmlnsXsi:          "http://www.w3.org/2001/XMLSchema-instance",
 		XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehResRS.xsd",
 		Version:           "1.008",
-		POS:               ota.NewPOS(mapping.MappedResidenceCountry(q.configuration, q.params.ResidenceCountry), q.configuration, ""),
+		POS:               mapping.NewPOS(mapping.MappedResidenceCountry(q.configuration, q.params.ResidenceCountry), q.configuration, ""),
 		VehModifyRQCore: ota.VehModifyRQCore{
 			Status:     "Confirmed",
 			ModifyType: "Quote",
@@ -75,7 +75,7 @@ func (q *quoteRequest) requestBody(vehicleClass string) []byte {
 			VehRentalCore: ota.VehRentalCore{
 				PickUpDateTime: pickUpDateTime.Format(schema.DateTimeFormat),
 				ReturnDateTime: dropOffDateTime.Format(schema.DateTimeFormat),
-				PickUpLocation: ota.Location{
+				PickUpLocation: &ota.Location{
 					LocationCode: q.params.PickUp.Code,
 				},
 			},
@@ -123,7 +123,7 @@ func (r *quoteRequest) parseQuote(modify *ota.VehModifyRS, pricedEquips []ota.Pr
 
 	taxMultiplier, taxCharge, taxIsPartOfTheVehiclePrice := reservation.VehSegmentCore.RentalRate.VehicleCharges.TaxCharge(
 		r.params,
-		r.configuration,
+		mapping.Pricing(r.configuration),
 		reservation.VehSegmentInfo.PaymentRules.PaymentRule,
 	)
 
@@ -137,14 +137,14 @@ func (r *quoteRequest) parseQuote(modify *ota.VehModifyRS, pricedEquips []ota.Pr
 		taxMultiplier,
 		reservation.VehSegmentInfo.PaymentRules.PaymentRule,
 		r.params,
-		r.configuration,
+		mapping.Pricing(r.configuration),
 	)
 
 	vehiclePrice.Amount += schema.RoundedFloat(coveragePricePartOfVehiclePrice)
 
 	fees := reservation.VehSegmentCore.Fees.Fees(
 		r.params,
-		r.configuration,
+		mapping.Pricing(r.configuration),
 		reservation.VehSegmentInfo.PaymentRules.PaymentRule,
 	)
 
@@ -177,9 +177,9 @@ func (r *quoteRequest) parseQuote(modify *ota.VehModifyRS, pricedEquips []ota.Pr
 		SmallSuitcases:        &reservation.VehSegmentCore.Vehicle.BaggageQuantity,
 		Doors:                 &reservation.VehSegmentCore.Vehicle.VehType.DoorCount,
 		Seats:                 &reservation.VehSegmentCore.Vehicle.PassengerQuantity,
-		TransmissionType:      mapping.Transmission(reservation.VehSegmentCore.Vehicle.TransmissionType),
-		FuelType:              mapping.FuelType(reservation.VehSegmentCore.Vehicle.FuelType),
-		DriveType:             mapping.DriveType(reservation.VehSegmentCore.Vehicle.DriveType),
+		TransmissionType:      ota.Transmission(reservation.VehSegmentCore.Vehicle.TransmissionType),
+		FuelType:              ota.FuelType(reservation.VehSegmentCore.Vehicle.FuelType),
+		DriveType:             ota.DriveType(reservation.VehSegmentCore.Vehicle.DriveType),
 		Mileage:               &mileage,
 	}
 
@@ -13,9 +13,10 @@ import (
 	"time"
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -69,7 +70,7 @@ func quoteParamsTemplate(configuration schema.HertzConfiguration) schema.RatesRe
 }
 
 func quoteRates(params schema.RatesRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.RatesResponse, error) {
-	service := hertz.New(redisClient)
+	service := hertz.New(caching.NewRedisCache(redisClient))
 	ctx := context.Background()
 	return service.GetRates(ctx, params, log)
 }
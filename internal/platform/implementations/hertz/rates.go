@@ -13,10 +13,11 @@ import (
 	"time"
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/mapping"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
 	"github.com/rs/zerolog"
@@ -101,13 +102,13 @@ func (r *ratesRequest) ratesRequestBody() ota.VehAvailRateRQ {
 		XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehAvailRateRQ.xsd",
 		Version:           "1.008",
 		MaxResponses:      maxResponses,
-		POS:               ota.NewPOS(mapping.MappedResidenceCountry(r.configuration, r.params.ResidenceCountry), r.configuration, ""),
+		POS:               mapping.NewPOS(mapping.MappedResidenceCountry(r.configuration, r.params.ResidenceCountry), r.configuration, ""),
 		VehAvailRQCore: ota.VehAvailRQCore{
 			Status: "All",
 			VehRentalCore: ota.VehRentalCore{
 				PickUpDateTime: pickUpDateTime.Format(schema.DateTimeFormat),
 				ReturnDateTime: dropOffDateTime.Format(schema.DateTimeFormat),
-				PickUpLocation: ota.Location{
+				PickUpLocation: &ota.Location{
 					LocationCode: r.params.PickUp.Code,
 				},
 				ReturnLocation: &ota.Location{
@@ -158,7 +159,7 @@ func (r *ratesRequest) parseVehicle(vehAvail ota.VehAvail, pricedEquips []ota.Pr
 
 	taxMultiplier, taxCharge, taxIsPartOfTheVehiclePrice := vehAvail.VehAvailCore.RentalRate.VehicleCharges.TaxCharge(
 		r.params,
-		r.configuration,
+		mapping.Pricing(r.configuration),
 		vehAvail.VehAvailInfo.PaymentRules.PaymentRule,
 	)
 
@@ -172,12 +173,12 @@ func (r *ratesRequest) parseVehicle(vehAvail ota.VehAvail, pricedEquips []ota.Pr
 		taxMultiplier,
 		vehAvail.VehAvailInfo.PaymentRules.PaymentRule,
 		r.params,
-		r.configuration,
+		mapping.Pricing(r.configuration),
 	)
 
 	vehiclePrice.Amount = schema.RoundedFloat(float64(vehiclePrice.Amount) + coveragePricePartOfVehiclePrice)
 
-	fees := vehAvail.VehAvailCore.Fees.Fees(r.params, r.configuration, vehAvail.VehAvailInfo.PaymentRules.PaymentRule)
+	fees := vehAvail.VehAvailCore.Fees.Fees(r.params, mapping.Pricing(r.configuration), vehAvail.VehAvailInfo.PaymentRules.PaymentRule)
 
 	extras := make([]schema.ExtraOrFee, len(pricedEquips))
 
@@ -208,9 +209,9 @@ func (r *ratesRequest) parseVehicle(vehAvail ota.VehAvail, pricedEquips []ota.Pr
 		SmallSuitcases:        &vehAvail.VehAvailCore.Vehicle.BaggageQuantity,
 		Doors:                 &vehAvail.VehAvailCore.Vehicle.VehType.DoorCount,
 		Seats:                 &vehAvail.VehAvailCore.Vehicle.PassengerQuantity,
-		TransmissionType:      mapping.Transmission(vehAvail.VehAvailCore.Vehicle.TransmissionType),
-		FuelType:              mapping.FuelType(vehAvail.VehAvailCore.Vehicle.FuelType),
-		DriveType:             mapping.DriveType(vehAvail.VehAvailCore.Vehicle.DriveType),
+		TransmissionType:      ota.Transmission(vehAvail.VehAvailCore.Vehicle.TransmissionType),
+		FuelType:              ota.FuelType(vehAvail.VehAvailCore.Vehicle.FuelType),
+		DriveType:             ota.DriveType(vehAvail.VehAvailCore.Vehicle.DriveType),
 		Mileage:               &mileage,
 	}
 
@@ -237,7 +238,7 @@ func (r *ratesRequest) ratesRequest(
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodPost, r.configuration.SupplierApiUrl, bytes.NewBuffer([]byte(requestBody)))
 	httpRequest.Header.Set("Content-Type", "application/xml; charset=utf-8")
 
-	go func() {
+	lifecycle.Go(ctx, "hertz:rates", func() {
 		defer r.recoverPanic(errChannel)
 
 		rs, e := requesting.RequestErrors(client.Do(httpRequest))
@@ -264,7 +265,7 @@ func (r *ratesRequest) ratesRequest(
 		}
 
 		resChannel <- otaRatesResponse
-	}()
+	})
 }
 
 func (r *ratesRequest) extrasCacheKey() string {
@@ -281,7 +282,11 @@ func (r *ratesRequest) extrasFromCache() ([]ota.PricedEquip, bool) {
 	var pricedEquip []ota.PricedEquip
 
 	// timeouts are enforced by the client
-	ok := r.cache.Fetch(context.Background(), r.extrasCacheKey(), &pricedEquip)
+	ok, err := r.cache.Fetch(context.Background(), r.extrasCacheKey(), &pricedEquip)
+	if err != nil {
+		r.logger.Warn().Err(err).Str("label", "cache").Msg("Unable to fetch extras from cache")
+	}
+
 	if !ok {
 		return pricedEquip, false
 	}
@@ -307,7 +312,7 @@ func (r *ratesRequest) extrasRequest(
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodPost, r.configuration.SupplierApiUrl, bytes.NewBuffer([]byte(requestBody)))
 	httpRequest.Header.Set("Content-Type", "application/xml; charset=utf-8")
 
-	go func() {
+	lifecycle.Go(ctx, "hertz:extras", func() {
 		defer r.recoverPanic(errChannel)
 
 		var pricedEquips []ota.PricedEquip
@@ -346,7 +351,7 @@ func (r *ratesRequest) extrasRequest(
 		}
 
 		resChannel <- pricedEquips
-	}()
+	})
 }
 
 func (r *ratesRequest) Extras() []ota.PricedEquip {
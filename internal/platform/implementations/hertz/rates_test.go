@@ -14,9 +14,10 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/mapping"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/hertz/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"github.com/go-redis/redismock/v9"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -119,7 +120,7 @@ func defaultSupplierRatesRequest() ota.VehAvailRateRQ {
 			VehRentalCore: ota.VehRentalCore{
 				PickUpDateTime: "2023-07-10T10:00:00",
 				ReturnDateTime: "2023-07-17T10:00:00",
-				PickUpLocation: ota.Location{
+				PickUpLocation: &ota.Location{
 					LocationCode: "QRY",
 				},
 				ReturnLocation: &ota.Location{
@@ -176,7 +177,7 @@ func defaultSupplierExtrasRequest() ota.VehAvailRateRQ {
 			VehRentalCore: ota.VehRentalCore{
 				PickUpDateTime: "2023-07-10T10:00:00",
 				ReturnDateTime: "2023-07-17T10:00:00",
-				PickUpLocation: ota.Location{
+				PickUpLocation: &ota.Location{
 					LocationCode: "QRY",
 				},
 				ReturnLocation: &ota.Location{
@@ -638,12 +639,12 @@ func TestRatesRequest(t *testing.T) {
 				}(),
 				expectedRatesRequest: func() ota.VehAvailRateRQ {
 					r := defaultSupplierRatesRequest()
-					r.POS = ota.NewPOS("BR", ratesDefaultConfiguration(), "")
+					r.POS = mapping.NewPOS("BR", ratesDefaultConfiguration(), "")
 					return r
 				}(),
 				expectedExtrasRequest: func() ota.VehAvailRateRQ {
 					r := defaultSupplierExtrasRequest()
-					r.POS = ota.NewPOS("BR", ratesDefaultConfiguration(), "")
+					r.POS = mapping.NewPOS("BR", ratesDefaultConfiguration(), "")
 					return r
 				}(),
 			},
@@ -657,12 +658,12 @@ func TestRatesRequest(t *testing.T) {
 				}(),
 				expectedRatesRequest: func() ota.VehAvailRateRQ {
 					r := defaultSupplierRatesRequest()
-					r.POS = ota.NewPOS("GB", ratesDefaultConfiguration(), "")
+					r.POS = mapping.NewPOS("GB", ratesDefaultConfiguration(), "")
 					return r
 				}(),
 				expectedExtrasRequest: func() ota.VehAvailRateRQ {
 					r := defaultSupplierExtrasRequest()
-					r.POS = ota.NewPOS("GB", ratesDefaultConfiguration(), "")
+					r.POS = mapping.NewPOS("GB", ratesDefaultConfiguration(), "")
 					return r
 				}(),
 			},
@@ -703,7 +704,7 @@ func TestRatesRequest(t *testing.T) {
 				params := mergeRatesParamsAndConfiguration(test.requestParams, test.configuration)
 
 				redisClient, _ := redismock.NewClientMock()
-				service := hertz.New(redisClient)
+				service := hertz.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 				service.GetRates(ctx, params, &log)
 			})
@@ -861,7 +862,7 @@ func TestRatesRequest(t *testing.T) {
 				params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), test.configuration)
 
 				redisClient, _ := redismock.NewClientMock()
-				service := hertz.New(redisClient)
+				service := hertz.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 				rates, err := service.GetRates(ctx, params, &log)
 
@@ -906,7 +907,7 @@ func TestRatesRequest(t *testing.T) {
 
 		redisClient, _ := redismock.NewClientMock()
 
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		response, err := service.GetRates(ctx, params, &log)
@@ -937,7 +938,7 @@ func TestRatesRequest(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(p, configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		ratesResponse, _ := service.GetRates(ctx, params, &log)
@@ -959,7 +960,7 @@ func TestRatesRequest(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.RatesResponse, 2)
 
@@ -989,7 +990,7 @@ func TestRatesRequest(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		ratesResponse, _ := service.GetRates(ctx, params, &log)
@@ -1026,7 +1027,7 @@ func TestRatesRequest(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		ratesResponse, _ := service.GetRates(ctx, params, &log)
@@ -1063,7 +1064,7 @@ func TestRatesRequest(t *testing.T) {
 		params := mergeRatesParamsAndConfiguration(ratesDefaultParams(), configuration)
 
 		redisClient, _ := redismock.NewClientMock()
-		service := hertz.New(redisClient)
+		service := hertz.New(caching.NewRedisCache(redisClient))
 		ctx := context.Background()
 
 		ratesResponse, _ := service.GetRates(ctx, params, &log)
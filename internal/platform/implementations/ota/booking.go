@@ -0,0 +1,161 @@
+package ota
+
+import (
+	"context"
+	"net/http"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/ota/mapping"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	messages "bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+)
+
+type bookingRequest struct {
+	params                schema.BookingRequestParams
+	configuration         schema.OtaConfiguration
+	supplierRateReference mapping.SupplierRateReference
+	logger                *zerolog.Logger
+}
+
+func (b *bookingRequest) requestBody() messages.SoapBody {
+	var paymentPref *messages.RentalPaymentPref = nil
+	if converting.Unwrap(b.configuration.SendVoucher) {
+		paymentPref = &messages.RentalPaymentPref{
+			Voucher: &messages.Voucher{
+				SeriesCode: b.params.BrokerReference,
+			},
+		}
+	}
+
+	var reference *messages.Reference = nil
+	if b.supplierRateReference.FromRates != "" {
+		reference = &messages.Reference{
+			Type: "16",
+			ID:   b.supplierRateReference.FromRates,
+		}
+	}
+
+	extrasAndFees := []schema.BookingExtraOrFee{}
+	if b.params.ExtrasAndFees != nil {
+		extrasAndFees = *b.params.ExtrasAndFees
+	}
+
+	extras := make([]messages.SpecialEquipPref, len(extrasAndFees))
+	for i, extra := range extrasAndFees {
+		extras[i] = messages.SpecialEquipPref{
+			EquipType: extra.Code,
+			Quantity:  converting.Unwrap(extra.Quantity),
+		}
+	}
+
+	var telephone *messages.Telephone = nil
+	if b.params.Customer.Phone != "" {
+		telephone = &messages.Telephone{
+			PhoneNumber:   b.params.Customer.Phone,
+			PhoneTechType: 1,
+		}
+	}
+
+	var tourInfo *messages.TourInfo = nil
+	if b.configuration.TourNumber != nil {
+		tourInfo = &messages.TourInfo{
+			TourNumber: *b.configuration.TourNumber,
+		}
+	}
+
+	comments := ""
+	if b.params.Comments != nil && b.params.Comments.Customer != nil {
+		comments = *b.params.Comments.Customer
+	}
+
+	return messages.SoapBody{
+		VehResRQ: &messages.VehResRQ{
+			Xmlns:    "http://www.opentravel.org/OTA/2003/05",
+			XmlnsXsi: "http://www.w3.org/2001/XMLSchema-instance",
+			Version:  mapping.Versions(b.configuration).VehRes,
+			Target:   converting.Unwrap(b.configuration.Target),
+			POS:      mapping.NewPOS(b.configuration),
+			VehResRQCore: messages.VehResRQCore{
+				Status: "All",
+				VehRentalCore: messages.VehRentalCore{
+					PickUpDateTime: b.params.PickUp.DateTime.Format(schema.DateTimeFormat),
+					ReturnDateTime: b.params.DropOff.DateTime.Format(schema.DateTimeFormat),
+					PickUpLocation: &messages.Location{
+						LocationCode: b.params.PickUp.Code,
+					},
+					ReturnLocation: &messages.Location{
+						LocationCode: b.params.DropOff.Code,
+					},
+				},
+				Customer: messages.BookingCustomer{
+					Primary: messages.BookingPrimary{
+						PersonName: messages.PersonName{
+							GivenName: converting.LatinCharacters(b.params.Customer.FirstName),
+							Surname:   converting.LatinCharacters(b.params.Customer.LastName),
+						},
+						Telephone: telephone,
+						Email:     string(b.params.Customer.Email),
+					},
+				},
+				SpecialEquipPrefs: &messages.SpecialEquipPrefs{
+					SpecialEquipPref: extras,
+				},
+			},
+			VehResRQInfo: messages.VehResRQInfo{
+				SpecialReqPref:    converting.LatinCharacters(comments),
+				RentalPaymentPref: paymentPref,
+				Reference:         reference,
+				TourInfo:          tourInfo,
+			},
+		},
+	}
+}
+
+func (b *bookingRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingResponse, error) {
+	booking := schema.BookingResponse{}
+
+	requestsBucket := schema.NewSupplierRequestsBucket()
+	errorsBucket := schema.NewErrorsBucket()
+
+	booking.SupplierRequests = requestsBucket.SupplierRequests()
+	booking.Errors = errorsBucket.Errors()
+	booking.Status = schema.BookingResponseStatusFAILED
+
+	timeout := b.params.Timeouts.Default
+	if b.params.Timeouts.Booking != nil {
+		timeout = *b.params.Timeouts.Booking
+	}
+
+	client := newClient(timeout, httpTransport, b.logger, &requestsBucket)
+
+	var vehResRS messages.VehResRS
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Booking)
+	if e := exchange(c, client, b.configuration, b.requestBody(), &vehResRS); e != nil {
+		errorsBucket.AddError(*e)
+		return booking, nil
+	}
+
+	if message := vehResRS.ErrorMessage(); message != "" {
+		errorsBucket.AddError(schema.NewSupplierError(message))
+		return booking, nil
+	}
+
+	confirmation := vehResRS.VehResRSCore.VehReservation.VehSegmentCore.ConfID.ID
+	if confirmation == "" {
+		errorsBucket.AddError(schema.NewSupplierError("supplier returned no confirmation"))
+		return booking, nil
+	}
+
+	supplierData := mapping.SupplierData{
+		LastName:         b.params.Customer.LastName,
+		ResidenceCountry: b.params.Customer.ResidenceCountry,
+	}
+
+	booking.Status = schema.BookingResponseStatusOK
+	booking.SupplierBookingReference = &confirmation
+	booking.SupplierData = supplierData.AsMap()
+
+	return booking, nil
+}
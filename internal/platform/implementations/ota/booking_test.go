@@ -0,0 +1,98 @@
+package ota_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/errors"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func bookingParams(configuration schema.OtaConfiguration) schema.BookingRequestParams {
+	pickUp := time.Date(2027, 1, 10, 10, 0, 0, 0, time.UTC)
+
+	b, _ := json.Marshal(configuration)
+
+	var cp schema.BookingRequestParams_Configuration
+	json.Unmarshal(b, &cp)
+
+	return schema.BookingRequestParams{
+		PickUp: schema.RequestBranchWithTimeZone{
+			Code:     "MUC",
+			Country:  "DE",
+			DateTime: pickUp,
+		},
+		DropOff: schema.RequestBranchWithTimeZone{
+			Code:     "MUC",
+			Country:  "DE",
+			DateTime: pickUp.AddDate(0, 0, 7),
+		},
+		BrokerReference: "B123",
+		Customer: schema.Customer{
+			FirstName:        "Max",
+			LastName:         "Muster",
+			Email:            "max@example.com",
+			ResidenceCountry: "DE",
+		},
+		ExtrasAndFees: &[]schema.BookingExtraOrFee{
+			{Code: "7", Quantity: converting.PointerToValue(1)},
+		},
+		SupplierRateReference: `{"fromRates":"LRV0IT41SV35543-6401","estimatedTotalAmount":"863.86","estimatedTotalAmountCurrency":"EUR"}`,
+		Timeouts:              schema.Timeouts{Default: 8000},
+		Configuration:         cp,
+	}
+}
+
+func createBooking(params schema.BookingRequestParams) (schema.BookingResponse, error) {
+	log := zerolog.Nop()
+
+	return service().CreateBooking(context.Background(), params, &log)
+}
+
+func TestBookingRequest(t *testing.T) {
+	t.Run("should book the rate of the reference", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SendVoucher = converting.PointerToValue(true)
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("booking/supplier_response_default.xml"), func(r *http.Request, body string) {
+			assert.Contains(t, body, `<OTA_VehResRQ xmlns="http://www.opentravel.org/OTA/2003/05" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" Version="1.008" Target="Test">`)
+			assert.Contains(t, body, `<Reference Type="16" ID="LRV0IT41SV35543-6401"></Reference>`)
+			assert.Contains(t, body, `<Voucher SeriesCode="B123"></Voucher>`)
+			assert.Contains(t, body, `<SpecialEquipPref EquipType="7" Quantity="1"></SpecialEquipPref>`)
+		}).URL
+
+		booking, err := createBooking(bookingParams(configuration))
+
+		assert.Nil(t, err)
+		assert.Empty(t, *booking.Errors)
+		assert.Equal(t, schema.BookingResponseStatusOK, booking.Status)
+		assert.Equal(t, "D48730916F3", *booking.SupplierBookingReference)
+		assert.Equal(t, map[string]interface{}{"lastName": "Muster", "residenceCountry": "DE"}, *booking.SupplierData)
+	})
+
+	t.Run("should fail on errors of the supplier", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("booking/supplier_response_errors.xml"), nil).URL
+
+		booking, err := createBooking(bookingParams(configuration))
+
+		assert.Nil(t, err)
+		assert.Equal(t, schema.BookingResponseStatusFAILED, booking.Status)
+		assert.Len(t, *booking.Errors, 1)
+		assert.Equal(t, "CAR TYPE NOT AVAILABLE", (*booking.Errors)[0].Message)
+	})
+
+	t.Run("should reject invalid rate references", func(t *testing.T) {
+		params := bookingParams(defaultConfiguration())
+		params.SupplierRateReference = "invalid"
+
+		_, err := createBooking(params)
+
+		assert.Equal(t, errors.ErrorInvalidRateReference, err)
+	})
+}
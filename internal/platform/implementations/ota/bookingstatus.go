@@ -0,0 +1,74 @@
+package ota
+
+import (
+	"context"
+	"net/http"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/ota/mapping"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	messages "bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+)
+
+type bookingStatusRequest struct {
+	params        schema.BookingStatusRequestParams
+	configuration schema.OtaConfiguration
+	logger        *zerolog.Logger
+}
+
+func (b *bookingStatusRequest) requestBody() messages.SoapBody {
+	return messages.SoapBody{
+		VehRetResRQ: &messages.VehRetResRQ{
+			Xmlns:    "http://www.opentravel.org/OTA/2003/05",
+			XmlnsXsi: "http://www.w3.org/2001/XMLSchema-instance",
+			Version:  mapping.Versions(b.configuration).VehRetRes,
+			Target:   converting.Unwrap(b.configuration.Target),
+			POS:      mapping.NewPOS(b.configuration),
+			VehRetResRQCore: messages.VehRetResRQCore{
+				UniqueID: messages.UniqueID{
+					Type: "14",
+					ID:   b.params.SupplierBookingReference,
+				},
+				PersonName: messages.PersonName{
+					Surname: converting.Unwrap(b.configuration.LastName),
+				},
+			},
+		},
+	}
+}
+
+func (b *bookingStatusRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingStatusResponse, error) {
+	bookingStatus := schema.BookingStatusResponse{}
+
+	requestsBucket := schema.NewSupplierRequestsBucket()
+	errorsBucket := schema.NewErrorsBucket()
+
+	bookingStatus.SupplierRequests = requestsBucket.SupplierRequests()
+	bookingStatus.Errors = errorsBucket.Errors()
+	bookingStatus.Status = schema.BookingStatusResponseStatusFAILED
+
+	client := newClient(b.params.Timeouts.Default, httpTransport, b.logger, &requestsBucket)
+
+	var vehRetResRS messages.VehRetResRS
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.BookingStatus)
+	if e := exchange(c, client, b.configuration, b.requestBody(), &vehRetResRS); e != nil {
+		errorsBucket.AddError(*e)
+		return bookingStatus, nil
+	}
+
+	if message := vehRetResRS.ErrorMessage(); message != "" {
+		errorsBucket.AddError(schema.NewSupplierError(message))
+		return bookingStatus, nil
+	}
+
+	confirmation := vehRetResRS.VehRetResRSCore.VehReservation.VehSegmentCore.ConfID.ID
+	bookingStatus.SupplierBookingReference = &confirmation
+
+	if confirmation != "" {
+		bookingStatus.Status = schema.BookingStatusResponseStatusOK
+	}
+
+	return bookingStatus, nil
+}
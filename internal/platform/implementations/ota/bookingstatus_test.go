@@ -0,0 +1,40 @@
+package ota_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingStatusRequest(t *testing.T) {
+	log := zerolog.Nop()
+
+	t.Run("should confirm bookings the supplier finds", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("bookingstatus/supplier_response_default.xml"), func(r *http.Request, body string) {
+			assert.Contains(t, body, "<OTA_VehRetResRQ")
+			assert.Contains(t, body, `<UniqueID Type="14" ID="D48730916F3"></UniqueID>`)
+		}).URL
+
+		b, _ := json.Marshal(configuration)
+
+		var cp schema.BookingStatusRequestParams_Configuration
+		json.Unmarshal(b, &cp)
+
+		bookingStatus, err := service().GetBookingStatus(context.Background(), schema.BookingStatusRequestParams{
+			SupplierBookingReference: "D48730916F3",
+			Timeouts:                 schema.Timeouts{Default: 8000},
+			Configuration:            cp,
+		}, &log)
+
+		assert.Nil(t, err)
+		assert.Empty(t, *bookingStatus.Errors)
+		assert.Equal(t, schema.BookingStatusResponseStatusOK, bookingStatus.Status)
+		assert.Equal(t, "D48730916F3", *bookingStatus.SupplierBookingReference)
+	})
+}
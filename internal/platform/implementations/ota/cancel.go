@@ -0,0 +1,98 @@
+package ota
+
+import (
+	"context"
+	"net/http"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/ota/mapping"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	messages "bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+)
+
+type cancelRequest struct {
+	params        schema.CancelRequestParams
+	configuration schema.OtaConfiguration
+	logger        *zerolog.Logger
+}
+
+func (c *cancelRequest) requestBody() messages.SoapBody {
+	return messages.SoapBody{
+		VehCancelRQ: &messages.VehCancelRQ{
+			Xmlns:    "http://www.opentravel.org/OTA/2003/05",
+			XmlnsXsi: "http://www.w3.org/2001/XMLSchema-instance",
+			Version:  mapping.Versions(c.configuration).VehCancel,
+			Target:   converting.Unwrap(c.configuration.Target),
+			POS:      mapping.NewPOS(c.configuration),
+			VehCancelRQCore: messages.VehCancelRQCore{
+				CancelType: "Book",
+				UniqueID: &messages.UniqueID{
+					Type: "14",
+					ID:   c.params.SupplierBookingReference,
+				},
+				PersonName: &messages.CancelPersonName{
+					Surname: converting.Unwrap(c.configuration.LastName),
+				},
+			},
+		},
+	}
+}
+
+// alreadyCancelled tells the answers of bookings cancelled before, by the OTA
+// error code 95 (booking already cancelled)
+func (c *cancelRequest) alreadyCancelled(errors messages.Errors) bool {
+	for _, e := range errors.Error {
+		if e.Code == "95" || e.Code == "095" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *cancelRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.CancelResponse, error) {
+	cancel := schema.CancelResponse{}
+
+	requestsBucket := schema.NewSupplierRequestsBucket()
+	errorsBucket := schema.NewErrorsBucket()
+
+	cancel.SupplierRequests = requestsBucket.SupplierRequests()
+	cancel.Errors = errorsBucket.Errors()
+
+	timeout := c.params.Timeouts.Default
+	if c.params.Timeouts.Cancel != nil {
+		timeout = *c.params.Timeouts.Cancel
+	}
+
+	client := newClient(timeout, httpTransport, c.logger, &requestsBucket)
+
+	var vehCancelRS messages.VehCancelRS
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Cancel)
+	if e := exchange(ctx, client, c.configuration, c.requestBody(), &vehCancelRS); e != nil {
+		errorsBucket.AddError(*e)
+		return cancel, nil
+	}
+
+	var status schema.CancelResponseStatus
+
+	switch {
+	case c.alreadyCancelled(vehCancelRS.Errors):
+		status = schema.CancelResponseStatusOK
+	case vehCancelRS.VehCancelRSCore.CancelStatus == messages.CoreCancelStatusCancelled:
+		status = schema.CancelResponseStatusOK
+	default:
+		message := vehCancelRS.ErrorMessage()
+		if message == "" {
+			message = "supplier did not confirm the cancellation"
+		}
+
+		errorsBucket.AddError(schema.NewSupplierError(message))
+		status = schema.CancelResponseStatusFAILED
+	}
+
+	cancel.Status = &status
+
+	return cancel, nil
+}
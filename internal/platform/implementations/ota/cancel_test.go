@@ -0,0 +1,61 @@
+package ota_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func cancelParams(configuration schema.OtaConfiguration) schema.CancelRequestParams {
+	b, _ := json.Marshal(configuration)
+
+	var cp schema.CancelRequestParams_Configuration
+	json.Unmarshal(b, &cp)
+
+	return schema.CancelRequestParams{
+		SupplierBookingReference: "D48730916F3",
+		BrokerReference:          "B123",
+		Timeouts:                 schema.Timeouts{Default: 8000},
+		Configuration:            cp,
+	}
+}
+
+func cancelBooking(configuration schema.OtaConfiguration) (schema.CancelResponse, error) {
+	log := zerolog.Nop()
+
+	return service().CancelBooking(context.Background(), cancelParams(configuration), &log)
+}
+
+func TestCancelRequest(t *testing.T) {
+	tests := []struct {
+		name             string
+		supplierResponse string
+		expectedStatus   schema.CancelResponseStatus
+		expectedErrors   int
+	}{
+		{"cancelled", "cancel/supplier_response_default.xml", schema.CancelResponseStatusOK, 0},
+		{"already cancelled", "cancel/supplier_response_already_cancelled.xml", schema.CancelResponseStatusOK, 0},
+		{"failed", "cancel/supplier_response_failed.xml", schema.CancelResponseStatusFAILED, 1},
+	}
+
+	for _, test := range tests {
+		t.Run("should map "+test.name+" responses", func(t *testing.T) {
+			configuration := defaultConfiguration()
+			configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture(test.supplierResponse), func(r *http.Request, body string) {
+				assert.Contains(t, body, `<UniqueID Type="14" ID="D48730916F3"></UniqueID>`)
+				assert.Contains(t, body, `<Surname>MUSTER</Surname>`)
+			}).URL
+
+			cancel, err := cancelBooking(configuration)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatus, *cancel.Status)
+			assert.Len(t, *cancel.Errors, test.expectedErrors)
+		})
+	}
+}
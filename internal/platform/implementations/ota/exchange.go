@@ -0,0 +1,86 @@
+package ota
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/ota/mapping"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	messages "bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+)
+
+type otaResponse interface {
+	ErrorMessage() string
+}
+
+func newClient(timeout int, httpTransport *http.Transport, logger *zerolog.Logger, requestsBucket requesting.RequestBucket) *http.Client {
+	return &http.Client{
+		Timeout: time.Duration(timeout) * time.Millisecond,
+		Transport: &requesting.InterceptorTransport{
+			Transport: httpTransport,
+			Middlewares: []requesting.TransportMiddleware{
+				requesting.NewLoggingTransportMiddleware(logger),
+				requesting.NewBucketTransportMiddleware(requestsBucket),
+			},
+		},
+	}
+}
+
+// requestBody sends the message as it is or wrapped in a SOAP envelope
+func requestBody(configuration schema.OtaConfiguration, body messages.SoapBody) []byte {
+	message := body.Message()
+	if converting.Unwrap(configuration.Envelope) == schema.Soap {
+		message = mapping.NewSoapEnvelope(configuration, body)
+	}
+
+	xmlBytes, _ := xml.MarshalIndent(message, "", "    ")
+
+	return xmlBytes
+}
+
+// exchange posts the message and binds the answer to response. Errors of the
+// OTA response are left to the caller, cancellations tell some of them apart.
+func exchange(
+	ctx context.Context,
+	client *http.Client,
+	configuration schema.OtaConfiguration,
+	body messages.SoapBody,
+	response otaResponse,
+) *schema.SupplierResponseError {
+	httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodPost, configuration.SupplierApiUrl, bytes.NewBuffer(requestBody(configuration, body)))
+	httpRequest.Header.Set("Content-Type", "application/xml; charset=utf-8")
+
+	for name, value := range converting.Unwrap(configuration.HttpHeaders) {
+		httpRequest.Header.Set(name, value)
+	}
+
+	rs, e := requesting.RequestErrors(client.Do(httpRequest))
+	if e != nil {
+		return e
+	}
+	defer rs.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(rs.Body)
+	rs.Body.Close()
+
+	var faultResponse messages.FaultEnvelope
+	_ = xml.Unmarshal(bodyBytes, &faultResponse)
+	if message := faultResponse.FaultMessage(); message != "" {
+		e := schema.NewSupplierError(message)
+		return &e
+	}
+
+	if err := messages.Decode(bodyBytes, response); err != nil {
+		e := schema.NewSupplierError("unable to parse the body")
+		return &e
+	}
+
+	return nil
+}
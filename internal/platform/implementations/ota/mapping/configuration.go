@@ -0,0 +1,114 @@
+package mapping
+
+import (
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+)
+
+const defaultVersion = "1.008"
+
+// MessageVersions holds the Version attribute of every request message
+type MessageVersions struct {
+	VehAvailRate string
+	VehRes       string
+	VehRetRes    string
+	VehCancel    string
+}
+
+func Versions(configuration schema.OtaConfiguration) MessageVersions {
+	versions := schema.OtaMessageVersions{}
+	if configuration.Versions != nil {
+		versions = *configuration.Versions
+	}
+
+	return MessageVersions{
+		VehAvailRate: version(versions.VehAvailRate),
+		VehRes:       version(versions.VehRes),
+		VehRetRes:    version(versions.VehRetRes),
+		VehCancel:    version(versions.VehCancel),
+	}
+}
+
+func version(configured *string) string {
+	if converting.Unwrap(configured) == "" {
+		return defaultVersion
+	}
+
+	return *configured
+}
+
+// NewPOS adds a source per configured requestor id
+func NewPOS(configuration schema.OtaConfiguration) ota.POS {
+	sources := make([]ota.Source, len(configuration.RequestorIds))
+
+	for i, requestorId := range configuration.RequestorIds {
+		sources[i] = ota.Source{
+			ISOCountry:    converting.Unwrap(requestorId.IsoCountry),
+			AgentDutyCode: converting.Unwrap(requestorId.AgentDutyCode),
+			RequestorID: ota.RequestorID{
+				Type: requestorId.Type,
+				ID:   requestorId.Id,
+			},
+		}
+
+		if requestorId.CompanyName != nil || requestorId.CompanyNameContext != nil {
+			sources[i].RequestorID.CompanyName = &ota.CompanyName{
+				Code:        converting.Unwrap(requestorId.CompanyName),
+				CodeContext: converting.Unwrap(requestorId.CompanyNameContext),
+			}
+		}
+	}
+
+	return ota.POS{
+		Source: sources,
+	}
+}
+
+func NewVendorPrefs(configuration schema.OtaConfiguration) *ota.VendorPrefs {
+	vendorCodes := converting.Unwrap(configuration.VendorCodes)
+	if len(vendorCodes) == 0 {
+		return nil
+	}
+
+	prefs := make([]ota.VendorPref, len(vendorCodes))
+	for i, code := range vendorCodes {
+		prefs[i] = ota.VendorPref{
+			Code: code,
+		}
+	}
+
+	return &ota.VendorPrefs{
+		VendorPref: prefs,
+	}
+}
+
+func Pricing(configuration schema.OtaConfiguration) ota.Pricing {
+	return ota.Pricing{
+		FeePayableLocally:           converting.Unwrap(configuration.FeePayableLocally),
+		FpPayFeesLocally:            converting.Unwrap(configuration.FpPayFeesLocally),
+		FpPaynowVehiclePriceWithTax: converting.Unwrap(configuration.FpPaynowVehiclePriceWithTax),
+		PayNowCoverages:             converting.Unwrap(configuration.PayNowCoverages),
+		AddTaxToCoverages:           converting.Unwrap(configuration.AddTaxToCoverages),
+		TaxAllCoverages:             converting.Unwrap(configuration.TaxAllCoverages),
+		TaxExclCoverageCountries:    converting.Unwrap(configuration.TaxExclCoverageCountries),
+		IncludeCoveragesInRate:      converting.Unwrap(configuration.IncludeCoveragesInRate),
+	}
+}
+
+// NewSoapEnvelope wraps the body in a SOAP 1.1 envelope, the header is taken
+// from the configuration as it is
+func NewSoapEnvelope(configuration schema.OtaConfiguration, body ota.SoapBody) ota.SoapEnvelope {
+	envelope := ota.SoapEnvelope{
+		XmlnsSoapEnv: "http://schemas.xmlsoap.org/soap/envelope/",
+		Body:         body,
+	}
+
+	if converting.Unwrap(configuration.SoapHeader) != "" {
+		envelope.Header = ota.SoapHeader{
+			Content: *configuration.SoapHeader,
+		}
+	}
+
+	return envelope
+}
@@ -0,0 +1,92 @@
+package ota
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/errors"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/ota/mapping"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultMaxResponses = 10
+)
+
+// ota talks to any supplier speaking the OTA 2003/05 vehicle messages, the
+// differences between the suppliers are covered by the OtaConfiguration
+type ota struct {
+	cache         *caching.Cacher
+	httpTransport *http.Transport
+}
+
+func (o *ota) GetRates(ctx context.Context, params schema.RatesRequestParams, logger *zerolog.Logger) (schema.RatesResponse, error) {
+	configuration, _ := params.Configuration.AsOtaConfiguration()
+
+	ratesRequest := ratesRequest{
+		params:        params,
+		configuration: configuration,
+		logger:        logger,
+		slowLogger:    slowlog.CreateLogger(logger),
+	}
+
+	return ratesRequest.Execute(ctx, o.httpTransport)
+}
+
+func (o *ota) CreateBooking(ctx context.Context, params schema.BookingRequestParams, logger *zerolog.Logger) (schema.BookingResponse, error) {
+	configuration, _ := params.Configuration.AsOtaConfiguration()
+
+	var supplierRateReference mapping.SupplierRateReference
+	err := json.Unmarshal([]byte(params.SupplierRateReference), &supplierRateReference)
+	if err != nil {
+		return schema.BookingResponse{}, errors.ErrorInvalidRateReference
+	}
+
+	bookingRequest := bookingRequest{
+		params:                params,
+		configuration:         configuration,
+		supplierRateReference: supplierRateReference,
+		logger:                logger,
+	}
+
+	return bookingRequest.Execute(ctx, o.httpTransport)
+}
+
+func (o *ota) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
+	configuration, _ := params.Configuration.AsOtaConfiguration()
+
+	bookingStatus := bookingStatusRequest{
+		params:        params,
+		configuration: configuration,
+		logger:        logger,
+	}
+
+	return bookingStatus.Execute(ctx, o.httpTransport)
+}
+
+func (o *ota) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
+	configuration, _ := params.Configuration.AsOtaConfiguration()
+
+	bookingCancel := cancelRequest{
+		params:        params,
+		configuration: configuration,
+		logger:        logger,
+	}
+
+	return bookingCancel.Execute(ctx, o.httpTransport)
+}
+
+func New(cache *caching.Cacher) *ota {
+	transport := http.DefaultTransport.(*http.Transport)
+	// improves durations a lot
+	transport.DisableKeepAlives = true
+
+	return &ota{
+		cache:         cache,
+		httpTransport: transport,
+	}
+}
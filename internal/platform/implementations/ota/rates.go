@@ -0,0 +1,201 @@
+package ota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/ota/mapping"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	messages "bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
+	"github.com/rs/zerolog"
+)
+
+type ratesRequest struct {
+	params        schema.RatesRequestParams
+	configuration schema.OtaConfiguration
+	logger        *zerolog.Logger
+	slowLogger    slowlog.Logger
+}
+
+func (r *ratesRequest) requestBody() messages.SoapBody {
+	maxResponses := defaultMaxResponses
+	if r.configuration.MaxResponses != nil {
+		maxResponses = *r.configuration.MaxResponses
+	}
+
+	var tourInfo *messages.TourInfo = nil
+	if r.configuration.TourNumber != nil {
+		tourInfo = &messages.TourInfo{
+			TourNumber: *r.configuration.TourNumber,
+		}
+	}
+
+	return messages.SoapBody{
+		VehAvailRateRQ: &messages.VehAvailRateRQ{
+			Xmlns:        "http://www.opentravel.org/OTA/2003/05",
+			XmlnsXsi:     "http://www.w3.org/2001/XMLSchema-instance",
+			Version:      mapping.Versions(r.configuration).VehAvailRate,
+			Target:       converting.Unwrap(r.configuration.Target),
+			MaxResponses: maxResponses,
+			POS:          mapping.NewPOS(r.configuration),
+			VehAvailRQCore: messages.VehAvailRQCore{
+				Status: "All",
+				VehRentalCore: messages.VehRentalCore{
+					PickUpDateTime: r.params.PickUp.DateTime.Format(schema.DateTimeFormat),
+					ReturnDateTime: r.params.DropOff.DateTime.Format(schema.DateTimeFormat),
+					PickUpLocation: &messages.Location{
+						LocationCode: r.params.PickUp.Code,
+					},
+					ReturnLocation: &messages.Location{
+						LocationCode: r.params.DropOff.Code,
+					},
+				},
+				VendorPrefs: mapping.NewVendorPrefs(r.configuration),
+				RateQualifier: messages.RateQualifier{
+					RateQualifier: converting.Unwrap(r.configuration.RateQualifier),
+					TravelPurpose: converting.Unwrap(r.configuration.TravelPurpose),
+				},
+			},
+			VehAvailRQInfo: messages.VehAvailRQInfo{
+				TourInfo: tourInfo,
+			},
+		},
+	}
+}
+
+func parseExtra(pricedEquip messages.PricedEquip, taxMultiplier float64) schema.ExtraOrFee {
+	return schema.ExtraOrFee{
+		Type: schema.EQP,
+		Code: pricedEquip.Equipment.EquipType,
+		Name: pricedEquip.Equipment.EquipType,
+		Price: schema.PriceAmount{
+			Amount:   schema.RoundedFloat(pricedEquip.Charge.Amount * taxMultiplier),
+			Currency: pricedEquip.Charge.CurrencyCode,
+		},
+		IncludedInRate: pricedEquip.Charge.IncludedInRate,
+		PayLocal:       !pricedEquip.Charge.IncludedInRate,
+		Mandatory:      false,
+	}
+}
+
+func (r *ratesRequest) parseVehicle(vehAvail messages.VehAvail) (schema.Vehicle, string) {
+	pricing := mapping.Pricing(r.configuration)
+	paymentRules := vehAvail.VehAvailInfo.PaymentRules.PaymentRule
+
+	qualifier, _ := json.Marshal(mapping.SupplierRateReference{
+		FromRates:                    vehAvail.VehAvailCore.Reference.ID,
+		EstimatedTotalAmount:         fmt.Sprintf("%.2f", vehAvail.VehAvailCore.TotalCharge.EstimatedTotalAmount),
+		EstimatedTotalAmountCurrency: vehAvail.VehAvailCore.TotalCharge.CurrencyCode,
+	})
+
+	vehiclePrice, err := vehAvail.VehAvailCore.TotalCharge.Price(r.params, paymentRules)
+	if err != "" {
+		return schema.Vehicle{}, err
+	}
+
+	mileage := vehAvail.VehAvailCore.RentalRate.RateDistance.Mileage()
+
+	taxMultiplier, taxCharge, taxIsPartOfTheVehiclePrice := vehAvail.VehAvailCore.RentalRate.VehicleCharges.TaxCharge(r.params, pricing, paymentRules)
+	if taxIsPartOfTheVehiclePrice {
+		vehiclePrice.Amount = schema.RoundedFloat(float64(vehiclePrice.Amount) * taxMultiplier)
+	}
+
+	charges := vehAvail.VehAvailCore.RentalRate.VehicleCharges.Charges()
+	coverages, coveragePricePartOfVehiclePrice := vehAvail.VehAvailInfo.PricedCoverages.VehicleCoverages(taxMultiplier, paymentRules, r.params, pricing)
+
+	vehiclePrice.Amount = schema.RoundedFloat(float64(vehiclePrice.Amount) + coveragePricePartOfVehiclePrice)
+
+	fees := vehAvail.VehAvailCore.Fees.Fees(r.params, pricing, paymentRules)
+
+	extrasAndFees := []schema.ExtraOrFee{}
+	if taxCharge != nil {
+		extrasAndFees = append(extrasAndFees, *taxCharge)
+	}
+
+	for _, pricedEquip := range vehAvail.VehAvailCore.PricedEquips.PricedEquip {
+		extrasAndFees = append(extrasAndFees, parseExtra(pricedEquip, taxMultiplier))
+	}
+
+	extrasAndFees = append(extrasAndFees, charges...)
+	extrasAndFees = append(extrasAndFees, fees...)
+	extrasAndFees = append(extrasAndFees, coverages...)
+
+	rateReference := string(qualifier)
+
+	return schema.Vehicle{
+		Name:                  vehAvail.VehAvailCore.Vehicle.VehMakeModel.Name,
+		Class:                 vehAvail.VehAvailCore.Vehicle.VehMakeModel.Code,
+		Price:                 vehiclePrice,
+		SupplierRateReference: &rateReference,
+		ExtrasAndFees:         &extrasAndFees,
+		AcrissCode:            &vehAvail.VehAvailCore.Vehicle.VehMakeModel.Code,
+		HasAirco:              &vehAvail.VehAvailCore.Vehicle.AirConditionInd,
+		Status:                schema.AVAILABLE,
+		SmallSuitcases:        &vehAvail.VehAvailCore.Vehicle.BaggageQuantity,
+		Doors:                 &vehAvail.VehAvailCore.Vehicle.VehType.DoorCount,
+		Seats:                 &vehAvail.VehAvailCore.Vehicle.PassengerQuantity,
+		TransmissionType:      messages.Transmission(vehAvail.VehAvailCore.Vehicle.TransmissionType),
+		FuelType:              messages.FuelType(vehAvail.VehAvailCore.Vehicle.FuelType),
+		DriveType:             messages.DriveType(vehAvail.VehAvailCore.Vehicle.DriveType),
+		Mileage:               &mileage,
+	}, ""
+}
+
+func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.RatesResponse, error) {
+	rates := schema.RatesResponse{
+		Vehicles: []schema.Vehicle{},
+	}
+
+	requestsBucket := schema.NewSupplierRequestsBucket()
+	errorsBucket := schema.NewErrorsBucket()
+
+	rates.SupplierRequests = requestsBucket.SupplierRequests()
+	rates.Errors = errorsBucket.Errors()
+
+	timeout := r.params.Timeouts.Default
+	if r.params.Timeouts.Rates != nil {
+		timeout = *r.params.Timeouts.Rates
+	}
+
+	client := newClient(timeout, httpTransport, r.logger, &requestsBucket)
+
+	r.slowLogger.Start("ota:rates:execute:request")
+	var vehAvailRateRS messages.VehAvailRateRS
+	c := context.WithValue(ctx, schema.RequestingTypeKey, schema.Rates)
+	e := exchange(c, client, r.configuration, r.requestBody(), &vehAvailRateRS)
+	r.slowLogger.Stop("ota:rates:execute:request")
+
+	if e != nil {
+		errorsBucket.AddError(*e)
+		return rates, nil
+	}
+
+	if message := vehAvailRateRS.ErrorMessage(); message != "" {
+		errorsBucket.AddError(schema.NewSupplierError(message))
+		return rates, nil
+	}
+
+	r.slowLogger.Start("ota:rates:execute:mapVehicles")
+	for _, vehAvail := range vehAvailRateRS.VehAvailRSCore.VehVendorAvails.VehVendorAvail.VehAvails.VehAvail {
+		if vehAvail.VehAvailCore.Status != "Available" {
+			continue
+		}
+
+		vehicle, err := r.parseVehicle(vehAvail)
+		if err != "" {
+			errorsBucket.AddError(schema.NewSupplierError(err))
+			continue
+		}
+
+		rates.Vehicles = append(rates.Vehicles, vehicle)
+	}
+	r.slowLogger.Stop("ota:rates:execute:mapVehicles")
+
+	rates.BranchVehicleWhereAt = vehAvailRateRS.VehAvailRSCore.VehVendorAvails.VehVendorAvail.Info.LocationDetails.AdditionalInfo.CounterLocation.Location
+
+	return rates, nil
+}
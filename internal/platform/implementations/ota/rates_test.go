@@ -0,0 +1,219 @@
+package ota_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"github.com/go-redis/redismock/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func fixture(name string) []byte {
+	content, _ := os.ReadFile("./testdata/" + name)
+	return content
+}
+
+func defaultConfiguration() schema.OtaConfiguration {
+	return schema.OtaConfiguration{
+		Envelope:   converting.PointerToValue(schema.Soap),
+		SoapHeader: converting.PointerToValue("<Credentials><UserName>test-user</UserName></Credentials>"),
+		HttpHeaders: &map[string]string{
+			"SOAPAction": "OTA",
+		},
+		RequestorIds: []schema.OtaRequestorId{
+			{
+				Type:          "4",
+				Id:            "T744",
+				CompanyName:   converting.PointerToValue("CP"),
+				IsoCountry:    converting.PointerToValue("DE"),
+				AgentDutyCode: converting.PointerToValue("DUTY"),
+			},
+			{
+				Type: "8",
+				Id:   "ZE",
+			},
+		},
+		VendorCodes: &[]string{"ZE", "ZT"},
+		Versions: &schema.OtaMessageVersions{
+			VehAvailRate: converting.PointerToValue("2.001"),
+		},
+		Target:        converting.PointerToValue("Test"),
+		RateQualifier: converting.PointerToValue("VAUW"),
+		LastName:      converting.PointerToValue("MUSTER"),
+	}
+}
+
+// supplier answers every request with the response and hands the request
+// body to check
+func supplier(t *testing.T, status int, response []byte, check func(r *http.Request, body string)) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if check != nil {
+			check(r, string(body))
+		}
+
+		w.WriteHeader(status)
+		w.Write(response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func ratesParams(configuration schema.OtaConfiguration) schema.RatesRequestParams {
+	pickUp := time.Date(2027, 1, 10, 10, 0, 0, 0, time.UTC)
+
+	b, _ := json.Marshal(configuration)
+
+	var cp schema.RatesRequestParams_Configuration
+	json.Unmarshal(b, &cp)
+
+	return schema.RatesRequestParams{
+		PickUp: schema.RequestBranch{
+			Code:     "MUC",
+			Country:  "DE",
+			DateTime: pickUp,
+		},
+		DropOff: schema.RequestBranch{
+			Code:     "MUC",
+			Country:  "DE",
+			DateTime: pickUp.AddDate(0, 0, 7),
+		},
+		RentalDays: 7,
+		Contract: schema.Contract{
+			Currency:    "EUR",
+			PaymentType: 0,
+		},
+		TaxRate:          19,
+		ResidenceCountry: "DE",
+		Age:              30,
+		Timeouts:         schema.Timeouts{Default: 8000},
+		Configuration:    cp,
+	}
+}
+
+func service() interface {
+	GetRates(context.Context, schema.RatesRequestParams, *zerolog.Logger) (schema.RatesResponse, error)
+	CreateBooking(context.Context, schema.BookingRequestParams, *zerolog.Logger) (schema.BookingResponse, error)
+	GetBookingStatus(context.Context, schema.BookingStatusRequestParams, *zerolog.Logger) (schema.BookingStatusResponse, error)
+	CancelBooking(context.Context, schema.CancelRequestParams, *zerolog.Logger) (schema.CancelResponse, error)
+} {
+	redisClient, _ := redismock.NewClientMock()
+
+	return ota.New(caching.NewRedisCache(redisClient))
+}
+
+func getRates(configuration schema.OtaConfiguration) (schema.RatesResponse, error) {
+	log := zerolog.Nop()
+
+	return service().GetRates(context.Background(), ratesParams(configuration), &log)
+}
+
+func TestRatesRequest(t *testing.T) {
+	t.Run("should send the configured soap envelope", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("rates/supplier_response_default.xml"), func(r *http.Request, body string) {
+			assert.Equal(t, "OTA", r.Header.Get("SOAPAction"))
+			assert.Equal(t, strings.TrimSpace(string(fixture("rates/supplier_request_soap.xml"))), body)
+		}).URL
+
+		_, err := getRates(configuration)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should send plain xml by default", func(t *testing.T) {
+		configuration := schema.OtaConfiguration{
+			RequestorIds: []schema.OtaRequestorId{{Type: "4", Id: "T744"}},
+		}
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("rates/supplier_response_plain.xml"), func(r *http.Request, body string) {
+			assert.Equal(t, strings.TrimSpace(string(fixture("rates/supplier_request_plain.xml"))), body)
+		}).URL
+
+		_, err := getRates(configuration)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should map the available vehicles", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("rates/supplier_response_default.xml"), nil).URL
+
+		rates, err := getRates(configuration)
+
+		assert.Nil(t, err)
+		assert.Empty(t, *rates.Errors)
+		assert.Len(t, *rates.SupplierRequests, 1)
+
+		rates.SupplierRequests = nil
+		actual, _ := json.MarshalIndent(rates, "", "\t")
+
+		assert.Equal(t, strings.TrimSpace(string(fixture("rates/response_default.json"))), string(actual))
+	})
+
+	t.Run("should read responses with and without envelope alike", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("rates/supplier_response_default.xml"), nil).URL
+		soap, _ := getRates(configuration)
+
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("rates/supplier_response_plain.xml"), nil).URL
+		plain, _ := getRates(configuration)
+
+		assert.Equal(t, soap.Vehicles, plain.Vehicles)
+	})
+
+	t.Run("should return the errors of the supplier", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("rates/supplier_response_errors.xml"), nil).URL
+
+		rates, err := getRates(configuration)
+
+		assert.Nil(t, err)
+		assert.Empty(t, rates.Vehicles)
+		assert.Len(t, *rates.Errors, 1)
+		assert.Equal(t, schema.SupplierError, (*rates.Errors)[0].Code)
+		assert.Equal(t, "PICK UP LOCATION NOT SERVED", (*rates.Errors)[0].Message)
+	})
+
+	t.Run("should return soap faults", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, fixture("rates/supplier_fault_response.xml"), nil).URL
+
+		rates, _ := getRates(configuration)
+
+		assert.Len(t, *rates.Errors, 1)
+		assert.Equal(t, "Service temporarily unavailable", (*rates.Errors)[0].Message)
+	})
+
+	t.Run("should return unparsable bodies", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusOK, []byte("<html><body>maintenance</body></html>"), nil).URL
+
+		rates, _ := getRates(configuration)
+
+		assert.Len(t, *rates.Errors, 1)
+		assert.Equal(t, "unable to parse the body", (*rates.Errors)[0].Message)
+	})
+
+	t.Run("should handle status != 200 error", func(t *testing.T) {
+		configuration := defaultConfiguration()
+		configuration.SupplierApiUrl = supplier(t, http.StatusNotFound, nil, nil).URL
+
+		rates, _ := getRates(configuration)
+
+		assert.Len(t, *rates.Errors, 1)
+		assert.Equal(t, "supplier returned status code 404", (*rates.Errors)[0].Message)
+	})
+}
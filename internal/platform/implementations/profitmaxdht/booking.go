@@ -9,9 +9,9 @@ import (
 	"time"
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/mapping"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"github.com/rs/zerolog"
 )
@@ -103,51 +103,46 @@ func (b *bookingRequest) requestBody(qualifier mapping.SupplierRateReference) st
 	}
 
 	xmlString, _ := xml.MarshalIndent(
-		ota.SoapEnvelope{
-			XmlnsSoapEnv:  "http://www.w3.org/2001/12/soap-envelope",
-			XmlnsXsd:      "http://www.w3.org/1999/XMLSchema",
-			XmlnsXsi:      "http://www.w3.org/1999/XMLSchema-instance",
-			SoapEnvHeader: ota.SoapEnvHeaderBuilder(b.configuration),
-			SoapEnvBody: ota.SoapEnvBody{
-				VehResRQ: &ota.VehResRQ{
-					Xmlns:             "http://www.opentravel.org/OTA/2003/05",
-					XmlnsXsi:          "http://www.w3.org/2001/XMLSchema-instance",
-					XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehResRS.xsd",
-					Version:           "1.008",
-					Target:            target,
-					POS:               ota.POSBuiler(b.configuration),
-					VehResRQInfo: ota.VehResRQInfo{
-						SpecialReqPref:    converting.LatinCharacters(comments),
-						RentalPaymentPref: paymentPref,
-						Reference:         reference,
-						TourInfo:          tourInfo,
-					},
-					VehResRQCore: ota.VehResRQCore{
-						VehRentalCore: vehRentalCore,
-						Status:        "All",
-						Customer: ota.BookingCustomer{
-							Primary: ota.BookingPrimary{
-								PersonName: ota.PersonName{
-									GivenName: converting.LatinCharacters(b.params.Customer.FirstName),
-									Surname:   converting.LatinCharacters(b.params.Customer.LastName),
-								},
-								Telephone: telephone,
-								Email:     string(b.params.Customer.Email),
+		mapping.NewSoapEnvelope(b.configuration, ota.SoapBody{
+			VehResRQ: &ota.VehResRQ{
+				Xmlns:             "http://www.opentravel.org/OTA/2003/05",
+				XmlnsXsi:          "http://www.w3.org/2001/XMLSchema-instance",
+				XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehResRS.xsd",
+				Version:           "1.008",
+				Target:            target,
+				POS:               mapping.NewPOS(b.configuration),
+				VehResRQInfo: ota.VehResRQInfo{
+					SpecialReqPref:    converting.LatinCharacters(comments),
+					RentalPaymentPref: paymentPref,
+					Reference:         reference,
+					TourInfo:          tourInfo,
+				},
+				VehResRQCore: ota.VehResRQCore{
+					VehRentalCore: vehRentalCore,
+					Status:        "All",
+					Customer: ota.BookingCustomer{
+						Primary: ota.BookingPrimary{
+							PersonName: ota.PersonName{
+								GivenName: converting.LatinCharacters(b.params.Customer.FirstName),
+								Surname:   converting.LatinCharacters(b.params.Customer.LastName),
 							},
+							Telephone: telephone,
+							Email:     string(b.params.Customer.Email),
 						},
-						SpecialEquipPrefs: &ota.SpecialEquipPrefs{
-							SpecialEquipPref: extras,
-						},
 					},
-				}}}, "", "    ")
+					SpecialEquipPrefs: &ota.SpecialEquipPrefs{
+						SpecialEquipPref: extras,
+					},
+				},
+			}}), "", "    ")
 
 	return string(xmlString)
 }
 
-func (b *bookingRequest) bookingRequest(client *http.Client) (*http.Response, error) {
+func (b *bookingRequest) bookingRequest(ctx context.Context, client *http.Client) (*http.Response, error) {
 	requestBody := b.requestBody(b.supplierRateReference)
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Booking)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Booking)
 	httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodPost, b.configuration.SupplierApiUrl, bytes.NewBuffer([]byte(requestBody)))
 	httpRequest.Header.Set("Content-Type", "application/xml; charset=utf-8")
 
@@ -159,7 +154,7 @@ func (b *bookingRequest) bookingRequest(client *http.Client) (*http.Response, er
 	return response, nil
 }
 
-func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingResponse, error) {
+func (b *bookingRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingResponse, error) {
 	booking := schema.BookingResponse{}
 	var faultResponse ota.FaultEnvelope
 
@@ -186,7 +181,7 @@ func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingR
 		},
 	}
 
-	response, e := requesting.RequestErrors(b.bookingRequest(client))
+	response, e := requesting.RequestErrors(b.bookingRequest(ctx, client))
 	if e != nil {
 		errorsBucket.AddError(*e)
 		return booking, nil
@@ -8,9 +8,10 @@ import (
 	"net/http"
 	"time"
 
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/mapping"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"github.com/rs/zerolog"
 )
@@ -28,38 +29,33 @@ func (b *bookingStatusRequest) requestBody() []byte {
 	}
 
 	xml, _ := xml.MarshalIndent(
-		ota.SoapEnvelope{
-			XmlnsSoapEnv:  "http://www.w3.org/2001/12/soap-envelope",
-			XmlnsXsd:      "http://www.w3.org/1999/XMLSchema",
-			XmlnsXsi:      "http://www.w3.org/1999/XMLSchema-instance",
-			SoapEnvHeader: ota.SoapEnvHeaderBuilder(b.configuration),
-			SoapEnvBody: ota.SoapEnvBody{
-				VehRetResRQ: &ota.VehRetResRQ{
-					Xmlns:             "http://www.opentravel.org/OTA/2003/05",
-					XmlnsXsi:          "http://www.w3.org/2001/XMLSchema-instance",
-					XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehRetResRQ.xsd",
-					Version:           "1.008",
-					Target:            target,
-					POS:               ota.POSBuiler(b.configuration),
-					VehRetResRQCore: ota.VehRetResRQCore{
-						UniqueID: ota.UniqueID{
-							Type: "14",
-							ID:   b.params.SupplierBookingReference,
-						},
-						PersonName: ota.PersonName{
-							Surname: *b.configuration.LastName,
-						},
+		mapping.NewSoapEnvelope(b.configuration, ota.SoapBody{
+			VehRetResRQ: &ota.VehRetResRQ{
+				Xmlns:             "http://www.opentravel.org/OTA/2003/05",
+				XmlnsXsi:          "http://www.w3.org/2001/XMLSchema-instance",
+				XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehRetResRQ.xsd",
+				Version:           "1.008",
+				Target:            target,
+				POS:               mapping.NewPOS(b.configuration),
+				VehRetResRQCore: ota.VehRetResRQCore{
+					UniqueID: ota.UniqueID{
+						Type: "14",
+						ID:   b.params.SupplierBookingReference,
+					},
+					PersonName: ota.PersonName{
+						Surname: *b.configuration.LastName,
 					},
 				},
-			}}, "", "	")
+			},
+		}), "", "	")
 	return xml
 }
 
-func (b *bookingStatusRequest) makeRequest(client *http.Client) (*http.Response, error) {
+func (b *bookingStatusRequest) makeRequest(ctx context.Context, client *http.Client) (*http.Response, error) {
 	body := bytes.NewBuffer(b.requestBody())
 	url := b.configuration.SupplierApiUrl
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Cancel)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Cancel)
 
 	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
@@ -76,7 +72,7 @@ func (b *bookingStatusRequest) makeRequest(client *http.Client) (*http.Response,
 	return httpResponse, nil
 }
 
-func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.BookingStatusResponse, error) {
+func (b *bookingStatusRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingStatusResponse, error) {
 	bookingStatus := schema.BookingStatusResponse{}
 	var faultResponse ota.FaultEnvelope
 
@@ -104,7 +100,7 @@ func (b *bookingStatusRequest) Execute(httpTransport *http.Transport) (schema.Bo
 		},
 	}
 
-	response, e := requesting.RequestErrors(b.makeRequest(client))
+	response, e := requesting.RequestErrors(b.makeRequest(ctx, client))
 	if e != nil {
 		errorsBucket.AddError(*e)
 		return bookingStatus, nil
@@ -8,9 +8,10 @@ import (
 	"net/http"
 	"time"
 
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/ota"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/mapping"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"github.com/rs/zerolog"
 )
@@ -40,31 +41,26 @@ func (c *cancelRequest) requestBody() []byte {
 	}
 
 	xml, _ := xml.MarshalIndent(
-		ota.SoapEnvelope{
-			XmlnsSoapEnv:  "http://www.w3.org/2001/12/soap-envelope",
-			XmlnsXsd:      "http://www.w3.org/1999/XMLSchema",
-			XmlnsXsi:      "http://www.w3.org/1999/XMLSchema-instance",
-			SoapEnvHeader: ota.SoapEnvHeaderBuilder(c.configuration),
-			SoapEnvBody: ota.SoapEnvBody{
-				VehCancelRQ: &ota.VehCancelRQ{
-					Xmlns:             "http://www.opentravel.org/OTA/2003/05",
-					XmlnsXsi:          "http://www.w3.org/2001/XMLSchema-instance",
-					XmlnsXsd:          "http://www.w3.org/2001/XMLSchema",
-					XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehCancelRQ.xsd",
-					Version:           "1.008",
-					Target:            target,
-					POS:               ota.POSBuiler(c.configuration),
-					VehCancelRQCore:   core,
-				},
-			}}, "", "	")
+		mapping.NewSoapEnvelope(c.configuration, ota.SoapBody{
+			VehCancelRQ: &ota.VehCancelRQ{
+				Xmlns:             "http://www.opentravel.org/OTA/2003/05",
+				XmlnsXsi:          "http://www.w3.org/2001/XMLSchema-instance",
+				XmlnsXsd:          "http://www.w3.org/2001/XMLSchema",
+				XsiSchemaLocation: "http://www.opentravel.org/OTA/2003/05 OTA_VehCancelRQ.xsd",
+				Version:           "1.008",
+				Target:            target,
+				POS:               mapping.NewPOS(c.configuration),
+				VehCancelRQCore:   core,
+			},
+		}), "", "	")
 	return xml
 }
 
-func (c *cancelRequest) makeRequest(client *http.Client) (*http.Response, error) {
+func (c *cancelRequest) makeRequest(ctx context.Context, client *http.Client) (*http.Response, error) {
 	body := bytes.NewBuffer(c.requestBody())
 	url := c.configuration.SupplierApiUrl
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Cancel)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Cancel)
 
 	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
@@ -94,7 +90,7 @@ func (c *cancelRequest) successfullyCancelled(cancelStatus ota.CoreCancelStatus)
 	return cancelStatus == ota.CoreCancelStatusCancelled
 }
 
-func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelResponse, error) {
+func (c *cancelRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.CancelResponse, error) {
 	cancel := schema.CancelResponse{}
 	var faultResponse ota.FaultEnvelope
 
@@ -121,7 +117,7 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 		},
 	}
 
-	response, e := requesting.RequestErrors(c.makeRequest(client))
+	response, e := requesting.RequestErrors(c.makeRequest(ctx, client))
 
 	// handle response
 	if e != nil {
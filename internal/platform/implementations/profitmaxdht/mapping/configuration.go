@@ -0,0 +1,116 @@
+package mapping
+
+import (
+	"fmt"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+)
+
+func NewPOS(configuration schema.ProfitMaxDHTConfiguration) ota.POS {
+	sources := make([]ota.Source, 0)
+
+	sources = append(sources,
+		ota.Source{
+			ISOCountry:    "US",
+			AgentDutyCode: converting.Unwrap(configuration.AgentDutyCode),
+			RequestorID: ota.RequestorID{
+				Type: "4",
+				ID:   converting.Unwrap(configuration.Vn),
+				CompanyName: &ota.CompanyName{
+					Code:        "CD:WC",
+					CodeContext: fmt.Sprintf("CC:%s", converting.Unwrap(configuration.Cp)),
+				},
+			},
+		})
+
+	if converting.Unwrap(configuration.VendorCode) != "" {
+		sources = append(sources,
+			ota.Source{
+				RequestorID: ota.RequestorID{
+					Type: "8",
+					ID:   converting.Unwrap(configuration.VendorCode),
+				},
+			})
+	}
+	return ota.POS{
+		Source: sources,
+	}
+}
+
+// Pricing taxes every coverage and includes mandatory coverages in the rate
+func Pricing(configuration schema.ProfitMaxDHTConfiguration) ota.Pricing {
+	return ota.Pricing{
+		FpPayFeesLocally:            converting.Unwrap(configuration.FpPayFeesLocally),
+		FpPaynowVehiclePriceWithTax: converting.Unwrap(configuration.FpPaynowVehiclePriceWithTax),
+		TaxAllCoverages:             true,
+		IncludeCoveragesInRate:      true,
+	}
+}
+
+func NewSoapEnvelope(configuration schema.ProfitMaxDHTConfiguration, body ota.SoapBody) ota.SoapEnvelope {
+	return ota.SoapEnvelope{
+		XmlnsSoapEnv: "http://www.w3.org/2001/12/soap-envelope",
+		XmlnsXsd:     "http://www.w3.org/1999/XMLSchema",
+		XmlnsXsi:     "http://www.w3.org/1999/XMLSchema-instance",
+		Header:       newSoapHeader(configuration),
+		Body:         body,
+	}
+}
+
+type SoapHeader struct {
+	Credentials Credentials `xml:"ns:credentials"`
+}
+
+func newSoapHeader(configuration schema.ProfitMaxDHTConfiguration) SoapHeader {
+	return SoapHeader{
+		Credentials: Credentials{
+			Xmlns: "http://wsg.avis.com/wsbang/authInAny",
+			UserId: UserId{
+				EncodingType: "xsd:string",
+				Value:        fmt.Sprintf("user:%s", converting.Unwrap(&configuration.Username)),
+			},
+			Password: Password{
+				EncodingType: "xsd:string",
+				Value:        fmt.Sprintf("password:%s", converting.Unwrap(&configuration.Password)),
+			},
+			Client: Client{
+				EncodingType: "xsd:string",
+				Value:        fmt.Sprintf("client:%s", converting.Unwrap(&configuration.Client)),
+			},
+			Destination: Destination{
+				EncodingType: "xsd:string",
+				Value:        fmt.Sprintf("destination:%s", converting.Unwrap(&configuration.Destination)),
+			},
+		},
+	}
+}
+
+type Credentials struct {
+	Xmlns       string      `xml:"xmlns:ns,attr"`
+	UserId      UserId      `xml:"ns:userID"`
+	Password    Password    `xml:"ns:password"`
+	Client      Client      `xml:"ns:client"`
+	Destination Destination `xml:"ns:destination"`
+}
+
+type UserId struct {
+	EncodingType string `xml:"ns:encodingType,attr"`
+	Value        string `xml:",chardata"`
+}
+
+type Password struct {
+	EncodingType string `xml:"ns:encodingType,attr"`
+	Value        string `xml:",chardata"`
+}
+
+type Client struct {
+	EncodingType string `xml:"ns:encodingType,attr"`
+	Value        string `xml:",chardata"`
+}
+
+type Destination struct {
+	EncodingType string `xml:"ns:encodingType,attr"`
+	Value        string `xml:",chardata"`
+}
@@ -14,7 +14,6 @@ import (
 	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
-	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 )
 
@@ -23,7 +22,7 @@ const (
 )
 
 type profitmaxdht struct {
-	redis         *redis.Client
+	cache         *caching.Cacher
 	httpTransport *http.Transport
 }
 
@@ -60,7 +59,7 @@ func (h *profitmaxdht) GetRates(ctx context.Context, params schema.RatesRequestP
 	slowLogger := slowlog.CreateLogger(logger)
 
 	ratesRequest := ratesRequest{
-		cache:         caching.NewRedisCache(h.redis),
+		cache:         h.cache,
 		params:        params,
 		configuration: configuration,
 		logger:        logger,
@@ -84,7 +83,7 @@ func (h *profitmaxdht) CancelBooking(ctx context.Context, params schema.CancelRe
 		logger:        logger,
 	}
 
-	return bookingCancel.Execute(h.httpTransport)
+	return bookingCancel.Execute(ctx, h.httpTransport)
 }
 
 func (h *profitmaxdht) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
@@ -96,7 +95,7 @@ func (h *profitmaxdht) GetBookingStatus(ctx context.Context, params schema.Booki
 		logger:        logger,
 	}
 
-	return bookingStatus.Execute(h.httpTransport)
+	return bookingStatus.Execute(ctx, h.httpTransport)
 }
 
 func (h *profitmaxdht) CreateBooking(ctx context.Context, params schema.BookingRequestParams, logger *zerolog.Logger) (schema.BookingResponse, error) {
@@ -115,16 +114,16 @@ func (h *profitmaxdht) CreateBooking(ctx context.Context, params schema.BookingR
 		logger:                logger,
 	}
 
-	return bookingRequest.Execute(h.httpTransport)
+	return bookingRequest.Execute(ctx, h.httpTransport)
 }
 
-func New(redisClient *redis.Client) *profitmaxdht {
+func New(cache *caching.Cacher) *profitmaxdht {
 	transport := http.DefaultTransport.(*http.Transport)
 	// improves durations a lot
 	transport.DisableKeepAlives = true
 
 	return &profitmaxdht{
-		redis:         redisClient,
+		cache:         cache,
 		httpTransport: transport,
 	}
 }
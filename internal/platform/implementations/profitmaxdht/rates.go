@@ -13,10 +13,11 @@ import (
 	"time"
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/mapping"
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
 	"github.com/rs/zerolog"
@@ -54,42 +55,36 @@ func (r *ratesRequest) ratesRequestBody() ota.SoapEnvelope {
 		tourInfo = &tourInfoEl
 	}
 
-	return ota.SoapEnvelope{
-		XmlnsSoapEnv:  "http://www.w3.org/2001/12/soap-envelope",
-		XmlnsXsd:      "http://www.w3.org/1999/XMLSchema",
-		XmlnsXsi:      "http://www.w3.org/1999/XMLSchema-instance",
-		SoapEnvHeader: ota.SoapEnvHeaderBuilder(r.configuration),
-		SoapEnvBody: ota.SoapEnvBody{
-			VehAvailRateRQ: &ota.VehAvailRateRQ{
-				Xmlns:        "http://www.opentravel.org/OTA/2003/05OTA_VehAvailRateRQ.xsd",
-				XmlnsXsi:     "http://www.w3.org/2001/XMLSchema-instance",
-				Version:      "1.008",
-				Target:       target,
-				MaxResponses: maxResponses,
-				POS:          ota.POSBuiler(r.configuration),
-				VehAvailRQCore: ota.VehAvailRQCore{
-					Status: "All",
-					VehRentalCore: ota.VehRentalCore{
-						PickUpDateTime: pickUpDateTime.Format(schema.DateTimeFormat),
-						ReturnDateTime: dropOffDateTime.Format(schema.DateTimeFormat),
-						PickUpLocation: &ota.Location{
-							LocationCode: r.params.PickUp.Code,
-						},
-						ReturnLocation: &ota.Location{
-							LocationCode: r.params.DropOff.Code,
-						},
+	return mapping.NewSoapEnvelope(r.configuration, ota.SoapBody{
+		VehAvailRateRQ: &ota.VehAvailRateRQ{
+			Xmlns:        "http://www.opentravel.org/OTA/2003/05OTA_VehAvailRateRQ.xsd",
+			XmlnsXsi:     "http://www.w3.org/2001/XMLSchema-instance",
+			Version:      "1.008",
+			Target:       target,
+			MaxResponses: maxResponses,
+			POS:          mapping.NewPOS(r.configuration),
+			VehAvailRQCore: ota.VehAvailRQCore{
+				Status: "All",
+				VehRentalCore: ota.VehRentalCore{
+					PickUpDateTime: pickUpDateTime.Format(schema.DateTimeFormat),
+					ReturnDateTime: dropOffDateTime.Format(schema.DateTimeFormat),
+					PickUpLocation: &ota.Location{
+						LocationCode: r.params.PickUp.Code,
 					},
-					RateQualifier: ota.RateQualifier{
-						RateQualifier: converting.Unwrap(r.configuration.RateQualifier),
-						TravelPurpose: converting.Unwrap(r.configuration.TravelPurpose),
+					ReturnLocation: &ota.Location{
+						LocationCode: r.params.DropOff.Code,
 					},
 				},
-				VehAvailRQInfo: ota.VehAvailRQInfo{
-					TourInfo: tourInfo,
+				RateQualifier: ota.RateQualifier{
+					RateQualifier: converting.Unwrap(r.configuration.RateQualifier),
+					TravelPurpose: converting.Unwrap(r.configuration.TravelPurpose),
 				},
 			},
+			VehAvailRQInfo: ota.VehAvailRQInfo{
+				TourInfo: tourInfo,
+			},
 		},
-	}
+	})
 }
 
 func (r *ratesRequest) requestBody(body ota.SoapEnvelope) string {
@@ -130,7 +125,7 @@ func (r *ratesRequest) parseVehicle(vehAvail ota.VehAvail, pricedEquips []ota.Pr
 
 	taxMultiplier, taxCharge, taxIsPartOfTheVehiclePrice := vehAvail.VehAvailCore.RentalRate.VehicleCharges.TaxCharge(
 		r.params,
-		r.configuration,
+		mapping.Pricing(r.configuration),
 		vehAvail.VehAvailInfo.PaymentRules.PaymentRule,
 	)
 
@@ -143,12 +138,12 @@ func (r *ratesRequest) parseVehicle(vehAvail ota.VehAvail, pricedEquips []ota.Pr
 		taxMultiplier,
 		vehAvail.VehAvailInfo.PaymentRules.PaymentRule,
 		r.params,
-		r.configuration,
+		mapping.Pricing(r.configuration),
 	)
 
 	vehiclePrice.Amount = schema.RoundedFloat(float64(vehiclePrice.Amount) + coveragePricePartOfVehiclePrice)
 
-	fees := vehAvail.VehAvailCore.Fees.Fees(r.params, r.configuration, vehAvail.VehAvailInfo.PaymentRules.PaymentRule)
+	fees := vehAvail.VehAvailCore.Fees.Fees(r.params, mapping.Pricing(r.configuration), vehAvail.VehAvailInfo.PaymentRules.PaymentRule)
 
 	extras := make([]schema.ExtraOrFee, len(pricedEquips))
 
@@ -179,9 +174,9 @@ func (r *ratesRequest) parseVehicle(vehAvail ota.VehAvail, pricedEquips []ota.Pr
 		SmallSuitcases:        &vehAvail.VehAvailCore.Vehicle.BaggageQuantity,
 		Doors:                 &vehAvail.VehAvailCore.Vehicle.VehType.DoorCount,
 		Seats:                 &vehAvail.VehAvailCore.Vehicle.PassengerQuantity,
-		TransmissionType:      mapping.Transmission(vehAvail.VehAvailCore.Vehicle.TransmissionType),
-		FuelType:              mapping.FuelType(vehAvail.VehAvailCore.Vehicle.FuelType),
-		DriveType:             mapping.DriveType(vehAvail.VehAvailCore.Vehicle.DriveType),
+		TransmissionType:      ota.Transmission(vehAvail.VehAvailCore.Vehicle.TransmissionType),
+		FuelType:              ota.FuelType(vehAvail.VehAvailCore.Vehicle.FuelType),
+		DriveType:             ota.DriveType(vehAvail.VehAvailCore.Vehicle.DriveType),
 		Mileage:               &mileage,
 	}
 
@@ -208,7 +203,7 @@ func (r *ratesRequest) ratesRequest(
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodPost, r.configuration.SupplierApiUrl, bytes.NewBuffer([]byte(requestBody)))
 	httpRequest.Header.Set("Content-Type", "application/xml; charset=utf-8")
 
-	go func() {
+	lifecycle.Go(ctx, "profitmaxdht:rates", func() {
 		defer r.recoverPanic(errChannel)
 
 		rs, e := requesting.RequestErrors(client.Do(httpRequest))
@@ -243,7 +238,7 @@ func (r *ratesRequest) ratesRequest(
 			return
 		}
 		resChannel <- otaRatesResponse
-	}()
+	})
 }
 
 func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.RatesResponse, error) {
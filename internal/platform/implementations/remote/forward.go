@@ -0,0 +1,70 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+)
+
+// forward posts params to the operation of the adapter and binds its answer to
+// response, the answer holds the supplier requests and errors of the adapter.
+// The request to the adapter is only returned when the exchange failed.
+func (r *remote) forward(
+	ctx context.Context,
+	operation schema.SupplierRequestName,
+	timeout int,
+	params any,
+	response any,
+	logger *zerolog.Logger,
+) (*schema.SupplierRequests, *schema.SupplierResponseError) {
+	requestsBucket := schema.NewSupplierRequestsBucket()
+
+	client := &http.Client{
+		Timeout: time.Duration(timeout) * time.Millisecond,
+		Transport: &requesting.InterceptorTransport{
+			Transport: r.httpTransport,
+			Middlewares: []requesting.TransportMiddleware{
+				requesting.NewLoggingTransportMiddleware(logger),
+				requesting.NewBucketTransportMiddleware(&requestsBucket),
+			},
+		},
+	}
+
+	// rates are given up with the caller, bookings must reach the adapter
+	if operation != schema.Rates {
+		ctx = requesting.Detach(ctx)
+	}
+	c := context.WithValue(ctx, schema.RequestingTypeKey, operation)
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		e := schema.NewSupplierError("unable to encode the request: " + err.Error())
+		return requestsBucket.SupplierRequests(), &e
+	}
+
+	httpRequest, _ := http.NewRequestWithContext(c, http.MethodPost, r.baseUrl+"/"+string(operation), bytes.NewBuffer(body))
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	rs, e := requesting.RequestErrors(client.Do(httpRequest))
+	if e != nil {
+		return requestsBucket.SupplierRequests(), e
+	}
+	defer rs.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(rs.Body)
+	rs.Body.Close()
+
+	if err := json.Unmarshal(bodyBytes, response); err != nil {
+		e := schema.NewSupplierError("unable to parse the body")
+		return requestsBucket.SupplierRequests(), &e
+	}
+
+	return nil, nil
+}
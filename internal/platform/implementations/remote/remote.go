@@ -0,0 +1,172 @@
+package remote
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"github.com/rs/zerolog"
+)
+
+// remote forwards the operations of a platform to an adapter speaking the
+// api/openapi.json contract. Grouping, history and error handling of the hub
+// apply as for the platforms compiled in.
+type remote struct {
+	name          string
+	baseUrl       string
+	httpTransport *http.Transport
+}
+
+// TrafficLightGroupingCacheKey groups equal searches, the configuration is
+// hashed as the hub does not know which of its parameters the adapter uses
+func (r *remote) TrafficLightGroupingCacheKey(ctx context.Context, params schema.RatesRequestParams, logger *zerolog.Logger) string {
+	configuration, _ := params.Configuration.MarshalJSON()
+	sum := sha256.Sum256(configuration)
+
+	pickUpDateTime := params.PickUp.DateTime
+	dropOffDateTime := params.DropOff.DateTime
+
+	keyPieces := [10]string{
+		"grouping",
+		"supplier-remote",
+		r.name,
+		params.PickUp.Code,
+		params.DropOff.Code,
+		pickUpDateTime.Format(time.DateOnly),
+		fmt.Sprintf("%.0f", dropOffDateTime.Sub(pickUpDateTime).Minutes()),
+		fmt.Sprintf("%d:%s", params.Age, params.ResidenceCountry),
+		params.Contract.Currency,
+		hex.EncodeToString(sum[:]),
+	}
+
+	return strings.ToLower(strings.Join(keyPieces[:], ":"))
+}
+
+func (r *remote) GetRates(ctx context.Context, params schema.RatesRequestParams, logger *zerolog.Logger) (schema.RatesResponse, error) {
+	timeout := params.Timeouts.Default
+	if params.Timeouts.Rates != nil {
+		timeout = *params.Timeouts.Rates
+	}
+
+	var rates schema.RatesResponse
+	requests, e := r.forward(ctx, schema.Rates, timeout, params, &rates, logger)
+	if e != nil {
+		return schema.RatesResponse{
+			Vehicles:         []schema.Vehicle{},
+			SupplierRequests: requests,
+			Errors:           &schema.SupplierResponseErrors{*e},
+		}, nil
+	}
+
+	return rates, nil
+}
+
+func (r *remote) CreateBooking(ctx context.Context, params schema.BookingRequestParams, logger *zerolog.Logger) (schema.BookingResponse, error) {
+	timeout := params.Timeouts.Default
+	if params.Timeouts.Booking != nil {
+		timeout = *params.Timeouts.Booking
+	}
+
+	var booking schema.BookingResponse
+	requests, e := r.forward(ctx, schema.Booking, timeout, params, &booking, logger)
+	if e != nil {
+		return schema.BookingResponse{
+			Status:           schema.BookingResponseStatusFAILED,
+			SupplierRequests: requests,
+			Errors:           &schema.SupplierResponseErrors{*e},
+		}, nil
+	}
+
+	return booking, nil
+}
+
+func (r *remote) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
+	var bookingStatus schema.BookingStatusResponse
+	requests, e := r.forward(ctx, schema.BookingStatus, params.Timeouts.Default, params, &bookingStatus, logger)
+	if e != nil {
+		return schema.BookingStatusResponse{
+			Status:           schema.BookingStatusResponseStatusFAILED,
+			SupplierRequests: requests,
+			Errors:           &schema.SupplierResponseErrors{*e},
+		}, nil
+	}
+
+	return bookingStatus, nil
+}
+
+func (r *remote) ModifyBooking(ctx context.Context, params schema.ModifyRequestParams, logger *zerolog.Logger) (schema.ModifyResponse, error) {
+	timeout := params.Timeouts.Default
+	if params.Timeouts.Booking != nil {
+		timeout = *params.Timeouts.Booking
+	}
+
+	var modify schema.ModifyResponse
+	requests, e := r.forward(ctx, schema.Modify, timeout, params, &modify, logger)
+	if e != nil {
+		return schema.ModifyResponse{
+			Status:           converting.PointerToValue(schema.ModifyResponseStatusFAILED),
+			SupplierRequests: requests,
+			Errors:           &schema.SupplierResponseErrors{*e},
+		}, nil
+	}
+
+	return modify, nil
+}
+
+func (r *remote) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
+	timeout := params.Timeouts.Default
+	if params.Timeouts.Cancel != nil {
+		timeout = *params.Timeouts.Cancel
+	}
+
+	var cancel schema.CancelResponse
+	requests, e := r.forward(ctx, schema.Cancel, timeout, params, &cancel, logger)
+	if e != nil {
+		return schema.CancelResponse{
+			Status:           converting.PointerToValue(schema.CancelResponseStatusFAILED),
+			SupplierRequests: requests,
+			Errors:           &schema.SupplierResponseErrors{*e},
+		}, nil
+	}
+
+	return cancel, nil
+}
+
+func (r *remote) GetLocations(ctx context.Context, params schema.LocationsRequestParams, logger *zerolog.Logger) (schema.LocationsResponse, error) {
+	timeout := params.Timeouts.Default
+	if params.Timeouts.Locations != nil {
+		timeout = *params.Timeouts.Locations
+	}
+
+	var locations schema.LocationsResponse
+	requests, e := r.forward(ctx, schema.Locations, timeout, params, &locations, logger)
+	if e != nil {
+		return schema.LocationsResponse{
+			Locations:        &[]schema.Location{},
+			SupplierRequests: requests,
+			Errors:           &schema.SupplierResponseErrors{*e},
+		}, nil
+	}
+
+	return locations, nil
+}
+
+// New serves the platform name by the adapter at baseUrl, operations are
+// posted to <baseUrl>/<operation>
+func New(name string, baseUrl string) *remote {
+	transport := http.DefaultTransport.(*http.Transport)
+	// improves durations a lot
+	transport.DisableKeepAlives = true
+
+	return &remote{
+		name:          name,
+		baseUrl:       strings.TrimSuffix(baseUrl, "/"),
+		httpTransport: transport,
+	}
+}
@@ -0,0 +1,187 @@
+package remote_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/remote"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// adapter answers every operation with status and body, the requested
+// operations and bodies are collected
+func adapter(t *testing.T, status int, body string) (*httptest.Server, *[]string, *[]map[string]interface{}) {
+	paths := []string{}
+	bodies := []map[string]interface{}{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+
+		var decoded map[string]interface{}
+		assert.Nil(t, json.Unmarshal(content, &decoded))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, decoded)
+
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &paths, &bodies
+}
+
+func ratesParams(settings map[string]interface{}) schema.RatesRequestParams {
+	var configuration schema.RatesRequestParams_Configuration
+	configuration.FromRemoteConfiguration(schema.RemoteConfiguration{Adapter: settings})
+
+	pickUp := time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC)
+
+	return schema.RatesRequestParams{
+		PickUp:           schema.RequestBranch{Code: "MUC", Country: "DE", DateTime: pickUp},
+		DropOff:          schema.RequestBranch{Code: "MUC", Country: "DE", DateTime: pickUp.AddDate(0, 0, 7)},
+		Contract:         schema.Contract{Currency: "EUR"},
+		Age:              30,
+		ResidenceCountry: "DE",
+		Timeouts:         schema.Timeouts{Default: 8000},
+		Configuration:    configuration,
+	}
+}
+
+func TestRates(t *testing.T) {
+	log := zerolog.Nop()
+
+	t.Run("should forward rates to the adapter", func(t *testing.T) {
+		server, paths, bodies := adapter(t, http.StatusOK, `{
+			"vehicles": [{"name": "VW Golf"}],
+			"supplierRequests": [{"name": "rates", "requestContent": {"url": "http://supplier.local"}}]
+		}`)
+
+		rates, err := remote.New("partner", server.URL+"/partner/").GetRates(context.Background(), ratesParams(map[string]interface{}{"apiKey": "secret"}), &log)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/partner/rates"}, *paths)
+		assert.Equal(t, map[string]interface{}{"adapter": map[string]interface{}{"apiKey": "secret"}}, (*bodies)[0]["configuration"])
+		assert.Equal(t, "MUC", (*bodies)[0]["pickUp"].(map[string]interface{})["code"])
+
+		assert.Len(t, rates.Vehicles, 1)
+		assert.Empty(t, rates.Errors)
+		// the exchange with the adapter is left out, the adapter returns its own
+		assert.Len(t, *rates.SupplierRequests, 1)
+		assert.Equal(t, "http://supplier.local", *(*rates.SupplierRequests)[0].RequestContent.Url)
+	})
+
+	t.Run("should return the adapter request on status != 200", func(t *testing.T) {
+		server, _, _ := adapter(t, http.StatusBadGateway, `{}`)
+
+		rates, err := remote.New("partner", server.URL).GetRates(context.Background(), ratesParams(nil), &log)
+
+		assert.Nil(t, err)
+		assert.Empty(t, rates.Vehicles)
+		assert.Len(t, *rates.Errors, 1)
+		assert.Equal(t, schema.SupplierError, (*rates.Errors)[0].Code)
+		assert.Equal(t, "supplier returned status code 502", (*rates.Errors)[0].Message)
+		assert.Len(t, *rates.SupplierRequests, 1)
+	})
+
+	t.Run("should fail on unparsable answers", func(t *testing.T) {
+		server, _, _ := adapter(t, http.StatusOK, `<html></html>`)
+
+		rates, _ := remote.New("partner", server.URL).GetRates(context.Background(), ratesParams(nil), &log)
+
+		assert.Len(t, *rates.Errors, 1)
+		assert.Equal(t, "unable to parse the body", (*rates.Errors)[0].Message)
+	})
+
+	t.Run("should handle timeouts", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(10 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		params := ratesParams(nil)
+		params.Timeouts.Rates = converting.PointerToValue(1)
+
+		rates, _ := remote.New("partner", server.URL).GetRates(context.Background(), params, &log)
+
+		assert.Len(t, *rates.Errors, 1)
+		assert.Equal(t, schema.TimeoutError, (*rates.Errors)[0].Code)
+	})
+}
+
+func TestOperations(t *testing.T) {
+	log := zerolog.Nop()
+	ctx := context.Background()
+
+	customer := schema.Customer{Email: "mock@example.com"}
+	contact := schema.Contact{Email: "mock@example.com"}
+
+	t.Run("should forward the operations to their paths", func(t *testing.T) {
+		server, paths, _ := adapter(t, http.StatusOK, `{"status": "OK", "locations": []}`)
+		platform := remote.New("partner", server.URL)
+
+		booking, _ := platform.CreateBooking(ctx, schema.BookingRequestParams{Customer: customer}, &log)
+		bookingStatus, _ := platform.GetBookingStatus(ctx, schema.BookingStatusRequestParams{}, &log)
+		modify, _ := platform.ModifyBooking(ctx, schema.ModifyRequestParams{Customer: customer}, &log)
+		cancel, _ := platform.CancelBooking(ctx, schema.CancelRequestParams{Contact: contact}, &log)
+		locations, _ := platform.GetLocations(ctx, schema.LocationsRequestParams{}, &log)
+
+		assert.Equal(t, []string{"/booking", "/booking-status", "/modify", "/cancel", "/locations"}, *paths)
+		assert.Equal(t, schema.BookingResponseStatusOK, booking.Status)
+		assert.Equal(t, schema.BookingStatusResponseStatusOK, bookingStatus.Status)
+		assert.Equal(t, schema.ModifyResponseStatusOK, *modify.Status)
+		assert.Equal(t, schema.CancelResponseStatusOK, *cancel.Status)
+		assert.Empty(t, locations.Errors)
+	})
+
+	t.Run("should fail the operations when the adapter fails", func(t *testing.T) {
+		server, _, _ := adapter(t, http.StatusInternalServerError, `{}`)
+		platform := remote.New("partner", server.URL)
+
+		booking, _ := platform.CreateBooking(ctx, schema.BookingRequestParams{Customer: customer}, &log)
+		bookingStatus, _ := platform.GetBookingStatus(ctx, schema.BookingStatusRequestParams{}, &log)
+		modify, _ := platform.ModifyBooking(ctx, schema.ModifyRequestParams{Customer: customer}, &log)
+		cancel, _ := platform.CancelBooking(ctx, schema.CancelRequestParams{Contact: contact}, &log)
+		locations, _ := platform.GetLocations(ctx, schema.LocationsRequestParams{}, &log)
+
+		assert.Equal(t, schema.BookingResponseStatusFAILED, booking.Status)
+		assert.Equal(t, schema.BookingStatusResponseStatusFAILED, bookingStatus.Status)
+		assert.Equal(t, schema.ModifyResponseStatusFAILED, *modify.Status)
+		assert.Equal(t, schema.CancelResponseStatusFAILED, *cancel.Status)
+		assert.Empty(t, *locations.Locations)
+
+		for _, errors := range []*schema.SupplierResponseErrors{booking.Errors, bookingStatus.Errors, modify.Errors, cancel.Errors, locations.Errors} {
+			assert.Len(t, *errors, 1)
+		}
+	})
+}
+
+func TestTrafficLightGroupingCacheKey(t *testing.T) {
+	log := zerolog.Nop()
+	ctx := context.Background()
+	platform := remote.New("partner", "http://adapter.local")
+
+	t.Run("should group equal searches of a configuration", func(t *testing.T) {
+		key := platform.TrafficLightGroupingCacheKey(ctx, ratesParams(map[string]interface{}{"apiKey": "a"}), &log)
+
+		assert.Equal(t, key, platform.TrafficLightGroupingCacheKey(ctx, ratesParams(map[string]interface{}{"apiKey": "a"}), &log))
+		assert.Contains(t, key, "grouping:supplier-remote:partner:muc:muc:2026-11-02:10080:30:de:eur:")
+		assert.NotContains(t, key, "apiKey")
+	})
+
+	t.Run("should not group searches of other configurations and platforms", func(t *testing.T) {
+		key := platform.TrafficLightGroupingCacheKey(ctx, ratesParams(map[string]interface{}{"apiKey": "a"}), &log)
+
+		assert.NotEqual(t, key, platform.TrafficLightGroupingCacheKey(ctx, ratesParams(map[string]interface{}{"apiKey": "b"}), &log))
+		assert.NotEqual(t, key, remote.New("other", "http://adapter.local").TrafficLightGroupingCacheKey(ctx, ratesParams(map[string]interface{}{"apiKey": "a"}), &log))
+	})
+}
@@ -31,7 +31,21 @@ type AuthResponse struct {
 	Token            *string                        `json:"token,omitempty"`
 }
 
-func (a *authRequest) Execute(httpTransport *http.Transport) (AuthResponse, error) {
+// Execute fetches the token once per batch of requests, the requests
+// reusing it do not report the auth request
+func (a *authRequest) Execute(ctx context.Context, httpTransport *http.Transport) (AuthResponse, error) {
+	authResponse, shared, err := requesting.Shared(ctx, a.getCacheKey(), func() (AuthResponse, error) {
+		return a.fetch(ctx, httpTransport)
+	})
+
+	if shared {
+		authResponse.SupplierRequests = &schema.SupplierRequests{}
+	}
+
+	return authResponse, err
+}
+
+func (a *authRequest) fetch(ctx context.Context, httpTransport *http.Transport) (AuthResponse, error) {
 	authResponse := AuthResponse{}
 
 	requestsBucket := schema.NewSupplierRequestsBucket()
@@ -40,10 +54,14 @@ func (a *authRequest) Execute(httpTransport *http.Transport) (AuthResponse, erro
 	authResponse.SupplierRequests = requestsBucket.SupplierRequests()
 	authResponse.Errors = errorsBucket.Errors()
 
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Auth)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Auth)
 
 	var cachedAuthToken string
-	ok := a.cache.Fetch(ctx, a.getCacheKey(), &cachedAuthToken)
+	ok, err := a.cache.Fetch(ctx, a.getCacheKey(), &cachedAuthToken)
+	if err != nil {
+		a.logger.Warn().Err(err).Str("label", "cache").Msg("Unable to fetch auth token from cache")
+	}
+
 	if ok {
 		authResponse.Token = &cachedAuthToken
 
@@ -81,9 +99,10 @@ func (a *authRequest) Execute(httpTransport *http.Transport) (AuthResponse, erro
 
 	authResponse.Token = &a.jsonAuthResponse.AccessToken
 
-	err := a.cache.Store(ctx, a.getCacheKey(), a.jsonAuthResponse.AccessToken, time.Duration(a.jsonAuthResponse.ExpiresIn)*time.Second)
+	err = a.cache.Store(ctx, a.getCacheKey(), a.jsonAuthResponse.AccessToken, time.Duration(a.jsonAuthResponse.ExpiresIn)*time.Second)
 	if err != nil {
-		return authResponse, err
+		// the token is still usable, it is only requested again next time
+		a.logger.Warn().Err(err).Str("label", "cache").Msg("Unable to store auth token in cache")
 	}
 
 	return authResponse, nil
@@ -28,7 +28,7 @@ type bookingRequest struct {
 	cache                 *caching.Cacher
 }
 
-func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingResponse, error) {
+func (b *bookingRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingResponse, error) {
 	booking := schema.BookingResponse{}
 	booking.Status = schema.BookingResponseStatusFAILED
 
@@ -46,7 +46,7 @@ func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingR
 		cache:         b.cache,
 	}
 
-	auth, err := authRequest.Execute(httpTransport)
+	auth, err := authRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*auth.SupplierRequests)
 	errorsBucket.AddErrors(*auth.Errors)
 
@@ -74,7 +74,7 @@ func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingR
 		},
 	}
 
-	response, err := b.makeRequest(client, *auth.Token)
+	response, err := b.makeRequest(ctx, client, *auth.Token)
 
 	if err != nil {
 		errorsBucket.AddError(schema.NewSupplierError(err.Error()))
@@ -88,13 +88,14 @@ func (b *bookingRequest) Execute(httpTransport *http.Transport) (schema.BookingR
 }
 
 func (b *bookingRequest) makeRequest(
+	ctx context.Context,
 	client *http.Client,
 	token string,
 ) (json.BookingRS, error) {
 	body := bytes.NewBuffer(b.requestBody())
 
 	url := b.configuration.SupplierApiUrl + "/api/Booking"
-	c := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Booking)
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Booking)
 
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodPost, url, body)
 	httpRequest.Header.Set("Content-Type", "application/json")
@@ -15,6 +15,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/rently"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -185,7 +186,7 @@ func TestBookingRequest(t *testing.T) {
 				mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 				mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-				service := rently.New(redisClient)
+				service := rently.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 				booking, err := service.CreateBooking(ctx, params, &log)
 				assert.Nil(t, err)
@@ -239,7 +240,7 @@ func TestBookingRequest(t *testing.T) {
 		mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 		mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-		service := rently.New(redisClient)
+		service := rently.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.BookingResponse, 1)
 
@@ -380,7 +381,7 @@ func bookingParamsTemplate(configuration schema.RentlyConfiguration) schema.Book
 }
 
 func createBooking(params schema.BookingRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.BookingResponse, error) {
-	service := rently.New(redisClient)
+	service := rently.New(caching.NewRedisCache(redisClient))
 	ctx := context.Background()
 	return service.CreateBooking(ctx, params, log)
 }
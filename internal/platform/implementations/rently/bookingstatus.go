@@ -0,0 +1,109 @@
+package rently
+
+import (
+	"context"
+	jsonEncoding "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/rently/json"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+)
+
+type bookingStatusRequest struct {
+	params        schema.BookingStatusRequestParams
+	configuration schema.RentlyConfiguration
+	logger        *zerolog.Logger
+	cache         *caching.Cacher
+}
+
+func (b *bookingStatusRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.BookingStatusResponse, error) {
+	bookingStatus := schema.BookingStatusResponse{}
+	bookingStatus.Status = schema.BookingStatusResponseStatusFAILED
+
+	requestsBucket := schema.NewSupplierRequestsBucket()
+	errorsBucket := schema.NewErrorsBucket()
+
+	bookingStatus.SupplierRequests = requestsBucket.SupplierRequests()
+	bookingStatus.Errors = errorsBucket.Errors()
+
+	// fetch auth token
+	authRequest := authRequest{
+		configuration: b.configuration,
+		logger:        b.logger,
+		timeout:       b.params.Timeouts.Default,
+		cache:         b.cache,
+	}
+
+	auth, err := authRequest.Execute(ctx, httpTransport)
+	requestsBucket.AddRequests(*auth.SupplierRequests)
+	errorsBucket.AddErrors(*auth.Errors)
+
+	if err != nil {
+		return bookingStatus, err
+	}
+
+	if auth.Token == nil {
+		return bookingStatus, nil
+	}
+
+	// prepare client
+	client := &http.Client{
+		Timeout: time.Duration(b.params.Timeouts.Default) * time.Millisecond,
+		Transport: &requesting.InterceptorTransport{
+			Transport: httpTransport,
+			Middlewares: []requesting.TransportMiddleware{
+				requesting.NewLoggingTransportMiddleware(b.logger),
+				requesting.NewBucketTransportMiddleware(&requestsBucket),
+			},
+		},
+	}
+
+	response, err := b.makeRequest(ctx, client, *auth.Token)
+
+	if err != nil {
+		errorsBucket.AddError(schema.NewSupplierError(err.Error()))
+		return bookingStatus, nil
+	}
+
+	bookingStatus.Status = response.GetBookingStatus()
+	bookingStatus.SupplierBookingReference = &response.Id
+
+	return bookingStatus, nil
+}
+
+func (b *bookingStatusRequest) makeRequest(
+	ctx context.Context,
+	client *http.Client,
+	token string,
+) (json.BookingRS, error) {
+	url := fmt.Sprintf("%v/api/Booking/%v", b.configuration.SupplierApiUrl, b.params.SupplierBookingReference)
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.BookingStatus)
+
+	httpRequest, _ := http.NewRequestWithContext(c, http.MethodGet, url, http.NoBody)
+	httpRequest.Header.Set("Authorization", "Bearer "+token)
+
+	rs, err := requesting.RequestErrors(client.Do(httpRequest))
+	if err != nil {
+		return json.BookingRS{}, errors.New(err.Message)
+	}
+	defer rs.Body.Close()
+
+	// bind the response body to the json
+	bodyBytes, _ := io.ReadAll(rs.Body)
+	rs.Body.Close()
+
+	var jsonBookingResponse json.BookingRS
+	jsonEncodeErr := jsonEncoding.Unmarshal(bodyBytes, &jsonBookingResponse)
+	if jsonEncodeErr != nil {
+		return json.BookingRS{}, errors.New(jsonEncodeErr.Error())
+	}
+
+	return jsonBookingResponse, nil
+}
@@ -0,0 +1,132 @@
+package rently_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/rently"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/go-redis/redismock/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingStatusRequest(t *testing.T) {
+	out := &bytes.Buffer{}
+	log := zerolog.New(out)
+
+	t.Run("should map the status of the booking", func(t *testing.T) {
+		tests := []struct {
+			name             string
+			supplierResponse string
+			expectedStatus   schema.BookingStatusResponseStatus
+		}{
+			{"reserved", "supplier_response_reserved.json", schema.BookingStatusResponseStatusOK},
+			{"canceled", "supplier_response_canceled.json", schema.BookingStatusResponseStatusCANCELLED},
+			{"closed", "supplier_response_closed.json", schema.BookingStatusResponseStatusFAILED},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				supplierResponse, _ := os.ReadFile("./testdata/booking/" + test.supplierResponse)
+
+				testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.RequestURI == "/connect/token" {
+						w.Write(defaultSupplierAuthResponse())
+						return
+					}
+
+					assert.Equal(t, "/api/Booking/JC000027-MIA", r.RequestURI)
+					assert.Equal(t, http.MethodGet, r.Method)
+					assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+					w.Write(supplierResponse)
+				}))
+				defer testServer.Close()
+
+				redisClient, mock := redismock.NewClientMock()
+				cachedKey, _ := getCachedAndCompressedAuthKey()
+				mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
+				mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
+
+				service := rently.New(caching.NewRedisCache(redisClient))
+				bookingStatus, err := service.GetBookingStatus(context.Background(), bookingStatusParamsTemplate(testServer.URL), &log)
+
+				assert.Nil(t, err)
+				assert.Empty(t, *bookingStatus.Errors)
+				assert.Equal(t, test.expectedStatus, bookingStatus.Status)
+				assert.Equal(t, "JC000027-MIA", *bookingStatus.SupplierBookingReference)
+				assert.Len(t, *bookingStatus.SupplierRequests, 2)
+			})
+		}
+	})
+
+	t.Run("should fetch the auth token once per batch", func(t *testing.T) {
+		var authCalls atomic.Int32
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.RequestURI == "/connect/token" {
+				authCalls.Add(1)
+				// keeps the token request in flight while the others wait for it
+				time.Sleep(20 * time.Millisecond)
+				w.Write(defaultSupplierAuthResponse())
+				return
+			}
+
+			w.Write(defaultSupplierBookingResponse())
+		}))
+		defer testServer.Close()
+
+		service := rently.New(caching.NewMemoryCache())
+		ctx := requesting.WithBatch(context.Background())
+		// the requests log concurrently
+		nop := zerolog.Nop()
+
+		var wait sync.WaitGroup
+		supplierRequests := make([]int, 4)
+
+		for i := range supplierRequests {
+			wait.Add(1)
+
+			go func(i int) {
+				defer wait.Done()
+
+				bookingStatus, _ := service.GetBookingStatus(ctx, bookingStatusParamsTemplate(testServer.URL), &nop)
+				supplierRequests[i] = len(*bookingStatus.SupplierRequests)
+			}(i)
+		}
+
+		wait.Wait()
+
+		assert.Equal(t, int32(1), authCalls.Load())
+		// the auth request is reported by the request that made it
+		assert.ElementsMatch(t, []int{2, 1, 1, 1}, supplierRequests)
+	})
+}
+
+func bookingStatusParamsTemplate(url string) schema.BookingStatusRequestParams {
+	configuration := bookingDefaultConfiguration()
+	configuration.SupplierApiUrl = url
+
+	b, _ := json.Marshal(configuration)
+
+	var cp schema.BookingStatusRequestParams_Configuration
+	json.Unmarshal(b, &cp)
+
+	return schema.BookingStatusRequestParams{
+		SupplierBookingReference: "JC000027-MIA",
+		ReservNumber:             "K48730916F3",
+		Timeouts:                 schema.Timeouts{Default: 8000},
+		Configuration:            cp,
+	}
+}
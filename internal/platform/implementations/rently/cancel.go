@@ -20,7 +20,7 @@ type cancelRequest struct {
 	cache         *caching.Cacher
 }
 
-func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelResponse, error) {
+func (c *cancelRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.CancelResponse, error) {
 	cancel := schema.CancelResponse{}
 
 	status := schema.CancelResponseStatusFAILED
@@ -40,7 +40,7 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 		cache:         c.cache,
 	}
 
-	auth, err := authRequest.Execute(httpTransport)
+	auth, err := authRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*auth.SupplierRequests)
 	errorsBucket.AddErrors(*auth.Errors)
 
@@ -69,7 +69,7 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 		},
 	}
 
-	err = c.makeRequest(client, *auth.Token)
+	err = c.makeRequest(ctx, client, *auth.Token)
 
 	if err != nil {
 		errorsBucket.AddError(schema.NewSupplierError(err.Error()))
@@ -83,11 +83,12 @@ func (c *cancelRequest) Execute(httpTransport *http.Transport) (schema.CancelRes
 }
 
 func (c *cancelRequest) makeRequest(
+	ctx context.Context,
 	client *http.Client,
 	token string,
 ) error {
 	url := fmt.Sprintf("%v/api/Booking/%v", c.configuration.SupplierApiUrl, c.params.SupplierBookingReference)
-	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Cancel)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Cancel)
 
 	httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodDelete, url, http.NoBody)
 	httpRequest.Header.Set("Authorization", "Bearer "+token)
@@ -28,7 +28,7 @@ type locationsRequest struct {
 	cache         *caching.Cacher
 }
 
-func (l *locationsRequest) Execute(httpTransport *http.Transport) (schema.LocationsResponse, error) {
+func (l *locationsRequest) Execute(ctx context.Context, httpTransport *http.Transport) (schema.LocationsResponse, error) {
 	locations := schema.LocationsResponse{
 		Locations: &[]schema.Location{},
 	}
@@ -47,7 +47,7 @@ func (l *locationsRequest) Execute(httpTransport *http.Transport) (schema.Locati
 		cache:         l.cache,
 	}
 
-	auth, err := authRequest.Execute(httpTransport)
+	auth, err := authRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*auth.SupplierRequests)
 	errorsBucket.AddErrors(*auth.Errors)
 
@@ -76,7 +76,7 @@ func (l *locationsRequest) Execute(httpTransport *http.Transport) (schema.Locati
 		},
 	}
 
-	response, err := l.makeRequest(client, *auth.Token)
+	response, err := l.makeRequest(ctx, client, *auth.Token)
 
 	if err != nil {
 		errorsBucket.AddError(schema.NewSupplierError(err.Error()))
@@ -97,11 +97,12 @@ func (l *locationsRequest) Execute(httpTransport *http.Transport) (schema.Locati
 }
 
 func (l *locationsRequest) makeRequest(
+	ctx context.Context,
 	client *http.Client,
 	token string,
 ) ([]json.PlaceRS, error) {
 	url := fmt.Sprintf("%v/api/Places", l.configuration.SupplierApiUrl)
-	c := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Locations)
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Locations)
 
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodGet, url, http.NoBody)
 	httpRequest.Header.Set("Authorization", "Bearer "+token)
@@ -47,7 +47,7 @@ func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 		cache:         r.cache,
 	}
 
-	auth, err := authRequest.Execute(httpTransport)
+	auth, err := authRequest.Execute(ctx, httpTransport)
 	requestsBucket.AddRequests(*auth.SupplierRequests)
 	errorsBucket.AddErrors(*auth.Errors)
 
@@ -75,7 +75,7 @@ func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 		},
 	}
 
-	response, err := r.makeRequest(client, *auth.Token)
+	response, err := r.makeRequest(ctx, client, *auth.Token)
 
 	if err != nil {
 		errorsBucket.AddError(schema.NewSupplierError(err.Error()))
@@ -85,8 +85,9 @@ func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 	for _, vehicle := range response {
 		vehicle, err := r.parseVehicle(vehicle)
 		if err != nil {
+			// one broken vehicle must not hide the others
 			errorsBucket.AddError(schema.NewSupplierError(err.Error()))
-			return rates, err
+			continue
 		}
 
 		rates.Vehicles = append(rates.Vehicles, vehicle)
@@ -96,6 +97,7 @@ func (r *ratesRequest) Execute(ctx context.Context, httpTransport *http.Transpor
 }
 
 func (r *ratesRequest) makeRequest(
+	ctx context.Context,
 	client *http.Client,
 	token string,
 ) ([]json.PricesAndAvailabilityRS, error) {
@@ -114,7 +116,7 @@ func (r *ratesRequest) makeRequest(
 	v, _ := query.Values(opt)
 
 	url := fmt.Sprintf("%v/api/AvailabilityByPlace?%v", r.configuration.SupplierApiUrl, v.Encode())
-	c := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Rates)
+	c := context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, schema.Rates)
 
 	httpRequest, _ := http.NewRequestWithContext(c, http.MethodGet, url, http.NoBody)
 	httpRequest.Header.Set("Authorization", "Bearer "+token)
@@ -13,6 +13,7 @@ import (
 
 	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/rently"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
@@ -134,7 +135,7 @@ func TestRatesRequest(t *testing.T) {
 				mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 				mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-				service := rently.New(redisClient)
+				service := rently.New(caching.NewRedisCache(redisClient))
 				ctx := context.Background()
 				vehicles, err := service.GetRates(ctx, params, &log)
 				assert.Nil(t, err)
@@ -187,7 +188,7 @@ func TestRatesRequest(t *testing.T) {
 		mock.ExpectGet(defaultAuthRedisCacheKey(testServer.URL)).RedisNil()
 		mock.ExpectSetEx(defaultAuthRedisCacheKey(testServer.URL), cachedKey, time.Duration(3600)*time.Second).SetVal("")
 
-		service := rently.New(redisClient)
+		service := rently.New(caching.NewRedisCache(redisClient))
 
 		channel := make(chan schema.RatesResponse, 1)
 
@@ -312,7 +313,7 @@ func ratesParamsTemplate(configuration schema.RentlyConfiguration) schema.RatesR
 }
 
 func getRates(params schema.RatesRequestParams, log *zerolog.Logger, redisClient *redis.Client) (schema.RatesResponse, error) {
-	service := rently.New(redisClient)
+	service := rently.New(caching.NewRedisCache(redisClient))
 	ctx := context.Background()
 	return service.GetRates(ctx, params, log)
 }
@@ -10,12 +10,11 @@ import (
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
-	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 )
 
 type rentlyCar struct {
-	redis         *redis.Client
+	cache         *caching.Cacher
 	httpTransport *http.Transport
 }
 
@@ -28,10 +27,10 @@ func (r *rentlyCar) GetLocations(ctx context.Context, params schema.LocationsReq
 		configuration: configuration,
 		logger:        logger,
 		slowLogger:    slowLogger,
-		cache:         caching.NewRedisCache(r.redis),
+		cache:         r.cache,
 	}
 
-	locations, err := locationsRequest.Execute(r.httpTransport)
+	locations, err := locationsRequest.Execute(ctx, r.httpTransport)
 	if err != nil {
 		return locations, err
 	}
@@ -48,7 +47,7 @@ func (a *rentlyCar) GetRates(ctx context.Context, params schema.RatesRequestPara
 		configuration: configuration,
 		logger:        logger,
 		slowLogger:    slowLogger,
-		cache:         caching.NewRedisCache(a.redis),
+		cache:         a.cache,
 	}
 
 	rates, err := ratesRequest.Execute(ctx, a.httpTransport)
@@ -73,10 +72,23 @@ func (a *rentlyCar) CreateBooking(ctx context.Context, params schema.BookingRequ
 		configuration:         configuration,
 		supplierRateReference: supplierRateReference,
 		logger:                logger,
-		cache:                 caching.NewRedisCache(a.redis),
+		cache:                 a.cache,
 	}
 
-	return bookingRequest.Execute(a.httpTransport)
+	return bookingRequest.Execute(ctx, a.httpTransport)
+}
+
+func (a *rentlyCar) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
+	configuration, _ := params.Configuration.AsRentlyConfiguration()
+
+	bookingStatusRequest := bookingStatusRequest{
+		params:        params,
+		configuration: configuration,
+		logger:        logger,
+		cache:         a.cache,
+	}
+
+	return bookingStatusRequest.Execute(ctx, a.httpTransport)
 }
 
 func (a *rentlyCar) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
@@ -86,19 +98,19 @@ func (a *rentlyCar) CancelBooking(ctx context.Context, params schema.CancelReque
 		params:        params,
 		configuration: configuration,
 		logger:        logger,
-		cache:         caching.NewRedisCache(a.redis),
+		cache:         a.cache,
 	}
 
-	return bookingCancel.Execute(a.httpTransport)
+	return bookingCancel.Execute(ctx, a.httpTransport)
 }
 
-func New(redisClient *redis.Client) *rentlyCar {
+func New(cache *caching.Cacher) *rentlyCar {
 	transport := http.DefaultTransport.(*http.Transport)
 	// improves durations a lot
 	transport.DisableKeepAlives = true
 
 	return &rentlyCar{
-		redis:         redisClient,
+		cache:         cache,
 		httpTransport: transport,
 	}
 }
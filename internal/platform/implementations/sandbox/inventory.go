@@ -0,0 +1,296 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+)
+
+const (
+	defaultVehicles = 6
+	defaultCurrency = "EUR"
+	// onRequestShare of the vehicles needs a confirmation of the supplier
+	onRequestShare = 0.1
+	// limitedMileageShare of the vehicles comes with limited mileage
+	limitedMileageShare = 0.2
+	youngDriverAge      = 25
+)
+
+type model struct {
+	name           string
+	acriss         string
+	doors          int
+	seats          int
+	bigSuitcases   int
+	smallSuitcases int
+	transmission   schema.VehicleTransmissionType
+	fuel           schema.VehicleFuelType
+	dailyRate      float64
+}
+
+// fleet are the models vehicles are drawn from, the acriss code is the class
+var fleet = []model{
+	{"Fiat 500", "MBMR", 3, 4, 0, 1, schema.Manual, schema.Petrol, 24},
+	{"VW Polo", "EDMR", 5, 5, 1, 1, schema.Manual, schema.Petrol, 29},
+	{"Renault Zoe", "ECAE", 5, 5, 1, 1, schema.Automatic, schema.Electric, 35},
+	{"VW Golf", "CDMR", 5, 5, 1, 2, schema.Manual, schema.Petrol, 36},
+	{"Toyota Corolla Hybrid", "CDAH", 5, 5, 1, 2, schema.Automatic, schema.Hybrid, 41},
+	{"Skoda Octavia Combi", "IWMD", 5, 5, 2, 2, schema.Manual, schema.Diesel, 45},
+	{"VW Tiguan", "IFAR", 5, 5, 2, 2, schema.Automatic, schema.Petrol, 58},
+	{"BMW 3 Series", "PDAR", 4, 5, 2, 1, schema.Automatic, schema.Petrol, 72},
+	{"Tesla Model 3", "PDAE", 4, 5, 1, 2, schema.Automatic, schema.Electric, 79},
+	{"Ford Transit Custom", "FVMD", 4, 9, 3, 3, schema.Manual, schema.Diesel, 89},
+	{"Mercedes E-Class", "LDAD", 4, 5, 2, 2, schema.Automatic, schema.Diesel, 95},
+}
+
+type charge struct {
+	code        string
+	name        string
+	unit        schema.ExtraOrFeeUnit
+	price       float64
+	maxQuantity int
+}
+
+// equipment are the extras vehicles are offered with, each one is drawn per vehicle
+var equipment = []charge{
+	{"GPS", "Navigation system", schema.PerDay, 9.5, 1},
+	{"CSI", "Child seat", schema.PerDay, 7, 2},
+	{"BST", "Booster seat", schema.PerDay, 5, 2},
+	{"ADD", "Additional driver", schema.PerDay, 11, 3},
+	{"SKI", "Ski rack", schema.PerRental, 25, 1},
+}
+
+// rateReference is all a booking needs, the sandbox keeps no rates
+type rateReference struct {
+	Seed  int                `json:"seed"`
+	Class string             `json:"class"`
+	Name  string             `json:"name"`
+	Price schema.PriceAmount `json:"price"`
+}
+
+var errorRateReference = errors.New("rate reference without class")
+
+func decodeRateReference(value string, reference *rateReference) error {
+	if err := json.Unmarshal([]byte(value), reference); err != nil {
+		return err
+	}
+
+	if reference.Class == "" {
+		return errorRateReference
+	}
+
+	return nil
+}
+
+// random is seeded by the configuration and the search, equal searches draw
+// equal vehicles
+func random(seed int, pieces ...string) *rand.Rand {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d", seed)
+
+	for _, piece := range pieces {
+		fmt.Fprintf(h, "|%s", piece)
+	}
+
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+func round(value float64) schema.RoundedFloat {
+	return schema.RoundedFloat(math.Round(value*100) / 100)
+}
+
+// vary draws value within +/- share of it
+func vary(r *rand.Rand, value float64, share float64) float64 {
+	return value * (1 - share + 2*share*r.Float64())
+}
+
+func rentalDays(params schema.RatesRequestParams) int {
+	if params.RentalDays > 0 {
+		return params.RentalDays
+	}
+
+	days := int(math.Ceil(params.DropOff.DateTime.Sub(params.PickUp.DateTime).Hours() / 24))
+	if days < 1 {
+		return 1
+	}
+
+	return days
+}
+
+// vehicles generates the vehicles of a search sorted by price
+func vehicles(configuration schema.SandboxConfiguration, params schema.RatesRequestParams) []schema.Vehicle {
+	days := rentalDays(params)
+	r := random(
+		configuration.Seed,
+		params.PickUp.Code,
+		params.DropOff.Code,
+		params.PickUp.DateTime.Format(time.DateOnly),
+		fmt.Sprint(days),
+	)
+
+	count := converting.Unwrap(configuration.Vehicles)
+	if count <= 0 {
+		count = defaultVehicles
+	}
+	if count > len(fleet) {
+		count = len(fleet)
+	}
+
+	currency := params.Contract.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	generated := []schema.Vehicle{}
+	for _, i := range r.Perm(len(fleet))[:count] {
+		generated = append(generated, vehicle(r, configuration.Seed, fleet[i], params, days, currency))
+	}
+
+	sort.SliceStable(generated, func(i, j int) bool {
+		return generated[i].Price.Amount < generated[j].Price.Amount
+	})
+
+	return generated
+}
+
+func vehicle(r *rand.Rand, seed int, m model, params schema.RatesRequestParams, days int, currency string) schema.Vehicle {
+	price := schema.PriceAmount{
+		Amount:   round(vary(r, m.dailyRate, 0.15) * float64(days)),
+		Currency: currency,
+	}
+
+	status := schema.AVAILABLE
+	if r.Float64() < onRequestShare {
+		status = schema.ONREQUEST
+	}
+
+	mileage := schema.Mileage{Unlimited: converting.PointerToValue(true)}
+	if r.Float64() < limitedMileageShare {
+		mileage = schema.Mileage{
+			Unlimited:        converting.PointerToValue(false),
+			IncludedDistance: converting.PointerToValue("200"),
+			DistanceUnit:     converting.PointerToValue(schema.Km),
+			PeriodUnit:       converting.PointerToValue(schema.Day),
+		}
+	}
+
+	extrasAndFees := append(extras(r, currency), fees(r, params, currency)...)
+	extrasAndFees = append(extrasAndFees, coverages(r, currency)...)
+
+	reference, _ := json.Marshal(rateReference{
+		Seed:  seed,
+		Class: m.acriss,
+		Name:  m.name,
+		Price: price,
+	})
+
+	return schema.Vehicle{
+		Name:                  m.name,
+		Class:                 m.acriss,
+		AcrissCode:            converting.PointerToValue(m.acriss),
+		Price:                 price,
+		Status:                status,
+		Doors:                 converting.PointerToValue(m.doors),
+		Seats:                 converting.PointerToValue(m.seats),
+		BigSuitcases:          converting.PointerToValue(m.bigSuitcases),
+		SmallSuitcases:        converting.PointerToValue(m.smallSuitcases),
+		TransmissionType:      converting.PointerToValue(m.transmission),
+		FuelType:              converting.PointerToValue(m.fuel),
+		HasAirco:              converting.PointerToValue(true),
+		Mileage:               &mileage,
+		ExtrasAndFees:         &extrasAndFees,
+		SupplierRateReference: converting.PointerToValue(string(reference)),
+	}
+}
+
+func extras(r *rand.Rand, currency string) []schema.ExtraOrFee {
+	offered := []schema.ExtraOrFee{}
+
+	for _, c := range equipment {
+		if r.Intn(2) == 0 {
+			continue
+		}
+
+		offered = append(offered, schema.ExtraOrFee{
+			Type:        schema.EQP,
+			Code:        c.code,
+			Name:        c.name,
+			Price:       schema.PriceAmount{Amount: round(vary(r, c.price, 0.1)), Currency: currency},
+			PayLocal:    true,
+			Unit:        converting.PointerToValue(c.unit),
+			MaxQuantity: converting.PointerToValue(c.maxQuantity),
+		})
+	}
+
+	return offered
+}
+
+// fees depend on the search, one-way rentals, airports and young drivers pay extra
+func fees(r *rand.Rand, params schema.RatesRequestParams, currency string) []schema.ExtraOrFee {
+	charged := []schema.ExtraOrFee{}
+
+	if params.PickUp.Code != params.DropOff.Code {
+		charged = append(charged, schema.ExtraOrFee{
+			Type:      schema.VCP,
+			Code:      "ONE",
+			Name:      "One-way fee",
+			Price:     schema.PriceAmount{Amount: round(vary(r, 75, 0.3)), Currency: currency},
+			PayLocal:  true,
+			Mandatory: true,
+			Unit:      converting.PointerToValue(schema.PerRental),
+		})
+	}
+
+	if params.PickUp.Iata != nil {
+		charged = append(charged, schema.ExtraOrFee{
+			Type:           schema.VCP,
+			Code:           "APT",
+			Name:           "Airport surcharge",
+			Price:          schema.PriceAmount{Amount: round(vary(r, 30, 0.2)), Currency: currency},
+			IncludedInRate: true,
+			Mandatory:      true,
+			Unit:           converting.PointerToValue(schema.PerRental),
+		})
+	}
+
+	if params.Age > 0 && params.Age < youngDriverAge {
+		charged = append(charged, schema.ExtraOrFee{
+			Type:      schema.VCP,
+			Code:      "YDR",
+			Name:      "Young driver fee",
+			Price:     schema.PriceAmount{Amount: round(vary(r, 15, 0.2)), Currency: currency},
+			PayLocal:  true,
+			Mandatory: true,
+			Unit:      converting.PointerToValue(schema.PerDay),
+		})
+	}
+
+	return charged
+}
+
+func coverages(r *rand.Rand, currency string) []schema.ExtraOrFee {
+	covered := []schema.ExtraOrFee{}
+
+	for _, c := range []struct{ code, name string }{{"CDW", "Collision damage waiver"}, {"TP", "Theft protection"}} {
+		covered = append(covered, schema.ExtraOrFee{
+			Type:           schema.VCT,
+			Code:           c.code,
+			Name:           c.name,
+			Price:          schema.PriceAmount{Amount: 0, Currency: currency},
+			IncludedInRate: true,
+			Mandatory:      true,
+			Unit:           converting.PointerToValue(schema.PerRental),
+			Excess:         &schema.PriceAmount{Amount: round(float64(500 + 100*r.Intn(11))), Currency: currency},
+		})
+	}
+
+	return covered
+}
@@ -0,0 +1,108 @@
+package sandbox
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const defaultLocations = 10
+
+type city struct {
+	iata       string
+	name       string
+	country    string
+	postalCode string
+	dialCode   string
+	latitude   float32
+	longitude  float32
+}
+
+// cities have an airport and a downtown branch each
+var cities = []city{
+	{"MUC", "Munich", "DE", "80331", "+49 89", 48.1374, 11.5755},
+	{"BER", "Berlin", "DE", "10117", "+49 30", 52.5200, 13.4050},
+	{"FRA", "Frankfurt", "DE", "60311", "+49 69", 50.1109, 8.6821},
+	{"HAM", "Hamburg", "DE", "20095", "+49 40", 53.5511, 9.9937},
+	{"VIE", "Vienna", "AT", "1010", "+43 1", 48.2082, 16.3738},
+	{"ZRH", "Zurich", "CH", "8001", "+41 44", 47.3769, 8.5417},
+	{"CDG", "Paris", "FR", "75001", "+33 1", 48.8566, 2.3522},
+	{"NCE", "Nice", "FR", "06000", "+33 4", 43.7102, 7.2620},
+	{"BCN", "Barcelona", "ES", "08002", "+34 93", 41.3874, 2.1686},
+	{"MAD", "Madrid", "ES", "28013", "+34 91", 40.4168, -3.7038},
+	{"LIS", "Lisbon", "PT", "1100-148", "+351 21", 38.7223, -9.1393},
+	{"FCO", "Rome", "IT", "00186", "+39 06", 41.9028, 12.4964},
+	{"MXP", "Milan", "IT", "20121", "+39 02", 45.4642, 9.1900},
+	{"AMS", "Amsterdam", "NL", "1012", "+31 20", 52.3676, 4.9041},
+	{"LHR", "London", "GB", "WC2N 5DU", "+44 20", 51.5072, -0.1276},
+	{"DUB", "Dublin", "IE", "D02", "+353 1", 53.3498, -6.2603},
+}
+
+// locations generates the branches of the sandbox, codes are the iata code
+// of the city followed by T for the airport terminal or C for downtown
+func locations(configuration schema.SandboxConfiguration) []schema.Location {
+	r := random(configuration.Seed, "locations")
+
+	count := converting.Unwrap(configuration.Locations)
+	if count <= 0 {
+		count = defaultLocations
+	}
+	if count > 2*len(cities) {
+		count = 2 * len(cities)
+	}
+
+	generated := []schema.Location{}
+	for _, i := range r.Perm(2 * len(cities))[:count] {
+		generated = append(generated, location(r, configuration.Seed, cities[i/2], i%2 == 0))
+	}
+
+	return generated
+}
+
+func location(r *rand.Rand, seed int, c city, airport bool) schema.Location {
+	code := c.iata + "C01"
+	name := c.name + " Downtown"
+	address := fmt.Sprintf("Main Street %d", 1+r.Intn(120))
+	openingHours := []schema.OpeningTime{
+		{Weekday: 0, Open: true, Start: "08:00", End: "18:00"},
+		{Weekday: 7, Open: false, Start: "00:00", End: "00:00"},
+	}
+
+	if airport {
+		code = c.iata + "T01"
+		name = c.name + " Airport"
+		address = fmt.Sprintf("Terminal %d", 1+r.Intn(3))
+		openingHours = []schema.OpeningTime{
+			{Weekday: 0, Open: true, Start: "06:00", End: "23:59"},
+		}
+	}
+
+	parsed := schema.Location{
+		Code:          code,
+		Name:          name,
+		Country:       c.country,
+		City:          converting.PointerToValue(c.name),
+		Address:       converting.PointerToValue(address),
+		PostalCode:    converting.PointerToValue(c.postalCode),
+		Phone:         converting.PointerToValue(fmt.Sprintf("%s %07d", c.dialCode, r.Intn(10000000))),
+		Email:         converting.PointerToValue(openapi_types.Email(strings.ToLower(code) + "@sandbox.example.com")),
+		Latitude:      converting.PointerToValue(c.latitude + float32(r.Intn(200)-100)/10000),
+		Longitude:     converting.PointerToValue(c.longitude + float32(r.Intn(200)-100)/10000),
+		OpeningHours:  &openingHours,
+		MinRentalDays: converting.PointerToValue(1),
+		MaxRentalDays: converting.PointerToValue(28 + 7*r.Intn(9)),
+	}
+
+	parsed.RawData.ContentType = "application/json"
+	parsed.RawData.Content = fmt.Sprintf(`{"seed":%d,"code":%q}`, seed, code)
+
+	if airport {
+		parsed.Iata = converting.PointerToValue(c.iata)
+	}
+
+	return parsed
+}
@@ -0,0 +1,305 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/errors"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// sandbox answers like a supplier without asking one. Vehicles and locations
+// are generated from the seed of the configuration, bookings are kept in
+// redis so booking, status, modify and cancel follow each other.
+type sandbox struct {
+	bookings *store
+}
+
+func timeout(timeouts schema.Timeouts, specific *int) int {
+	if specific != nil {
+		return *specific
+	}
+
+	return timeouts.Default
+}
+
+func newReference() string {
+	return "SBX" + strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:12])
+}
+
+func (s *sandbox) TrafficLightGroupingCacheKey(ctx context.Context, params schema.RatesRequestParams, logger *zerolog.Logger) string {
+	configuration, _ := params.Configuration.AsSandboxConfiguration()
+
+	var reservNumber string
+	if params.Booking != nil {
+		reservNumber = converting.Unwrap(params.Booking.ReservNumber)
+	}
+
+	keyPieces := [12]string{
+		"grouping",
+		"supplier-sandbox",
+		fmt.Sprint(configuration.Seed),
+		fmt.Sprint(converting.Unwrap(configuration.Vehicles)),
+		params.PickUp.Code,
+		params.DropOff.Code,
+		params.PickUp.DateTime.Format(time.DateOnly),
+		fmt.Sprint(rentalDays(params)),
+		params.Contract.Currency,
+		fmt.Sprint(params.PickUp.Iata != nil),
+		fmt.Sprint(params.Age > 0 && params.Age < youngDriverAge),
+		string(scenarioOf(reservNumber)),
+	}
+
+	return strings.ToLower(strings.Join(keyPieces[:], ":"))
+}
+
+func (s *sandbox) GetRates(ctx context.Context, params schema.RatesRequestParams, logger *zerolog.Logger) (schema.RatesResponse, error) {
+	configuration, _ := params.Configuration.AsSandboxConfiguration()
+
+	rates := schema.RatesResponse{
+		Vehicles:         []schema.Vehicle{},
+		SupplierRequests: &schema.SupplierRequests{},
+		Errors:           &schema.SupplierResponseErrors{},
+	}
+
+	// rates have no reservNumber unless an existing booking is modified
+	if params.Booking != nil {
+		scenario := scenarioOf(converting.Unwrap(params.Booking.ReservNumber))
+		if e := scenario.failure(ctx, timeout(params.Timeouts, params.Timeouts.Rates)); e != nil {
+			*rates.Errors = append(*rates.Errors, *e)
+			return rates, nil
+		}
+	}
+
+	rates.Vehicles = vehicles(configuration, params)
+
+	return rates, nil
+}
+
+func (s *sandbox) CreateBooking(ctx context.Context, params schema.BookingRequestParams, logger *zerolog.Logger) (schema.BookingResponse, error) {
+	var vehicle rateReference
+	if err := decodeRateReference(params.SupplierRateReference, &vehicle); err != nil {
+		return schema.BookingResponse{}, errors.ErrorInvalidRateReference
+	}
+
+	response := schema.BookingResponse{
+		Status:           schema.BookingResponseStatusFAILED,
+		SupplierRequests: &schema.SupplierRequests{},
+		Errors:           &schema.SupplierResponseErrors{},
+	}
+
+	ctx = requesting.Detach(ctx)
+
+	scenario := scenarioOf(params.ReservNumber)
+	if e := scenario.failure(ctx, timeout(params.Timeouts, params.Timeouts.Booking)); e != nil {
+		*response.Errors = append(*response.Errors, *e)
+		return response, nil
+	}
+
+	now := time.Now().UTC()
+	b := booking{
+		Reference:       newReference(),
+		ReservNumber:    params.ReservNumber,
+		BrokerReference: params.BrokerReference,
+		Status:          schema.BookingStatusResponseStatusOK,
+		Vehicle:         vehicle,
+		PickUp:          params.PickUp,
+		DropOff:         params.DropOff,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	response.Status = schema.BookingResponseStatusOK
+	if scenario == scenarioPending {
+		b.Status = schema.BookingStatusResponseStatusPENDING
+		response.Status = schema.BookingResponseStatusPENDING
+	}
+
+	if err := s.bookings.save(ctx, b); err != nil {
+		return schema.BookingResponse{}, err
+	}
+
+	response.SupplierBookingReference = converting.PointerToValue(b.Reference)
+
+	return response, nil
+}
+
+func (s *sandbox) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
+	response := schema.BookingStatusResponse{
+		Status:           schema.BookingStatusResponseStatusFAILED,
+		SupplierRequests: &schema.SupplierRequests{},
+		Errors:           &schema.SupplierResponseErrors{},
+	}
+
+	ctx = requesting.Detach(ctx)
+
+	if e := scenarioOf(params.ReservNumber).failure(ctx, params.Timeouts.Default); e != nil {
+		*response.Errors = append(*response.Errors, *e)
+		return response, nil
+	}
+
+	b, found, err := s.bookings.load(ctx, params.SupplierBookingReference)
+	if err != nil {
+		return schema.BookingStatusResponse{}, err
+	}
+
+	if !found {
+		*response.Errors = append(*response.Errors, schema.NewSupplierError("booking not found"))
+		return response, nil
+	}
+
+	response.Status = b.Status
+
+	// pending bookings are confirmed by the supplier while the status is checked
+	if b.Status == schema.BookingStatusResponseStatusPENDING {
+		b.Checks++
+		if b.Checks >= pendingChecks {
+			b.Status = schema.BookingStatusResponseStatusOK
+			response.Status = b.Status
+		}
+
+		b.UpdatedAt = time.Now().UTC()
+		if err := s.bookings.save(ctx, b); err != nil {
+			return schema.BookingStatusResponse{}, err
+		}
+	}
+
+	response.SupplierBookingReference = converting.PointerToValue(b.Reference)
+
+	return response, nil
+}
+
+func (s *sandbox) ModifyBooking(ctx context.Context, params schema.ModifyRequestParams, logger *zerolog.Logger) (schema.ModifyResponse, error) {
+	var vehicle rateReference
+	if params.SupplierRateReference != "" {
+		if err := decodeRateReference(params.SupplierRateReference, &vehicle); err != nil {
+			return schema.ModifyResponse{}, errors.ErrorInvalidRateReference
+		}
+	}
+
+	response := schema.ModifyResponse{
+		Status:           converting.PointerToValue(schema.ModifyResponseStatusFAILED),
+		SupplierRequests: &schema.SupplierRequests{},
+		Errors:           &schema.SupplierResponseErrors{},
+	}
+
+	ctx = requesting.Detach(ctx)
+
+	scenario := scenarioOf(params.ReservNumber)
+	if e := scenario.failure(ctx, timeout(params.Timeouts, params.Timeouts.Booking)); e != nil {
+		*response.Errors = append(*response.Errors, *e)
+		return response, nil
+	}
+
+	b, found, err := s.bookings.load(ctx, params.SupplierBookingReference)
+	if err != nil {
+		return schema.ModifyResponse{}, err
+	}
+
+	if !found {
+		*response.Errors = append(*response.Errors, schema.NewSupplierError("booking not found"))
+		return response, nil
+	}
+
+	if b.Status == schema.BookingStatusResponseStatusCANCELLED {
+		*response.Errors = append(*response.Errors, schema.NewSupplierError("booking is cancelled"))
+		return response, nil
+	}
+
+	if vehicle.Class != "" {
+		b.Vehicle = vehicle
+	}
+
+	b.ReservNumber = params.ReservNumber
+	b.PickUp = params.PickUp
+	b.DropOff = params.DropOff
+	b.Modifications++
+	b.Checks = 0
+	b.UpdatedAt = time.Now().UTC()
+
+	b.Status = schema.BookingStatusResponseStatusOK
+	response.Status = converting.PointerToValue(schema.ModifyResponseStatusOK)
+	if scenario == scenarioPending {
+		b.Status = schema.BookingStatusResponseStatusPENDING
+		response.Status = converting.PointerToValue(schema.ModifyResponseStatusPENDING)
+	}
+
+	if err := s.bookings.save(ctx, b); err != nil {
+		return schema.ModifyResponse{}, err
+	}
+
+	response.SupplierBookingReference = converting.PointerToValue(b.Reference)
+
+	return response, nil
+}
+
+func (s *sandbox) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
+	response := schema.CancelResponse{
+		Status:           converting.PointerToValue(schema.CancelResponseStatusFAILED),
+		SupplierRequests: &schema.SupplierRequests{},
+		Errors:           &schema.SupplierResponseErrors{},
+	}
+
+	ctx = requesting.Detach(ctx)
+
+	scenario := scenarioOf(params.ReservNumber)
+	if e := scenario.failure(ctx, timeout(params.Timeouts, params.Timeouts.Cancel)); e != nil {
+		*response.Errors = append(*response.Errors, *e)
+		return response, nil
+	}
+
+	if scenario == scenarioNoCancel {
+		*response.Errors = append(*response.Errors, schema.NewSupplierError("sandbox scenario "+string(scenario)))
+		return response, nil
+	}
+
+	b, found, err := s.bookings.load(ctx, params.SupplierBookingReference)
+	if err != nil {
+		return schema.CancelResponse{}, err
+	}
+
+	if !found {
+		*response.Errors = append(*response.Errors, schema.NewSupplierError("booking not found"))
+		return response, nil
+	}
+
+	// cancelling again succeeds, as with most suppliers
+	if b.Status != schema.BookingStatusResponseStatusCANCELLED {
+		b.Status = schema.BookingStatusResponseStatusCANCELLED
+		b.UpdatedAt = time.Now().UTC()
+
+		if err := s.bookings.save(ctx, b); err != nil {
+			return schema.CancelResponse{}, err
+		}
+	}
+
+	response.Status = converting.PointerToValue(schema.CancelResponseStatusOK)
+
+	return response, nil
+}
+
+func (s *sandbox) GetLocations(ctx context.Context, params schema.LocationsRequestParams, logger *zerolog.Logger) (schema.LocationsResponse, error) {
+	configuration, _ := params.Configuration.AsSandboxConfiguration()
+
+	generated := locations(configuration)
+
+	return schema.LocationsResponse{
+		Locations:        &generated,
+		SupplierRequests: &schema.SupplierRequests{},
+		Errors:           &schema.SupplierResponseErrors{},
+	}, nil
+}
+
+// New keeps the bookings in the redis of client
+func New(client redis.UniversalClient) *sandbox {
+	return &sandbox{
+		bookings: &store{client: client},
+	}
+}
@@ -0,0 +1,269 @@
+package sandbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/errors"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/sandbox"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+var log = zerolog.Nop()
+
+type platform interface {
+	GetRates(context.Context, schema.RatesRequestParams, *zerolog.Logger) (schema.RatesResponse, error)
+	CreateBooking(context.Context, schema.BookingRequestParams, *zerolog.Logger) (schema.BookingResponse, error)
+	GetBookingStatus(context.Context, schema.BookingStatusRequestParams, *zerolog.Logger) (schema.BookingStatusResponse, error)
+	ModifyBooking(context.Context, schema.ModifyRequestParams, *zerolog.Logger) (schema.ModifyResponse, error)
+	CancelBooking(context.Context, schema.CancelRequestParams, *zerolog.Logger) (schema.CancelResponse, error)
+	GetLocations(context.Context, schema.LocationsRequestParams, *zerolog.Logger) (schema.LocationsResponse, error)
+}
+
+func service(t *testing.T) platform {
+	redisServer := miniredis.RunT(t)
+
+	return sandbox.New(redis.NewClient(&redis.Options{Addr: redisServer.Addr()}))
+}
+
+func configuration(seed int) []byte {
+	b, _ := json.Marshal(schema.SandboxConfiguration{Seed: seed})
+	return b
+}
+
+func ratesParams(seed int) schema.RatesRequestParams {
+	var cp schema.RatesRequestParams_Configuration
+	json.Unmarshal(configuration(seed), &cp)
+
+	pickUp := time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC)
+
+	return schema.RatesRequestParams{
+		PickUp:     schema.RequestBranch{Code: "MUCT01", Country: "DE", DateTime: pickUp, Iata: converting.PointerToValue("MUC")},
+		DropOff:    schema.RequestBranch{Code: "BERC01", Country: "DE", DateTime: pickUp.AddDate(0, 0, 7)},
+		RentalDays: 7,
+		Age:        30,
+		Contract:   schema.Contract{Currency: "EUR"},
+		Timeouts:   schema.Timeouts{Default: 8000},
+
+		Configuration: cp,
+	}
+}
+
+func bookingParams(reservNumber string, rateReference string) schema.BookingRequestParams {
+	var cp schema.BookingRequestParams_Configuration
+	json.Unmarshal(configuration(1), &cp)
+
+	return schema.BookingRequestParams{
+		ReservNumber:          reservNumber,
+		BrokerReference:       "B123",
+		SupplierRateReference: rateReference,
+		Customer:              schema.Customer{Email: "mock@example.com"},
+		Timeouts:              schema.Timeouts{Default: 8000},
+		Configuration:         cp,
+	}
+}
+
+func statusParams(reservNumber string, reference string) schema.BookingStatusRequestParams {
+	return schema.BookingStatusRequestParams{
+		ReservNumber:             reservNumber,
+		SupplierBookingReference: reference,
+		Timeouts:                 schema.Timeouts{Default: 8000},
+	}
+}
+
+func modifyParams(reservNumber string, reference string) schema.ModifyRequestParams {
+	return schema.ModifyRequestParams{
+		ReservNumber:             reservNumber,
+		SupplierBookingReference: reference,
+		Customer:                 schema.Customer{Email: "mock@example.com"},
+		Timeouts:                 schema.Timeouts{Default: 8000},
+	}
+}
+
+func cancelParams(reservNumber string, reference string) schema.CancelRequestParams {
+	return schema.CancelRequestParams{
+		ReservNumber:             reservNumber,
+		SupplierBookingReference: reference,
+		Contact:                  schema.Contact{Email: "mock@example.com"},
+		Timeouts:                 schema.Timeouts{Default: 8000},
+	}
+}
+
+// book returns the reference of a booking of the cheapest vehicle
+func book(t *testing.T, s platform, reservNumber string) (schema.BookingResponse, string) {
+	rates, _ := s.GetRates(context.Background(), ratesParams(1), &log)
+
+	booking, err := s.CreateBooking(context.Background(), bookingParams(reservNumber, *rates.Vehicles[0].SupplierRateReference), &log)
+	assert.Nil(t, err)
+
+	return booking, converting.Unwrap(booking.SupplierBookingReference)
+}
+
+func TestRates(t *testing.T) {
+	t.Run("should generate the vehicles of the seed", func(t *testing.T) {
+		rates, err := service(t).GetRates(context.Background(), ratesParams(1), &log)
+		assert.Nil(t, err)
+
+		actual, _ := json.MarshalIndent(rates, "", "\t")
+		expected, _ := os.ReadFile("./testdata/rates/response_default.json")
+
+		assert.Equal(t, strings.TrimSpace(string(expected)), string(actual))
+	})
+
+	t.Run("should generate other vehicles for other seeds", func(t *testing.T) {
+		s := service(t)
+
+		one, _ := s.GetRates(context.Background(), ratesParams(1), &log)
+		two, _ := s.GetRates(context.Background(), ratesParams(2), &log)
+
+		assert.NotEqual(t, one.Vehicles, two.Vehicles)
+	})
+
+	t.Run("should charge fees by the search", func(t *testing.T) {
+		params := ratesParams(1)
+		params.DropOff.Code = params.PickUp.Code
+		params.PickUp.Iata = nil
+		params.Age = 21
+
+		rates, _ := service(t).GetRates(context.Background(), params, &log)
+
+		for _, vehicle := range rates.Vehicles {
+			codes := []string{}
+			for _, fee := range *vehicle.ExtrasAndFees {
+				if fee.Type == schema.VCP {
+					codes = append(codes, fee.Code)
+				}
+			}
+
+			assert.Equal(t, []string{"YDR"}, codes)
+		}
+	})
+
+	t.Run("should fail searches of scenarios", func(t *testing.T) {
+		params := ratesParams(1)
+		params.Booking = &schema.ExistingBooking{ReservNumber: converting.PointerToValue("SANDBOX-ERROR-1")}
+
+		rates, _ := service(t).GetRates(context.Background(), params, &log)
+
+		assert.Empty(t, rates.Vehicles)
+		assert.Len(t, *rates.Errors, 1)
+		assert.Equal(t, schema.SupplierError, (*rates.Errors)[0].Code)
+	})
+}
+
+func TestLocations(t *testing.T) {
+	t.Run("should generate the locations of the seed", func(t *testing.T) {
+		var cp schema.LocationsRequestParams_Configuration
+		cp.FromSandboxConfiguration(schema.SandboxConfiguration{Seed: 1, Locations: converting.PointerToValue(4)})
+
+		s := service(t)
+		params := schema.LocationsRequestParams{Configuration: cp}
+
+		one, err := s.GetLocations(context.Background(), params, &log)
+		two, _ := s.GetLocations(context.Background(), params, &log)
+
+		assert.Nil(t, err)
+		assert.Len(t, *one.Locations, 4)
+		assert.Equal(t, one, two)
+	})
+}
+
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should keep the status of bookings", func(t *testing.T) {
+		s := service(t)
+
+		booking, reference := book(t, s, "R123")
+		assert.Equal(t, schema.BookingResponseStatusOK, booking.Status)
+		assert.True(t, strings.HasPrefix(reference, "SBX"))
+
+		status, _ := s.GetBookingStatus(ctx, statusParams("R123", reference), &log)
+		assert.Equal(t, schema.BookingStatusResponseStatusOK, status.Status)
+
+		modify, _ := s.ModifyBooking(ctx, modifyParams("R123", reference), &log)
+		assert.Equal(t, schema.ModifyResponseStatusOK, *modify.Status)
+		assert.Equal(t, reference, *modify.SupplierBookingReference)
+
+		cancel, _ := s.CancelBooking(ctx, cancelParams("R123", reference), &log)
+		assert.Equal(t, schema.CancelResponseStatusOK, *cancel.Status)
+
+		status, _ = s.GetBookingStatus(ctx, statusParams("R123", reference), &log)
+		assert.Equal(t, schema.BookingStatusResponseStatusCANCELLED, status.Status)
+
+		cancel, _ = s.CancelBooking(ctx, cancelParams("R123", reference), &log)
+		assert.Equal(t, schema.CancelResponseStatusOK, *cancel.Status)
+
+		modify, _ = s.ModifyBooking(ctx, modifyParams("R123", reference), &log)
+		assert.Equal(t, schema.ModifyResponseStatusFAILED, *modify.Status)
+		assert.Equal(t, "booking is cancelled", (*modify.Errors)[0].Message)
+	})
+
+	t.Run("should confirm pending bookings on the second status check", func(t *testing.T) {
+		s := service(t)
+
+		booking, reference := book(t, s, "sandbox-pending-1")
+		assert.Equal(t, schema.BookingResponseStatusPENDING, booking.Status)
+
+		status, _ := s.GetBookingStatus(ctx, statusParams("sandbox-pending-1", reference), &log)
+		assert.Equal(t, schema.BookingStatusResponseStatusPENDING, status.Status)
+
+		status, _ = s.GetBookingStatus(ctx, statusParams("sandbox-pending-1", reference), &log)
+		assert.Equal(t, schema.BookingStatusResponseStatusOK, status.Status)
+	})
+
+	t.Run("should fail operations of the error scenario", func(t *testing.T) {
+		booking, reference := book(t, service(t), "SANDBOX-ERROR")
+
+		assert.Equal(t, schema.BookingResponseStatusFAILED, booking.Status)
+		assert.Empty(t, reference)
+		assert.Equal(t, "sandbox scenario ERROR", (*booking.Errors)[0].Message)
+	})
+
+	t.Run("should time out operations of the timeout scenario", func(t *testing.T) {
+		s := service(t)
+		params := statusParams("SANDBOX-TIMEOUT-2", "SBX1")
+		params.Timeouts.Default = 5
+
+		start := time.Now()
+		status, _ := s.GetBookingStatus(ctx, params, &log)
+
+		assert.GreaterOrEqual(t, time.Since(start), 5*time.Millisecond)
+		assert.Equal(t, schema.BookingStatusResponseStatusFAILED, status.Status)
+		assert.Equal(t, schema.TimeoutError, (*status.Errors)[0].Code)
+	})
+
+	t.Run("should refuse cancellations of the no cancel scenario", func(t *testing.T) {
+		s := service(t)
+		_, reference := book(t, s, "SANDBOX-NOCANCEL")
+
+		cancel, _ := s.CancelBooking(ctx, cancelParams("SANDBOX-NOCANCEL", reference), &log)
+		assert.Equal(t, schema.CancelResponseStatusFAILED, *cancel.Status)
+
+		status, _ := s.GetBookingStatus(ctx, statusParams("SANDBOX-NOCANCEL", reference), &log)
+		assert.Equal(t, schema.BookingStatusResponseStatusOK, status.Status)
+	})
+
+	t.Run("should not find unknown bookings", func(t *testing.T) {
+		status, err := service(t).GetBookingStatus(ctx, statusParams("R123", "SBX0"), &log)
+
+		assert.Nil(t, err)
+		assert.Equal(t, schema.BookingStatusResponseStatusFAILED, status.Status)
+		assert.Equal(t, "booking not found", (*status.Errors)[0].Message)
+	})
+
+	t.Run("should reject invalid rate references", func(t *testing.T) {
+		_, err := service(t).CreateBooking(ctx, bookingParams("R123", "{}"), &log)
+
+		assert.ErrorIs(t, err, errors.ErrorInvalidRateReference)
+	})
+}
@@ -0,0 +1,62 @@
+package sandbox
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+)
+
+// scenario is chosen by the reservNumber, e.g. SANDBOX-PENDING-123
+type scenario string
+
+const (
+	scenarioPrefix = "SANDBOX-"
+
+	// scenarioPending books and modifies PENDING, status checks confirm the
+	// booking after pendingChecks
+	scenarioPending scenario = "PENDING"
+	// scenarioError fails every operation with a supplier error
+	scenarioError scenario = "ERROR"
+	// scenarioTimeout answers every operation after its timeout
+	scenarioTimeout scenario = "TIMEOUT"
+	// scenarioNoCancel fails cancellations with a supplier error
+	scenarioNoCancel scenario = "NOCANCEL"
+
+	pendingChecks = 2
+)
+
+func scenarioOf(reservNumber string) scenario {
+	value := strings.ToUpper(reservNumber)
+	if !strings.HasPrefix(value, scenarioPrefix) {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(value[len(scenarioPrefix):], "-")
+
+	return scenario(name)
+}
+
+// failure returns the error of scenarios failing every operation, timeouts
+// are waited for so callers see the latency of a supplier timing out
+func (s scenario) failure(ctx context.Context, timeout int) *schema.SupplierResponseError {
+	switch s {
+	case scenarioError:
+		e := schema.NewSupplierError("sandbox scenario " + string(s))
+		return &e
+	case scenarioTimeout:
+		timer := time.NewTimer(time.Duration(timeout) * time.Millisecond)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+
+		e := schema.NewTimeoutError("sandbox scenario " + string(s))
+		return &e
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"github.com/redis/go-redis/v9"
+)
+
+// bookingTtl keeps sandbox bookings long enough for test suites running
+// against them, they are not meant to be kept
+const bookingTtl = 30 * 24 * time.Hour
+
+type booking struct {
+	Reference       string                             `json:"reference"`
+	ReservNumber    string                             `json:"reservNumber"`
+	BrokerReference string                             `json:"brokerReference"`
+	Status          schema.BookingStatusResponseStatus `json:"status"`
+	Vehicle         rateReference                      `json:"vehicle"`
+	PickUp          schema.RequestBranchWithTimeZone   `json:"pickUp"`
+	DropOff         schema.RequestBranchWithTimeZone   `json:"dropOff"`
+	Modifications   int                                `json:"modifications"`
+	// Checks counts the status checks of a PENDING booking
+	Checks    int       `json:"checks"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type store struct {
+	client redis.UniversalClient
+}
+
+func bookingKey(reference string) string {
+	return "sandbox:booking:" + reference
+}
+
+// load returns false for bookings not made or expired
+func (s *store) load(ctx context.Context, reference string) (booking, bool, error) {
+	var b booking
+
+	content, err := s.client.Get(ctx, bookingKey(reference)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return b, false, nil
+	}
+
+	if err != nil {
+		return b, false, err
+	}
+
+	return b, true, json.Unmarshal(content, &b)
+}
+
+func (s *store) save(ctx context.Context, b booking) error {
+	content, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, bookingKey(b.Reference), content, bookingTtl).Err()
+}
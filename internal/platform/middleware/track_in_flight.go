@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"github.com/gin-gonic/gin"
+)
+
+// TrackInFlight registers the request with the lifecycle tracker,
+// so shutdown waits for it and reports it when it is cut off
+func TrackInFlight(name string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tracker, ok := lifecycle.FromContext(ctx.Request.Context())
+		if !ok {
+			return
+		}
+
+		done := tracker.Begin(lifecycle.Operation{
+			Name:          name,
+			Platform:      ctx.Params.ByName("platform"),
+			CorrelationId: ctx.GetString("correlationId"),
+		})
+		defer done()
+
+		ctx.Next()
+	}
+}
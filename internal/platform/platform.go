@@ -1,17 +1,21 @@
 package platform
 
 import (
+	goErrors "errors"
 	"fmt"
 	"net/http"
 
 	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/cancelretry"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/errors"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/factory"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/interfaces"
 	platformMiddleware "bitbucket.org/crgw/supplier-hub/internal/platform/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/rebook"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
+	"bitbucket.org/crgw/supplier-hub/internal/tracking"
 	"bitbucket.org/crgw/supplier-hub/internal/trafficlight/grouping"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
@@ -21,7 +25,16 @@ func RegisterRoutes(
 	router *gin.Engine,
 	factory *factory.Factory,
 	redisFactory *redisfactory.Factory,
-) {
+	rebooker *rebook.Rebooker,
+	statusTracker *tracking.Tracker,
+	cancelRetrier *cancelretry.Retrier,
+	exposeGroupingRole bool,
+) error {
+	trafficlightClient, err := redisFactory.TrafficlightClient()
+	if err != nil {
+		return err
+	}
+
 	group := router.Group(
 		"/:platform",
 		platformMiddleware.PreparePlatform(factory),
@@ -32,7 +45,11 @@ func RegisterRoutes(
 		platformMiddleware.PrepareParams(schema.RatesRequestParams{}),
 		grouping.Middleware(grouping.MiddlewareOptions{
 			CreateManager: grouping.NewRequestManager,
-			RedisClient:   redisFactory.TrafficlightClient(),
+			RedisClient:   trafficlightClient,
+			Available: func() bool {
+				return redisFactory.Available(redisfactory.Trafficlight)
+			},
+			ExposeRole: exposeGroupingRole,
 		}),
 		func(ctx *gin.Context) {
 			logger := ctx.MustGet("logger").(*zerolog.Logger)
@@ -66,6 +83,7 @@ func RegisterRoutes(
 	)
 
 	group.POST("/booking",
+		platformMiddleware.TrackInFlight("booking"),
 		platformMiddleware.PrepareParams(schema.BookingRequestParams{}),
 		func(ctx *gin.Context) {
 			platformWithRatesRequest, ok := ctx.MustGet(platformMiddleware.PlatformKey).(interfaces.WithCreateBooking)
@@ -88,6 +106,8 @@ func RegisterRoutes(
 				return
 			}
 
+			statusTracker.TrackBooking(ctx.Request.Context(), ctx.Params.ByName("platform"), ctx.GetString("clientId"), ctx.GetString("correlationId"), *params, response, logger)
+
 			ctx.JSON(http.StatusOK, response)
 		},
 	)
@@ -119,34 +139,64 @@ func RegisterRoutes(
 		},
 	)
 
+	group.POST("/booking-status/batch",
+		platformMiddleware.PrepareParams(schema.BookingStatusBatchRequestParams{}),
+		bookingStatusBatch,
+	)
+
 	group.POST("/modify",
+		platformMiddleware.TrackInFlight("modify"),
 		platformMiddleware.PrepareParams(schema.ModifyRequestParams{}),
 		func(ctx *gin.Context) {
 			platformWithRatesRequest, ok := ctx.MustGet(platformMiddleware.PlatformKey).(interfaces.WithModifyBooking)
-			if !ok {
+
+			// platforms without modify are rebooked when they book and cancel
+			platformToRebook, rebookable := ctx.MustGet(platformMiddleware.PlatformKey).(rebook.Platform)
+			if !ok && (rebooker == nil || !rebookable) {
 				middleware.HandleError(ctx, http.StatusBadRequest, "Modify not implemented", errors.ErrorNotImplemented)
 				return
 			}
 
-			params, ok := ctx.MustGet(platformMiddleware.ParamsKey).(*schema.ModifyRequestParams)
-			if !ok {
+			params, paramsOk := ctx.MustGet(platformMiddleware.ParamsKey).(*schema.ModifyRequestParams)
+			if !paramsOk {
 				middleware.HandleError(ctx, http.StatusInternalServerError, "Bad request params", nil)
 				return
 			}
 
 			logger := ctx.MustGet("logger").(*zerolog.Logger)
 
-			response, err := platformWithRatesRequest.ModifyBooking(ctx.Request.Context(), *params, logger)
+			var response schema.ModifyResponse
+			var err error
+
+			if ok {
+				response, err = platformWithRatesRequest.ModifyBooking(ctx.Request.Context(), *params, logger)
+			} else {
+				response, err = rebooker.Modify(ctx.Request.Context(), ctx.Params.ByName("platform"), platformToRebook, *params, logger)
+			}
+
+			if goErrors.Is(err, rebook.ErrorInProgress) {
+				middleware.HandleError(ctx, http.StatusConflict, "Modify in progress", err)
+				return
+			}
+
+			if goErrors.Is(err, rebook.ErrorInterrupted) {
+				middleware.HandleError(ctx, http.StatusConflict, err.Error(), err)
+				return
+			}
+
 			if err != nil {
 				middleware.HandleError(ctx, http.StatusInternalServerError, "Failed requesting modifying", nil)
 				return
 			}
 
+			statusTracker.TrackModify(ctx.Request.Context(), ctx.Params.ByName("platform"), ctx.GetString("clientId"), ctx.GetString("correlationId"), *params, response, logger)
+
 			ctx.JSON(http.StatusOK, response)
 		},
 	)
 
 	group.POST("/cancel",
+		platformMiddleware.TrackInFlight("cancel"),
 		platformMiddleware.PrepareParams(schema.CancelRequestParams{}),
 		func(ctx *gin.Context) {
 			platformWithRatesRequest, ok := ctx.MustGet(platformMiddleware.PlatformKey).(interfaces.WithCancelBooking)
@@ -169,6 +219,8 @@ func RegisterRoutes(
 				return
 			}
 
+			response = cancelRetrier.RetryFailed(ctx.Request.Context(), ctx.Params.ByName("platform"), ctx.GetString("clientId"), ctx.GetString("correlationId"), *params, response, logger)
+
 			ctx.JSON(http.StatusOK, response)
 		},
 	)
@@ -200,4 +252,6 @@ func RegisterRoutes(
 			ctx.JSON(http.StatusOK, response)
 		},
 	)
+
+	return nil
 }
@@ -0,0 +1,440 @@
+// Package rebook modifies bookings of platforms without a modify operation
+// by booking again and cancelling the old booking. Every step is saved so an
+// interrupted modification continues when it is requested again.
+package rebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/interfaces"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrorNotFound   = errors.New("rebooking not found")
+	ErrorInProgress = errors.New("rebooking is in progress")
+	// ErrorInterrupted is returned for rebookings interrupted while booking or
+	// booking without an answer of the supplier when the new booking is not
+	// found, booking again could book twice
+	ErrorInterrupted = errors.New("rebooking was interrupted while booking and the new booking was not found")
+)
+
+type State string
+
+const (
+	// StateStarted has not booked yet. Bookings failed by errors, timeouts or
+	// connection errors stay here, the supplier may have booked.
+	StateStarted State = "started"
+	// StateBooked made the new booking, the old one is not cancelled yet.
+	// Cancellations failed by timeouts or connection errors stay here.
+	StateBooked State = "booked"
+	// StateCompleted cancelled the old booking
+	StateCompleted State = "completed"
+	// StateRollingBack had the cancellation of the old booking rejected by
+	// the supplier, the new booking is not cancelled yet
+	StateRollingBack State = "rollingBack"
+	// StateRolledBack cancelled the new booking, the old one is kept
+	StateRolledBack State = "rolledBack"
+	// StateFailed had the booking rejected by the supplier, nothing changed
+	StateFailed State = "failed"
+)
+
+// Final states are not continued, requesting the modification again starts
+// over unless it completed
+func (s State) Final() bool {
+	return s == StateCompleted || s == StateRolledBack || s == StateFailed
+}
+
+type StepName string
+
+const (
+	StepBook     StepName = "book"
+	StepRecover  StepName = "recover"
+	StepCancel   StepName = "cancel"
+	StepRollback StepName = "rollback"
+)
+
+// Step is an operation of the saga on the booking with Reference
+type Step struct {
+	Name       StepName                      `json:"name"`
+	Reference  string                        `json:"reference,omitempty"`
+	Status     string                        `json:"status"`
+	Errors     schema.SupplierResponseErrors `json:"errors,omitempty"`
+	StartedAt  time.Time                     `json:"startedAt"`
+	FinishedAt time.Time                     `json:"finishedAt"`
+}
+
+// Saga replaces the booking OldReference with NewReference. Request params
+// are not kept, they hold supplier credentials.
+type Saga struct {
+	Platform     string                       `json:"platform"`
+	ReservNumber string                       `json:"reservNumber"`
+	OldReference string                       `json:"oldReference"`
+	NewReference string                       `json:"newReference,omitempty"`
+	NewStatus    schema.BookingResponseStatus `json:"newStatus,omitempty"`
+	State        State                        `json:"state"`
+	Steps        []Step                       `json:"steps"`
+	CreatedAt    time.Time                    `json:"createdAt"`
+	UpdatedAt    time.Time                    `json:"updatedAt"`
+}
+
+// Id is unique per booking replaced, a platform booking is replaced once
+func (s Saga) Id() string {
+	return SagaId(s.Platform, s.OldReference)
+}
+
+func SagaId(platform string, reference string) string {
+	return platform + ":" + reference
+}
+
+type Platform interface {
+	interfaces.WithCreateBooking
+	interfaces.WithCancelBooking
+}
+
+type Rebooker struct {
+	store *Store
+	now   func() time.Time
+}
+
+func New(store *Store) *Rebooker {
+	return &Rebooker{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Modify books with the params and cancels params.SupplierBookingReference,
+// the new booking is cancelled when the supplier rejects that. Sagas not
+// finished are continued from the last saved step. A booking interrupted
+// before it was saved or failed without an answer of the supplier is looked
+// up instead of booked again, see recover.
+func (r *Rebooker) Modify(ctx context.Context, platform string, p Platform, params schema.ModifyRequestParams, logger *zerolog.Logger) (schema.ModifyResponse, error) {
+	ctx = requesting.Detach(ctx)
+	id := SagaId(platform, params.SupplierBookingReference)
+
+	token, locked, err := r.store.lock(ctx, id)
+	if err != nil {
+		return schema.ModifyResponse{}, err
+	}
+
+	if !locked {
+		return schema.ModifyResponse{}, ErrorInProgress
+	}
+
+	defer r.store.unlock(ctx, id, token)
+
+	saga, err := r.store.Get(ctx, id)
+	interrupted := err == nil && saga.State == StateStarted
+
+	if errors.Is(err, ErrorNotFound) || (err == nil && saga.State.Final() && saga.State != StateCompleted) {
+		now := r.now().UTC()
+		saga = Saga{
+			Platform:     platform,
+			ReservNumber: params.ReservNumber,
+			OldReference: params.SupplierBookingReference,
+			State:        StateStarted,
+			Steps:        []Step{},
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+
+		err = r.store.Save(ctx, saga)
+	}
+
+	if err != nil {
+		return schema.ModifyResponse{}, err
+	}
+
+	response := schema.ModifyResponse{
+		Status:           converting.PointerToValue(schema.ModifyResponseStatusFAILED),
+		SupplierRequests: &schema.SupplierRequests{},
+		Errors:           &schema.SupplierResponseErrors{},
+	}
+
+	if interrupted {
+		err = r.recover(ctx, &saga, p, params, &response, logger)
+		if err != nil {
+			return schema.ModifyResponse{}, err
+		}
+	}
+
+	if saga.State == StateStarted {
+		err = r.book(ctx, &saga, p, params, &response, logger)
+		if err != nil {
+			return schema.ModifyResponse{}, err
+		}
+	}
+
+	if saga.State == StateBooked {
+		err = r.cancel(ctx, &saga, p, params, &response, logger)
+		if err != nil {
+			return schema.ModifyResponse{}, err
+		}
+	}
+
+	if saga.State == StateRollingBack {
+		err = r.rollback(ctx, &saga, p, params, &response, logger)
+		if err != nil {
+			return schema.ModifyResponse{}, err
+		}
+	}
+
+	switch saga.State {
+	case StateStarted:
+		*response.Errors = append(*response.Errors, schema.NewSupplierError(
+			"the outcome of the booking is unknown, it is looked up when the modification is requested again",
+		))
+	case StateCompleted:
+		response.Status = converting.PointerToValue(schema.ModifyResponseStatusOK)
+		if saga.NewStatus == schema.BookingResponseStatusPENDING {
+			response.Status = converting.PointerToValue(schema.ModifyResponseStatusPENDING)
+		}
+
+		response.SupplierBookingReference = converting.PointerToValue(saga.NewReference)
+	case StateBooked:
+		*response.Errors = append(*response.Errors, schema.NewSupplierError(
+			fmt.Sprintf("cancelling the booking did not finish, the new booking %s is kept and cancelling continues when the modification is requested again", saga.NewReference),
+		))
+	case StateRolledBack:
+		*response.Errors = append(*response.Errors, schema.NewSupplierError(
+			fmt.Sprintf("cancelling the booking failed, the new booking %s was cancelled", saga.NewReference),
+		))
+	case StateRollingBack:
+		*response.Errors = append(*response.Errors, schema.NewSupplierError(
+			fmt.Sprintf("cancelling the booking failed, cancelling the new booking %s failed too", saga.NewReference),
+		))
+	}
+
+	return response, nil
+}
+
+// record adds the step to the saga and the response, the saga is saved with
+// its next state
+func (r *Rebooker) record(ctx context.Context, saga *Saga, step Step, next State, requests *schema.SupplierRequests, errs *schema.SupplierResponseErrors, response *schema.ModifyResponse) error {
+	step.FinishedAt = r.now().UTC()
+
+	if errs != nil {
+		step.Errors = *errs
+		*response.Errors = append(*response.Errors, *errs...)
+	}
+
+	if requests != nil {
+		*response.SupplierRequests = append(*response.SupplierRequests, *requests...)
+	}
+
+	saga.Steps = append(saga.Steps, step)
+	saga.State = next
+	saga.UpdatedAt = step.FinishedAt
+
+	return r.store.Save(ctx, *saga)
+}
+
+func (r *Rebooker) book(ctx context.Context, saga *Saga, p Platform, params schema.ModifyRequestParams, response *schema.ModifyResponse, logger *zerolog.Logger) error {
+	step := Step{Name: StepBook, StartedAt: r.now().UTC()}
+
+	bookingParams, err := toBooking(params)
+	if err != nil {
+		return err
+	}
+
+	booking, err := p.CreateBooking(ctx, bookingParams, logger)
+	if err != nil {
+		step.Status = string(schema.BookingResponseStatusFAILED)
+
+		if saveErr := r.record(ctx, saga, step, StateStarted, nil, nil, response); saveErr != nil {
+			logger.Err(saveErr).Str("saga", saga.Id()).Msg("Failed saving rebooking")
+		}
+
+		return err
+	}
+
+	step.Status = string(booking.Status)
+	next := StateFailed
+
+	switch {
+	case booking.Status != schema.BookingResponseStatusFAILED && converting.Unwrap(booking.SupplierBookingReference) != "":
+		saga.NewReference = *booking.SupplierBookingReference
+		saga.NewStatus = booking.Status
+		step.Reference = saga.NewReference
+		next = StateBooked
+	case uncertain(converting.Unwrap(booking.Errors)):
+		logger.Error().Str("saga", saga.Id()).Msg("Booking did not finish, it is looked up on the next request")
+		next = StateStarted
+	}
+
+	return r.record(ctx, saga, step, next, booking.SupplierRequests, booking.Errors, response)
+}
+
+// recover continues a saga interrupted while booking or booking without an
+// answer of the supplier. The outcome of the
+// booking is unknown, so the booking status of the reservation is requested:
+// a booking other than the old one is taken as the new booking. Otherwise
+// nothing is booked and the saga is left for inspection.
+func (r *Rebooker) recover(ctx context.Context, saga *Saga, p Platform, params schema.ModifyRequestParams, response *schema.ModifyResponse, logger *zerolog.Logger) error {
+	platformWithBookingStatus, ok := p.(interfaces.WithBookingStatus)
+	if !ok {
+		return ErrorInterrupted
+	}
+
+	step := Step{Name: StepRecover, StartedAt: r.now().UTC()}
+
+	status, err := platformWithBookingStatus.GetBookingStatus(ctx, toBookingStatus(params, saga.CreatedAt), logger)
+	if err != nil {
+		logger.Err(err).Str("saga", saga.Id()).Msg("Failed looking up the booking of an interrupted rebooking")
+		return ErrorInterrupted
+	}
+
+	reference := converting.Unwrap(status.SupplierBookingReference)
+
+	switch {
+	case reference == "" || reference == saga.OldReference:
+		return ErrorInterrupted
+	case status.Status == schema.BookingStatusResponseStatusOK:
+		saga.NewStatus = schema.BookingResponseStatusOK
+	case status.Status == schema.BookingStatusResponseStatusPENDING:
+		saga.NewStatus = schema.BookingResponseStatusPENDING
+	default:
+		return ErrorInterrupted
+	}
+
+	saga.NewReference = reference
+	step.Reference = reference
+	step.Status = string(status.Status)
+
+	logger.Warn().Str("saga", saga.Id()).Str("reference", reference).Msg("Found the booking of an interrupted rebooking")
+
+	return r.record(ctx, saga, step, StateBooked, status.SupplierRequests, status.Errors, response)
+}
+
+// cancel rolls back only when the supplier rejected the cancellation, after
+// timeouts and connection errors the old booking may be cancelled already
+// and the cancellation is continued instead
+func (r *Rebooker) cancel(ctx context.Context, saga *Saga, p Platform, params schema.ModifyRequestParams, response *schema.ModifyResponse, logger *zerolog.Logger) error {
+	step := Step{Name: StepCancel, Reference: saga.OldReference, StartedAt: r.now().UTC()}
+
+	cancel, err := p.CancelBooking(ctx, toCancel(params, saga.OldReference), logger)
+
+	step.Status = string(converting.Unwrap(cancel.Status))
+	next := StateRollingBack
+
+	switch {
+	case err == nil && converting.Unwrap(cancel.Status) == schema.CancelResponseStatusOK:
+		next = StateCompleted
+	case err != nil || uncertain(converting.Unwrap(cancel.Errors)):
+		logger.Error().Err(err).Str("saga", saga.Id()).Msg("Cancelling the old booking did not finish, it is continued on the next request")
+		next = StateBooked
+	default:
+		logger.Warn().Str("saga", saga.Id()).Msg("Cancelling the old booking failed, rolling back")
+	}
+
+	return r.record(ctx, saga, step, next, cancel.SupplierRequests, cancel.Errors, response)
+}
+
+// uncertain failures did not get an answer of the supplier
+func uncertain(errs schema.SupplierResponseErrors) bool {
+	for _, e := range errs {
+		if e.Code == schema.TimeoutError || e.Code == schema.ConnectionError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Rebooker) rollback(ctx context.Context, saga *Saga, p Platform, params schema.ModifyRequestParams, response *schema.ModifyResponse, logger *zerolog.Logger) error {
+	step := Step{Name: StepRollback, Reference: saga.NewReference, StartedAt: r.now().UTC()}
+
+	cancel, err := p.CancelBooking(ctx, toCancel(params, saga.NewReference), logger)
+
+	step.Status = string(converting.Unwrap(cancel.Status))
+	next := StateRolledBack
+
+	if err != nil || converting.Unwrap(cancel.Status) != schema.CancelResponseStatusOK {
+		logger.Error().Err(err).Str("saga", saga.Id()).Msg("Failed rolling back rebooking, two bookings are kept")
+		next = StateRollingBack
+	}
+
+	return r.record(ctx, saga, step, next, cancel.SupplierRequests, cancel.Errors, response)
+}
+
+// toBooking books what the modification asks for
+func toBooking(params schema.ModifyRequestParams) (schema.BookingRequestParams, error) {
+	booking := schema.BookingRequestParams{
+		AirlineCode:                 params.AirlineCode,
+		BrokerReference:             params.BrokerReference,
+		Comments:                    params.Comments,
+		ConfigurationRef:            params.ConfigurationRef,
+		Contract:                    params.Contract,
+		Customer:                    params.Customer,
+		DropOff:                     params.DropOff,
+		ExtrasAndFees:               params.ExtrasAndFees,
+		FlightNo:                    params.FlightNo,
+		FlightNumber:                params.FlightNumber,
+		ModuleId:                    params.ModuleId,
+		OnlineFeeMap:                params.OnlineFeeMap,
+		PickUp:                      params.PickUp,
+		RentalDays:                  params.RentalDays,
+		ReservNumber:                params.ReservNumber,
+		SupplierRateReference:       params.SupplierRateReference,
+		SupplierSpecificInformation: params.SupplierSpecificInformation,
+		Timeouts:                    params.Timeouts,
+		VehicleClass:                params.VehicleClass,
+	}
+
+	configuration, err := params.Configuration.MarshalJSON()
+	if err != nil {
+		return booking, err
+	}
+
+	return booking, booking.Configuration.UnmarshalJSON(configuration)
+}
+
+// toBookingStatus looks up the booking of the reservation, the supplier
+// reference of the new booking is not known
+func toBookingStatus(params schema.ModifyRequestParams, bookingDateTime time.Time) schema.BookingStatusRequestParams {
+	status := schema.BookingStatusRequestParams{
+		BookingDateTime:  bookingDateTime,
+		BrokerReference:  params.BrokerReference,
+		ConfigurationRef: params.ConfigurationRef,
+		Contact:          &schema.Contact{Email: params.Customer.Email},
+		ModuleId:         params.ModuleId,
+		ReservNumber:     params.ReservNumber,
+		Timeouts:         params.Timeouts,
+	}
+
+	configuration, _ := params.Configuration.MarshalJSON()
+	_ = status.Configuration.UnmarshalJSON(configuration)
+
+	return status
+}
+
+// toCancel cancels the booking with reference, the pick-up of the old
+// booking is not known so the modified one is sent
+func toCancel(params schema.ModifyRequestParams, reference string) schema.CancelRequestParams {
+	cancel := schema.CancelRequestParams{
+		BrokerReference:  params.BrokerReference,
+		ConfigurationRef: params.ConfigurationRef,
+		Contact:          schema.Contact{Email: params.Customer.Email},
+		ModuleId:         params.ModuleId,
+		PickUp: schema.RequestBranch{
+			Code:     params.PickUp.Code,
+			Country:  params.PickUp.Country,
+			DateTime: params.PickUp.DateTime,
+		},
+		ReservNumber:             params.ReservNumber,
+		SupplierBookingReference: reference,
+		Timeouts:                 params.Timeouts,
+	}
+
+	configuration, _ := params.Configuration.MarshalJSON()
+	_ = cancel.Configuration.UnmarshalJSON(configuration)
+
+	return cancel
+}
@@ -0,0 +1,363 @@
+package rebook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/platform/rebook"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+var log = zerolog.Nop()
+
+// platform books NEW, the supplier rejects cancelling the references in
+// failing and does not answer cancelling the references in timingOut. Booking
+// is not answered either with bookingTimeout, onBooking runs while booking.
+// The booking status of the reservation is found, it is empty when not booked.
+type platform struct {
+	booking        schema.BookingResponseStatus
+	bookingTimeout bool
+	onBooking      func()
+	failing        map[string]bool
+	timingOut      map[string]bool
+	found          string
+	bookings       int
+	cancelled      []string
+}
+
+func (p *platform) CreateBooking(ctx context.Context, params schema.BookingRequestParams, logger *zerolog.Logger) (schema.BookingResponse, error) {
+	p.bookings++
+
+	if p.onBooking != nil {
+		p.onBooking()
+	}
+
+	response := schema.BookingResponse{
+		Status:           p.booking,
+		SupplierRequests: &schema.SupplierRequests{{Name: converting.PointerToValue(schema.SupplierRequestName("booking"))}},
+		Errors:           &schema.SupplierResponseErrors{},
+	}
+
+	if p.bookingTimeout {
+		response.Status = schema.BookingResponseStatusFAILED
+		*response.Errors = append(*response.Errors, schema.NewTimeoutError("booking timed out"))
+		return response, nil
+	}
+
+	if p.booking == schema.BookingResponseStatusFAILED {
+		*response.Errors = append(*response.Errors, schema.NewSupplierError("vehicle not available"))
+		return response, nil
+	}
+
+	response.SupplierBookingReference = converting.PointerToValue("NEW")
+
+	return response, nil
+}
+
+func (p *platform) CancelBooking(ctx context.Context, params schema.CancelRequestParams, logger *zerolog.Logger) (schema.CancelResponse, error) {
+	response := schema.CancelResponse{
+		Status:           converting.PointerToValue(schema.CancelResponseStatusOK),
+		SupplierRequests: &schema.SupplierRequests{{Name: converting.PointerToValue(schema.SupplierRequestName("cancel " + params.SupplierBookingReference))}},
+		Errors:           &schema.SupplierResponseErrors{},
+	}
+
+	if p.failing[params.SupplierBookingReference] {
+		response.Status = converting.PointerToValue(schema.CancelResponseStatusFAILED)
+		*response.Errors = append(*response.Errors, schema.NewSupplierError("booking can not be cancelled"))
+		return response, nil
+	}
+
+	if p.timingOut[params.SupplierBookingReference] {
+		response.Status = converting.PointerToValue(schema.CancelResponseStatusFAILED)
+		*response.Errors = append(*response.Errors, schema.NewTimeoutError("cancel timed out"))
+		return response, nil
+	}
+
+	p.cancelled = append(p.cancelled, params.SupplierBookingReference)
+
+	return response, nil
+}
+
+func (p *platform) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
+	if p.found == "" {
+		errs := schema.SupplierResponseErrors{schema.NewSupplierError("booking not found")}
+		return schema.BookingStatusResponse{Status: schema.BookingStatusResponseStatusFAILED, Errors: &errs}, nil
+	}
+
+	return schema.BookingStatusResponse{
+		Status:                   schema.BookingStatusResponseStatusOK,
+		SupplierBookingReference: converting.PointerToValue(p.found),
+		SupplierRequests:         &schema.SupplierRequests{{Name: converting.PointerToValue(schema.SupplierRequestName("booking-status"))}},
+	}, nil
+}
+
+func setup(t *testing.T) (*rebook.Rebooker, *rebook.Store, *miniredis.Miniredis) {
+	redisServer := miniredis.RunT(t)
+	store := rebook.NewStore(redis.NewClient(&redis.Options{Addr: redisServer.Addr()}), time.Hour)
+
+	return rebook.New(store), store, redisServer
+}
+
+func params() schema.ModifyRequestParams {
+	return schema.ModifyRequestParams{
+		ReservNumber:             "R123",
+		BrokerReference:          "B123",
+		SupplierBookingReference: "OLD",
+		SupplierRateReference:    "rate",
+		Customer:                 schema.Customer{Email: "mock@example.com"},
+		Timeouts:                 schema.Timeouts{Default: 8000},
+	}
+}
+
+func names(response schema.ModifyResponse) []string {
+	names := []string{}
+	for _, request := range *response.SupplierRequests {
+		names = append(names, string(converting.Unwrap(request.Name)))
+	}
+
+	return names
+}
+
+func TestModify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should book again and cancel the old booking", func(t *testing.T) {
+		rebooker, store, _ := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusOK}
+
+		response, err := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Nil(t, err)
+		assert.Equal(t, schema.ModifyResponseStatusOK, *response.Status)
+		assert.Equal(t, "NEW", *response.SupplierBookingReference)
+		assert.Equal(t, []string{"booking", "cancel OLD"}, names(response))
+		assert.Equal(t, []string{"OLD"}, p.cancelled)
+
+		saga, _ := store.Get(ctx, "hertz:OLD")
+		assert.Equal(t, rebook.StateCompleted, saga.State)
+		assert.Len(t, saga.Steps, 2)
+
+		open, _ := store.Open(ctx)
+		assert.Empty(t, open)
+	})
+
+	t.Run("should keep pending bookings pending", func(t *testing.T) {
+		rebooker, _, _ := setup(t)
+
+		response, _ := rebooker.Modify(ctx, "hertz", &platform{booking: schema.BookingResponseStatusPENDING}, params(), &log)
+
+		assert.Equal(t, schema.ModifyResponseStatusPENDING, *response.Status)
+	})
+
+	t.Run("should keep the old booking when booking fails", func(t *testing.T) {
+		rebooker, store, _ := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusFAILED}
+
+		response, _ := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, schema.ModifyResponseStatusFAILED, *response.Status)
+		assert.Equal(t, "vehicle not available", (*response.Errors)[0].Message)
+		assert.Empty(t, p.cancelled)
+
+		saga, _ := store.Get(ctx, "hertz:OLD")
+		assert.Equal(t, rebook.StateFailed, saga.State)
+
+		// failed rebookings start over
+		p.booking = schema.BookingResponseStatusOK
+		response, _ = rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, schema.ModifyResponseStatusOK, *response.Status)
+		assert.Equal(t, 2, p.bookings)
+	})
+
+	t.Run("should look up bookings without an answer instead of booking again", func(t *testing.T) {
+		rebooker, store, _ := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusOK, bookingTimeout: true}
+
+		response, err := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Nil(t, err)
+		assert.Equal(t, schema.ModifyResponseStatusFAILED, *response.Status)
+		assert.Contains(t, (*response.Errors)[1].Message, "the outcome of the booking is unknown")
+
+		saga, _ := store.Get(ctx, "hertz:OLD")
+		assert.Equal(t, rebook.StateStarted, saga.State)
+
+		p.bookingTimeout = false
+		_, err = rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.ErrorIs(t, err, rebook.ErrorInterrupted)
+		assert.Equal(t, 1, p.bookings)
+
+		p.found = "NEW"
+		response, err = rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Nil(t, err)
+		assert.Equal(t, schema.ModifyResponseStatusOK, *response.Status)
+		assert.Equal(t, []string{"booking-status", "cancel OLD"}, names(response))
+		assert.Equal(t, 1, p.bookings)
+	})
+
+	t.Run("should cancel the new booking when cancelling the old one fails", func(t *testing.T) {
+		rebooker, store, _ := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusOK, failing: map[string]bool{"OLD": true}}
+
+		response, _ := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, schema.ModifyResponseStatusFAILED, *response.Status)
+		assert.Nil(t, response.SupplierBookingReference)
+		assert.Equal(t, []string{"booking", "cancel OLD", "cancel NEW"}, names(response))
+		assert.Equal(t, []string{"NEW"}, p.cancelled)
+		assert.Equal(t, "cancelling the booking failed, the new booking NEW was cancelled", (*response.Errors)[1].Message)
+
+		saga, _ := store.Get(ctx, "hertz:OLD")
+		assert.Equal(t, rebook.StateRolledBack, saga.State)
+	})
+
+	t.Run("should report and continue failed rollbacks", func(t *testing.T) {
+		rebooker, store, _ := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusOK, failing: map[string]bool{"OLD": true, "NEW": true}}
+
+		response, _ := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, schema.ModifyResponseStatusFAILED, *response.Status)
+		assert.Equal(t, "cancelling the booking failed, cancelling the new booking NEW failed too", (*response.Errors)[2].Message)
+
+		open, _ := store.Open(ctx)
+		assert.Len(t, open, 1)
+		assert.Equal(t, rebook.StateRollingBack, open[0].State)
+
+		p.failing["NEW"] = false
+		response, _ = rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, []string{"cancel NEW"}, names(response))
+		assert.Equal(t, 1, p.bookings)
+
+		open, _ = store.Open(ctx)
+		assert.Empty(t, open)
+	})
+
+	t.Run("should continue cancelling after timeouts without rolling back", func(t *testing.T) {
+		rebooker, store, _ := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusOK, timingOut: map[string]bool{"OLD": true}}
+
+		response, _ := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, schema.ModifyResponseStatusFAILED, *response.Status)
+		assert.Equal(t, []string{"booking", "cancel OLD"}, names(response))
+		assert.Empty(t, p.cancelled)
+		assert.Contains(t, (*response.Errors)[1].Message, "the new booking NEW is kept")
+
+		saga, _ := store.Get(ctx, "hertz:OLD")
+		assert.Equal(t, rebook.StateBooked, saga.State)
+
+		p.timingOut["OLD"] = false
+		response, _ = rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, schema.ModifyResponseStatusOK, *response.Status)
+		assert.Equal(t, []string{"cancel OLD"}, names(response))
+		assert.Equal(t, []string{"OLD"}, p.cancelled)
+		assert.Equal(t, 1, p.bookings)
+	})
+
+	t.Run("should not book again when interrupted while booking", func(t *testing.T) {
+		rebooker, store, _ := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusOK}
+
+		store.Save(ctx, rebook.Saga{
+			Platform:     "hertz",
+			ReservNumber: "R123",
+			OldReference: "OLD",
+			State:        rebook.StateStarted,
+		})
+
+		_, err := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.ErrorIs(t, err, rebook.ErrorInterrupted)
+		assert.Equal(t, 0, p.bookings)
+
+		p.found = "OLD"
+		_, err = rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.ErrorIs(t, err, rebook.ErrorInterrupted)
+
+		p.found = "NEW"
+		response, err := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Nil(t, err)
+		assert.Equal(t, schema.ModifyResponseStatusOK, *response.Status)
+		assert.Equal(t, "NEW", *response.SupplierBookingReference)
+		assert.Equal(t, []string{"booking-status", "cancel OLD"}, names(response))
+		assert.Equal(t, 0, p.bookings)
+
+		saga, _ := store.Get(ctx, "hertz:OLD")
+		assert.Equal(t, rebook.StateCompleted, saga.State)
+		assert.Equal(t, rebook.StepRecover, saga.Steps[0].Name)
+	})
+
+	t.Run("should continue interrupted rebookings", func(t *testing.T) {
+		rebooker, store, _ := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusOK}
+
+		store.Save(ctx, rebook.Saga{
+			Platform:     "hertz",
+			OldReference: "OLD",
+			NewReference: "NEW",
+			NewStatus:    schema.BookingResponseStatusOK,
+			State:        rebook.StateBooked,
+		})
+
+		response, _ := rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, schema.ModifyResponseStatusOK, *response.Status)
+		assert.Equal(t, "NEW", *response.SupplierBookingReference)
+		assert.Equal(t, 0, p.bookings)
+
+		// completed rebookings are answered again without supplier requests
+		response, _ = rebooker.Modify(ctx, "hertz", p, params(), &log)
+
+		assert.Equal(t, "NEW", *response.SupplierBookingReference)
+		assert.Empty(t, names(response))
+		assert.Equal(t, []string{"OLD"}, p.cancelled)
+	})
+
+	t.Run("should refuse rebookings in progress", func(t *testing.T) {
+		rebooker, _, redisServer := setup(t)
+		redisServer.Set("rebook:lock:hertz:OLD", "1")
+
+		_, err := rebooker.Modify(ctx, "hertz", &platform{}, params(), &log)
+
+		assert.True(t, errors.Is(err, rebook.ErrorInProgress))
+	})
+
+	t.Run("should not release locks taken by other requests", func(t *testing.T) {
+		rebooker, _, redisServer := setup(t)
+		p := &platform{booking: schema.BookingResponseStatusOK}
+
+		// the lock expired while booking and another request took it
+		p.onBooking = func() {
+			redisServer.Set("rebook:lock:hertz:OLD", "other")
+		}
+
+		_, err := rebooker.Modify(ctx, "hertz", p, params(), &log)
+		assert.Nil(t, err)
+
+		lock, err := redisServer.Get("rebook:lock:hertz:OLD")
+		assert.Nil(t, err)
+		assert.Equal(t, "other", lock)
+
+		p.onBooking = nil
+		redisServer.Del("rebook:lock:hertz:OLD")
+
+		_, err = rebooker.Modify(ctx, "hertz", p, params(), &log)
+		assert.Nil(t, err)
+		assert.False(t, redisServer.Exists("rebook:lock:hertz:OLD"))
+	})
+}
@@ -0,0 +1,135 @@
+package rebook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyPrefix = "rebook:"
+	openKey        = redisKeyPrefix + "open"
+
+	// lockTtl releases sagas of instances stopped while running them
+	lockTtl = 5 * time.Minute
+)
+
+// unlockScript deletes the lock only while it holds the token, a lock
+// released by its ttl may be held by another request already
+var unlockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Store keeps sagas in redis, unfinished ones are indexed so they can be
+// reported
+type Store struct {
+	client    redis.UniversalClient
+	retention time.Duration
+}
+
+func NewStore(client redis.UniversalClient, retention time.Duration) *Store {
+	return &Store{
+		client:    client,
+		retention: retention,
+	}
+}
+
+func sagaKey(id string) string {
+	return redisKeyPrefix + "saga:" + id
+}
+
+func lockKey(id string) string {
+	return redisKeyPrefix + "lock:" + id
+}
+
+// Get returns ErrorNotFound for unknown and expired sagas
+func (s *Store) Get(ctx context.Context, id string) (Saga, error) {
+	var saga Saga
+
+	content, err := s.client.Get(ctx, sagaKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return saga, ErrorNotFound
+	}
+
+	if err != nil {
+		return saga, err
+	}
+
+	return saga, json.Unmarshal(content, &saga)
+}
+
+func (s *Store) Save(ctx context.Context, saga Saga) error {
+	content, err := json.Marshal(saga)
+	if err != nil {
+		return err
+	}
+
+	id := saga.Id()
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sagaKey(id), content, s.retention)
+
+		if saga.State.Final() {
+			pipe.SRem(ctx, openKey, id)
+		} else {
+			pipe.SAdd(ctx, openKey, id)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Open lists the sagas not finished, sorted by creation. Expired sagas are
+// removed from the index.
+func (s *Store) Open(ctx context.Context) ([]Saga, error) {
+	ids, err := s.client.SMembers(ctx, openKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sagas := make([]Saga, 0, len(ids))
+
+	for _, id := range ids {
+		saga, err := s.Get(ctx, id)
+		if errors.Is(err, ErrorNotFound) {
+			s.client.SRem(ctx, openKey, id)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		sagas = append(sagas, saga)
+	}
+
+	sort.Slice(sagas, func(i, j int) bool {
+		return sagas[i].CreatedAt.Before(sagas[j].CreatedAt)
+	})
+
+	return sagas, nil
+}
+
+// lock keeps two requests from running the same saga, false when it is held.
+// The returned token releases the lock with unlock.
+func (s *Store) lock(ctx context.Context, id string) (string, bool, error) {
+	token := uuid.New().String()
+
+	locked, err := s.client.SetNX(ctx, lockKey(id), token, lockTtl).Result()
+
+	return token, locked, err
+}
+
+func (s *Store) unlock(ctx context.Context, id string, token string) error {
+	return unlockScript.Run(ctx, s.client, []string{lockKey(id)}, token).Err()
+}
@@ -51,6 +51,26 @@ const (
 	PerRental ExtraOrFeeUnit = "per rental"
 )
 
+// Defines values for HealthCheckResultStatus.
+const (
+	HealthCheckResultStatusDown HealthCheckResultStatus = "down"
+	HealthCheckResultStatusUp   HealthCheckResultStatus = "up"
+)
+
+// Defines values for HealthReportStatus.
+const (
+	HealthReportStatusDown HealthReportStatus = "down"
+	HealthReportStatusUp   HealthReportStatus = "up"
+)
+
+// Defines values for HistoryMode.
+const (
+	HistoryModeFull     HistoryMode = "full"
+	HistoryModeMetadata HistoryMode = "metadata"
+	HistoryModeNone     HistoryMode = "none"
+	HistoryModeOffload  HistoryMode = "offload"
+)
+
 // Defines values for LocationAdditionalContactContactType.
 const (
 	Fax      LocationAdditionalContactContactType = "fax"
@@ -80,6 +100,12 @@ const (
 	ModifyResponseStatusPENDING ModifyResponseStatus = "PENDING"
 )
 
+// Defines values for OtaConfigurationEnvelope.
+const (
+	Soap OtaConfigurationEnvelope = "soap"
+	Xml  OtaConfigurationEnvelope = "xml"
+)
+
 // Defines values for RentlyConfigurationCommercialAgreementCode.
 const (
 	PayOnDestination RentlyConfigurationCommercialAgreementCode = "PayOnDestination"
@@ -141,8 +167,10 @@ const (
 	RequiredPlatformInPathAnyrent      RequiredPlatformInPath = "anyrent"
 	RequiredPlatformInPathBookingcom   RequiredPlatformInPath = "bookingcom"
 	RequiredPlatformInPathHertz        RequiredPlatformInPath = "hertz"
+	RequiredPlatformInPathOta          RequiredPlatformInPath = "ota"
 	RequiredPlatformInPathProfitmaxdht RequiredPlatformInPath = "profitmaxdht"
 	RequiredPlatformInPathRently       RequiredPlatformInPath = "rently"
+	RequiredPlatformInPathSandbox      RequiredPlatformInPath = "sandbox"
 )
 
 // Defines values for CreateBookingParamsPlatform.
@@ -150,8 +178,10 @@ const (
 	CreateBookingParamsPlatformAnyrent      CreateBookingParamsPlatform = "anyrent"
 	CreateBookingParamsPlatformBookingcom   CreateBookingParamsPlatform = "bookingcom"
 	CreateBookingParamsPlatformHertz        CreateBookingParamsPlatform = "hertz"
+	CreateBookingParamsPlatformOta          CreateBookingParamsPlatform = "ota"
 	CreateBookingParamsPlatformProfitmaxdht CreateBookingParamsPlatform = "profitmaxdht"
 	CreateBookingParamsPlatformRently       CreateBookingParamsPlatform = "rently"
+	CreateBookingParamsPlatformSandbox      CreateBookingParamsPlatform = "sandbox"
 )
 
 // Defines values for CheckBookingStatusParamsPlatform.
@@ -159,8 +189,10 @@ const (
 	CheckBookingStatusParamsPlatformAnyrent      CheckBookingStatusParamsPlatform = "anyrent"
 	CheckBookingStatusParamsPlatformBookingcom   CheckBookingStatusParamsPlatform = "bookingcom"
 	CheckBookingStatusParamsPlatformHertz        CheckBookingStatusParamsPlatform = "hertz"
+	CheckBookingStatusParamsPlatformOta          CheckBookingStatusParamsPlatform = "ota"
 	CheckBookingStatusParamsPlatformProfitmaxdht CheckBookingStatusParamsPlatform = "profitmaxdht"
 	CheckBookingStatusParamsPlatformRently       CheckBookingStatusParamsPlatform = "rently"
+	CheckBookingStatusParamsPlatformSandbox      CheckBookingStatusParamsPlatform = "sandbox"
 )
 
 // Defines values for CancelBookingParamsPlatform.
@@ -168,8 +200,10 @@ const (
 	CancelBookingParamsPlatformAnyrent      CancelBookingParamsPlatform = "anyrent"
 	CancelBookingParamsPlatformBookingcom   CancelBookingParamsPlatform = "bookingcom"
 	CancelBookingParamsPlatformHertz        CancelBookingParamsPlatform = "hertz"
+	CancelBookingParamsPlatformOta          CancelBookingParamsPlatform = "ota"
 	CancelBookingParamsPlatformProfitmaxdht CancelBookingParamsPlatform = "profitmaxdht"
 	CancelBookingParamsPlatformRently       CancelBookingParamsPlatform = "rently"
+	CancelBookingParamsPlatformSandbox      CancelBookingParamsPlatform = "sandbox"
 )
 
 // Defines values for GetLocationsParamsPlatform.
@@ -177,8 +211,10 @@ const (
 	GetLocationsParamsPlatformAnyrent      GetLocationsParamsPlatform = "anyrent"
 	GetLocationsParamsPlatformBookingcom   GetLocationsParamsPlatform = "bookingcom"
 	GetLocationsParamsPlatformHertz        GetLocationsParamsPlatform = "hertz"
+	GetLocationsParamsPlatformOta          GetLocationsParamsPlatform = "ota"
 	GetLocationsParamsPlatformProfitmaxdht GetLocationsParamsPlatform = "profitmaxdht"
 	GetLocationsParamsPlatformRently       GetLocationsParamsPlatform = "rently"
+	GetLocationsParamsPlatformSandbox      GetLocationsParamsPlatform = "sandbox"
 )
 
 // Defines values for ModifyBookingParamsPlatform.
@@ -186,8 +222,10 @@ const (
 	ModifyBookingParamsPlatformAnyrent      ModifyBookingParamsPlatform = "anyrent"
 	ModifyBookingParamsPlatformBookingcom   ModifyBookingParamsPlatform = "bookingcom"
 	ModifyBookingParamsPlatformHertz        ModifyBookingParamsPlatform = "hertz"
+	ModifyBookingParamsPlatformOta          ModifyBookingParamsPlatform = "ota"
 	ModifyBookingParamsPlatformProfitmaxdht ModifyBookingParamsPlatform = "profitmaxdht"
 	ModifyBookingParamsPlatformRently       ModifyBookingParamsPlatform = "rently"
+	ModifyBookingParamsPlatformSandbox      ModifyBookingParamsPlatform = "sandbox"
 )
 
 // Defines values for GetRatesParamsPlatform.
@@ -195,8 +233,10 @@ const (
 	Anyrent      GetRatesParamsPlatform = "anyrent"
 	Bookingcom   GetRatesParamsPlatform = "bookingcom"
 	Hertz        GetRatesParamsPlatform = "hertz"
+	Ota          GetRatesParamsPlatform = "ota"
 	Profitmaxdht GetRatesParamsPlatform = "profitmaxdht"
 	Rently       GetRatesParamsPlatform = "rently"
+	Sandbox      GetRatesParamsPlatform = "sandbox"
 )
 
 // AgeRange defines model for AgeRange.
@@ -222,6 +262,9 @@ type BookingComConfiguration struct {
 	// AffiliateCode Affiliate code
 	AffiliateCode string `json:"affiliateCode"`
 
+	// AllSuppliers Return the vehicles of all suppliers, each tagged with its supplier, instead of filtering by supplierName
+	AllSuppliers *bool `json:"allSuppliers,omitempty"`
+
 	// Password Password
 	Password string `json:"password"`
 
@@ -278,11 +321,16 @@ type BookingRequestParams struct {
 		// Customer A message from customer to supplier
 		Customer *string `json:"customer,omitempty"`
 	} `json:"comments,omitempty"`
-	Configuration BookingRequestParams_Configuration `json:"configuration"`
-	Contract      Contract                           `json:"contract"`
-	Customer      Customer                           `json:"customer"`
-	DropOff       RequestBranchWithTimeZone          `json:"dropOff"`
-	ExtrasAndFees *[]BookingExtraOrFee               `json:"extrasAndFees,omitempty"`
+
+	// Configuration Supplier configuration, may be omitted when configurationRef is sent
+	Configuration BookingRequestParams_Configuration `json:"configuration,omitempty"`
+
+	// ConfigurationRef Reference to supplier configuration stored in the hub for the calling client, stored values take precedence over configuration
+	ConfigurationRef *string                   `json:"configurationRef,omitempty"`
+	Contract         Contract                  `json:"contract"`
+	Customer         Customer                  `json:"customer"`
+	DropOff          RequestBranchWithTimeZone `json:"dropOff"`
+	ExtrasAndFees    *[]BookingExtraOrFee      `json:"extrasAndFees,omitempty"`
 
 	// FlightNo Flight number
 	FlightNo *string `json:"flightNo,omitempty"`
@@ -301,7 +349,10 @@ type BookingRequestParams struct {
 	RentalDays *int `json:"rentalDays,omitempty"`
 
 	// ReservNumber Car Rental Gateway booking reservation number
-	ReservNumber        string               `json:"reservNumber"`
+	ReservNumber string `json:"reservNumber"`
+
+	// StatusCallbackUrl Receives the final status of PENDING bookings when status tracking is enabled and the request references stored configuration with configurationRef, payloads are signed with the x-timestamp and x-signature headers
+	StatusCallbackUrl   *string              `json:"statusCallbackUrl,omitempty"`
 	SupplierPassthrough *SupplierPassthrough `json:"supplierPassthrough,omitempty"`
 
 	// SupplierRateReference Supplier rate reference from rates request
@@ -325,6 +376,9 @@ type BookingResponse struct {
 	// Errors List supplier errors
 	Errors *SupplierResponseErrors `json:"errors,omitempty"`
 
+	// HistoryId Id of the offloaded supplier requests, returned in offload history mode, see GET /history/{id}
+	HistoryId *string `json:"historyId,omitempty"`
+
 	// Status Booking status
 	Status BookingResponseStatus `json:"status"`
 
@@ -341,13 +395,43 @@ type BookingResponse struct {
 // BookingResponseStatus Booking status
 type BookingResponseStatus string
 
+// BookingStatusBatchRequestParams defines model for BookingStatusBatchRequestParams.
+type BookingStatusBatchRequestParams struct {
+	// Items Booking status requests, each may reference stored configuration
+	Items []BookingStatusRequestParams `json:"items"`
+}
+
+// BookingStatusBatchResult defines model for BookingStatusBatchResult.
+type BookingStatusBatchResult struct {
+	// Code HTTP status code the booking status request would be answered with
+	Code int `json:"code"`
+
+	// Error Reason of failed requests without a response
+	Error *string `json:"error,omitempty"`
+
+	// Index Position of the request in items
+	Index int `json:"index"`
+
+	// ReservNumber Car Rental Gateway booking reservation number
+	ReservNumber string                 `json:"reservNumber"`
+	Response     *BookingStatusResponse `json:"response,omitempty"`
+
+	// SupplierBookingReference Supplier booking reference
+	SupplierBookingReference string `json:"supplierBookingReference"`
+}
+
 // BookingStatusRequestParams defines model for BookingStatusRequestParams.
 type BookingStatusRequestParams struct {
 	BookingDateTime time.Time `json:"bookingDateTime"`
 
 	// BrokerReference Car Rental Gateway booking/broker reference
-	BrokerReference string                                   `json:"brokerReference"`
-	Configuration   BookingStatusRequestParams_Configuration `json:"configuration"`
+	BrokerReference string `json:"brokerReference"`
+
+	// Configuration Supplier configuration, may be omitted when configurationRef is sent
+	Configuration BookingStatusRequestParams_Configuration `json:"configuration,omitempty"`
+
+	// ConfigurationRef Reference to supplier configuration stored in the hub for the calling client, stored values take precedence over configuration
+	ConfigurationRef *string `json:"configurationRef,omitempty"`
 
 	// Contact Contact info
 	Contact *Contact `json:"contact,omitempty"`
@@ -373,6 +457,9 @@ type BookingStatusResponse struct {
 	// Errors List supplier errors
 	Errors *SupplierResponseErrors `json:"errors,omitempty"`
 
+	// HistoryId Id of the offloaded supplier requests, returned in offload history mode, see GET /history/{id}
+	HistoryId *string `json:"historyId,omitempty"`
+
 	// Status Booking status
 	Status BookingStatusResponseStatus `json:"status"`
 
@@ -394,8 +481,13 @@ type CancelRequestParams struct {
 	BrokerReference string `json:"brokerReference"`
 
 	// CancelReason Cancel reason (value in range 2 to 15)
-	CancelReason  *int                              `json:"cancelReason,omitempty"`
-	Configuration CancelRequestParams_Configuration `json:"configuration"`
+	CancelReason *int `json:"cancelReason,omitempty"`
+
+	// Configuration Supplier configuration, may be omitted when configurationRef is sent
+	Configuration CancelRequestParams_Configuration `json:"configuration,omitempty"`
+
+	// ConfigurationRef Reference to supplier configuration stored in the hub for the calling client, stored values take precedence over configuration
+	ConfigurationRef *string `json:"configurationRef,omitempty"`
 
 	// Contact Contact info
 	Contact Contact `json:"contact"`
@@ -422,6 +514,12 @@ type CancelResponse struct {
 	// Errors List supplier errors
 	Errors *SupplierResponseErrors `json:"errors,omitempty"`
 
+	// HistoryId Id of the offloaded supplier requests, returned in offload history mode, see GET /history/{id}
+	HistoryId *string `json:"historyId,omitempty"`
+
+	// RetryId Id of the background retry of a cancellation failed by a timeout or connection error, returned when cancel retries are enabled and the request references stored configuration with configurationRef
+	RetryId *string `json:"retryId,omitempty"`
+
 	// Status Was the cancellation successful.
 	Status *CancelResponseStatus `json:"status,omitempty"`
 
@@ -543,6 +641,30 @@ type ExtraOrFeeType string
 // ExtraOrFeeUnit defines model for ExtraOrFee.Unit.
 type ExtraOrFeeUnit string
 
+// HealthCheckResult defines model for HealthCheckResult.
+type HealthCheckResult struct {
+	Error *string `json:"error,omitempty"`
+
+	// Latency Latency in milliseconds
+	Latency float32 `json:"latency"`
+
+	// Required Readiness fails when a required dependency is down
+	Required bool                    `json:"required"`
+	Status   HealthCheckResultStatus `json:"status"`
+}
+
+// HealthCheckResultStatus defines model for HealthCheckResult.Status.
+type HealthCheckResultStatus string
+
+// HealthReport defines model for HealthReport.
+type HealthReport struct {
+	Checks map[string]HealthCheckResult `json:"checks"`
+	Status HealthReportStatus           `json:"status"`
+}
+
+// HealthReportStatus defines model for HealthReport.Status.
+type HealthReportStatus string
+
 // HertzConfiguration Supplier specific parameters for all post-type requests except booking. Override in supplier module for all post-routes except booking
 type HertzConfiguration struct {
 	// FrequentTravellerProgramId For FTN identifies the company associated with the FTN/MembershipId
@@ -666,6 +788,9 @@ type HertzConfiguration struct {
 	VoucherContractBillingType *string `json:"voucherContractBillingType,omitempty"`
 }
 
+// HistoryMode How supplier requests are returned, sent in the x-supplier-history header or the history query parameter. full embeds bodies, metadata only url, method, status and timings, offload stores full history and returns historyId
+type HistoryMode string
+
 // Location defines model for Location.
 type Location struct {
 	// AdditionalContact All other means for contacting the supplier's location
@@ -760,8 +885,12 @@ type LocationAdditionalContactContactType string
 
 // LocationsRequestParams defines model for LocationsRequestParams.
 type LocationsRequestParams struct {
-	Configuration LocationsRequestParams_Configuration `json:"configuration"`
-	Timeouts      Timeouts                             `json:"timeouts"`
+	// Configuration Supplier configuration, may be omitted when configurationRef is sent
+	Configuration LocationsRequestParams_Configuration `json:"configuration,omitempty"`
+
+	// ConfigurationRef Reference to supplier configuration stored in the hub for the calling client, stored values take precedence over configuration
+	ConfigurationRef *string  `json:"configurationRef,omitempty"`
+	Timeouts         Timeouts `json:"timeouts"`
 }
 
 // LocationsRequestParams_Configuration defines model for LocationsRequestParams.Configuration.
@@ -774,6 +903,9 @@ type LocationsResponse struct {
 	// Errors List supplier errors
 	Errors *SupplierResponseErrors `json:"errors,omitempty"`
 
+	// HistoryId Id of the offloaded supplier requests, returned in offload history mode, see GET /history/{id}
+	HistoryId *string `json:"historyId,omitempty"`
+
 	// Locations Locations available for the supplier
 	Locations *[]Location `json:"locations,omitempty"`
 
@@ -812,11 +944,16 @@ type ModifyRequestParams struct {
 		// Customer A message from customer to supplier
 		Customer *string `json:"customer,omitempty"`
 	} `json:"comments,omitempty"`
-	Configuration ModifyRequestParams_Configuration `json:"configuration"`
-	Contract      Contract                          `json:"contract"`
-	Customer      Customer                          `json:"customer"`
-	DropOff       RequestBranchWithTimeZone         `json:"dropOff"`
-	ExtrasAndFees *[]BookingExtraOrFee              `json:"extrasAndFees,omitempty"`
+
+	// Configuration Supplier configuration, may be omitted when configurationRef is sent
+	Configuration ModifyRequestParams_Configuration `json:"configuration,omitempty"`
+
+	// ConfigurationRef Reference to supplier configuration stored in the hub for the calling client, stored values take precedence over configuration
+	ConfigurationRef *string                   `json:"configurationRef,omitempty"`
+	Contract         Contract                  `json:"contract"`
+	Customer         Customer                  `json:"customer"`
+	DropOff          RequestBranchWithTimeZone `json:"dropOff"`
+	ExtrasAndFees    *[]BookingExtraOrFee      `json:"extrasAndFees,omitempty"`
 
 	// FlightNo Flight number
 	FlightNo *string `json:"flightNo,omitempty"`
@@ -837,6 +974,9 @@ type ModifyRequestParams struct {
 	// ReservNumber Car Rental Gateway booking reservation number
 	ReservNumber string `json:"reservNumber"`
 
+	// StatusCallbackUrl Receives the final status of PENDING bookings when status tracking is enabled and the request references stored configuration with configurationRef, payloads are signed with the x-timestamp and x-signature headers
+	StatusCallbackUrl *string `json:"statusCallbackUrl,omitempty"`
+
 	// SupplierBookingReference Supplier booking reference
 	SupplierBookingReference string `json:"supplierBookingReference"`
 
@@ -861,6 +1001,9 @@ type ModifyResponse struct {
 	// Errors List supplier errors
 	Errors *SupplierResponseErrors `json:"errors,omitempty"`
 
+	// HistoryId Id of the offloaded supplier requests, returned in offload history mode, see GET /history/{id}
+	HistoryId *string `json:"historyId,omitempty"`
+
 	// Status Booking status
 	Status *ModifyResponseStatus `json:"status,omitempty"`
 
@@ -908,6 +1051,114 @@ type OpeningTime struct {
 	Year *int `json:"year,omitempty"`
 }
 
+// OtaConfiguration Supplier specific parameters for all post-type requests of suppliers speaking OTA 2003/05 vehicle messages
+type OtaConfiguration struct {
+	// AddTaxToCoverages Coverage codes for which tax is added to the price
+	AddTaxToCoverages *[]string `json:"addTaxToCoverages,omitempty"`
+
+	// Envelope Messages are posted as plain xml or wrapped in a SOAP envelope. Default is xml
+	Envelope *OtaConfigurationEnvelope `json:"envelope,omitempty"`
+
+	// FeePayableLocally Fees that are considered allways payable locally in certain countries. Example: { "AIRPORT CONCESSION RECOVERY": ["SE", "SA"] }
+	FeePayableLocally *map[string][]string `json:"feePayableLocally,omitempty"`
+
+	// FpPayFeesLocally It is used with full-prepay deals, to determine are the fees payable locally (true) or pay now (false). Default is false
+	FpPayFeesLocally *bool `json:"fpPayFeesLocally,omitempty"`
+
+	// FpPaynowVehiclePriceWithTax It works with full-prepay deals. If true, the Tax (VAT) is added to the vehicle price. Default is false
+	FpPaynowVehiclePriceWithTax *bool `json:"fpPaynowVehiclePriceWithTax,omitempty"`
+
+	// HttpHeaders Additional HTTP headers of every request. Example: {"SOAPAction": "OTA"}
+	HttpHeaders *map[string]string `json:"httpHeaders,omitempty"`
+
+	// IncludeCoveragesInRate If true, all required coverages will be included in vehicle price
+	IncludeCoveragesInRate *bool `json:"includeCoveragesInRate,omitempty"`
+
+	// LastName Customer last name who is the owner of the booking
+	LastName *string `json:"lastName,omitempty"`
+
+	// MaxResponses Max number of responses expected from the supplier. Default is 10
+	MaxResponses *int `json:"maxResponses,omitempty"`
+
+	// PayNowCoverages Supplier code(s) of coverage(s) that are not included in rate and are have to be set pay now. Example: ["7","48"]
+	PayNowCoverages *[]string `json:"payNowCoverages,omitempty"`
+
+	// RateQualifier Rate Qualifier
+	RateQualifier *string `json:"rateQualifier,omitempty"`
+
+	// RequestorIds Sources of the POS element, one per requestor id
+	RequestorIds []OtaRequestorId `json:"requestorIds"`
+
+	// SendVoucher Send or not Voucher element
+	SendVoucher *bool `json:"sendVoucher,omitempty"`
+
+	// SoapHeader Raw xml content of the SOAP header, mostly credentials. Example: <auth><user>broker</user></auth>
+	SoapHeader *string `json:"soapHeader,omitempty"`
+
+	// SupplierApiUrl Supplier API url
+	SupplierApiUrl string `json:"supplierApiUrl"`
+
+	// Target Target of the messages like Test or Production. Not sent if empty
+	Target *string `json:"target,omitempty"`
+
+	// TaxAllCoverages If true, tax is added to the price of all coverages
+	TaxAllCoverages *bool `json:"taxAllCoverages,omitempty"`
+
+	// TaxExclCoverageCountries Tax exclusive coverage countries. Example: ["AA", "BB"]
+	TaxExclCoverageCountries *[]string `json:"taxExclCoverageCountries,omitempty"`
+
+	// TourNumber Tour Number
+	TourNumber *string `json:"tourNumber,omitempty"`
+
+	// TravelPurpose Travel Purpose
+	TravelPurpose *string `json:"travelPurpose,omitempty"`
+
+	// VendorCodes Vendor codes the rates are requested for. Example: ["ZE", "ZT"]
+	VendorCodes *[]string `json:"vendorCodes,omitempty"`
+
+	// Versions Version attributes of the messages. Default is 1.008
+	Versions *OtaMessageVersions `json:"versions,omitempty"`
+}
+
+// OtaConfigurationEnvelope Messages are posted as plain xml or wrapped in a SOAP envelope. Default is xml
+type OtaConfigurationEnvelope string
+
+// OtaMessageVersions Version attributes of the messages. Default is 1.008
+type OtaMessageVersions struct {
+	// VehAvailRate Version of OTA_VehAvailRateRQ
+	VehAvailRate *string `json:"vehAvailRate,omitempty"`
+
+	// VehCancel Version of OTA_VehCancelRQ
+	VehCancel *string `json:"vehCancel,omitempty"`
+
+	// VehRes Version of OTA_VehResRQ
+	VehRes *string `json:"vehRes,omitempty"`
+
+	// VehRetRes Version of OTA_VehRetResRQ
+	VehRetRes *string `json:"vehRetRes,omitempty"`
+}
+
+// OtaRequestorId Requestor id of a POS source
+type OtaRequestorId struct {
+	// AgentDutyCode Agent duty code of the source
+	AgentDutyCode *string `json:"agentDutyCode,omitempty"`
+
+	// CompanyName Code of the company name
+	CompanyName *string `json:"companyName,omitempty"`
+
+	// CompanyNameContext Code context of the company name
+	CompanyNameContext *string `json:"companyNameContext,omitempty"`
+
+	// Id Requestor id
+	Id string `json:"id"`
+
+	// IsoCountry ISO country of the source
+	IsoCountry *string `json:"isoCountry,omitempty"`
+
+	// Type OTA code of the requestor id type like 4 (agent) or 8 (vendor)
+	Type string `json:"type"`
+}
+
 // PassthroughPayment defines model for PassthroughPayment.
 type PassthroughPayment struct {
 	// Method Payment method
@@ -991,9 +1242,14 @@ type RatesRequestParams struct {
 	Booking   *ExistingBooking `json:"booking,omitempty"`
 
 	// BranchExtras Array of request's pickup branch extra OTA codes that is optionally submitted when supplier needs this data for the request
-	BranchExtras  *[]string                        `json:"branchExtras,omitempty"`
-	Configuration RatesRequestParams_Configuration `json:"configuration"`
-	Contract      Contract                         `json:"contract"`
+	BranchExtras *[]string `json:"branchExtras,omitempty"`
+
+	// Configuration Supplier configuration, may be omitted when configurationRef is sent
+	Configuration RatesRequestParams_Configuration `json:"configuration,omitempty"`
+
+	// ConfigurationRef Reference to supplier configuration stored in the hub for the calling client, stored values take precedence over configuration
+	ConfigurationRef *string  `json:"configurationRef,omitempty"`
+	Contract         Contract `json:"contract"`
 
 	// DriverAgeRange Driver age range array of objects
 	DriverAgeRange *[]struct {
@@ -1038,6 +1294,9 @@ type RatesResponse struct {
 	// Errors List supplier errors
 	Errors *SupplierResponseErrors `json:"errors,omitempty"`
 
+	// HistoryId Id of the offloaded supplier requests, returned in offload history mode, see GET /history/{id}
+	HistoryId *string `json:"historyId,omitempty"`
+
 	// SpecialInstructions Branch specific special instructions. Misu updates branch 'specialInstructions' property when parsing rates
 	SpecialInstructions string `json:"specialInstructions,omitempty"`
 
@@ -1048,6 +1307,12 @@ type RatesResponse struct {
 	Vehicles []Vehicle `json:"vehicles"`
 }
 
+// RemoteConfiguration Parameters of platforms served by a remote adapter, see the remote section of the service configuration. The configuration is forwarded to the adapter unchanged
+type RemoteConfiguration struct {
+	// Adapter Adapter specific parameters, e.g. credentials of the supplier
+	Adapter map[string]interface{} `json:"adapter"`
+}
+
 // RentlyConfiguration Supplier specific parameters for all post-type requests except booking. Override in supplier module for all post-routes except booking
 type RentlyConfiguration struct {
 	// CommercialAgreementCode Commercial agreement code
@@ -1117,6 +1382,39 @@ type ResponseContent struct {
 	StatusCode *int `json:"statusCode,omitempty"`
 }
 
+// SandboxConfiguration Parameters of the sandbox platform. It generates vehicles and locations and keeps bookings without a supplier, scenarios are triggered by reservNumber values SANDBOX-PENDING, SANDBOX-ERROR, SANDBOX-TIMEOUT and SANDBOX-NOCANCEL, optionally followed by a dash and any suffix
+type SandboxConfiguration struct {
+	// Locations Number of locations. Default is 10
+	Locations *int `json:"locations,omitempty"`
+
+	// Seed Seeds the generated vehicles, extras, fees and locations. Equal seeds return equal results for equal searches
+	Seed int `json:"seed"`
+
+	// Vehicles Number of vehicles of a search. Default is 6
+	Vehicles *int `json:"vehicles,omitempty"`
+}
+
+// SupplierHistory defines model for SupplierHistory.
+type SupplierHistory struct {
+	// CorrelationId Correlation id of the platform request
+	CorrelationId *string `json:"correlationId,omitempty"`
+
+	// CreatedAt Datetime when the history was stored
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Id History id
+	Id string `json:"id"`
+
+	// Operation Platform operation, e.g. rates or booking
+	Operation string `json:"operation"`
+
+	// Platform Platform ID
+	Platform string `json:"platform"`
+
+	// SupplierRequests Requests made to and responses received from supplier system
+	SupplierRequests SupplierRequests `json:"supplierRequests"`
+}
+
 // SupplierPassthrough defines model for SupplierPassthrough.
 type SupplierPassthrough struct {
 	Payment PassthroughPayment `json:"payment"`
@@ -1271,6 +1569,9 @@ type Vehicle struct {
 	// Status Status
 	Status VehicleStatus `json:"status"`
 
+	// Supplier Name of the supplier offering the vehicle, set by platforms searching several suppliers at once
+	Supplier *string `json:"supplier,omitempty"`
+
 	// SupplierRateReference Supplier rate reference
 	SupplierRateReference *string `json:"supplierRateReference,omitempty"`
 
@@ -1293,6 +1594,12 @@ type VehicleTransmissionType string
 // RequiredPlatformInPath defines model for requiredPlatformInPath.
 type RequiredPlatformInPath string
 
+// GetHealthReadyParams defines parameters for GetHealthReady.
+type GetHealthReadyParams struct {
+	// Suppliers Include configured supplier reachability probes
+	Suppliers *bool `form:"suppliers,omitempty" json:"suppliers,omitempty"`
+}
+
 // CreateBookingParamsPlatform defines parameters for CreateBooking.
 type CreateBookingParamsPlatform string
 
@@ -1317,6 +1624,9 @@ type CreateBookingJSONRequestBody = BookingRequestParams
 // CheckBookingStatusJSONRequestBody defines body for CheckBookingStatus for application/json ContentType.
 type CheckBookingStatusJSONRequestBody = BookingStatusRequestParams
 
+// CheckBookingStatusBatchJSONRequestBody defines body for CheckBookingStatusBatch for application/json ContentType.
+type CheckBookingStatusBatchJSONRequestBody = BookingStatusBatchRequestParams
+
 // CancelBookingJSONRequestBody defines body for CancelBooking for application/json ContentType.
 type CancelBookingJSONRequestBody = CancelRequestParams
 
@@ -1459,6 +1769,84 @@ func (t *BookingRequestParams_Configuration) MergeRentlyConfiguration(v RentlyCo
 	return err
 }
 
+// AsOtaConfiguration returns the union data inside the BookingRequestParams_Configuration as a OtaConfiguration
+func (t BookingRequestParams_Configuration) AsOtaConfiguration() (OtaConfiguration, error) {
+	var body OtaConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromOtaConfiguration overwrites any union data inside the BookingRequestParams_Configuration as the provided OtaConfiguration
+func (t *BookingRequestParams_Configuration) FromOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeOtaConfiguration performs a merge with any union data inside the BookingRequestParams_Configuration, using the provided OtaConfiguration
+func (t *BookingRequestParams_Configuration) MergeOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRemoteConfiguration returns the union data inside the BookingRequestParams_Configuration as a RemoteConfiguration
+func (t BookingRequestParams_Configuration) AsRemoteConfiguration() (RemoteConfiguration, error) {
+	var body RemoteConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRemoteConfiguration overwrites any union data inside the BookingRequestParams_Configuration as the provided RemoteConfiguration
+func (t *BookingRequestParams_Configuration) FromRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRemoteConfiguration performs a merge with any union data inside the BookingRequestParams_Configuration, using the provided RemoteConfiguration
+func (t *BookingRequestParams_Configuration) MergeRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSandboxConfiguration returns the union data inside the BookingRequestParams_Configuration as a SandboxConfiguration
+func (t BookingRequestParams_Configuration) AsSandboxConfiguration() (SandboxConfiguration, error) {
+	var body SandboxConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSandboxConfiguration overwrites any union data inside the BookingRequestParams_Configuration as the provided SandboxConfiguration
+func (t *BookingRequestParams_Configuration) FromSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSandboxConfiguration performs a merge with any union data inside the BookingRequestParams_Configuration, using the provided SandboxConfiguration
+func (t *BookingRequestParams_Configuration) MergeSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t BookingRequestParams_Configuration) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -1599,6 +1987,84 @@ func (t *BookingStatusRequestParams_Configuration) MergeRentlyConfiguration(v Re
 	return err
 }
 
+// AsOtaConfiguration returns the union data inside the BookingStatusRequestParams_Configuration as a OtaConfiguration
+func (t BookingStatusRequestParams_Configuration) AsOtaConfiguration() (OtaConfiguration, error) {
+	var body OtaConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromOtaConfiguration overwrites any union data inside the BookingStatusRequestParams_Configuration as the provided OtaConfiguration
+func (t *BookingStatusRequestParams_Configuration) FromOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeOtaConfiguration performs a merge with any union data inside the BookingStatusRequestParams_Configuration, using the provided OtaConfiguration
+func (t *BookingStatusRequestParams_Configuration) MergeOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRemoteConfiguration returns the union data inside the BookingStatusRequestParams_Configuration as a RemoteConfiguration
+func (t BookingStatusRequestParams_Configuration) AsRemoteConfiguration() (RemoteConfiguration, error) {
+	var body RemoteConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRemoteConfiguration overwrites any union data inside the BookingStatusRequestParams_Configuration as the provided RemoteConfiguration
+func (t *BookingStatusRequestParams_Configuration) FromRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRemoteConfiguration performs a merge with any union data inside the BookingStatusRequestParams_Configuration, using the provided RemoteConfiguration
+func (t *BookingStatusRequestParams_Configuration) MergeRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSandboxConfiguration returns the union data inside the BookingStatusRequestParams_Configuration as a SandboxConfiguration
+func (t BookingStatusRequestParams_Configuration) AsSandboxConfiguration() (SandboxConfiguration, error) {
+	var body SandboxConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSandboxConfiguration overwrites any union data inside the BookingStatusRequestParams_Configuration as the provided SandboxConfiguration
+func (t *BookingStatusRequestParams_Configuration) FromSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSandboxConfiguration performs a merge with any union data inside the BookingStatusRequestParams_Configuration, using the provided SandboxConfiguration
+func (t *BookingStatusRequestParams_Configuration) MergeSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t BookingStatusRequestParams_Configuration) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -1739,6 +2205,84 @@ func (t *CancelRequestParams_Configuration) MergeRentlyConfiguration(v RentlyCon
 	return err
 }
 
+// AsOtaConfiguration returns the union data inside the CancelRequestParams_Configuration as a OtaConfiguration
+func (t CancelRequestParams_Configuration) AsOtaConfiguration() (OtaConfiguration, error) {
+	var body OtaConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromOtaConfiguration overwrites any union data inside the CancelRequestParams_Configuration as the provided OtaConfiguration
+func (t *CancelRequestParams_Configuration) FromOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeOtaConfiguration performs a merge with any union data inside the CancelRequestParams_Configuration, using the provided OtaConfiguration
+func (t *CancelRequestParams_Configuration) MergeOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRemoteConfiguration returns the union data inside the CancelRequestParams_Configuration as a RemoteConfiguration
+func (t CancelRequestParams_Configuration) AsRemoteConfiguration() (RemoteConfiguration, error) {
+	var body RemoteConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRemoteConfiguration overwrites any union data inside the CancelRequestParams_Configuration as the provided RemoteConfiguration
+func (t *CancelRequestParams_Configuration) FromRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRemoteConfiguration performs a merge with any union data inside the CancelRequestParams_Configuration, using the provided RemoteConfiguration
+func (t *CancelRequestParams_Configuration) MergeRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSandboxConfiguration returns the union data inside the CancelRequestParams_Configuration as a SandboxConfiguration
+func (t CancelRequestParams_Configuration) AsSandboxConfiguration() (SandboxConfiguration, error) {
+	var body SandboxConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSandboxConfiguration overwrites any union data inside the CancelRequestParams_Configuration as the provided SandboxConfiguration
+func (t *CancelRequestParams_Configuration) FromSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSandboxConfiguration performs a merge with any union data inside the CancelRequestParams_Configuration, using the provided SandboxConfiguration
+func (t *CancelRequestParams_Configuration) MergeSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t CancelRequestParams_Configuration) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -1879,6 +2423,84 @@ func (t *LocationsRequestParams_Configuration) MergeRentlyConfiguration(v Rently
 	return err
 }
 
+// AsOtaConfiguration returns the union data inside the LocationsRequestParams_Configuration as a OtaConfiguration
+func (t LocationsRequestParams_Configuration) AsOtaConfiguration() (OtaConfiguration, error) {
+	var body OtaConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromOtaConfiguration overwrites any union data inside the LocationsRequestParams_Configuration as the provided OtaConfiguration
+func (t *LocationsRequestParams_Configuration) FromOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeOtaConfiguration performs a merge with any union data inside the LocationsRequestParams_Configuration, using the provided OtaConfiguration
+func (t *LocationsRequestParams_Configuration) MergeOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRemoteConfiguration returns the union data inside the LocationsRequestParams_Configuration as a RemoteConfiguration
+func (t LocationsRequestParams_Configuration) AsRemoteConfiguration() (RemoteConfiguration, error) {
+	var body RemoteConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRemoteConfiguration overwrites any union data inside the LocationsRequestParams_Configuration as the provided RemoteConfiguration
+func (t *LocationsRequestParams_Configuration) FromRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRemoteConfiguration performs a merge with any union data inside the LocationsRequestParams_Configuration, using the provided RemoteConfiguration
+func (t *LocationsRequestParams_Configuration) MergeRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSandboxConfiguration returns the union data inside the LocationsRequestParams_Configuration as a SandboxConfiguration
+func (t LocationsRequestParams_Configuration) AsSandboxConfiguration() (SandboxConfiguration, error) {
+	var body SandboxConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSandboxConfiguration overwrites any union data inside the LocationsRequestParams_Configuration as the provided SandboxConfiguration
+func (t *LocationsRequestParams_Configuration) FromSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSandboxConfiguration performs a merge with any union data inside the LocationsRequestParams_Configuration, using the provided SandboxConfiguration
+func (t *LocationsRequestParams_Configuration) MergeSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t LocationsRequestParams_Configuration) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -2019,6 +2641,84 @@ func (t *ModifyRequestParams_Configuration) MergeRentlyConfiguration(v RentlyCon
 	return err
 }
 
+// AsOtaConfiguration returns the union data inside the ModifyRequestParams_Configuration as a OtaConfiguration
+func (t ModifyRequestParams_Configuration) AsOtaConfiguration() (OtaConfiguration, error) {
+	var body OtaConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromOtaConfiguration overwrites any union data inside the ModifyRequestParams_Configuration as the provided OtaConfiguration
+func (t *ModifyRequestParams_Configuration) FromOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeOtaConfiguration performs a merge with any union data inside the ModifyRequestParams_Configuration, using the provided OtaConfiguration
+func (t *ModifyRequestParams_Configuration) MergeOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRemoteConfiguration returns the union data inside the ModifyRequestParams_Configuration as a RemoteConfiguration
+func (t ModifyRequestParams_Configuration) AsRemoteConfiguration() (RemoteConfiguration, error) {
+	var body RemoteConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRemoteConfiguration overwrites any union data inside the ModifyRequestParams_Configuration as the provided RemoteConfiguration
+func (t *ModifyRequestParams_Configuration) FromRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRemoteConfiguration performs a merge with any union data inside the ModifyRequestParams_Configuration, using the provided RemoteConfiguration
+func (t *ModifyRequestParams_Configuration) MergeRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSandboxConfiguration returns the union data inside the ModifyRequestParams_Configuration as a SandboxConfiguration
+func (t ModifyRequestParams_Configuration) AsSandboxConfiguration() (SandboxConfiguration, error) {
+	var body SandboxConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSandboxConfiguration overwrites any union data inside the ModifyRequestParams_Configuration as the provided SandboxConfiguration
+func (t *ModifyRequestParams_Configuration) FromSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSandboxConfiguration performs a merge with any union data inside the ModifyRequestParams_Configuration, using the provided SandboxConfiguration
+func (t *ModifyRequestParams_Configuration) MergeSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t ModifyRequestParams_Configuration) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
@@ -2159,6 +2859,84 @@ func (t *RatesRequestParams_Configuration) MergeRentlyConfiguration(v RentlyConf
 	return err
 }
 
+// AsOtaConfiguration returns the union data inside the RatesRequestParams_Configuration as a OtaConfiguration
+func (t RatesRequestParams_Configuration) AsOtaConfiguration() (OtaConfiguration, error) {
+	var body OtaConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromOtaConfiguration overwrites any union data inside the RatesRequestParams_Configuration as the provided OtaConfiguration
+func (t *RatesRequestParams_Configuration) FromOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeOtaConfiguration performs a merge with any union data inside the RatesRequestParams_Configuration, using the provided OtaConfiguration
+func (t *RatesRequestParams_Configuration) MergeOtaConfiguration(v OtaConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsRemoteConfiguration returns the union data inside the RatesRequestParams_Configuration as a RemoteConfiguration
+func (t RatesRequestParams_Configuration) AsRemoteConfiguration() (RemoteConfiguration, error) {
+	var body RemoteConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromRemoteConfiguration overwrites any union data inside the RatesRequestParams_Configuration as the provided RemoteConfiguration
+func (t *RatesRequestParams_Configuration) FromRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeRemoteConfiguration performs a merge with any union data inside the RatesRequestParams_Configuration, using the provided RemoteConfiguration
+func (t *RatesRequestParams_Configuration) MergeRemoteConfiguration(v RemoteConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSandboxConfiguration returns the union data inside the RatesRequestParams_Configuration as a SandboxConfiguration
+func (t RatesRequestParams_Configuration) AsSandboxConfiguration() (SandboxConfiguration, error) {
+	var body SandboxConfiguration
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSandboxConfiguration overwrites any union data inside the RatesRequestParams_Configuration as the provided SandboxConfiguration
+func (t *RatesRequestParams_Configuration) FromSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSandboxConfiguration performs a merge with any union data inside the RatesRequestParams_Configuration, using the provided SandboxConfiguration
+func (t *RatesRequestParams_Configuration) MergeSandboxConfiguration(v SandboxConfiguration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t RatesRequestParams_Configuration) MarshalJSON() ([]byte, error) {
 	b, err := t.union.MarshalJSON()
 	return b, err
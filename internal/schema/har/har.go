@@ -0,0 +1,301 @@
+// Package har converts supplier requests to HTTP Archive 1.2 documents,
+// they open in browser devtools and most replay tools.
+package har
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+)
+
+const (
+	MimeType = "application/har+json"
+
+	version        = "1.2"
+	httpVersion    = "HTTP/1.1"
+	creatorName    = "supplier-hub"
+	creatorVersion = "1.0.0"
+)
+
+var ErrorNoSupplierRequests = errors.New("no supplier requests found")
+
+type Har struct {
+	Log Log `json:"log"`
+}
+
+type Log struct {
+	Version string  `json:"version"`
+	Creator Creator `json:"creator"`
+	Entries []Entry `json:"entries"`
+}
+
+type Creator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type Entry struct {
+	StartedDateTime string   `json:"startedDateTime"`
+	Time            float64  `json:"time"`
+	Request         Request  `json:"request"`
+	Response        Response `json:"response"`
+	Cache           struct{} `json:"cache"`
+	Timings         Timings  `json:"timings"`
+	// Comment carries the supplier request name, e.g. rates or auth
+	Comment string `json:"comment,omitempty"`
+}
+
+type Request struct {
+	Method      string      `json:"method"`
+	Url         string      `json:"url"`
+	HttpVersion string      `json:"httpVersion"`
+	Cookies     []NameValue `json:"cookies"`
+	Headers     []NameValue `json:"headers"`
+	QueryString []NameValue `json:"queryString"`
+	PostData    *PostData   `json:"postData,omitempty"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+type Response struct {
+	Status      int         `json:"status"`
+	StatusText  string      `json:"statusText"`
+	HttpVersion string      `json:"httpVersion"`
+	Cookies     []NameValue `json:"cookies"`
+	Headers     []NameValue `json:"headers"`
+	Content     Content     `json:"content"`
+	RedirectUrl string      `json:"redirectURL"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type PostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type Content struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// Timings only know the total duration, it is reported as waiting
+type Timings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func value[T any](pointer *T) T {
+	var zero T
+	if pointer == nil {
+		return zero
+	}
+
+	return *pointer
+}
+
+// headers flattens header maps, values are []string before and
+// []interface{} after a JSON round trip
+func headers(values *map[string]interface{}) []NameValue {
+	pairs := []NameValue{}
+
+	if values == nil {
+		return pairs
+	}
+
+	for name, raw := range *values {
+		switch v := raw.(type) {
+		case string:
+			pairs = append(pairs, NameValue{Name: name, Value: v})
+		case []string:
+			for _, item := range v {
+				pairs = append(pairs, NameValue{Name: name, Value: item})
+			}
+		case []interface{}:
+			for _, item := range v {
+				pairs = append(pairs, NameValue{Name: name, Value: fmt.Sprint(item)})
+			}
+		default:
+			pairs = append(pairs, NameValue{Name: name, Value: fmt.Sprint(v)})
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Name < pairs[j].Name
+	})
+
+	return pairs
+}
+
+func queryString(rawUrl string) []NameValue {
+	pairs := []NameValue{}
+
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return pairs
+	}
+
+	query := parsed.Query()
+
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, item := range query[name] {
+			pairs = append(pairs, NameValue{Name: name, Value: item})
+		}
+	}
+
+	return pairs
+}
+
+// mimeType prefers the Content-Type header and falls back to sniffing the body,
+// suppliers speak XML or JSON
+func mimeType(pairs []NameValue, body string) string {
+	for _, pair := range pairs {
+		if strings.EqualFold(pair.Name, "Content-Type") {
+			return pair.Value
+		}
+	}
+
+	switch trimmed := strings.TrimSpace(body); {
+	case strings.HasPrefix(trimmed, "<"):
+		return "application/xml"
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		return "application/json"
+	default:
+		return "text/plain"
+	}
+}
+
+func entry(request schema.SupplierRequest) Entry {
+	requestContent := value(request.RequestContent)
+	responseContent := value(request.ResponseContent)
+
+	started := time.Unix(0, 0).UTC()
+	if request.StartDateTime != nil {
+		started = *request.StartDateTime
+	}
+
+	duration := float64(value(request.Duration))
+
+	requestHeaders := headers(requestContent.Headers)
+	requestBody := value(requestContent.Body)
+
+	e := Entry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            duration,
+		Request: Request{
+			Method:      value(requestContent.Method),
+			Url:         value(requestContent.Url),
+			HttpVersion: httpVersion,
+			Cookies:     []NameValue{},
+			Headers:     requestHeaders,
+			QueryString: queryString(value(requestContent.Url)),
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Timings: Timings{
+			Wait: duration,
+		},
+		Comment: string(value(request.Name)),
+	}
+
+	if requestBody != "" {
+		e.Request.PostData = &PostData{
+			MimeType: mimeType(requestHeaders, requestBody),
+			Text:     requestBody,
+		}
+	}
+
+	responseHeaders := headers(responseContent.Headers)
+	responseBody := value(responseContent.Body)
+	status := value(responseContent.StatusCode)
+
+	e.Response = Response{
+		Status:      status,
+		StatusText:  http.StatusText(status),
+		HttpVersion: httpVersion,
+		Cookies:     []NameValue{},
+		Headers:     responseHeaders,
+		Content: Content{
+			Size:     len(responseBody),
+			MimeType: mimeType(responseHeaders, responseBody),
+			Text:     responseBody,
+		},
+		HeadersSize: -1,
+		BodySize:    len(responseBody),
+	}
+
+	return e
+}
+
+// FromSupplierRequests converts the requests in their order
+func FromSupplierRequests(requests schema.SupplierRequests) Har {
+	entries := make([]Entry, 0, len(requests))
+
+	for _, request := range requests {
+		entries = append(entries, entry(request))
+	}
+
+	return Har{
+		Log: Log{
+			Version: version,
+			Creator: Creator{
+				Name:    creatorName,
+				Version: creatorVersion,
+			},
+			Entries: entries,
+		},
+	}
+}
+
+// Parse reads supplier requests from a platform response, an offloaded
+// history or a plain SupplierRequests array
+func Parse(content []byte) (schema.SupplierRequests, error) {
+	var requests schema.SupplierRequests
+
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "[") {
+		err := json.Unmarshal(content, &requests)
+		return requests, err
+	}
+
+	var document struct {
+		SupplierRequests *schema.SupplierRequests `json:"supplierRequests"`
+		History          *struct {
+			SupplierRequests *schema.SupplierRequests `json:"supplierRequests"`
+		} `json:"history"`
+	}
+
+	err := json.Unmarshal(content, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case document.SupplierRequests != nil:
+		return *document.SupplierRequests, nil
+	case document.History != nil && document.History.SupplierRequests != nil:
+		return *document.History.SupplierRequests, nil
+	default:
+		return nil, ErrorNoSupplierRequests
+	}
+}
@@ -0,0 +1,93 @@
+package har_test
+
+import (
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/schema/har"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSupplierRequests(t *testing.T) {
+	name := schema.SupplierRequestName("rates")
+	url := "https://supplier.example/rates?pickUp=MUC&pickUp=FRA"
+	method := "POST"
+	requestBody := "<OTA_VehAvailRateRQ/>"
+	responseBody := `{"vehicles":[]}`
+	statusCode := 404
+	duration := 250
+	started := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	requests := schema.SupplierRequests{{
+		Name:          &name,
+		Duration:      &duration,
+		StartDateTime: &started,
+		RequestContent: &schema.RequestContent{
+			Url:    &url,
+			Method: &method,
+			Body:   &requestBody,
+			Headers: &map[string]interface{}{
+				"Soapaction": []string{"rates"},
+				"Accept":     []interface{}{"text/xml"},
+			},
+		},
+		ResponseContent: &schema.ResponseContent{
+			StatusCode: &statusCode,
+			Body:       &responseBody,
+		},
+	}}
+
+	t.Run("should convert supplier requests to entries", func(t *testing.T) {
+		archive := har.FromSupplierRequests(requests)
+
+		assert.Equal(t, "1.2", archive.Log.Version)
+		assert.Len(t, archive.Log.Entries, 1)
+
+		entry := archive.Log.Entries[0]
+		assert.Equal(t, "2023-08-01T10:00:00Z", entry.StartedDateTime)
+		assert.Equal(t, float64(250), entry.Time)
+		assert.Equal(t, float64(250), entry.Timings.Wait)
+		assert.Equal(t, "rates", entry.Comment)
+
+		assert.Equal(t, "POST", entry.Request.Method)
+		assert.Equal(t, url, entry.Request.Url)
+		assert.Equal(t, []har.NameValue{{Name: "Accept", Value: "text/xml"}, {Name: "Soapaction", Value: "rates"}}, entry.Request.Headers)
+		assert.Equal(t, []har.NameValue{{Name: "pickUp", Value: "MUC"}, {Name: "pickUp", Value: "FRA"}}, entry.Request.QueryString)
+		assert.Equal(t, "application/xml", entry.Request.PostData.MimeType)
+		assert.Equal(t, requestBody, entry.Request.PostData.Text)
+
+		assert.Equal(t, 404, entry.Response.Status)
+		assert.Equal(t, "Not Found", entry.Response.StatusText)
+		assert.Equal(t, "application/json", entry.Response.Content.MimeType)
+		assert.Equal(t, len(responseBody), entry.Response.Content.Size)
+	})
+
+	t.Run("should convert requests without content or timings", func(t *testing.T) {
+		archive := har.FromSupplierRequests(schema.SupplierRequests{{Name: &name}})
+
+		entry := archive.Log.Entries[0]
+		assert.Equal(t, "1970-01-01T00:00:00Z", entry.StartedDateTime)
+		assert.Nil(t, entry.Request.PostData)
+		assert.Equal(t, []har.NameValue{}, entry.Request.Headers)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should read responses, histories and arrays", func(t *testing.T) {
+		for _, content := range []string{
+			`{"vehicles":[],"supplierRequests":[{"name":"rates"}]}`,
+			`{"clientId":"broker","history":{"id":"1","supplierRequests":[{"name":"rates"}]}}`,
+			`[{"name":"rates"}]`,
+		} {
+			requests, err := har.Parse([]byte(content))
+			assert.Nil(t, err)
+			assert.Len(t, requests, 1)
+		}
+	})
+
+	t.Run("should fail without supplier requests", func(t *testing.T) {
+		_, err := har.Parse([]byte(`{"vehicles":[]}`))
+		assert.ErrorIs(t, err, har.ErrorNoSupplierRequests)
+	})
+}
@@ -1,8 +1,8 @@
 package schema
 
 import (
+	"context"
 	"net/http"
-	"os"
 	"sync"
 	"time"
 
@@ -15,6 +15,19 @@ const (
 	RequestingTypeKey Key = "requestingType"
 )
 
+type omitTimingsKey struct{}
+
+// WithoutTimings leaves durations and start times out of the supplier requests
+// made with the context, it keeps test output deterministic
+func WithoutTimings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, omitTimingsKey{}, true)
+}
+
+func timingsOmitted(ctx context.Context) bool {
+	omit, _ := ctx.Value(omitTimingsKey{}).(bool)
+	return omit
+}
+
 type supplierRequestsBucket struct {
 	supplierRequests SupplierRequests
 	sync.Mutex
@@ -37,6 +50,7 @@ func (r *supplierRequestsBucket) AddRequests(requests SupplierRequests) {
 }
 
 func (r *supplierRequestsBucket) FinishedRequest(
+	ctx context.Context,
 	requestType SupplierRequestName,
 	startTime time.Time,
 	statusCode int,
@@ -71,7 +85,7 @@ func (r *supplierRequestsBucket) FinishedRequest(
 
 	historyRequest.ResponseContent = &res
 
-	if os.Getenv("TEST") != "true" {
+	if !timingsOmitted(ctx) {
 		duration := int(time.Since(startTime).Milliseconds())
 		historyRequest.Duration = &duration
 		historyRequest.StartDateTime = &startTime
@@ -1,92 +1,131 @@
 package caching
 
 import (
-	"bytes"
-	"compress/flate"
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	EngineRedis  string = "redis"
+	EngineMemory string = "memory"
+	EngineTiered string = "tiered"
+
+	defaultLocalTtl = 1 * time.Minute
+)
+
+// Engine stores raw values. Fetch returns nil value and nil error on a miss.
 type Engine interface {
-	Store(ctx context.Context, key string, value any, ttl time.Duration) error
+	Store(ctx context.Context, key string, value []byte, ttl time.Duration) error
 	Fetch(ctx context.Context, key string) ([]byte, error)
 }
 
 type Cacher struct {
 	engine Engine
+	codec  Codec
 }
 
-func NewRedisCache(redisClient *redis.Client) *Cacher {
+type Options struct {
+	// Engine one of redis, memory or tiered, defaults to redis
+	Engine string
+	// Codec one of deflate, zstd or snappy, defaults to deflate
+	Codec string
+	// LocalTtl caps how long the tiered engine keeps values in memory
+	LocalTtl time.Duration
+}
+
+func New(engine Engine, codec Codec) *Cacher {
 	return &Cacher{
-		engine: &redisCache{
-			redis: redisClient,
-		},
+		engine: engine,
+		codec:  codec,
 	}
 }
 
-func deflate(uncompressed []byte) ([]byte, error) {
-	var buffer bytes.Buffer
-	writer, _ := flate.NewWriter(&buffer, flate.BestSpeed)
+func NewRedisCache(redisClient redis.UniversalClient) *Cacher {
+	return New(NewRedisEngine(redisClient), Deflate)
+}
 
-	_, err := writer.Write(uncompressed)
+func NewMemoryCache() *Cacher {
+	return New(NewMemoryEngine(), Deflate)
+}
+
+// NewFromOptions skips redis while available reports false, the same way
+// grouping does, available is optional
+func NewFromOptions(o Options, redisClient redis.UniversalClient, available func() bool) (*Cacher, error) {
+	codec, err := CodecByName(o.Codec)
 	if err != nil {
 		return nil, err
 	}
 
-	err = writer.Close()
-	if err != nil {
-		return nil, err
+	localTtl := o.LocalTtl
+	if localTtl <= 0 {
+		localTtl = defaultLocalTtl
 	}
 
-	return buffer.Bytes(), nil
-}
+	remote := NewRedisEngine(redisClient)
+	if available != nil {
+		remote = SkipUnavailable(remote, available)
+	}
 
-func inflate(compressed []byte) ([]byte, error) {
-	buffer := bytes.NewReader(compressed)
-	reader := flate.NewReader(buffer)
-	defer reader.Close()
+	switch o.Engine {
+	case "", EngineRedis:
+		return New(remote, codec), nil
+	case EngineMemory:
+		return New(NewMemoryEngine(), codec), nil
+	case EngineTiered:
+		return New(NewTieredEngine(NewMemoryEngine(), remote, localTtl), codec), nil
+	default:
+		return nil, fmt.Errorf("unknown cache engine %s", o.Engine)
+	}
+}
 
-	var out bytes.Buffer
-	_, err := out.ReadFrom(reader)
+// Encode marshals value to json and compresses it with the codec header
+func Encode(codec Codec, value any) ([]byte, error) {
+	bytes, err := json.Marshal(value)
 	if err != nil {
-		return []byte{}, err
+		return nil, err
 	}
 
-	return out.Bytes(), nil
+	return encode(codec, bytes)
 }
 
-func (c *Cacher) Store(ctx context.Context, key string, value any, ttl time.Duration) error {
-	bytes, err := json.Marshal(value)
+// Decode decompresses a stored value and unmarshals it into destination
+func Decode(value []byte, destination any) error {
+	uncompressed, err := decode(value)
 	if err != nil {
 		return err
 	}
 
-	compressed, err := deflate(bytes)
+	return json.Unmarshal(uncompressed, destination)
+}
+
+func (c *Cacher) Store(ctx context.Context, key string, value any, ttl time.Duration) error {
+	encoded, err := Encode(c.codec, value)
 	if err != nil {
 		return err
 	}
 
-	return c.engine.Store(ctx, key, compressed, ttl)
+	return c.engine.Store(ctx, key, encoded, ttl)
 }
 
-func (c *Cacher) Fetch(ctx context.Context, key string, destination any) bool {
+// Fetch reports whether the key was found, errors are never reported as a hit
+func (c *Cacher) Fetch(ctx context.Context, key string, destination any) (bool, error) {
 	value, err := c.engine.Fetch(ctx, key)
 	if err != nil {
-		return false
+		return false, err
 	}
 
 	if value == nil {
-		return false
+		return false, nil
 	}
 
-	uncompressed, err := inflate(value)
+	err = Decode(value, destination)
 	if err != nil {
-		return false
+		return false, err
 	}
 
-	err = json.Unmarshal(uncompressed, destination)
-	return err == nil
+	return true, nil
 }
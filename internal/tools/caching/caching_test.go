@@ -0,0 +1,229 @@
+package caching_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+type value struct {
+	Name string `json:"name"`
+}
+
+func TestCodecs(t *testing.T) {
+	for _, name := range []string{"deflate", "zstd", "snappy"} {
+		t.Run("should round trip with "+name, func(t *testing.T) {
+			codec, err := caching.CodecByName(name)
+			assert.Nil(t, err)
+
+			encoded, err := caching.Encode(codec, value{Name: "test"})
+			assert.Nil(t, err)
+			assert.Equal(t, codec.Header(), encoded[0])
+
+			var decoded value
+			assert.Nil(t, caching.Decode(encoded, &decoded))
+			assert.Equal(t, "test", decoded.Name)
+		})
+	}
+
+	t.Run("should default to deflate", func(t *testing.T) {
+		codec, err := caching.CodecByName("")
+		assert.Nil(t, err)
+		assert.Equal(t, caching.Deflate, codec)
+	})
+
+	t.Run("should fail on unknown codec", func(t *testing.T) {
+		_, err := caching.CodecByName("lz4")
+		assert.ErrorIs(t, err, caching.ErrorUnknownCodec)
+	})
+
+	t.Run("should decode legacy values without header", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, _ := flate.NewWriter(&buffer, flate.BestSpeed)
+		writer.Write([]byte(`{"name":"legacy"}`))
+		writer.Close()
+
+		var decoded value
+		assert.Nil(t, caching.Decode(buffer.Bytes(), &decoded))
+		assert.Equal(t, "legacy", decoded.Name)
+	})
+
+	t.Run("should fail on unknown header", func(t *testing.T) {
+		var decoded value
+		assert.ErrorIs(t, caching.Decode([]byte{0xfe, 0x00}, &decoded), caching.ErrorUnknownCodec)
+		assert.ErrorIs(t, caching.Decode([]byte{}, &decoded), caching.ErrorEmptyValue)
+	})
+}
+
+func TestRedisCache(t *testing.T) {
+	redisClient, redisMock := redismock.NewClientMock()
+	cache := caching.NewRedisCache(redisClient)
+
+	t.Run("should report a hit", func(t *testing.T) {
+		encoded, _ := caching.Encode(caching.Deflate, value{Name: "hit"})
+		redisMock.ExpectGet("key").SetVal(string(encoded))
+
+		var fetched value
+		hit, err := cache.Fetch(context.Background(), "key", &fetched)
+		assert.Nil(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, "hit", fetched.Name)
+	})
+
+	t.Run("should report a miss without error", func(t *testing.T) {
+		redisMock.ExpectGet("key").RedisNil()
+
+		var fetched value
+		hit, err := cache.Fetch(context.Background(), "key", &fetched)
+		assert.Nil(t, err)
+		assert.False(t, hit)
+	})
+
+	t.Run("should report errors separately", func(t *testing.T) {
+		redisMock.ExpectGet("key").SetErr(assert.AnError)
+
+		var fetched value
+		hit, err := cache.Fetch(context.Background(), "key", &fetched)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, hit)
+	})
+
+	t.Run("should skip caching while redis is unavailable", func(t *testing.T) {
+		encoded, _ := caching.Encode(caching.Deflate, value{Name: "skipped"})
+		redisMock.ExpectGet("key").SetErr(redisfactory.ErrorUnavailable)
+		redisMock.ExpectSetEx("key", encoded, time.Minute).SetErr(redisfactory.ErrorUnavailable)
+
+		var fetched value
+		hit, err := cache.Fetch(context.Background(), "key", &fetched)
+		assert.Nil(t, err)
+		assert.False(t, hit)
+
+		assert.Nil(t, cache.Store(context.Background(), "key", value{Name: "skipped"}, time.Minute))
+		assert.Nil(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("should report corrupted values as errors", func(t *testing.T) {
+		redisMock.ExpectGet("key").SetVal("\xfecorrupted")
+
+		var fetched value
+		hit, err := cache.Fetch(context.Background(), "key", &fetched)
+		assert.NotNil(t, err)
+		assert.False(t, hit)
+	})
+
+	t.Run("should store encoded values", func(t *testing.T) {
+		encoded, _ := caching.Encode(caching.Deflate, value{Name: "stored"})
+		redisMock.ExpectSetEx("key", encoded, time.Minute).SetVal("OK")
+
+		assert.Nil(t, cache.Store(context.Background(), "key", value{Name: "stored"}, time.Minute))
+		assert.Nil(t, redisMock.ExpectationsWereMet())
+	})
+}
+
+func TestNewFromOptions(t *testing.T) {
+	t.Run("should skip redis while it is unavailable", func(t *testing.T) {
+		redisClient, redisMock := redismock.NewClientMock()
+		available := false
+
+		cache, err := caching.NewFromOptions(caching.Options{Engine: caching.EngineTiered}, redisClient, func() bool {
+			return available
+		})
+		assert.Nil(t, err)
+
+		assert.Nil(t, cache.Store(context.Background(), "key", value{Name: "local"}, time.Minute))
+
+		var fetched value
+		hit, err := cache.Fetch(context.Background(), "key", &fetched)
+		assert.Nil(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, "local", fetched.Name)
+
+		hit, err = cache.Fetch(context.Background(), "other", &fetched)
+		assert.Nil(t, err)
+		assert.False(t, hit)
+		assert.Nil(t, redisMock.ExpectationsWereMet())
+
+		available = true
+		redisMock.ExpectGet("other").RedisNil()
+
+		hit, err = cache.Fetch(context.Background(), "other", &fetched)
+		assert.Nil(t, err)
+		assert.False(t, hit)
+		assert.Nil(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("should fail on unknown engines", func(t *testing.T) {
+		redisClient, _ := redismock.NewClientMock()
+
+		_, err := caching.NewFromOptions(caching.Options{Engine: "disk"}, redisClient, nil)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := caching.NewMemoryCache()
+
+	t.Run("should store and fetch values", func(t *testing.T) {
+		assert.Nil(t, cache.Store(context.Background(), "key", value{Name: "memory"}, time.Minute))
+
+		var fetched value
+		hit, err := cache.Fetch(context.Background(), "key", &fetched)
+		assert.Nil(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, "memory", fetched.Name)
+	})
+
+	t.Run("should expire values", func(t *testing.T) {
+		assert.Nil(t, cache.Store(context.Background(), "expiring", value{Name: "memory"}, time.Nanosecond))
+		time.Sleep(time.Millisecond)
+
+		var fetched value
+		hit, err := cache.Fetch(context.Background(), "expiring", &fetched)
+		assert.Nil(t, err)
+		assert.False(t, hit)
+	})
+}
+
+func TestTieredEngine(t *testing.T) {
+	redisClient, redisMock := redismock.NewClientMock()
+	local := caching.NewMemoryEngine()
+	engine := caching.NewTieredEngine(local, caching.NewRedisEngine(redisClient), time.Minute)
+
+	t.Run("should read through and keep the value locally", func(t *testing.T) {
+		redisMock.ExpectGet("key").SetVal("remote")
+
+		value, err := engine.Fetch(context.Background(), "key")
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("remote"), value)
+
+		// second fetch must not reach redis
+		value, err = engine.Fetch(context.Background(), "key")
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("remote"), value)
+		assert.Nil(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("should write both tiers", func(t *testing.T) {
+		redisMock.ExpectSetEx("stored", []byte("value"), time.Hour).SetVal("OK")
+
+		assert.Nil(t, engine.Store(context.Background(), "stored", []byte("value"), time.Hour))
+
+		value, _ := local.Fetch(context.Background(), "stored")
+		assert.Equal(t, []byte("value"), value)
+		assert.Nil(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("should report remote errors on local miss", func(t *testing.T) {
+		redisMock.ExpectGet("failing").SetErr(assert.AnError)
+
+		_, err := engine.Fetch(context.Background(), "failing")
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,177 @@
+package caching
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Every stored value starts with a header byte naming the codec.
+// Header values use the reserved deflate block type (bits 1-2 set), so values
+// stored before headers were introduced are still recognised as raw deflate.
+const (
+	HeaderDeflate byte = 0x16
+	HeaderZstd    byte = 0x1e
+	HeaderSnappy  byte = 0x26
+
+	reservedBlockType byte = 0x06
+)
+
+var (
+	ErrorEmptyValue   = errors.New("empty cache value")
+	ErrorUnknownCodec = errors.New("unknown cache codec")
+)
+
+type Codec interface {
+	Name() string
+	Header() byte
+	Compress(uncompressed []byte) ([]byte, error)
+	Decompress(compressed []byte) ([]byte, error)
+}
+
+var (
+	Deflate Codec = &deflateCodec{}
+	Zstd    Codec = &zstdCodec{}
+	Snappy  Codec = &snappyCodec{}
+)
+
+var codecs = []Codec{Deflate, Zstd, Snappy}
+
+// CodecByName returns the codec registered under the name, empty name falls back to deflate
+func CodecByName(name string) (Codec, error) {
+	if name == "" {
+		return Deflate, nil
+	}
+
+	for _, codec := range codecs {
+		if codec.Name() == name {
+			return codec, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrorUnknownCodec, name)
+}
+
+func codecByHeader(header byte) (Codec, bool) {
+	for _, codec := range codecs {
+		if codec.Header() == header {
+			return codec, true
+		}
+	}
+
+	return nil, false
+}
+
+func encode(codec Codec, uncompressed []byte) ([]byte, error) {
+	compressed, err := codec.Compress(uncompressed)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{codec.Header()}, compressed...), nil
+}
+
+func decode(value []byte) ([]byte, error) {
+	if len(value) == 0 {
+		return nil, ErrorEmptyValue
+	}
+
+	codec, ok := codecByHeader(value[0])
+	if ok {
+		return codec.Decompress(value[1:])
+	}
+
+	// legacy values without a header
+	if value[0]&reservedBlockType != reservedBlockType {
+		return Deflate.Decompress(value)
+	}
+
+	return nil, fmt.Errorf("%w: header %#x", ErrorUnknownCodec, value[0])
+}
+
+type deflateCodec struct{}
+
+func (d *deflateCodec) Name() string {
+	return "deflate"
+}
+
+func (d *deflateCodec) Header() byte {
+	return HeaderDeflate
+}
+
+func (d *deflateCodec) Compress(uncompressed []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer, _ := flate.NewWriter(&buffer, flate.BestSpeed)
+
+	_, err := writer.Write(uncompressed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (d *deflateCodec) Decompress(compressed []byte) ([]byte, error) {
+	buffer := bytes.NewReader(compressed)
+	reader := flate.NewReader(buffer)
+	defer reader.Close()
+
+	var out bytes.Buffer
+	_, err := out.ReadFrom(reader)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// encoder and decoder are safe for concurrent EncodeAll and DecodeAll calls
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+type zstdCodec struct{}
+
+func (z *zstdCodec) Name() string {
+	return "zstd"
+}
+
+func (z *zstdCodec) Header() byte {
+	return HeaderZstd
+}
+
+func (z *zstdCodec) Compress(uncompressed []byte) ([]byte, error) {
+	return zstdEncoder.EncodeAll(uncompressed, nil), nil
+}
+
+func (z *zstdCodec) Decompress(compressed []byte) ([]byte, error) {
+	return zstdDecoder.DecodeAll(compressed, nil)
+}
+
+type snappyCodec struct{}
+
+func (s *snappyCodec) Name() string {
+	return "snappy"
+}
+
+func (s *snappyCodec) Header() byte {
+	return HeaderSnappy
+}
+
+func (s *snappyCodec) Compress(uncompressed []byte) ([]byte, error) {
+	return snappy.Encode(nil, uncompressed), nil
+}
+
+func (s *snappyCodec) Decompress(compressed []byte) ([]byte, error) {
+	return snappy.Decode(nil, compressed)
+}
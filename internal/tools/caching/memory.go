@@ -0,0 +1,80 @@
+package caching
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// expired entries are swept after this many stores
+	memorySweepInterval = 1024
+)
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type memoryEngine struct {
+	entries map[string]memoryEntry
+	stores  int
+	sync.RWMutex
+}
+
+// NewMemoryEngine keeps values in process memory, meant for local development and tests
+func NewMemoryEngine() *memoryEngine {
+	return &memoryEngine{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (m *memoryEngine) Store(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	copied := make([]byte, len(value))
+	copy(copied, value)
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.entries[key] = memoryEntry{
+		value:     copied,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	m.stores++
+	if m.stores%memorySweepInterval == 0 {
+		m.sweep()
+	}
+
+	return nil
+}
+
+func (m *memoryEngine) Fetch(ctx context.Context, key string) ([]byte, error) {
+	m.RLock()
+	entry, ok := m.entries[key]
+	m.RUnlock()
+
+	if !ok {
+		return nil, nil
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		m.Lock()
+		delete(m.entries, key)
+		m.Unlock()
+
+		return nil, nil
+	}
+
+	return entry.value, nil
+}
+
+func (m *memoryEngine) sweep() {
+	now := time.Now()
+
+	for key, entry := range m.entries {
+		if now.After(entry.expiresAt) {
+			delete(m.entries, key)
+		}
+	}
+}
@@ -2,17 +2,31 @@ package caching
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
 	"github.com/redis/go-redis/v9"
 )
 
-type redisCache struct {
-	redis *redis.Client
+type redisEngine struct {
+	redis redis.UniversalClient
 }
 
-func (c *redisCache) Store(ctx context.Context, key string, value any, ttl time.Duration) error {
+func NewRedisEngine(redisClient redis.UniversalClient) Engine {
+	return &redisEngine{
+		redis: redisClient,
+	}
+}
+
+func (c *redisEngine) Store(ctx context.Context, key string, value []byte, ttl time.Duration) error {
 	_, err := c.redis.SetEx(ctx, key, value, ttl).Result()
+
+	// caching is skipped while redis is down
+	if errors.Is(err, redisfactory.ErrorUnavailable) {
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
@@ -20,15 +34,46 @@ func (c *redisCache) Store(ctx context.Context, key string, value any, ttl time.
 	return nil
 }
 
-func (c *redisCache) Fetch(ctx context.Context, key string) ([]byte, error) {
+func (c *redisEngine) Fetch(ctx context.Context, key string) ([]byte, error) {
 	value, err := c.redis.Get(ctx, key).Bytes()
+
+	if err == redis.Nil || errors.Is(err, redisfactory.ErrorUnavailable) {
+		return nil, nil
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	if err == redis.Nil {
+	return value, nil
+}
+
+type availableEngine struct {
+	engine    Engine
+	available func() bool
+}
+
+// SkipUnavailable stores nothing and misses while available reports false,
+// so requests do not wait for an engine that is down
+func SkipUnavailable(engine Engine, available func() bool) Engine {
+	return &availableEngine{
+		engine:    engine,
+		available: available,
+	}
+}
+
+func (a *availableEngine) Store(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if !a.available() {
+		return nil
+	}
+
+	return a.engine.Store(ctx, key, value, ttl)
+}
+
+func (a *availableEngine) Fetch(ctx context.Context, key string) ([]byte, error) {
+	if !a.available() {
 		return nil, nil
 	}
 
-	return value, nil
+	return a.engine.Fetch(ctx, key)
 }
@@ -0,0 +1,56 @@
+package caching
+
+import (
+	"context"
+	"time"
+)
+
+type tieredEngine struct {
+	local    Engine
+	remote   Engine
+	localTtl time.Duration
+}
+
+// NewTieredEngine reads through a local engine before the remote one.
+// Values are kept locally for at most localTtl, so remote purges are picked up.
+func NewTieredEngine(local Engine, remote Engine, localTtl time.Duration) Engine {
+	return &tieredEngine{
+		local:    local,
+		remote:   remote,
+		localTtl: localTtl,
+	}
+}
+
+func (t *tieredEngine) localTtlFor(ttl time.Duration) time.Duration {
+	if ttl < t.localTtl {
+		return ttl
+	}
+
+	return t.localTtl
+}
+
+func (t *tieredEngine) Store(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	err := t.local.Store(ctx, key, value, t.localTtlFor(ttl))
+	if err != nil {
+		return err
+	}
+
+	return t.remote.Store(ctx, key, value, ttl)
+}
+
+func (t *tieredEngine) Fetch(ctx context.Context, key string) ([]byte, error) {
+	value, err := t.local.Fetch(ctx, key)
+	if err == nil && value != nil {
+		return value, nil
+	}
+
+	value, err = t.remote.Fetch(ctx, key)
+	if err != nil || value == nil {
+		return value, err
+	}
+
+	// remote ttl is unknown here, keep it for the local ttl only
+	_ = t.local.Store(ctx, key, value, t.localTtl)
+
+	return value, nil
+}
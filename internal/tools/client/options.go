@@ -2,7 +2,6 @@ package client
 
 import (
 	"fmt"
-	"os"
 	"time"
 )
 
@@ -14,7 +13,7 @@ type Options struct {
 	// Name of the caller service, used for logging
 	name string
 
-	// Defaults to CRG_SERVICE_DOMAIN
+	// Domain of the internal services, the service host is prepended to it
 	serviceDomain string
 
 	// ServiceHost - defaults to user-service
@@ -23,7 +22,7 @@ type Options struct {
 	// pathPrefix - added before openapi provided path
 	pathPrefix string
 
-	// UseHttps - defaults to false, used only with CRG_SERVICE_DOMAIN
+	// UseHttps - defaults to false, used only with serviceDomain
 	useHTTPS bool
 
 	// BaseURL - full URL to the service (including protocol) - overrides ServiceDomain and ServiceHost
@@ -90,18 +89,13 @@ func (o *Options) BaseURL(subDomain string, pathPrefix string) string {
 		return o.baseURL
 	}
 
-	var serviceDomain string
-	if o.serviceDomain == "" {
-		serviceDomain = os.Getenv("CRG_SERVICE_DOMAIN")
-	}
-
-	var serviceHost string
-	if o.serviceHost == "" {
+	serviceHost := o.serviceHost
+	if serviceHost == "" {
 		serviceHost = subDomain
 	}
 
-	var prefix string
-	if o.pathPrefix == "" {
+	prefix := o.pathPrefix
+	if prefix == "" {
 		prefix = pathPrefix
 	}
 
@@ -110,7 +104,7 @@ func (o *Options) BaseURL(subDomain string, pathPrefix string) string {
 		protocol = "https"
 	}
 
-	return fmt.Sprintf("%s://%s.%s%s", protocol, serviceHost, serviceDomain, prefix)
+	return fmt.Sprintf("%s://%s.%s%s", protocol, serviceHost, o.serviceDomain, prefix)
 }
 
 func (o *Options) Timeout() time.Duration {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
 
 	"bitbucket.org/crgw/supplier-hub/internal/tools/client"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/client/userservice/openapi"
@@ -15,12 +14,14 @@ type Client struct {
 	client *openapi.ClientWithResponses
 }
 
-func NewClient(logger *zerolog.Logger, optionFuncs ...client.OptionFunc) (*Client, error) {
-	baseURL := os.Getenv("CRG_URL_USER_SERVICE")
-	clientOptions := []client.OptionFunc{client.WithBaseURL(baseURL)}
-	clientOptions = append(clientOptions, optionFuncs...)
+// BaseURL returns the url requested by clients created with the same options
+func BaseURL(optionFuncs ...client.OptionFunc) string {
+	options, _ := client.NewOptions(optionFuncs...)
+	return options.BaseURL("user-service", "")
+}
 
-	options, err := client.NewOptions(clientOptions...)
+func NewClient(logger *zerolog.Logger, optionFuncs ...client.OptionFunc) (*Client, error) {
+	options, err := client.NewOptions(optionFuncs...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,248 @@
+// Package jobqueue keeps jobs in redis and runs them when they are due. Any
+// instance runs due jobs, a claimed job is leased to one instance and is due
+// again when that instance stops before saving it.
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// tickInterval is how often due jobs are looked for
+	tickInterval = time.Second
+	// claimLease covers running a job, see claim
+	claimLease = 2 * time.Minute
+	claimBatch = 20
+)
+
+var ErrorNotFound = errors.New("job not found")
+
+// claimScript moves a due job to the end of its lease, instances stopped
+// while running a job leave it due again when the lease ends
+var claimScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if score and tonumber(score) <= tonumber(ARGV[2]) then
+	redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+	return 1
+end
+return 0
+`)
+
+// Handler runs a due job and saves it with Schedule or Finish
+type Handler[T any] func(ctx context.Context, job T, logger *zerolog.Logger)
+
+// Queue keeps jobs as JSON under the prefix. The due key scores the ids of
+// scheduled jobs with the unix milliseconds they are due at.
+type Queue[T any] struct {
+	client redis.UniversalClient
+	name   string
+	prefix string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// New names the queue for logs and lifecycle operations, jobs expire ttl
+// after they were saved last
+func New[T any](client redis.UniversalClient, name string, prefix string, ttl time.Duration) *Queue[T] {
+	return &Queue[T]{
+		client: client,
+		name:   name,
+		prefix: prefix,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Key returns a key under the prefix of the queue, e.g. for lists kept
+// next to it
+func (q *Queue[T]) Key(name string) string {
+	return q.prefix + name
+}
+
+func (q *Queue[T]) jobKey(id string) string {
+	return q.prefix + "job:" + id
+}
+
+func (q *Queue[T]) dueKey() string {
+	return q.prefix + "due"
+}
+
+// Get returns ErrorNotFound for unknown and expired jobs
+func (q *Queue[T]) Get(ctx context.Context, id string) (T, error) {
+	var job T
+
+	content, err := q.client.Get(ctx, q.jobKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return job, ErrorNotFound
+	}
+
+	if err != nil {
+		return job, err
+	}
+
+	return job, json.Unmarshal(content, &job)
+}
+
+// Schedule saves the job to run at due. The commands added by also run in
+// the same transaction.
+func (q *Queue[T]) Schedule(ctx context.Context, id string, job T, due time.Time, also ...func(redis.Pipeliner)) error {
+	return q.save(ctx, id, job, func(pipe redis.Pipeliner) {
+		pipe.ZAdd(ctx, q.dueKey(), redis.Z{Score: float64(due.UnixMilli()), Member: id})
+	}, also)
+}
+
+// Finish saves the job without running it again, like Schedule
+func (q *Queue[T]) Finish(ctx context.Context, id string, job T, also ...func(redis.Pipeliner)) error {
+	return q.save(ctx, id, job, func(pipe redis.Pipeliner) {
+		pipe.ZRem(ctx, q.dueKey(), id)
+	}, also)
+}
+
+func (q *Queue[T]) save(ctx context.Context, id string, job T, schedule func(redis.Pipeliner), also []func(redis.Pipeliner)) error {
+	content, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, q.jobKey(id), content, q.ttl)
+		schedule(pipe)
+
+		for _, a := range also {
+			a(pipe)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Scheduled returns the scheduled jobs, soonest first
+func (q *Queue[T]) Scheduled(ctx context.Context) ([]T, error) {
+	ids, err := q.client.ZRange(ctx, q.dueKey(), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Load(ctx, ids)
+}
+
+// Load returns the jobs in the order of the ids, expired jobs are skipped
+func (q *Queue[T]) Load(ctx context.Context, ids []string) ([]T, error) {
+	jobs := make([]T, 0, len(ids))
+
+	for _, id := range ids {
+		job, err := q.Get(ctx, id)
+		if errors.Is(err, ErrorNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Run runs due jobs until the context ends or shutdown starts, jobs are
+// registered with the lifecycle tracker so shutdown waits for them
+func (q *Queue[T]) Run(ctx context.Context, handle Handler[T], lifecycleTracker *lifecycle.Tracker, logger *zerolog.Logger) {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if lifecycleTracker.Draining() {
+			return
+		}
+
+		done := lifecycleTracker.Begin(lifecycle.Operation{Name: q.name, Background: true})
+		q.RunDue(ctx, handle, logger)
+		done()
+	}
+}
+
+// RunDue runs the jobs due now and returns how many
+func (q *Queue[T]) RunDue(ctx context.Context, handle Handler[T], logger *zerolog.Logger) int {
+	ids, err := q.claim(ctx, q.now(), claimLease, claimBatch)
+	if err != nil {
+		logger.Err(err).Str("queue", q.name).Msg("Failed claiming jobs")
+	}
+
+	for _, id := range ids {
+		job, err := q.Get(ctx, id)
+		if errors.Is(err, ErrorNotFound) {
+			_ = q.client.ZRem(ctx, q.dueKey(), id).Err()
+			continue
+		}
+
+		if err != nil {
+			logger.Err(err).Str("queue", q.name).Str("jobId", id).Msg("Failed loading job")
+			continue
+		}
+
+		handle(ctx, job, logger)
+	}
+
+	return len(ids)
+}
+
+// claim returns up to limit jobs due at now, they are not returned to other
+// instances until the lease ends
+func (q *Queue[T]) claim(ctx context.Context, now time.Time, lease time.Duration, limit int64) ([]string, error) {
+	due, err := q.client.ZRangeByScore(ctx, q.dueKey(), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.UnixMilli(), 10),
+		Count: limit,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]string, 0, len(due))
+
+	for _, id := range due {
+		ok, err := claimScript.Run(ctx, q.client, []string{q.dueKey()}, id, now.UnixMilli(), now.Add(lease).UnixMilli()).Int()
+		if err != nil {
+			return claimed, err
+		}
+
+		if ok == 1 {
+			claimed = append(claimed, id)
+		}
+	}
+
+	return claimed, nil
+}
+
+// Backoff doubles the first wait per attempt up to the max wait
+func Backoff(first time.Duration, max time.Duration, attempts int) time.Duration {
+	wait := first
+
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+
+	if wait > max {
+		return max
+	}
+
+	return wait
+}
@@ -0,0 +1,95 @@
+package jobqueue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/jobqueue"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+var log = zerolog.Nop()
+
+type job struct {
+	Id   string `json:"id"`
+	Runs int    `json:"runs"`
+}
+
+func setup(t *testing.T) (*jobqueue.Queue[job], *miniredis.Miniredis) {
+	redisServer := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+
+	return jobqueue.New[job](client, "test", "test:", time.Hour), redisServer
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should run due jobs once per lease", func(t *testing.T) {
+		queue, _ := setup(t)
+
+		assert.Nil(t, queue.Schedule(ctx, "due", job{Id: "due"}, time.Now().Add(-time.Second)))
+		assert.Nil(t, queue.Schedule(ctx, "later", job{Id: "later"}, time.Now().Add(time.Hour)))
+
+		var ran []string
+		handle := func(ctx context.Context, j job, logger *zerolog.Logger) {
+			ran = append(ran, j.Id)
+		}
+
+		assert.Equal(t, 1, queue.RunDue(ctx, handle, &log))
+		assert.Equal(t, 0, queue.RunDue(ctx, handle, &log))
+		assert.Equal(t, []string{"due"}, ran)
+
+		scheduled, err := queue.Scheduled(ctx)
+		assert.Nil(t, err)
+		// the lease keeps the claimed job scheduled
+		assert.Equal(t, []job{{Id: "due"}, {Id: "later"}}, scheduled)
+	})
+
+	t.Run("should keep finished jobs without running them", func(t *testing.T) {
+		queue, redisServer := setup(t)
+
+		assert.Nil(t, queue.Finish(ctx, "done", job{Id: "done", Runs: 1}, func(pipe redis.Pipeliner) {
+			pipe.LPush(ctx, queue.Key("finished"), "done")
+		}))
+
+		saved, err := queue.Get(ctx, "done")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, saved.Runs)
+
+		assert.Equal(t, 0, queue.RunDue(ctx, func(context.Context, job, *zerolog.Logger) {}, &log))
+
+		finished, _ := redisServer.List("test:finished")
+		assert.Equal(t, []string{"done"}, finished)
+	})
+
+	t.Run("should forget expired jobs", func(t *testing.T) {
+		queue, redisServer := setup(t)
+
+		assert.Nil(t, queue.Schedule(ctx, "expired", job{Id: "expired"}, time.Now()))
+		redisServer.FastForward(2 * time.Hour)
+
+		_, err := queue.Get(ctx, "expired")
+		assert.ErrorIs(t, err, jobqueue.ErrorNotFound)
+
+		assert.Equal(t, 1, queue.RunDue(ctx, func(context.Context, job, *zerolog.Logger) {
+			t.Error("expired jobs must not run")
+		}, &log))
+
+		scheduled, _ := queue.Scheduled(ctx)
+		assert.Empty(t, scheduled)
+		assert.False(t, redisServer.Exists("test:due"))
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Run("should double the wait up to the max", func(t *testing.T) {
+		assert.Equal(t, time.Second, jobqueue.Backoff(time.Second, time.Minute, 1))
+		assert.Equal(t, 4*time.Second, jobqueue.Backoff(time.Second, time.Minute, 3))
+		assert.Equal(t, time.Minute, jobqueue.Backoff(time.Second, time.Minute, 20))
+	})
+}
@@ -0,0 +1,142 @@
+package lifecycle
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type contextKey string
+
+const trackerKey contextKey = "lifecycleTracker"
+
+// Operation is a unit of in-flight work the process should not abandon silently
+type Operation struct {
+	Name          string
+	Platform      string
+	CorrelationId string
+	Started       time.Time
+	Background    bool
+}
+
+// Tracker keeps in-flight operations so shutdown can wait for them
+// and report the ones that were cut off. Operations may begin while
+// waiting, idle is closed when the last one is done.
+type Tracker struct {
+	operations map[uint64]Operation
+	next       uint64
+	idle       chan struct{}
+	draining   atomic.Bool
+	sync.Mutex
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		operations: make(map[uint64]Operation),
+	}
+}
+
+// Begin registers an operation, the returned function must be called when it is done
+func (t *Tracker) Begin(operation Operation) func() {
+	if operation.Started.IsZero() {
+		operation.Started = time.Now()
+	}
+
+	t.Lock()
+	t.next++
+	id := t.next
+	if len(t.operations) == 0 {
+		t.idle = make(chan struct{})
+	}
+	t.operations[id] = operation
+	t.Unlock()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			t.Lock()
+			delete(t.operations, id)
+			if len(t.operations) == 0 {
+				close(t.idle)
+			}
+			t.Unlock()
+		})
+	}
+}
+
+// Drain marks the process as shutting down, new work is still accepted
+// until the listener is closed
+func (t *Tracker) Drain() {
+	t.draining.Store(true)
+}
+
+func (t *Tracker) Draining() bool {
+	return t.draining.Load()
+}
+
+// InFlight returns operations that are still running, oldest first
+func (t *Tracker) InFlight() []Operation {
+	t.Lock()
+	defer t.Unlock()
+
+	operations := make([]Operation, 0, len(t.operations))
+	for _, operation := range t.operations {
+		operations = append(operations, operation)
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].Started.Before(operations[j].Started)
+	})
+
+	return operations
+}
+
+// Wait blocks until all operations are done or the context ends,
+// operations still running at that point are returned
+func (t *Tracker) Wait(ctx context.Context) []Operation {
+	t.Lock()
+	if len(t.operations) == 0 {
+		t.Unlock()
+		return nil
+	}
+	idle := t.idle
+	t.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return t.InFlight()
+	}
+}
+
+func WithTracker(ctx context.Context, tracker *Tracker) context.Context {
+	return context.WithValue(ctx, trackerKey, tracker)
+}
+
+func FromContext(ctx context.Context) (*Tracker, bool) {
+	tracker, ok := ctx.Value(trackerKey).(*Tracker)
+	return tracker, ok
+}
+
+// Go runs fn in a goroutine tracked by the tracker of the context, if any
+func Go(ctx context.Context, name string, fn func()) {
+	tracker, ok := FromContext(ctx)
+	if !ok {
+		go fn()
+		return
+	}
+
+	done := tracker.Begin(Operation{
+		Name:       name,
+		Background: true,
+	})
+
+	go func() {
+		defer done()
+		fn()
+	}()
+}
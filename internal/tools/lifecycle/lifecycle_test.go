@@ -0,0 +1,115 @@
+package lifecycle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker(t *testing.T) {
+	t.Run("should wait for operations to finish", func(t *testing.T) {
+		tracker := lifecycle.NewTracker()
+
+		done := tracker.Begin(lifecycle.Operation{Name: "booking"})
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			done()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		assert.Empty(t, tracker.Wait(ctx))
+	})
+
+	t.Run("should wait for operations begun while waiting", func(t *testing.T) {
+		tracker := lifecycle.NewTracker()
+
+		first := tracker.Begin(lifecycle.Operation{Name: "booking"})
+
+		waited := make(chan []lifecycle.Operation)
+		go func() {
+			waited <- tracker.Wait(context.Background())
+		}()
+
+		second := tracker.Begin(lifecycle.Operation{Name: "cancel"})
+		first()
+
+		select {
+		case <-waited:
+			t.Fatal("waiting ended with an operation in flight")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		second()
+		assert.Empty(t, <-waited)
+
+		// the tracker is reusable once idle
+		done := tracker.Begin(lifecycle.Operation{Name: "modify"})
+		done()
+		assert.Empty(t, tracker.Wait(context.Background()))
+	})
+
+	t.Run("should report operations cut off by the deadline", func(t *testing.T) {
+		tracker := lifecycle.NewTracker()
+
+		done := tracker.Begin(lifecycle.Operation{Name: "cancel", Platform: "hertz"})
+		defer done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		cutOff := tracker.Wait(ctx)
+
+		assert.Len(t, cutOff, 1)
+		assert.Equal(t, "cancel", cutOff[0].Name)
+		assert.Equal(t, "hertz", cutOff[0].Platform)
+	})
+
+	t.Run("should tolerate repeated done calls", func(t *testing.T) {
+		tracker := lifecycle.NewTracker()
+
+		done := tracker.Begin(lifecycle.Operation{Name: "modify"})
+		done()
+		done()
+
+		assert.Empty(t, tracker.InFlight())
+	})
+
+	t.Run("should track goroutines started with the context tracker", func(t *testing.T) {
+		tracker := lifecycle.NewTracker()
+		ctx := lifecycle.WithTracker(context.Background(), tracker)
+
+		release := make(chan struct{})
+		lifecycle.Go(ctx, "hertz:extras", func() {
+			<-release
+		})
+
+		inFlight := tracker.InFlight()
+		assert.Len(t, inFlight, 1)
+		assert.True(t, inFlight[0].Background)
+
+		close(release)
+		assert.Empty(t, tracker.Wait(context.Background()))
+	})
+
+	t.Run("should run goroutines without a tracker", func(t *testing.T) {
+		ran := make(chan struct{})
+		lifecycle.Go(context.Background(), "untracked", func() {
+			close(ran)
+		})
+
+		<-ran
+	})
+
+	t.Run("should report draining", func(t *testing.T) {
+		tracker := lifecycle.NewTracker()
+		assert.False(t, tracker.Draining())
+
+		tracker.Drain()
+		assert.True(t, tracker.Draining())
+	})
+}
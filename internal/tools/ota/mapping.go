@@ -1,4 +1,4 @@
-package mapping
+package ota
 
 import "bitbucket.org/crgw/supplier-hub/internal/schema"
 
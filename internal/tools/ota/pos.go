@@ -0,0 +1,22 @@
+package ota
+
+type POS struct {
+	Source []Source
+}
+
+type Source struct {
+	ISOCountry    string      `xml:"ISOCountry,attr,omitempty"`
+	AgentDutyCode string      `xml:"AgentDutyCode,attr,omitempty"`
+	RequestorID   RequestorID `xml:"RequestorID"`
+}
+
+type RequestorID struct {
+	Type        string       `xml:"Type,attr"`
+	ID          string       `xml:"ID,attr"`
+	CompanyName *CompanyName `xml:"CompanyName,omitempty"`
+}
+
+type CompanyName struct {
+	Code        string `xml:"Code,attr"`
+	CodeContext string `xml:"CodeContext,attr"`
+}
@@ -0,0 +1,85 @@
+package ota
+
+import "bitbucket.org/crgw/supplier-hub/internal/schema"
+
+// paymentRulePrePay is the payment rule of vehicles paid in full when booking
+const paymentRulePrePay int = 2
+
+// Pricing holds the supplier settings deciding which fees, taxes and
+// coverages of a vehicle are included in its price and which are paid locally
+type Pricing struct {
+	// FeePayableLocally lists the countries paying a fee locally by its
+	// upper cased description
+	FeePayableLocally map[string][]string
+
+	// FpPayFeesLocally pays fees and taxes of full prepaid vehicles locally
+	FpPayFeesLocally bool
+
+	// FpPaynowVehiclePriceWithTax adds the tax to the price of full prepaid
+	// vehicles without payment rules
+	FpPaynowVehiclePriceWithTax bool
+
+	// PayNowCoverages are paid when booking instead of locally
+	PayNowCoverages []string
+
+	// AddTaxToCoverages are taxed regardless of TaxExclCoverageCountries and
+	// of being required
+	AddTaxToCoverages []string
+
+	// TaxAllCoverages taxes every coverage which is not tax inclusive
+	TaxAllCoverages bool
+
+	// TaxExclCoverageCountries are the pick up countries of untaxed coverages
+	TaxExclCoverageCountries []string
+
+	// IncludeCoveragesInRate adds mandatory coverages to the vehicle price
+	IncludeCoveragesInRate bool
+}
+
+func (p Pricing) taxesCoverage(coverageType string) bool {
+	return p.TaxAllCoverages || contains(p.AddTaxToCoverages, coverageType)
+}
+
+type feeIncludeType string
+
+const (
+	feesIncluded              feeIncludeType = "feesIncluded"
+	feesNotIncludedPayNow     feeIncludeType = "feesNotIncludedPayNow"
+	feesNotIncludedPayLocally feeIncludeType = "feesNotIncludedPayLocally"
+	feesUnknown               feeIncludeType = "feesUnknown"
+)
+
+type coverageIncludedType string
+
+const (
+	coverageIncluded          coverageIncludedType = "feesIncluded"
+	coverageNotIncluded       coverageIncludedType = "coverageNotIncluded"
+	coverageNotIncludedPayNow coverageIncludedType = "feesNotIncludedPayNow"
+	coverageUnknown           coverageIncludedType = "coverageUnknown"
+)
+
+func mapContains(m map[string][]string, key string, e any) bool {
+	value, ok := m[key]
+	if !ok {
+		return false
+	}
+
+	return contains(value, e)
+}
+
+func contains(s []string, e any) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+func isPartialPayment(params schema.RatesRequestParams) bool {
+	return params.Contract.PaymentType == int(schema.PaymentTypePartialPrepay)
+}
+
+func fullWithoutPaymentRules(params schema.RatesRequestParams, paymentRules []PaymentRule) bool {
+	return params.Contract.PaymentType == int(schema.PaymentTypeFullPrepay) && !(len(paymentRules) > 0)
+}
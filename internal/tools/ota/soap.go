@@ -0,0 +1,87 @@
+package ota
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+var ErrorSoapBody = errors.New("soap envelope without body content")
+
+type SoapEnvelope struct {
+	XMLName      xml.Name `xml:"SOAP-ENV:Envelope"`
+	XmlnsSoapEnv string   `xml:"xmlns:SOAP-ENV,attr"`
+	XmlnsXsd     string   `xml:"xmlns:xsd,attr,omitempty"`
+	XmlnsXsi     string   `xml:"xmlns:xsi,attr,omitempty"`
+
+	// Header is supplier specific, mostly credentials
+	Header any      `xml:"SOAP-ENV:Header,omitempty"`
+	Body   SoapBody `xml:"SOAP-ENV:Body"`
+}
+
+type SoapBody struct {
+	VehAvailRateRQ *VehAvailRateRQ `xml:"OTA_VehAvailRateRQ,omitempty"`
+	VehResRQ       *VehResRQ       `xml:"OTA_VehResRQ,omitempty"`
+	VehModifyRQ    *VehModifyRQ    `xml:"OTA_VehModifyRQ,omitempty"`
+	VehRetResRQ    *VehRetResRQ    `xml:"OTA_VehRetResRQ,omitempty"`
+	VehCancelRQ    *VehCancelRQ    `xml:"OTA_VehCancelRQ,omitempty"`
+}
+
+// Message returns the request message of the body, to be sent without envelope
+func (b SoapBody) Message() any {
+	switch {
+	case b.VehAvailRateRQ != nil:
+		return b.VehAvailRateRQ
+	case b.VehResRQ != nil:
+		return b.VehResRQ
+	case b.VehModifyRQ != nil:
+		return b.VehModifyRQ
+	case b.VehRetResRQ != nil:
+		return b.VehRetResRQ
+	case b.VehCancelRQ != nil:
+		return b.VehCancelRQ
+	}
+
+	return nil
+}
+
+// SoapHeader is a header configured as raw xml
+type SoapHeader struct {
+	Content string `xml:",innerxml"`
+}
+
+// Decode binds an OTA message to response, the message is either the root of
+// the body or the content of a SOAP body
+func Decode(body []byte, response any) error {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	inEnvelope := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return ErrorSoapBody
+		}
+
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case !inEnvelope && start.Name.Local == "Envelope":
+			inEnvelope = true
+		case inEnvelope && start.Name.Local == "Header":
+			if err := decoder.Skip(); err != nil {
+				return err
+			}
+		case inEnvelope && start.Name.Local == "Body":
+		default:
+			return decoder.DecodeElement(response, &start)
+		}
+	}
+}
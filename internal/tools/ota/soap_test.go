@@ -0,0 +1,68 @@
+package ota_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/ota"
+	"github.com/stretchr/testify/assert"
+)
+
+const cancelled = `<OTA_VehCancelRS Version="1.008"><VehCancelRSCore CancelStatus="Cancelled"></VehCancelRSCore></OTA_VehCancelRS>`
+
+func TestDecode(t *testing.T) {
+	t.Run("should decode plain messages", func(t *testing.T) {
+		var response ota.VehCancelRS
+
+		assert.Nil(t, ota.Decode([]byte(`<?xml version="1.0"?>`+cancelled), &response))
+		assert.Equal(t, ota.CoreCancelStatusCancelled, response.VehCancelRSCore.CancelStatus)
+	})
+
+	t.Run("should decode the body of soap envelopes", func(t *testing.T) {
+		var response ota.VehCancelRS
+
+		body := `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+			`<soap:Header><OTA_VehCancelRS><VehCancelRSCore CancelStatus="Pending"></VehCancelRSCore></OTA_VehCancelRS></soap:Header>` +
+			`<soap:Body>` + cancelled + `</soap:Body>` +
+			`</soap:Envelope>`
+
+		assert.Nil(t, ota.Decode([]byte(body), &response))
+		assert.Equal(t, ota.CoreCancelStatusCancelled, response.VehCancelRSCore.CancelStatus)
+	})
+
+	t.Run("should fail on envelopes without body content", func(t *testing.T) {
+		var response ota.VehCancelRS
+
+		body := `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body></soap:Body></soap:Envelope>`
+
+		assert.Equal(t, ota.ErrorSoapBody, ota.Decode([]byte(body), &response))
+	})
+
+	t.Run("should fail on other messages", func(t *testing.T) {
+		var response ota.VehCancelRS
+
+		assert.NotNil(t, ota.Decode([]byte(`<OTA_VehResRS></OTA_VehResRS>`), &response))
+	})
+}
+
+func TestSoapEnvelope(t *testing.T) {
+	t.Run("should wrap the message with the raw header", func(t *testing.T) {
+		body, _ := xml.Marshal(ota.SoapEnvelope{
+			XmlnsSoapEnv: "http://schemas.xmlsoap.org/soap/envelope/",
+			Header:       ota.SoapHeader{Content: "<Token>secret</Token>"},
+			Body: ota.SoapBody{
+				VehRetResRQ: &ota.VehRetResRQ{Version: "1.008"},
+			},
+		})
+
+		assert.Contains(t, string(body), `<SOAP-ENV:Header><Token>secret</Token></SOAP-ENV:Header>`)
+		assert.Contains(t, string(body), `<SOAP-ENV:Body><OTA_VehRetResRQ`)
+	})
+
+	t.Run("should return the message of the body", func(t *testing.T) {
+		message := &ota.VehCancelRQ{Version: "1.008"}
+
+		assert.Equal(t, message, ota.SoapBody{VehCancelRQ: message}.Message())
+		assert.Nil(t, ota.SoapBody{}.Message())
+	})
+}
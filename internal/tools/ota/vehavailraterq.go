@@ -2,23 +2,14 @@ package ota
 
 import "encoding/xml"
 
-type CompanyName struct {
-	Code        string `xml:"Code,attr"`
-	CodeContext string `xml:"CodeContext,attr"`
-}
-
-type RequestorID struct {
-	Type        string       `xml:"Type,attr"`
-	ID          string       `xml:"ID,attr"`
-	CompanyName *CompanyName `xml:"CompanyName,omitempty"`
-}
-
 type VehAvailRateRQ struct {
 	XMLName           xml.Name       `xml:"OTA_VehAvailRateRQ"`
 	Xmlns             string         `xml:"xmlns,attr"`
 	XmlnsXsi          string         `xml:"xmlns:xsi,attr"`
-	XsiSchemaLocation string         `xml:"xsi:schemaLocation,attr"`
+	XsiSchemaLocation string         `xml:"xsi:schemaLocation,attr,omitempty"`
 	Version           string         `xml:"Version,attr"`
+	Target            string         `xml:"Target,attr,omitempty"`
+	SequenceNmbr      string         `xml:"SequenceNmbr,attr,omitempty"`
 	MaxResponses      int            `xml:"MaxResponses,attr"`
 	POS               POS            `xml:"POS"`
 	VehAvailRQCore    VehAvailRQCore `xml:"VehAvailRQCore"`
@@ -27,12 +18,13 @@ type VehAvailRateRQ struct {
 
 type Location struct {
 	LocationCode string `xml:"LocationCode,attr"`
+	CodeContext  string `xml:"CodeContext,attr,omitempty"`
 }
 
 type VehRentalCore struct {
 	PickUpDateTime string    `xml:"PickUpDateTime,attr"`
 	ReturnDateTime string    `xml:"ReturnDateTime,attr"`
-	PickUpLocation Location  `xml:"PickUpLocation"`
+	PickUpLocation *Location `xml:"PickUpLocation"`
 	ReturnLocation *Location `xml:"ReturnLocation"`
 }
 
@@ -55,8 +47,36 @@ type SpecialEquipPrefs struct {
 type VehAvailRQCore struct {
 	Status            string             `xml:"Status,attr"`
 	VehRentalCore     VehRentalCore      `xml:"VehRentalCore"`
+	VendorPrefs       *VendorPrefs       `xml:"VendorPrefs,omitempty"`
 	RateQualifier     RateQualifier      `xml:"RateQualifier,omitempty"`
 	SpecialEquipPrefs *SpecialEquipPrefs `xml:"SpecialEquipPrefs,omitempty"`
+	VehPrefs          *VehPrefs          `xml:"VehPrefs,omitempty"`
+}
+
+type VendorPrefs struct {
+	VendorPref []VendorPref `xml:"VendorPref"`
+}
+
+type VendorPref struct {
+	Code string `xml:"Code,attr"`
+}
+
+type VehPrefs struct {
+	VehPref []VehPref `xml:"VehPref"`
+}
+
+// VehPref selects a vehicle by its code when booking and by its
+// characteristics when searching
+type VehPref struct {
+	Code             string    `xml:"Code,attr,omitempty"`
+	CodeContext      string    `xml:"CodeContext,attr,omitempty"`
+	AirConditionInd  string    `xml:"AirConditionInd,attr,omitempty"`
+	TransmissionType string    `xml:"TransmissionType,attr,omitempty"`
+	FuelType         string    `xml:"FuelType,attr,omitempty"`
+	DriveType        string    `xml:"DriveType,attr,omitempty"`
+	UpSellInd        string    `xml:"UpSellInd,attr,omitempty"`
+	VehType          *VehType  `xml:"VehType,omitempty"`
+	VehClass         *VehClass `xml:"VehClass,omitempty"`
 }
 
 type TourInfo struct {
@@ -5,9 +5,7 @@ import (
 	"strconv"
 	"strings"
 
-	"bitbucket.org/crgw/supplier-hub/internal/platform/implementations/profitmaxdht/mapping"
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
-	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
 )
 
 type VehAvailRateRS struct {
@@ -86,23 +84,25 @@ type Fees struct {
 func feeIncludedType(
 	fee Fee,
 	params schema.RatesRequestParams,
-	configuration schema.ProfitMaxDHTConfiguration,
+	pricing Pricing,
 	paymentRules []PaymentRule,
 ) feeIncludeType {
-	feePayableLocally := make(map[string][]string)
-
 	feeNameUpper := strings.ToUpper(fee.Description)
 
 	switch params.Contract.PaymentType {
 	case int(schema.PaymentTypeFullPrepay):
-		if mapContains(feePayableLocally, feeNameUpper, params.PickUp.Country) {
+		if mapContains(pricing.FeePayableLocally, feeNameUpper, params.PickUp.Country) {
 			return feesNotIncludedPayLocally
 		}
 
-		if len(paymentRules) > 0 && (paymentRules)[0].RuleType == hertzPaymentRulePrePay {
+		if len(paymentRules) > 0 && (paymentRules)[0].RuleType == paymentRulePrePay {
 			return feesIncluded
 		}
 
+		if pricing.FpPayFeesLocally {
+			return feesNotIncludedPayLocally
+		}
+
 		return feesNotIncludedPayNow
 	case int(schema.PaymentTypePartialPrepay):
 		if fee.IncludedInRate {
@@ -118,7 +118,7 @@ func feeIncludedType(
 
 func (f *Fees) Fees(
 	params schema.RatesRequestParams,
-	configuration schema.ProfitMaxDHTConfiguration,
+	pricing Pricing,
 	paymentRules []PaymentRule,
 ) []schema.ExtraOrFee {
 	var fees = make([]schema.ExtraOrFee, len(f.Fee))
@@ -128,7 +128,7 @@ func (f *Fees) Fees(
 		includedInRate := false
 		payLocal := false
 
-		switch feeIncludedType(fee, params, configuration, paymentRules) {
+		switch feeIncludedType(fee, params, pricing, paymentRules) {
 		case feesIncluded:
 			includedInRate = true
 			payLocal = false
@@ -192,7 +192,7 @@ func (c *TotalCharge) Price(params schema.RatesRequestParams, paymentRules []Pay
 	if params.Contract.PaymentType == int(schema.PaymentTypeFullPrepay) && len(paymentRules) > 0 {
 		rule := (paymentsRules)[0]
 
-		if rule.RuleType == hertzPaymentRulePrePay {
+		if rule.RuleType == paymentRulePrePay {
 			if rule.CurrencyCode == "" {
 				return schema.PriceAmount{}, "Cant parse price from PaymentRule"
 			}
@@ -267,13 +267,11 @@ func (v *VehicleCharges) Charges() []schema.ExtraOrFee {
 
 func (v *VehicleCharges) TaxCharge(
 	params schema.RatesRequestParams,
-	configuration schema.ProfitMaxDHTConfiguration,
+	pricing Pricing,
 	paymentRules []PaymentRule,
 ) (float64, *schema.ExtraOrFee, bool) {
 	taxMultiplier := 1.0
 
-	fpPaynowVehiclePriceWithTax := converting.Unwrap(configuration.FpPaynowVehiclePriceWithTax)
-
 	for _, charge := range v.VehicleCharge {
 		if charge.Purpose == int(schema.OtaVcpVehicleRentalFee) && len(charge.TaxAmounts.TaxAmount) > 0 {
 			for _, tax := range charge.TaxAmounts.TaxAmount {
@@ -281,16 +279,16 @@ func (v *VehicleCharges) TaxCharge(
 					taxMultiplier = 1 + (tax.Percentage / 100)
 					taxAmountForVehicle := tax.Total
 
-					if fpPaynowVehiclePriceWithTax && fullWithoutPaymentRules(params, paymentRules) {
+					if pricing.FpPaynowVehiclePriceWithTax && fullWithoutPaymentRules(params, paymentRules) {
 						return taxMultiplier, nil, true
 					}
 
 					payLocal := true
 					if params.Contract.PaymentType == int(schema.PaymentTypeFullPrepay) {
-						payLocal = converting.Unwrap(configuration.FpPayFeesLocally)
+						payLocal = pricing.FpPayFeesLocally
 					}
 
-					if isPartialPayment(params) || (!fpPaynowVehiclePriceWithTax && fullWithoutPaymentRules(params, paymentRules)) {
+					if isPartialPayment(params) || (!pricing.FpPaynowVehiclePriceWithTax && fullWithoutPaymentRules(params, paymentRules)) {
 						return taxMultiplier, &schema.ExtraOrFee{
 							Code:           strconv.Itoa(int(schema.OtaVcpTax)),
 							Name:           "Tax",
@@ -353,13 +351,13 @@ func (rateDistance *RateDistance) Mileage() schema.Mileage {
 		Unlimited: &rateDistance.Unlimited,
 	}
 
-	mileage.DistanceUnit = mapping.DistanceUnit(rateDistance.DistUnitName)
+	mileage.DistanceUnit = DistanceUnit(rateDistance.DistUnitName)
 	if mileage.DistanceUnit != nil {
 		unit := schema.Km
 		mileage.DistanceUnit = &unit
 	}
 
-	mileage.PeriodUnit = mapping.PeriodUnit(rateDistance.VehiclePeriodUnitName)
+	mileage.PeriodUnit = PeriodUnit(rateDistance.VehiclePeriodUnitName)
 	if mileage.PeriodUnit != nil {
 		unit := schema.Km
 		mileage.DistanceUnit = &unit
@@ -406,7 +404,7 @@ func (p *PricedCoverages) VehicleCoverages(
 	taxMultiplier float64,
 	paymentRules []PaymentRule,
 	params schema.RatesRequestParams,
-	configuration schema.ProfitMaxDHTConfiguration,
+	pricing Pricing,
 ) ([]schema.ExtraOrFee, float64) {
 	priceToBeAddedToVehiclePrice := 0.0
 
@@ -418,7 +416,7 @@ func (p *PricedCoverages) VehicleCoverages(
 
 		mandatory := coverage.Charge.IncludedInRate || coverage.Required || coverage.Coverage.Required
 
-		switch whichCoverageIncludedType(coverage, paymentRules, params, configuration) {
+		switch whichCoverageIncludedType(coverage, paymentRules, params, pricing) {
 		case coverageIncluded:
 			includedInRate = true
 			payLocal = false
@@ -463,13 +461,13 @@ func (p *PricedCoverages) VehicleCoverages(
 			continue
 		}
 
-		if !coverage.Charge.TaxInclusive {
+		if pricing.taxesCoverage(coverage.Coverage.CoverageType) && !coverage.Charge.TaxInclusive {
 			price.Amount = schema.RoundedFloat(taxMultiplier * float64(price.Amount))
-		} else if !coverage.Charge.TaxInclusive && !coverage.Required {
+		} else if !coverage.Charge.TaxInclusive && !coverage.Required && !contains(pricing.TaxExclCoverageCountries, params.PickUp.Country) {
 			price.Amount = schema.RoundedFloat(taxMultiplier * float64(price.Amount))
 		}
 
-		if mandatory {
+		if mandatory && pricing.IncludeCoveragesInRate {
 			priceToBeAddedToVehiclePrice = priceToBeAddedToVehiclePrice + float64(price.Amount)
 			includedInRate = true
 			mandatory = true
@@ -493,11 +491,9 @@ func whichCoverageIncludedType(
 	coverage PricedCoverage,
 	paymentRules []PaymentRule,
 	params schema.RatesRequestParams,
-	configuration schema.ProfitMaxDHTConfiguration,
+	pricing Pricing,
 ) coverageIncludedType {
-	payNowCoverages := []string{}
-
-	if contains(payNowCoverages, coverage.Coverage.CoverageType) {
+	if contains(pricing.PayNowCoverages, coverage.Coverage.CoverageType) {
 		return coverageNotIncludedPayNow
 	}
 
@@ -511,7 +507,7 @@ func whichCoverageIncludedType(
 	case int(schema.PaymentTypeFullPrepay):
 		if len(paymentRules) > 0 {
 			rule := (paymentRules)[0]
-			if rule.RuleType == hertzPaymentRulePrePay && required {
+			if rule.RuleType == paymentRulePrePay && required {
 				return coverageIncluded
 			}
 		}
@@ -6,9 +6,10 @@ type VehCancelRQ struct {
 	XMLName           xml.Name        `xml:"OTA_VehCancelRQ"`
 	Xmlns             string          `xml:"xmlns,attr"`
 	XmlnsXsi          string          `xml:"xmlns:xsi,attr"`
-	XmlnsXsd          string          `xml:"xmlns:xsd,attr"`
-	XsiSchemaLocation string          `xml:"xsi:schemaLocation,attr"`
+	XmlnsXsd          string          `xml:"xmlns:xsd,attr,omitempty"`
+	XsiSchemaLocation string          `xml:"xsi:schemaLocation,attr,omitempty"`
 	Version           string          `xml:"Version,attr"`
+	Target            string          `xml:"Target,attr,omitempty"`
 	POS               POS             `xml:"POS"`
 	VehCancelRQCore   VehCancelRQCore `xml:"VehCancelRQCore"`
 }
@@ -6,8 +6,9 @@ type VehModifyRQ struct {
 	XMLName           xml.Name        `xml:"OTA_VehModifyRQ"`
 	Xmlns             string          `xml:"xmlns,attr"`
 	XmlnsXsi          string          `xml:"xmlns:xsi,attr"`
-	XsiSchemaLocation string          `xml:"xsi:schemaLocation,attr"`
+	XsiSchemaLocation string          `xml:"xsi:schemaLocation,attr,omitempty"`
 	Version           string          `xml:"Version,attr"`
+	Target            string          `xml:"Target,attr,omitempty"`
 	POS               POS             `xml:"POS"`
 	VehModifyRQCore   VehModifyRQCore `xml:"VehModifyRQCore"`
 	VehModifyRQInfo   VehModifyRQInfo `xml:"VehModifyRQInfo"`
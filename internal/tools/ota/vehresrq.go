@@ -6,9 +6,10 @@ type VehResRQ struct {
 	XMLName           xml.Name     `xml:"OTA_VehResRQ"`
 	Xmlns             string       `xml:"xmlns,attr"`
 	XmlnsXsi          string       `xml:"xmlns:xsi,attr"`
-	XsiSchemaLocation string       `xml:"xsi:schemaLocation,attr"`
+	XsiSchemaLocation string       `xml:"xsi:schemaLocation,attr,omitempty"`
 	Version           string       `xml:"Version,attr"`
-	MaxResponses      int          `xml:"MaxResponses,attr"`
+	Target            string       `xml:"Target,attr,omitempty"`
+	MaxResponses      int          `xml:"MaxResponses,attr,omitempty"`
 	POS               POS          `xml:"POS"`
 	VehResRQCore      VehResRQCore `xml:"VehResRQCore"`
 	VehResRQInfo      VehResRQInfo `xml:"VehResRQInfo"`
@@ -22,11 +23,6 @@ type VehResRQCore struct {
 	SpecialEquipPrefs *SpecialEquipPrefs `xml:"SpecialEquipPrefs,omitempty"`
 }
 
-type VehPref struct {
-	Code        string `xml:"Code,attr"`
-	CodeContext string `xml:"CodeContext,attr"`
-}
-
 type BookingCustomer struct {
 	Primary BookingPrimary `xml:"Primary"`
 }
@@ -39,8 +35,8 @@ type BookingPrimary struct {
 }
 
 type PersonName struct {
-	GivenName string `xml:"GivenName"`
-	Surname   string `xml:"Surname"`
+	GivenName string `xml:"GivenName,omitempty"`
+	Surname   string `xml:"Surname,omitempty"`
 }
 
 type Telephone struct {
@@ -13,9 +13,9 @@ type VehResRSCore struct {
 }
 
 type VehReservation struct {
-	Customer       Customer       `xml:"Customer"`
-	VehSegmentCore VehSegmentCore `xml:"VehSegmentCore"`
-	VehSegmentInfo VehSegmentInfo `xml:"VehSegmentInfo"`
+	Customer       BookingCustomer `xml:"Customer"`
+	VehSegmentCore VehSegmentCore  `xml:"VehSegmentCore"`
+	VehSegmentInfo VehSegmentInfo  `xml:"VehSegmentInfo"`
 }
 
 type VehSegmentInfo struct {
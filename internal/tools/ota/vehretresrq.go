@@ -6,9 +6,9 @@ type VehRetResRQ struct {
 	XMLName           xml.Name        `xml:"OTA_VehRetResRQ"`
 	Xmlns             string          `xml:"xmlns,attr"`
 	XmlnsXsi          string          `xml:"xmlns:xsi,attr"`
-	XsiSchemaLocation string          `xml:"xsi:schemaLocation,attr"`
+	XsiSchemaLocation string          `xml:"xsi:schemaLocation,attr,omitempty"`
 	Version           string          `xml:"Version,attr"`
-	Target            string          `xml:"Target,attr"`
+	Target            string          `xml:"Target,attr,omitempty"`
 	POS               POS             `xml:"POS"`
 	VehRetResRQCore   VehRetResRQCore `xml:"VehRetResRQCore"`
 }
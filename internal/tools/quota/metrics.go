@@ -0,0 +1,73 @@
+package quota
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func labels(k key) string {
+	return fmt.Sprintf(`client="%s",scope="%s"`, labelEscaper.Replace(k.client), labelEscaper.Replace(k.scope))
+}
+
+// WriteMetrics writes the limiter state in the Prometheus text format
+func (l *Limiter) WriteMetrics(w io.Writer) error {
+	l.Lock()
+	defer l.Unlock()
+
+	keys := make([]key, 0, len(l.states))
+	for k := range l.states {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].client != keys[j].client {
+			return keys[i].client < keys[j].client
+		}
+
+		return keys[i].scope < keys[j].scope
+	})
+
+	var b strings.Builder
+
+	b.WriteString("# HELP supplier_hub_quota_capacity Concurrent platform requests of all clients.\n")
+	b.WriteString("# TYPE supplier_hub_quota_capacity gauge\n")
+	fmt.Fprintf(&b, "supplier_hub_quota_capacity{kind=\"total\"} %d\n", l.options.Capacity)
+	fmt.Fprintf(&b, "supplier_hub_quota_capacity{kind=\"reserved\"} %d\n", l.options.Reserved)
+	fmt.Fprintf(&b, "supplier_hub_quota_capacity{kind=\"in_flight\"} %d\n", l.inFlight)
+
+	b.WriteString("# HELP supplier_hub_quota_in_flight Concurrent requests per client and scope.\n")
+	b.WriteString("# TYPE supplier_hub_quota_in_flight gauge\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "supplier_hub_quota_in_flight{%s} %d\n", labels(k), l.states[k].inFlight)
+	}
+
+	b.WriteString("# HELP supplier_hub_quota_tokens Rate tokens left per client and scope.\n")
+	b.WriteString("# TYPE supplier_hub_quota_tokens gauge\n")
+	for _, k := range keys {
+		s := l.states[k]
+		if s.limit.Rate > 0 {
+			fmt.Fprintf(&b, "supplier_hub_quota_tokens{%s} %g\n", labels(k), s.tokens)
+		}
+	}
+
+	b.WriteString("# HELP supplier_hub_quota_requests_total Requests admitted and rejected per client and scope.\n")
+	b.WriteString("# TYPE supplier_hub_quota_requests_total counter\n")
+	for _, k := range keys {
+		s := l.states[k]
+
+		fmt.Fprintf(&b, "supplier_hub_quota_requests_total{%s,result=\"admitted\"} %d\n", labels(k), s.admitted)
+
+		for _, reason := range []string{ReasonConcurrency, ReasonCapacity, ReasonRate} {
+			if s.rejected[reason] > 0 {
+				fmt.Fprintf(&b, "supplier_hub_quota_requests_total{%s,result=\"rejected\",reason=\"%s\"} %d\n", labels(k), reason, s.rejected[reason])
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,256 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+)
+
+const (
+	ReasonRate        = "rate"
+	ReasonConcurrency = "concurrency"
+	ReasonCapacity    = "capacity"
+
+	// concurrencyRetryAfter is suggested when slots are taken, requests
+	// usually finish within a few seconds
+	concurrencyRetryAfter = time.Second
+
+	// ContextKey holds the Acquire of the calling client in request contexts
+	ContextKey = "quota"
+)
+
+var ErrorRejected = errors.New("quota exceeded")
+
+// defaultReservedOperations may use the reserved part of the capacity,
+// they carry money and must not be starved by searches
+var defaultReservedOperations = []string{"booking", "modify", "cancel"}
+
+// Limit is a configured limit, see config.QuotaLimit
+type Limit config.QuotaLimit
+
+// Rejection tells the caller why and for how long it should back off
+type Rejection struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("%s: %s", ErrorRejected, r.Reason)
+}
+
+func (r *Rejection) Unwrap() error {
+	return ErrorRejected
+}
+
+type key struct {
+	client string
+	scope  string
+}
+
+// state is kept per client and scope
+type state struct {
+	limit    Limit
+	inFlight int
+	tokens   float64
+	updated  time.Time
+	admitted uint64
+	rejected map[string]uint64
+}
+
+type Limiter struct {
+	options  config.Quota
+	limits   []Limit
+	reserved map[string]bool
+	states   map[key]*state
+	inFlight int
+	now      func() time.Time
+	sync.Mutex
+}
+
+func matches(pattern string, value string) bool {
+	return pattern == "" || pattern == "*" || pattern == value
+}
+
+func (l Limit) matches(client string, scope string) bool {
+	if !matches(l.Client, client) {
+		return false
+	}
+
+	if l.Scope == "" {
+		return true
+	}
+
+	platformPattern, operationPattern, _ := strings.Cut(l.Scope, ":")
+	platform, operation, _ := strings.Cut(scope, ":")
+
+	return matches(platformPattern, platform) && matches(operationPattern, operation)
+}
+
+func (l Limit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+
+	return math.Max(1, math.Ceil(l.Rate))
+}
+
+func New(o config.Quota) *Limiter {
+	l := &Limiter{
+		options:  o,
+		reserved: make(map[string]bool),
+		states:   make(map[key]*state),
+		now:      time.Now,
+	}
+
+	for _, limit := range o.Limits {
+		l.limits = append(l.limits, Limit(limit))
+	}
+
+	operations := o.ReservedOperations
+	if len(operations) == 0 {
+		operations = defaultReservedOperations
+	}
+
+	for _, operation := range operations {
+		l.reserved[operation] = true
+	}
+
+	return l
+}
+
+func (l *Limiter) limit(client string, scope string) Limit {
+	for _, limit := range l.limits {
+		if limit.matches(client, scope) {
+			return limit
+		}
+	}
+
+	return Limit{}
+}
+
+func (l *Limiter) state(client string, scope string, now time.Time) *state {
+	k := key{client: client, scope: scope}
+
+	s, ok := l.states[k]
+	if !ok {
+		limit := l.limit(client, scope)
+
+		s = &state{
+			limit:    limit,
+			tokens:   limit.burst(),
+			updated:  now,
+			rejected: make(map[string]uint64),
+		}
+
+		l.states[k] = s
+	}
+
+	return s
+}
+
+// refill adds tokens for the time passed since the last request
+func (s *state) refill(now time.Time) {
+	if s.limit.Rate <= 0 {
+		return
+	}
+
+	s.tokens = math.Min(s.limit.burst(), s.tokens+now.Sub(s.updated).Seconds()*s.limit.Rate)
+	s.updated = now
+}
+
+// available is the capacity the operation may use, non reserved operations
+// leave the reserved slots alone
+func (l *Limiter) available(scope string) int {
+	_, operation, _ := strings.Cut(scope, ":")
+
+	if l.reserved[operation] {
+		return l.options.Capacity
+	}
+
+	return l.options.Capacity - l.options.Reserved
+}
+
+// Acquire admits a request of the client on <platform>:<operation>, the returned
+// function must be called when the request is done. Rejections are *Rejection.
+func (l *Limiter) Acquire(client string, scope string) (func(), error) {
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	s := l.state(client, scope, now)
+	s.refill(now)
+
+	var rejection *Rejection
+
+	switch {
+	case s.limit.Concurrency > 0 && s.inFlight >= s.limit.Concurrency:
+		rejection = &Rejection{Reason: ReasonConcurrency, RetryAfter: concurrencyRetryAfter}
+
+	case l.options.Capacity > 0 && l.inFlight >= l.available(scope):
+		rejection = &Rejection{Reason: ReasonCapacity, RetryAfter: concurrencyRetryAfter}
+
+	case s.limit.Rate > 0 && s.tokens < 1:
+		wait := (1 - s.tokens) / s.limit.Rate
+		rejection = &Rejection{Reason: ReasonRate, RetryAfter: time.Duration(wait * float64(time.Second))}
+	}
+
+	if rejection != nil {
+		s.rejected[rejection.Reason]++
+		return nil, rejection
+	}
+
+	if s.limit.Rate > 0 {
+		s.tokens--
+	}
+
+	s.inFlight++
+	s.admitted++
+	l.inFlight++
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			l.Lock()
+			s.inFlight--
+			l.inFlight--
+			l.Unlock()
+		})
+	}, nil
+}
+
+// Acquire admits requests of one client, routes running several supplier
+// requests acquire each of them, see ContextKey
+type Acquire func(scope string) (func(), error)
+
+// Wait acquires on scope, rejections are retried after their RetryAfter
+// until ctx ends. Nil acquires admit everything.
+func (a Acquire) Wait(ctx context.Context, scope string) (func(), error) {
+	if a == nil {
+		return func() {}, nil
+	}
+
+	for {
+		release, err := a(scope)
+
+		var rejection *Rejection
+		if !errors.As(err, &rejection) {
+			return release, err
+		}
+
+		timer := time.NewTimer(rejection.RetryAfter)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package quota_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/quota"
+	"github.com/stretchr/testify/assert"
+)
+
+func rejection(t *testing.T, err error) *quota.Rejection {
+	var rejection *quota.Rejection
+
+	assert.ErrorIs(t, err, quota.ErrorRejected)
+	assert.True(t, errors.As(err, &rejection))
+
+	return rejection
+}
+
+func TestLimiter(t *testing.T) {
+	t.Run("should limit concurrency per client and scope", func(t *testing.T) {
+		limiter := quota.New(config.Quota{
+			Limits: []config.QuotaLimit{{Scope: "*:rates", Concurrency: 1}},
+		})
+
+		release, err := limiter.Acquire("crawler", "hertz:rates")
+		assert.Nil(t, err)
+
+		_, err = limiter.Acquire("crawler", "hertz:rates")
+		assert.Equal(t, quota.ReasonConcurrency, rejection(t, err).Reason)
+
+		_, err = limiter.Acquire("crawler", "rently:rates")
+		assert.Nil(t, err)
+
+		_, err = limiter.Acquire("broker", "hertz:rates")
+		assert.Nil(t, err)
+
+		release()
+		release()
+
+		_, err = limiter.Acquire("crawler", "hertz:rates")
+		assert.Nil(t, err)
+	})
+
+	t.Run("should apply the first matching limit", func(t *testing.T) {
+		limiter := quota.New(config.Quota{
+			Limits: []config.QuotaLimit{
+				{Client: "broker", Concurrency: 2},
+				{Concurrency: 1},
+			},
+		})
+
+		_, err := limiter.Acquire("broker", "hertz:booking")
+		assert.Nil(t, err)
+		_, err = limiter.Acquire("broker", "hertz:booking")
+		assert.Nil(t, err)
+
+		_, err = limiter.Acquire("crawler", "hertz:booking")
+		assert.Nil(t, err)
+		_, err = limiter.Acquire("crawler", "hertz:booking")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should limit the rate with a retry hint", func(t *testing.T) {
+		limiter := quota.New(config.Quota{
+			Limits: []config.QuotaLimit{{Rate: 0.5, Burst: 2}},
+		})
+
+		for i := 0; i < 2; i++ {
+			release, err := limiter.Acquire("crawler", "hertz:rates")
+			assert.Nil(t, err)
+			release()
+		}
+
+		_, err := limiter.Acquire("crawler", "hertz:rates")
+
+		rejected := rejection(t, err)
+		assert.Equal(t, quota.ReasonRate, rejected.Reason)
+		assert.InDelta(t, 2*time.Second, rejected.RetryAfter, float64(100*time.Millisecond))
+	})
+
+	t.Run("should keep reserved capacity for bookings", func(t *testing.T) {
+		limiter := quota.New(config.Quota{
+			Capacity: 3,
+			Reserved: 1,
+		})
+
+		_, err := limiter.Acquire("crawler", "hertz:rates")
+		assert.Nil(t, err)
+		_, err = limiter.Acquire("crawler", "rently:rates")
+		assert.Nil(t, err)
+
+		_, err = limiter.Acquire("crawler", "hertz:rates")
+		assert.Equal(t, quota.ReasonCapacity, rejection(t, err).Reason)
+
+		release, err := limiter.Acquire("broker", "hertz:booking")
+		assert.Nil(t, err)
+
+		_, err = limiter.Acquire("broker", "hertz:cancel")
+		assert.Equal(t, quota.ReasonCapacity, rejection(t, err).Reason)
+
+		release()
+
+		_, err = limiter.Acquire("broker", "hertz:cancel")
+		assert.Nil(t, err)
+	})
+
+	t.Run("should expose the state as metrics", func(t *testing.T) {
+		limiter := quota.New(config.Quota{
+			Capacity: 2,
+			Limits:   []config.QuotaLimit{{Concurrency: 1}},
+		})
+
+		_, _ = limiter.Acquire(`crawler "a"`, "hertz:rates")
+		_, _ = limiter.Acquire(`crawler "a"`, "hertz:rates")
+
+		var metrics strings.Builder
+		assert.Nil(t, limiter.WriteMetrics(&metrics))
+
+		assert.Contains(t, metrics.String(), `supplier_hub_quota_capacity{kind="in_flight"} 1`)
+		assert.Contains(t, metrics.String(), `supplier_hub_quota_in_flight{client="crawler \"a\"",scope="hertz:rates"} 1`)
+		assert.Contains(t, metrics.String(), `supplier_hub_quota_requests_total{client="crawler \"a\"",scope="hertz:rates",result="admitted"} 1`)
+		assert.Contains(t, metrics.String(), `supplier_hub_quota_requests_total{client="crawler \"a\"",scope="hertz:rates",result="rejected",reason="concurrency"} 1`)
+	})
+}
+
+func TestAcquireWait(t *testing.T) {
+	t.Run("should wait for rejections to pass", func(t *testing.T) {
+		limiter := quota.New(config.Quota{
+			Limits: []config.QuotaLimit{{Scope: "*:booking-status", Rate: 20, Burst: 1}},
+		})
+		acquire := quota.Acquire(func(scope string) (func(), error) {
+			return limiter.Acquire("broker", scope)
+		})
+
+		started := time.Now()
+
+		for i := 0; i < 3; i++ {
+			release, err := acquire.Wait(context.Background(), "hertz:booking-status")
+			assert.Nil(t, err)
+			release()
+		}
+
+		// the burst admits the first request, the others wait 50ms each
+		assert.GreaterOrEqual(t, time.Since(started), 90*time.Millisecond)
+	})
+
+	t.Run("should give up when the context ends", func(t *testing.T) {
+		limiter := quota.New(config.Quota{
+			Limits: []config.QuotaLimit{{Concurrency: 1}},
+		})
+		acquire := quota.Acquire(func(scope string) (func(), error) {
+			return limiter.Acquire("broker", scope)
+		})
+
+		_, err := acquire.Wait(context.Background(), "hertz:booking-status")
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = acquire.Wait(ctx, "hertz:booking-status")
+		assert.Equal(t, quota.ReasonConcurrency, rejection(t, err).Reason)
+	})
+
+	t.Run("should admit everything without quota", func(t *testing.T) {
+		var acquire quota.Acquire
+
+		release, err := acquire.Wait(context.Background(), "hertz:booking-status")
+		assert.Nil(t, err)
+		release()
+	})
+}
@@ -0,0 +1,81 @@
+package redisfactory
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// how long a client is considered down after a connection failure
+const unavailableCooldown = 5 * time.Second
+
+var ErrorUnavailable = errors.New("redis is unavailable")
+
+// availability short-circuits commands for a while after a connection failure,
+// so callers skip redis instead of waiting for timeouts on every request
+type availability struct {
+	downUntil atomic.Int64
+	cooldown  time.Duration
+}
+
+func newAvailability(cooldown time.Duration) *availability {
+	return &availability{
+		cooldown: cooldown,
+	}
+}
+
+func (a *availability) available() bool {
+	return time.Now().UnixNano() >= a.downUntil.Load()
+}
+
+func (a *availability) observe(err error) {
+	// deadlines of the caller say nothing about redis itself
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		a.downUntil.Store(time.Now().Add(a.cooldown).UnixNano())
+	}
+}
+
+func (a *availability) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		a.observe(err)
+		return conn, err
+	}
+}
+
+func (a *availability) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !a.available() {
+			cmd.SetErr(ErrorUnavailable)
+			return ErrorUnavailable
+		}
+
+		err := next(ctx, cmd)
+		a.observe(err)
+		return err
+	}
+}
+
+func (a *availability) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !a.available() {
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrorUnavailable)
+			}
+			return ErrorUnavailable
+		}
+
+		err := next(ctx, cmds)
+		a.observe(err)
+		return err
+	}
+}
@@ -0,0 +1,268 @@
+package redisfactory
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ModeSingle   = "single"
+	ModeSentinel = "sentinel"
+	ModeCluster  = "cluster"
+
+	defaultDialTimeout  = 4 * time.Second
+	defaultReadTimeout  = 3 * time.Second
+	defaultWriteTimeout = 3 * time.Second
+)
+
+var (
+	ErrorMissingUri       = errors.New("redis uri is missing")
+	ErrorUnknownMode      = errors.New("unknown redis mode")
+	ErrorMissingMaster    = errors.New("sentinel master name is missing")
+	ErrorInvalidParameter = errors.New("invalid redis parameter")
+)
+
+// ClientOptions describe a single named client.
+//
+// Sentinel uri lists sentinels as redis://[:password@]host:port/db?addr=host:port,
+// cluster uri lists nodes the same way, see redis.ParseClusterURL. PoolSize and
+// the timeouts override the pool_size and *_timeout uri parameters when set.
+type ClientOptions struct {
+	Mode             string
+	Uri              string
+	MasterName       string
+	SentinelPassword string
+	Tls              bool
+	PoolSize         int
+	DialTimeout      time.Duration
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+}
+
+// EnvPrefix returns prefix of the environment variables configuring the client,
+// for example TRAFFICLIGHT_REDIS_ for "trafficlight"
+func EnvPrefix(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_REDIS_"
+}
+
+// OptionsFromEnv reads <PREFIX>_URI, _MODE, _MASTER_NAME, _SENTINEL_PASSWORD, _TLS,
+// _POOL_SIZE, _DIAL_TIMEOUT, _READ_TIMEOUT and _WRITE_TIMEOUT
+func OptionsFromEnv(name string) (ClientOptions, error) {
+	o, err := ApplyEnv(name, ClientOptions{}, os.Getenv)
+	if err != nil {
+		return o, err
+	}
+
+	return o, o.Validate()
+}
+
+// ApplyEnv overrides options with the environment variables of the client that are set,
+// it does not validate the result
+func ApplyEnv(name string, o ClientOptions, getenv func(string) string) (ClientOptions, error) {
+	prefix := EnvPrefix(name)
+
+	values := map[string]*string{
+		"MODE":              &o.Mode,
+		"URI":               &o.Uri,
+		"MASTER_NAME":       &o.MasterName,
+		"SENTINEL_PASSWORD": &o.SentinelPassword,
+	}
+
+	for key, target := range values {
+		if value := getenv(prefix + key); value != "" {
+			*target = value
+		}
+	}
+
+	var err error
+
+	if value := getenv(prefix + "TLS"); value != "" {
+		o.Tls, err = strconv.ParseBool(value)
+		if err != nil {
+			return o, fmt.Errorf("%w: %sTLS: %s", ErrorInvalidParameter, prefix, err)
+		}
+	}
+
+	if value := getenv(prefix + "POOL_SIZE"); value != "" {
+		o.PoolSize, err = strconv.Atoi(value)
+		if err != nil {
+			return o, fmt.Errorf("%w: %sPOOL_SIZE: %s", ErrorInvalidParameter, prefix, err)
+		}
+	}
+
+	timeouts := map[string]*time.Duration{
+		"DIAL_TIMEOUT":  &o.DialTimeout,
+		"READ_TIMEOUT":  &o.ReadTimeout,
+		"WRITE_TIMEOUT": &o.WriteTimeout,
+	}
+
+	for key, target := range timeouts {
+		value := getenv(prefix + key)
+		if value == "" {
+			continue
+		}
+
+		*target, err = time.ParseDuration(value)
+		if err != nil {
+			return o, fmt.Errorf("%w: %s%s: %s", ErrorInvalidParameter, prefix, key, err)
+		}
+	}
+
+	return o, nil
+}
+
+// Validate checks the options without connecting
+func (o ClientOptions) Validate() error {
+	if o.Uri == "" {
+		return ErrorMissingUri
+	}
+
+	switch o.Mode {
+	case "", ModeSingle, ModeCluster:
+	case ModeSentinel:
+		if o.MasterName == "" {
+			if u, err := url.Parse(o.Uri); err != nil || u.Query().Get("master_name") == "" {
+				return ErrorMissingMaster
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrorUnknownMode, o.Mode)
+	}
+
+	// creating a client does not connect, so broken uris are caught at startup
+	client, err := o.newClient()
+	if err != nil {
+		return err
+	}
+
+	return client.Close()
+}
+
+func durationOrDefault(value time.Duration, fallback time.Duration) time.Duration {
+	if value == 0 {
+		return fallback
+	}
+
+	return value
+}
+
+// override replaces the pool size and timeouts parsed from the uri with the
+// options that are set, timeouts set by neither get the defaults
+func (o ClientOptions) override(poolSize *int, dialTimeout *time.Duration, readTimeout *time.Duration, writeTimeout *time.Duration) {
+	if o.PoolSize != 0 {
+		*poolSize = o.PoolSize
+	}
+
+	*dialTimeout = durationOrDefault(o.DialTimeout, durationOrDefault(*dialTimeout, defaultDialTimeout))
+	*readTimeout = durationOrDefault(o.ReadTimeout, durationOrDefault(*readTimeout, defaultReadTimeout))
+	*writeTimeout = durationOrDefault(o.WriteTimeout, durationOrDefault(*writeTimeout, defaultWriteTimeout))
+}
+
+func (o ClientOptions) tlsConfig(current *tls.Config, host string) *tls.Config {
+	if current != nil || !o.Tls {
+		return current
+	}
+
+	return &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+func (o ClientOptions) newClient() (redis.UniversalClient, error) {
+	switch o.Mode {
+	case ModeCluster:
+		opt, err := redis.ParseClusterURL(o.Uri)
+		if err != nil {
+			return nil, err
+		}
+
+		host, _, _ := net.SplitHostPort(opt.Addrs[0])
+
+		opt.TLSConfig = o.tlsConfig(opt.TLSConfig, host)
+		o.override(&opt.PoolSize, &opt.DialTimeout, &opt.ReadTimeout, &opt.WriteTimeout)
+
+		return redis.NewClusterClient(opt), nil
+
+	case ModeSentinel:
+		opt, err := o.failoverOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		o.override(&opt.PoolSize, &opt.DialTimeout, &opt.ReadTimeout, &opt.WriteTimeout)
+
+		return redis.NewFailoverClient(opt), nil
+
+	default:
+		opt, err := redis.ParseURL(o.Uri)
+		if err != nil {
+			return nil, err
+		}
+
+		host, _, _ := net.SplitHostPort(opt.Addr)
+
+		opt.TLSConfig = o.tlsConfig(opt.TLSConfig, host)
+		o.override(&opt.PoolSize, &opt.DialTimeout, &opt.ReadTimeout, &opt.WriteTimeout)
+
+		return redis.NewClient(opt), nil
+	}
+}
+
+func (o ClientOptions) failoverOptions() (*redis.FailoverOptions, error) {
+	u, err := url.Parse(o.Uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("%w: invalid scheme %s", ErrorInvalidParameter, u.Scheme)
+	}
+
+	query := u.Query()
+
+	opt := &redis.FailoverOptions{
+		MasterName:       o.MasterName,
+		SentinelAddrs:    append([]string{u.Host}, query["addr"]...),
+		SentinelPassword: o.SentinelPassword,
+	}
+
+	if opt.MasterName == "" {
+		opt.MasterName = query.Get("master_name")
+	}
+
+	if u.User != nil {
+		opt.Username = u.User.Username()
+		opt.Password, _ = u.User.Password()
+	}
+
+	db := strings.TrimPrefix(u.Path, "/")
+	if db == "" {
+		db = query.Get("db")
+	}
+
+	if db != "" {
+		opt.DB, err = strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid database %s", ErrorInvalidParameter, db)
+		}
+	}
+
+	var current *tls.Config
+	if u.Scheme == "rediss" {
+		current = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+
+	opt.TLSConfig = o.tlsConfig(current, u.Hostname())
+
+	return opt, nil
+}
@@ -1,56 +1,197 @@
 package redisfactory
 
 import (
-	"os"
-	"time"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// If one connection needs to be broken up new function should be introduced
-// example: TrafficlightHertzClient()
+// Clients are named, options are read from the environment prefixed with the
+// upper-cased name, see EnvPrefix. If one database needs to be broken up,
+// a new name should be introduced, example: "trafficlight-hertz".
+const (
+	Trafficlight   = "trafficlight"
+	ResponsesCache = "responses-cache"
+	Credentials    = "credentials"
+	History        = "history"
+	Sandbox        = "sandbox"
+	Rebook         = "rebook"
+	Tracking       = "tracking"
+	CancelRetry    = "cancel-retry"
+)
+
+var ErrorUnknownClient = errors.New("unknown redis client")
 
-// todo: what if one DB has to be broken up?
-// todo: clients can to be created on-demand, but got to figure out how to fail fast if URIs are missing
+type client struct {
+	redis.UniversalClient
+	availability *availability
+}
 
 type Factory struct {
-	trafficlightCache *redis.Client
-	responsesCache    *redis.Client
+	options map[string]ClientOptions
+	clients map[string]*client
+	mu      sync.Mutex
+}
+
+// New validates options of the clients, the clients required at startup must be present.
+// Connections are only opened when a client is first used.
+func New(options map[string]ClientOptions) (*Factory, error) {
+	f := &Factory{
+		options: make(map[string]ClientOptions),
+		clients: make(map[string]*client),
+	}
+
+	for _, name := range []string{Trafficlight, ResponsesCache} {
+		if _, ok := options[name]; !ok {
+			return nil, fmt.Errorf("%s: %w", name, ErrorMissingUri)
+		}
+	}
+
+	for name, o := range options {
+		err := o.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		f.options[name] = o
+	}
+
+	return f, nil
+}
+
+// Register adds or replaces options of a named client
+func (f *Factory) Register(name string, o ClientOptions) error {
+	err := o.Validate()
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.options[name] = o
+
+	return nil
+}
+
+// Client returns the named client, creating it on first use.
+// Clients not registered beforehand are configured from the environment.
+func (f *Factory) Client(name string) (redis.UniversalClient, error) {
+	c, err := f.client(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UniversalClient, nil
 }
 
-func New() *Factory {
-	opt, err := redis.ParseURL(os.Getenv("TRAFFICLIGHT_REDIS_URI"))
+func (f *Factory) client(name string) (*client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if c, ok := f.clients[name]; ok {
+		return c, nil
+	}
+
+	o, ok := f.options[name]
+	if !ok {
+		var err error
+		o, err = OptionsFromEnv(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %s", ErrorUnknownClient, name, err)
+		}
+
+		f.options[name] = o
+	}
+
+	universalClient, err := o.newClient()
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("%s: %w", name, err)
 	}
 
-	opt.DialTimeout = 4 * time.Second
-	opt.ReadTimeout = 3 * time.Second
-	opt.WriteTimeout = 3 * time.Second
+	c := &client{
+		UniversalClient: universalClient,
+		availability:    newAvailability(unavailableCooldown),
+	}
+	c.AddHook(c.availability)
+
+	f.clients[name] = c
 
-	trafficlightCache := redis.NewClient(opt)
+	return c, nil
+}
 
-	opt, err = redis.ParseURL(os.Getenv("RESPONSES_CACHE_REDIS_URI"))
+// Available reports false for a while after the named client failed to connect.
+// Callers are expected to skip optional work like grouping and caching.
+func (f *Factory) Available(name string) bool {
+	c, err := f.client(name)
 	if err != nil {
-		panic(err)
+		return false
+	}
+
+	return c.availability.available()
+}
+
+// Names returns names of all registered clients
+func (f *Factory) Names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	names := make([]string, 0, len(f.options))
+	for name := range f.options {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Check pings all registered clients and returns errors by client name
+func (f *Factory) Check(ctx context.Context) map[string]error {
+	failures := make(map[string]error)
+
+	for _, name := range f.Names() {
+		c, err := f.client(name)
+		if err == nil {
+			err = c.Ping(ctx).Err()
+		}
+
+		if err != nil {
+			failures[name] = err
+		}
 	}
 
-	opt.DialTimeout = 4 * time.Second
-	opt.ReadTimeout = 3 * time.Second
-	opt.WriteTimeout = 3 * time.Second
+	return failures
+}
+
+// Close closes all clients created so far
+func (f *Factory) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 
-	responsesCache := redis.NewClient(opt)
+	var result error
+	for name, c := range f.clients {
+		err := c.Close()
+		if err != nil && result == nil {
+			result = err
+		}
 
-	return &Factory{
-		trafficlightCache: trafficlightCache,
-		responsesCache:    responsesCache,
+		delete(f.clients, name)
 	}
+
+	return result
 }
 
-func (f *Factory) TrafficlightClient() *redis.Client {
-	return f.trafficlightCache
+// TrafficlightClient and ResponsesCacheClient are required, their options
+// are validated in New
+func (f *Factory) TrafficlightClient() (redis.UniversalClient, error) {
+	return f.Client(Trafficlight)
 }
 
-func (f *Factory) ResponsesCacheClient() *redis.Client {
-	return f.responsesCache
+func (f *Factory) ResponsesCacheClient() (redis.UniversalClient, error) {
+	return f.Client(ResponsesCache)
 }
@@ -0,0 +1,206 @@
+package redisfactory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func lookup(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func optionsFromLookup(name string, getenv func(string) string) (ClientOptions, error) {
+	o, err := ApplyEnv(name, ClientOptions{}, getenv)
+	if err != nil {
+		return o, err
+	}
+
+	return o, o.Validate()
+}
+
+func TestOptionsFromEnv(t *testing.T) {
+	t.Run("should read options with the name prefix", func(t *testing.T) {
+		o, err := optionsFromLookup("responses-cache", lookup(map[string]string{
+			"RESPONSES_CACHE_REDIS_URI":          "redis://localhost/1",
+			"RESPONSES_CACHE_REDIS_POOL_SIZE":    "20",
+			"RESPONSES_CACHE_REDIS_READ_TIMEOUT": "500ms",
+			"RESPONSES_CACHE_REDIS_TLS":          "true",
+		}))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 20, o.PoolSize)
+		assert.Equal(t, 500*time.Millisecond, o.ReadTimeout)
+		assert.True(t, o.Tls)
+	})
+
+	t.Run("should keep options not set in the environment", func(t *testing.T) {
+		o, err := ApplyEnv("trafficlight", ClientOptions{
+			Uri:      "redis://localhost/2",
+			PoolSize: 5,
+		}, lookup(map[string]string{
+			"TRAFFICLIGHT_REDIS_MODE": "single",
+		}))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "redis://localhost/2", o.Uri)
+		assert.Equal(t, ModeSingle, o.Mode)
+		assert.Equal(t, 5, o.PoolSize)
+	})
+
+	t.Run("should fail on missing uri", func(t *testing.T) {
+		_, err := optionsFromLookup("trafficlight", lookup(map[string]string{}))
+		assert.ErrorIs(t, err, ErrorMissingUri)
+	})
+
+	t.Run("should fail on invalid values", func(t *testing.T) {
+		_, err := optionsFromLookup("trafficlight", lookup(map[string]string{
+			"TRAFFICLIGHT_REDIS_URI":          "redis://localhost/2",
+			"TRAFFICLIGHT_REDIS_DIAL_TIMEOUT": "soon",
+		}))
+		assert.ErrorIs(t, err, ErrorInvalidParameter)
+	})
+
+	t.Run("should fail on unknown mode", func(t *testing.T) {
+		_, err := optionsFromLookup("trafficlight", lookup(map[string]string{
+			"TRAFFICLIGHT_REDIS_URI":  "redis://localhost/2",
+			"TRAFFICLIGHT_REDIS_MODE": "ring",
+		}))
+		assert.ErrorIs(t, err, ErrorUnknownMode)
+	})
+
+	t.Run("should fail on unparsable uri", func(t *testing.T) {
+		_, err := optionsFromLookup("trafficlight", lookup(map[string]string{
+			"TRAFFICLIGHT_REDIS_URI": "http://localhost/2",
+		}))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should require master name in sentinel mode", func(t *testing.T) {
+		_, err := optionsFromLookup("trafficlight", lookup(map[string]string{
+			"TRAFFICLIGHT_REDIS_URI":  "redis://sentinel-1:26379/2",
+			"TRAFFICLIGHT_REDIS_MODE": "sentinel",
+		}))
+		assert.ErrorIs(t, err, ErrorMissingMaster)
+	})
+}
+
+func TestClientModes(t *testing.T) {
+	t.Run("should parse sentinel addresses", func(t *testing.T) {
+		o := ClientOptions{
+			Mode: ModeSentinel,
+			Uri:  "rediss://:secret@sentinel-1:26379/2?addr=sentinel-2:26379&master_name=main",
+		}
+
+		opt, err := o.failoverOptions()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "main", opt.MasterName)
+		assert.Equal(t, []string{"sentinel-1:26379", "sentinel-2:26379"}, opt.SentinelAddrs)
+		assert.Equal(t, "secret", opt.Password)
+		assert.Equal(t, 2, opt.DB)
+		assert.NotNil(t, opt.TLSConfig)
+	})
+
+	t.Run("should create a cluster client", func(t *testing.T) {
+		o := ClientOptions{
+			Mode: ModeCluster,
+			Uri:  "redis://node-1:6379?addr=node-2:6379",
+		}
+
+		client, err := o.newClient()
+
+		assert.Nil(t, err)
+		assert.IsType(t, &redis.ClusterClient{}, client)
+		client.Close()
+	})
+
+	t.Run("should keep pool size and timeouts of the uri unless set", func(t *testing.T) {
+		o := ClientOptions{
+			Uri:         "redis://localhost:6379/1?pool_size=7&dial_timeout=2s&read_timeout=-1",
+			ReadTimeout: 500 * time.Millisecond,
+		}
+
+		client, err := o.newClient()
+		assert.Nil(t, err)
+		defer client.Close()
+
+		opt := client.(*redis.Client).Options()
+		assert.Equal(t, 7, opt.PoolSize)
+		assert.Equal(t, 2*time.Second, opt.DialTimeout)
+		assert.Equal(t, 500*time.Millisecond, opt.ReadTimeout)
+		assert.Equal(t, defaultWriteTimeout, opt.WriteTimeout)
+	})
+}
+
+func TestFactory(t *testing.T) {
+	redisServer := miniredis.RunT(t)
+
+	options := map[string]ClientOptions{
+		Trafficlight:   {Uri: "redis://" + redisServer.Addr() + "/2"},
+		ResponsesCache: {Uri: "redis://" + redisServer.Addr() + "/1"},
+	}
+
+	t.Run("should fail fast on invalid configuration", func(t *testing.T) {
+		_, err := New(map[string]ClientOptions{
+			Trafficlight: {Uri: "redis://" + redisServer.Addr() + "/2"},
+		})
+		assert.ErrorIs(t, err, ErrorMissingUri)
+
+		_, err = New(map[string]ClientOptions{
+			Trafficlight:   {Uri: "redis://" + redisServer.Addr() + "/2", Mode: "ring"},
+			ResponsesCache: {Uri: "redis://" + redisServer.Addr() + "/1"},
+		})
+		assert.ErrorIs(t, err, ErrorUnknownMode)
+	})
+
+	t.Run("should create named clients on demand", func(t *testing.T) {
+		t.Setenv("AUDIT_REDIS_URI", "redis://"+redisServer.Addr()+"/3")
+
+		f, err := New(options)
+		assert.Nil(t, err)
+		defer f.Close()
+
+		client, err := f.Client("audit")
+		assert.Nil(t, err)
+
+		again, _ := f.Client("audit")
+		assert.Same(t, client, again)
+
+		_, err = f.Client("unknown")
+		assert.ErrorIs(t, err, ErrorUnknownClient)
+
+		assert.Equal(t, []string{"audit", ResponsesCache, Trafficlight}, f.Names())
+		assert.Empty(t, f.Check(context.Background()))
+	})
+
+	t.Run("should report unavailable clients", func(t *testing.T) {
+		f, err := New(options)
+		assert.Nil(t, err)
+		defer f.Close()
+
+		err = f.Register("offline", ClientOptions{
+			Uri:         "redis://127.0.0.1:1/0",
+			DialTimeout: 100 * time.Millisecond,
+		})
+		assert.Nil(t, err)
+
+		assert.True(t, f.Available("offline"))
+
+		failures := f.Check(context.Background())
+		assert.Len(t, failures, 1)
+		assert.NotNil(t, failures["offline"])
+
+		assert.False(t, f.Available("offline"))
+		assert.True(t, f.Available(Trafficlight))
+
+		client, _ := f.Client("offline")
+		assert.ErrorIs(t, client.Get(context.Background(), "key").Err(), ErrorUnavailable)
+	})
+}
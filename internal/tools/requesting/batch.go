@@ -0,0 +1,56 @@
+package requesting
+
+import (
+	"context"
+	"sync"
+)
+
+type batchKey struct{}
+
+// batch holds the results shared by the requests of a batch
+type batch struct {
+	entries map[string]*batchEntry
+	sync.Mutex
+}
+
+type batchEntry struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+// WithBatch lets the requests made with the context share results, e.g.
+// the auth token of a supplier, see Shared
+func WithBatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, batchKey{}, &batch{entries: make(map[string]*batchEntry)})
+}
+
+// Shared runs fetch once per key for all requests of the batch of ctx, shared
+// is true for the requests reusing the result of another one. Without a batch
+// fetch runs every time.
+func Shared[T any](ctx context.Context, key string, fetch func() (T, error)) (value T, shared bool, err error) {
+	b, ok := ctx.Value(batchKey{}).(*batch)
+	if !ok {
+		value, err = fetch()
+		return value, false, err
+	}
+
+	b.Lock()
+	entry, ok := b.entries[key]
+	if !ok {
+		entry = &batchEntry{}
+		b.entries[key] = entry
+	}
+	b.Unlock()
+
+	shared = true
+
+	entry.once.Do(func() {
+		shared = false
+		entry.value, entry.err = fetch()
+	})
+
+	value, _ = entry.value.(T)
+
+	return value, shared, entry.err
+}
@@ -0,0 +1,78 @@
+package requesting_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShared(t *testing.T) {
+	t.Run("should fetch once per key within a batch", func(t *testing.T) {
+		ctx := requesting.WithBatch(context.Background())
+
+		var fetches, reused int
+		var lock sync.Mutex
+		var wait sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wait.Add(1)
+
+			go func() {
+				defer wait.Done()
+
+				value, shared, err := requesting.Shared(ctx, "token", func() (string, error) {
+					lock.Lock()
+					fetches++
+					lock.Unlock()
+
+					return "secret", nil
+				})
+
+				assert.Nil(t, err)
+				assert.Equal(t, "secret", value)
+
+				if shared {
+					lock.Lock()
+					reused++
+					lock.Unlock()
+				}
+			}()
+		}
+
+		wait.Wait()
+
+		assert.Equal(t, 1, fetches)
+		assert.Equal(t, 7, reused)
+
+		_, shared, err := requesting.Shared(ctx, "other", func() (string, error) {
+			return "", errors.New("failed")
+		})
+		assert.False(t, shared)
+		assert.EqualError(t, err, "failed")
+
+		_, shared, err = requesting.Shared(ctx, "other", func() (string, error) {
+			return "retried", nil
+		})
+		assert.True(t, shared)
+		assert.EqualError(t, err, "failed")
+	})
+
+	t.Run("should fetch every time without a batch", func(t *testing.T) {
+		fetches := 0
+
+		for i := 0; i < 2; i++ {
+			_, shared, _ := requesting.Shared(context.Background(), "token", func() (int, error) {
+				fetches++
+				return fetches, nil
+			})
+
+			assert.False(t, shared)
+		}
+
+		assert.Equal(t, 2, fetches)
+	})
+}
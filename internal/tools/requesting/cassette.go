@@ -0,0 +1,278 @@
+package requesting
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+)
+
+const (
+	CassetteRecord = "record"
+	CassetteReplay = "replay"
+
+	cassetteExtension = ".json"
+	unnamedRequest    = "request"
+	hashLength        = 16
+)
+
+var (
+	ErrorCassetteMiss = errors.New("no recorded exchange for request")
+	ErrorCassetteMode = errors.New("cassette mode must be record or replay")
+)
+
+var (
+	// volatile values change between runs and are left out of the hash
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+	uuidPattern      = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	tagSpacePattern  = regexp.MustCompile(`>\s+<`)
+)
+
+// Exchange is one recorded request and response, stored as JSON so it can be
+// read and edited by hand
+type Exchange struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	Url     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Cassette records outbound exchanges to files named <request name>-<hash>.json
+// and replays them. The hash covers method, path, query and the normalised body,
+// the host is left out so recordings replay against any supplier url.
+type Cassette struct {
+	dir    string
+	mode   string
+	strict bool
+	misses []string
+	mu     sync.Mutex
+}
+
+type cassetteTransport struct {
+	Transport http.RoundTripper
+	cassette  *Cassette
+}
+
+// NewCassette creates the directory when recording. In strict replay mode
+// unmatched requests fail, otherwise they are sent to the supplier.
+func NewCassette(dir string, mode string, strict bool) (*Cassette, error) {
+	switch mode {
+	case CassetteRecord:
+		err := os.MkdirAll(dir, 0o755)
+		if err != nil {
+			return nil, err
+		}
+	case CassetteReplay:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrorCassetteMode, mode)
+	}
+
+	return &Cassette{
+		dir:    dir,
+		mode:   mode,
+		strict: strict,
+	}, nil
+}
+
+// redactHeaders keeps credentials out of recordings, they are committed with the tests
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+
+	for name := range redacted {
+		lower := strings.ToLower(name)
+
+		for _, sensitive := range []string{"authorization", "cookie", "key", "token", "secret", "password"} {
+			if strings.Contains(lower, sensitive) {
+				redacted[name] = []string{"<redacted>"}
+				break
+			}
+		}
+	}
+
+	return redacted
+}
+
+// NormalizeBody collapses whitespace between XML tags and masks timestamps and uuids
+func NormalizeBody(body string) string {
+	body = strings.TrimSpace(body)
+	body = tagSpacePattern.ReplaceAllString(body, "><")
+	body = timestampPattern.ReplaceAllString(body, "<timestamp>")
+	body = uuidPattern.ReplaceAllString(body, "<uuid>")
+
+	return body
+}
+
+// Key names the file of a request
+func Key(request *http.Request, body []byte) string {
+	name, _ := request.Context().Value(schema.RequestingTypeKey).(schema.SupplierRequestName)
+	if name == "" {
+		name = unnamedRequest
+	}
+
+	query := request.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", request.Method, request.URL.Path)
+
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%s\n", key, NormalizeBody(strings.Join(query[key], ",")))
+	}
+
+	hash.Write([]byte(NormalizeBody(string(body))))
+
+	return fmt.Sprintf("%s-%s", name, hex.EncodeToString(hash.Sum(nil))[:hashLength])
+}
+
+func (c *Cassette) path(key string) string {
+	return filepath.Join(c.dir, key+cassetteExtension)
+}
+
+// Misses lists keys of requests that had no recording
+func (c *Cassette) Misses() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.misses...)
+}
+
+func (c *Cassette) Middleware() TransportMiddleware {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &cassetteTransport{
+			Transport: rt,
+			cassette:  c,
+		}
+	}
+}
+
+func (t *cassetteTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if request.Body != nil {
+		body, _ = io.ReadAll(request.Body)
+		request.Body.Close()
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	key := Key(request, body)
+
+	if t.cassette.mode == CassetteReplay {
+		return t.replay(request, key)
+	}
+
+	response, err := t.Transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	exchange := Exchange{
+		Request: RecordedRequest{
+			Method:  request.Method,
+			Url:     request.URL.String(),
+			Headers: redactHeaders(request.Header),
+			Body:    string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Headers:    redactHeaders(response.Header),
+			Body:       string(responseBody),
+		},
+	}
+
+	var content bytes.Buffer
+
+	// bodies are mostly XML, escaping their brackets would make the files unreadable
+	encoder := json.NewEncoder(&content)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+
+	err = encoder.Encode(exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(t.cassette.path(key), content.Bytes(), 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (t *cassetteTransport) replay(request *http.Request, key string) (*http.Response, error) {
+	content, err := os.ReadFile(t.cassette.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		t.cassette.mu.Lock()
+		t.cassette.misses = append(t.cassette.misses, key)
+		t.cassette.mu.Unlock()
+
+		if t.cassette.strict {
+			return nil, fmt.Errorf("%w: %s %s (%s)", ErrorCassetteMiss, request.Method, request.URL, key)
+		}
+
+		return t.Transport.RoundTrip(request)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var exchange Exchange
+
+	err = json.Unmarshal(content, &exchange)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", t.cassette.path(key), err)
+	}
+
+	headers := exchange.Response.Headers
+	if headers == nil {
+		headers = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.Response.StatusCode, http.StatusText(exchange.Response.StatusCode)),
+		StatusCode:    exchange.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(strings.NewReader(exchange.Response.Body)),
+		ContentLength: int64(len(exchange.Response.Body)),
+		Request:       request,
+	}, nil
+}
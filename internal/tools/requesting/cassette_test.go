@@ -0,0 +1,106 @@
+package requesting_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/stretchr/testify/assert"
+)
+
+func send(t *testing.T, cassette *requesting.Cassette, url string, body string) (*http.Response, error) {
+	ctx := context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Cancel)
+	ctx = requesting.WithMiddlewares(ctx, cassette.Middleware())
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+	assert.Nil(t, err)
+	request.Header.Set("Authorization", "Bearer secret")
+
+	client := http.Client{Transport: &requesting.InterceptorTransport{Transport: http.DefaultTransport}}
+
+	return client.Do(request)
+}
+
+func TestCassette(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("<Cancelled/>"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	t.Run("should record and replay exchanges without the supplier", func(t *testing.T) {
+		recorder, err := requesting.NewCassette(dir, requesting.CassetteRecord, false)
+		assert.Nil(t, err)
+
+		_, err = send(t, recorder, server.URL+"/cancel", "<Cancel Time=\"2023-08-01T10:00:00Z\"/>")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, calls)
+
+		files, _ := filepath.Glob(filepath.Join(dir, "cancel-*.json"))
+		assert.Len(t, files, 1)
+
+		content, _ := os.ReadFile(files[0])
+		assert.NotContains(t, string(content), "secret")
+
+		player, err := requesting.NewCassette(dir, requesting.CassetteReplay, true)
+		assert.Nil(t, err)
+
+		// host and timestamps do not take part in matching
+		response, err := send(t, player, "https://supplier.example/cancel", "<Cancel Time=\"2024-01-01T00:00:00Z\"/>")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusAccepted, response.StatusCode)
+
+		body, _ := io.ReadAll(response.Body)
+		assert.Equal(t, "<Cancelled/>", string(body))
+		assert.Empty(t, player.Misses())
+	})
+
+	t.Run("should fail unmatched requests in strict mode", func(t *testing.T) {
+		player, err := requesting.NewCassette(dir, requesting.CassetteReplay, true)
+		assert.Nil(t, err)
+
+		_, err = send(t, player, server.URL+"/cancel", "<Cancel Id=\"other\"/>")
+		assert.ErrorIs(t, err, requesting.ErrorCassetteMiss)
+		assert.Len(t, player.Misses(), 1)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should pass unmatched requests through otherwise", func(t *testing.T) {
+		player, err := requesting.NewCassette(dir, requesting.CassetteReplay, false)
+		assert.Nil(t, err)
+
+		_, err = send(t, player, server.URL+"/cancel", "<Cancel Id=\"other\"/>")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("should reject unknown modes", func(t *testing.T) {
+		_, err := requesting.NewCassette(dir, "rewind", false)
+		assert.ErrorIs(t, err, requesting.ErrorCassetteMode)
+	})
+}
+
+func TestKey(t *testing.T) {
+	t.Run("should ignore formatting between tags", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "https://a.example/ota?b=2&a=1", nil)
+		other := httptest.NewRequest(http.MethodPost, "https://b.example/ota?a=1&b=2", nil)
+
+		assert.Equal(t,
+			requesting.Key(request, []byte("<a>\n\t<b/>\n</a>")),
+			requesting.Key(other, []byte("<a><b/></a>")),
+		)
+		assert.True(t, strings.HasPrefix(requesting.Key(request, nil), "request-"))
+	})
+}
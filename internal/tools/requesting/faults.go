@@ -0,0 +1,252 @@
+package requesting
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+)
+
+const (
+	// FaultLatency delays the supplier request, client timeouts still apply
+	FaultLatency = "latency"
+	// FaultReset fails the request like a connection closed by the supplier
+	FaultReset = "reset"
+	// FaultStatus answers with the status without calling the supplier
+	FaultStatus = "status"
+	// FaultTruncated cuts the supplier response body in half
+	FaultTruncated = "truncated"
+	// FaultInvalid replaces the second half of the body with content that is neither XML nor JSON
+	FaultInvalid = "invalid"
+
+	// FaultHeader is set on faked responses, so logs and history tell them apart
+	FaultHeader = "x-fault-injection"
+
+	defaultFaultLatency = 3 * time.Second
+	defaultFaultStatus  = http.StatusServiceUnavailable
+)
+
+var (
+	ErrorFaultReset   = errors.New("connection reset by peer (fault injection)")
+	ErrorUnknownFault = errors.New("unknown fault")
+)
+
+// Fault breaks the supplier exchanges of matching requests, empty platform or
+// request match all of them
+type Fault struct {
+	Name     string                     `yaml:"name" json:"name"`
+	Kind     string                     `yaml:"kind" json:"kind"`
+	Platform string                     `yaml:"platform" json:"platform,omitempty"`
+	Request  schema.SupplierRequestName `yaml:"request" json:"request,omitempty"`
+	// Probability of the fault applying to a matching request, 0 means always
+	Probability float64       `yaml:"probability" json:"probability,omitempty"`
+	Latency     time.Duration `yaml:"latency" json:"latency,omitempty"`
+	Status      int           `yaml:"status" json:"status,omitempty"`
+}
+
+// DefaultFaults are available by kind name without configuration
+func DefaultFaults() []Fault {
+	return []Fault{
+		{Name: FaultLatency, Kind: FaultLatency, Latency: defaultFaultLatency},
+		{Name: FaultReset, Kind: FaultReset},
+		{Name: FaultStatus, Kind: FaultStatus, Status: defaultFaultStatus},
+		{Name: FaultTruncated, Kind: FaultTruncated},
+		{Name: FaultInvalid, Kind: FaultInvalid},
+	}
+}
+
+func (f Fault) Validate() error {
+	var problems []string
+
+	if f.Name == "" {
+		problems = append(problems, "name is required")
+	}
+
+	switch f.Kind {
+	case FaultReset, FaultTruncated, FaultInvalid:
+	case FaultLatency:
+		if f.Latency <= 0 {
+			problems = append(problems, "latency must be positive")
+		}
+	case FaultStatus:
+		if f.Status < 100 || f.Status > 599 {
+			problems = append(problems, "status must be a HTTP status code")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("%s %q", ErrorUnknownFault, f.Kind))
+	}
+
+	if f.Probability < 0 || f.Probability > 1 {
+		problems = append(problems, "probability must be between 0 and 1")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("fault %s: %s", f.Name, strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+func (f Fault) Matches(platform string, request schema.SupplierRequestName) bool {
+	return (f.Platform == "" || f.Platform == platform) && (f.Request == "" || f.Request == request)
+}
+
+type faultsKey struct{}
+
+// WithFaults attaches the faults matching the platform to the context of a
+// hub request, supplier requests made with it are broken accordingly
+func WithFaults(ctx context.Context, platform string, faults []Fault) context.Context {
+	var matching []Fault
+
+	for _, fault := range faults {
+		if fault.Platform == "" || fault.Platform == platform {
+			matching = append(matching, fault)
+		}
+	}
+
+	if len(matching) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, faultsKey{}, matching)
+}
+
+func faultsFrom(ctx context.Context) []Fault {
+	faults, _ := ctx.Value(faultsKey{}).([]Fault)
+	return faults
+}
+
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (d detachedContext) Value(key any) any {
+	return d.parent.Value(key)
+}
+
+// Detach keeps the values of ctx, e.g. the faults, without its cancellation.
+// Supplier requests end by their own timeouts, a caller going away must not
+// stop a booking half way.
+func Detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+var (
+	faultRandom   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	faultRandomMu sync.Mutex
+)
+
+func applies(probability float64) bool {
+	if probability == 0 {
+		return true
+	}
+
+	faultRandomMu.Lock()
+	defer faultRandomMu.Unlock()
+
+	return faultRandom.Float64() < probability
+}
+
+type FaultTransportMiddleware struct {
+	Transport http.RoundTripper
+	faults    []Fault
+}
+
+// NewFaultTransportMiddleware breaks supplier exchanges by the faults matching
+// the request name, platforms are matched by WithFaults already
+func NewFaultTransportMiddleware(faults []Fault) TransportMiddleware {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &FaultTransportMiddleware{
+			Transport: rt,
+			faults:    faults,
+		}
+	}
+}
+
+func (f *FaultTransportMiddleware) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestType, _ := request.Context().Value(schema.RequestingTypeKey).(schema.SupplierRequestName)
+
+	var body []Fault
+
+	for _, fault := range f.faults {
+		if (fault.Request != "" && fault.Request != requestType) || !applies(fault.Probability) {
+			continue
+		}
+
+		switch fault.Kind {
+		case FaultLatency:
+			select {
+			case <-time.After(fault.Latency):
+			case <-request.Context().Done():
+				return nil, request.Context().Err()
+			}
+		case FaultReset:
+			return nil, ErrorFaultReset
+		case FaultStatus:
+			return faultResponse(request, fault), nil
+		default:
+			body = append(body, fault)
+		}
+	}
+
+	response, err := f.Transport.RoundTrip(request)
+	if err != nil || len(body) == 0 {
+		return response, err
+	}
+
+	content, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fault := range body {
+		content = breakBody(fault.Kind, content)
+		response.Header.Add(FaultHeader, fault.Name)
+	}
+
+	response.Body = io.NopCloser(bytes.NewReader(content))
+	response.ContentLength = int64(len(content))
+	response.Header.Set("Content-Length", strconv.Itoa(len(content)))
+
+	return response, nil
+}
+
+func faultResponse(request *http.Request, fault Fault) *http.Response {
+	content := []byte(http.StatusText(fault.Status))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fault.Status, http.StatusText(fault.Status)),
+		StatusCode:    fault.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"text/plain"}, http.CanonicalHeaderKey(FaultHeader): {fault.Name}},
+		Body:          io.NopCloser(bytes.NewReader(content)),
+		ContentLength: int64(len(content)),
+		Request:       request,
+	}
+}
+
+func breakBody(kind string, content []byte) []byte {
+	half := content[: len(content)/2 : len(content)/2]
+
+	if kind == FaultInvalid {
+		return append(half, []byte("<<{{ not a supplier response")...)
+	}
+
+	return half
+}
@@ -0,0 +1,136 @@
+package requesting_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/stretchr/testify/assert"
+)
+
+func sendWithFaults(t *testing.T, url string, name schema.SupplierRequestName, timeout time.Duration, faults ...requesting.Fault) (*http.Response, string, error) {
+	ctx := requesting.WithFaults(context.Background(), "hertz", faults)
+	ctx = context.WithValue(requesting.Detach(ctx), schema.RequestingTypeKey, name)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader("<Request/>"))
+	assert.Nil(t, err)
+
+	client := http.Client{Timeout: timeout, Transport: &requesting.InterceptorTransport{Transport: http.DefaultTransport}}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+
+	return response, string(body), nil
+}
+
+func TestFaults(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte("<Response>available</Response>"))
+	}))
+	defer server.Close()
+
+	t.Run("should pass through without faults", func(t *testing.T) {
+		response, body, err := sendWithFaults(t, server.URL, schema.Rates, time.Second)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "<Response>available</Response>", body)
+	})
+
+	t.Run("should answer with the status without calling the supplier", func(t *testing.T) {
+		calls = 0
+
+		response, body, err := sendWithFaults(t, server.URL, schema.Rates, time.Second,
+			requesting.Fault{Name: "gateway", Kind: requesting.FaultStatus, Status: http.StatusBadGateway})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+		assert.Equal(t, "gateway", response.Header.Get(requesting.FaultHeader))
+		assert.Equal(t, "Bad Gateway", body)
+	})
+
+	t.Run("should reset the connection", func(t *testing.T) {
+		_, _, err := sendWithFaults(t, server.URL, schema.Rates, time.Second,
+			requesting.Fault{Name: "reset", Kind: requesting.FaultReset})
+
+		assert.ErrorIs(t, err, requesting.ErrorFaultReset)
+	})
+
+	t.Run("should truncate and break bodies", func(t *testing.T) {
+		_, body, err := sendWithFaults(t, server.URL, schema.Rates, time.Second,
+			requesting.Fault{Name: "truncated", Kind: requesting.FaultTruncated})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "<Response>avail", body)
+
+		response, body, err := sendWithFaults(t, server.URL, schema.Rates, time.Second,
+			requesting.Fault{Name: "invalid", Kind: requesting.FaultInvalid})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "<Response>avail<<{{ not a supplier response", body)
+		assert.Equal(t, int64(len(body)), response.ContentLength)
+	})
+
+	t.Run("should delay until the client times out", func(t *testing.T) {
+		start := time.Now()
+
+		_, _, err := sendWithFaults(t, server.URL, schema.Rates, 50*time.Millisecond,
+			requesting.Fault{Name: "slow", Kind: requesting.FaultLatency, Latency: time.Minute})
+
+		assert.True(t, os.IsTimeout(err))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should only break matching requests", func(t *testing.T) {
+		reset := requesting.Fault{Name: "reset", Kind: requesting.FaultReset, Request: schema.Cancel}
+
+		_, _, err := sendWithFaults(t, server.URL, schema.Rates, time.Second, reset)
+		assert.Nil(t, err)
+
+		reset.Request = ""
+		reset.Platform = "anyrent"
+
+		_, _, err = sendWithFaults(t, server.URL, schema.Rates, time.Second, reset)
+		assert.Nil(t, err)
+	})
+
+	t.Run("should validate faults", func(t *testing.T) {
+		for _, fault := range requesting.DefaultFaults() {
+			assert.Nil(t, fault.Validate())
+		}
+
+		err := requesting.Fault{Kind: "flood", Probability: 2}.Validate()
+		assert.ErrorContains(t, err, "name is required")
+		assert.ErrorContains(t, err, `unknown fault "flood"`)
+		assert.ErrorContains(t, err, "probability must be between 0 and 1")
+
+		err = requesting.Fault{Name: "slow", Kind: requesting.FaultLatency}.Validate()
+		assert.ErrorContains(t, err, "latency must be positive")
+	})
+
+	t.Run("should keep values but not cancellation when detached", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), schema.RequestingTypeKey, schema.Booking))
+		cancel()
+
+		detached := requesting.Detach(ctx)
+
+		assert.Nil(t, detached.Err())
+		assert.Nil(t, detached.Done())
+		assert.Equal(t, schema.Booking, detached.Value(schema.RequestingTypeKey))
+	})
+}
@@ -2,6 +2,7 @@ package requesting
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"time"
@@ -12,6 +13,20 @@ import (
 
 type TransportMiddleware func(http.RoundTripper) http.RoundTripper
 
+type middlewaresKey struct{}
+
+// WithMiddlewares wraps the network transport of the supplier requests made
+// with the context, below the middlewares of the platform so replayed
+// responses are still logged
+func WithMiddlewares(ctx context.Context, middlewares ...TransportMiddleware) context.Context {
+	return context.WithValue(ctx, middlewaresKey{}, middlewares)
+}
+
+func middlewaresFrom(ctx context.Context) []TransportMiddleware {
+	middlewares, _ := ctx.Value(middlewaresKey{}).([]TransportMiddleware)
+	return middlewares
+}
+
 type InterceptorTransport struct {
 	Transport   http.RoundTripper
 	Middlewares []TransportMiddleware
@@ -19,6 +34,16 @@ type InterceptorTransport struct {
 
 func (t *InterceptorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	transport := t.Transport
+
+	for _, middleware := range middlewaresFrom(req.Context()) {
+		transport = middleware(transport)
+	}
+
+	// above the context middlewares, so faults apply to replayed responses too
+	if faults := faultsFrom(req.Context()); len(faults) > 0 {
+		transport = NewFaultTransportMiddleware(faults)(transport)
+	}
+
 	for _, middleware := range t.Middlewares {
 		transport = middleware(transport)
 	}
@@ -72,6 +97,7 @@ func (t *LoggingTransportMiddleware) RoundTrip(req *http.Request) (*http.Respons
 
 type RequestBucket interface {
 	FinishedRequest(
+		ctx context.Context,
 		requestType schema.SupplierRequestName,
 		startTime time.Time,
 		statusCode int,
@@ -113,6 +139,7 @@ func (b *BucketTransportMiddleware) RoundTrip(request *http.Request) (*http.Resp
 
 	defer func() {
 		b.Bucket.FinishedRequest(
+			request.Context(),
 			requestType,
 			startTime,
 			status,
@@ -0,0 +1,26 @@
+// Package signing signs HTTP requests with a shared secret. The hub verifies
+// signed client requests and signs the status callbacks it sends the same way.
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	TimestampHeader string = "x-timestamp"
+	SignatureHeader string = "x-signature"
+)
+
+// Sign returns the hex encoded HMAC-SHA256 of method, path with query,
+// unix timestamp and the sha256 of the body, separated by new lines
+func Sign(secret string, method string, uri string, timestamp string, body []byte) string {
+	bodyDigest := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s", method, uri, timestamp, hex.EncodeToString(bodyDigest[:]))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
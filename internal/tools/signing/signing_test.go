@@ -0,0 +1,18 @@
+package signing_test
+
+import (
+	"testing"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/signing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("should sign method, uri, timestamp and body", func(t *testing.T) {
+		signature := signing.Sign("secret", "POST", "/hertz/booking?trace=1", "1700000000", []byte("{}"))
+		assert.Equal(t, "51458299f5214d161e293c618b34562e76014145f8edb9ce21a8f3fc582b8798", signature)
+
+		assert.NotEqual(t, signature, signing.Sign("secret", "POST", "/hertz/booking", "1700000000", []byte("{}")))
+		assert.NotEqual(t, signature, signing.Sign("other", "POST", "/hertz/booking?trace=1", "1700000000", []byte("{}")))
+	})
+}
@@ -0,0 +1,45 @@
+package tracking
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/tools/jobqueue"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyPrefix = "tracking:"
+
+	// jobTtl keeps finished jobs for inspection
+	jobTtl = 7 * 24 * time.Hour
+)
+
+// Store keeps jobs in redis so they survive restarts
+type Store struct {
+	queue *jobqueue.Queue[Job]
+}
+
+func NewStore(client redis.UniversalClient) *Store {
+	return &Store{queue: jobqueue.New[Job](client, "tracking", redisKeyPrefix, jobTtl)}
+}
+
+// Get returns ErrorNotFound for unknown and expired jobs
+func (s *Store) Get(ctx context.Context, id string) (Job, error) {
+	job, err := s.queue.Get(ctx, id)
+	if errors.Is(err, jobqueue.ErrorNotFound) {
+		return job, ErrorNotFound
+	}
+
+	return job, err
+}
+
+// Save schedules unfinished jobs at NextAt
+func (s *Store) Save(ctx context.Context, job Job) error {
+	if job.State.Finished() {
+		return s.queue.Finish(ctx, job.Id, job)
+	}
+
+	return s.queue.Schedule(ctx, job.Id, job, job.NextAt)
+}
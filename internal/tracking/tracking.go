@@ -0,0 +1,219 @@
+// Package tracking polls the status of PENDING bookings in the background
+// and delivers the final status to the callback url of the booking request.
+// Jobs are kept in redis, any instance continues them after restarts. Only
+// bookings referencing stored configuration are tracked, supplier
+// credentials are not kept in redis.
+package tracking
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/jobqueue"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	OperationBooking = "booking"
+	OperationModify  = "modify"
+
+	// JobIdHeader identifies the job of a callback, it is the same for retries
+	JobIdHeader = "x-tracking-id"
+)
+
+var (
+	ErrorNotFound           = errors.New("tracking job not found")
+	ErrorInvalidCallbackUrl = errors.New("status callback url must be an absolute http url")
+	// ErrorPrivateCallbackAddress keeps callbacks from reaching the
+	// internal network, see config.Tracking.AllowPrivateCallbacks
+	ErrorPrivateCallbackAddress = errors.New("status callback address is not public")
+	// ErrorConfigurationRefRequired keeps supplier credentials out of redis,
+	// only bookings referencing stored configuration are tracked
+	ErrorConfigurationRefRequired = errors.New("status tracking requires a configuration reference")
+)
+
+// backoff doubles the poll interval per attempt up to the max poll interval
+func backoff(o config.Tracking, attempts int) time.Duration {
+	return jobqueue.Backoff(o.PollInterval, o.MaxPollInterval, attempts)
+}
+
+type State string
+
+const (
+	// StatePolling waits for a final status
+	StatePolling State = "polling"
+	// StateDelivering waits for the callback to be accepted
+	StateDelivering State = "delivering"
+	// StateDelivered is done
+	StateDelivered State = "delivered"
+	// StateFailed gave up delivering the callback
+	StateFailed State = "failed"
+)
+
+func (s State) Finished() bool {
+	return s == StateDelivered || s == StateFailed
+}
+
+// Job tracks a booking. Params keep the configuration reference, the
+// configuration is resolved for each poll as ClientId and never stored.
+type Job struct {
+	Id            string                             `json:"id"`
+	Platform      string                             `json:"platform"`
+	Operation     string                             `json:"operation"`
+	CallbackUrl   string                             `json:"callbackUrl"`
+	ClientId      string                             `json:"clientId,omitempty"`
+	CorrelationId string                             `json:"correlationId,omitempty"`
+	Params        schema.BookingStatusRequestParams  `json:"params"`
+	State         State                              `json:"state"`
+	Status        schema.BookingStatusResponseStatus `json:"status"`
+	Errors        schema.SupplierResponseErrors      `json:"errors,omitempty"`
+	// Final is false when polling stopped at the max age
+	Final      bool   `json:"final"`
+	Polls      int    `json:"polls"`
+	Deliveries int    `json:"deliveries"`
+	LastError  string `json:"lastError,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	NextAt    time.Time `json:"nextAt"`
+}
+
+// Callback is posted to the callback url
+type Callback struct {
+	Id                       string                             `json:"id"`
+	Platform                 string                             `json:"platform"`
+	Operation                string                             `json:"operation"`
+	ReservNumber             string                             `json:"reservNumber"`
+	SupplierBookingReference string                             `json:"supplierBookingReference"`
+	Status                   schema.BookingStatusResponseStatus `json:"status"`
+	Final                    bool                               `json:"final"`
+	Errors                   schema.SupplierResponseErrors      `json:"errors,omitempty"`
+}
+
+func (j Job) callback() Callback {
+	return Callback{
+		Id:                       j.Id,
+		Platform:                 j.Platform,
+		Operation:                j.Operation,
+		ReservNumber:             j.Params.ReservNumber,
+		SupplierBookingReference: j.Params.SupplierBookingReference,
+		Status:                   j.Status,
+		Final:                    j.Final,
+		Errors:                   j.Errors,
+	}
+}
+
+func validateCallbackUrl(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrorInvalidCallbackUrl
+	}
+
+	return nil
+}
+
+// Track schedules the first status poll of the booking, params without a
+// configuration reference are rejected
+func (t *Tracker) Track(ctx context.Context, platform string, clientId string, operation string, callbackUrl string, correlationId string, params schema.BookingStatusRequestParams) (Job, error) {
+	if err := validateCallbackUrl(callbackUrl); err != nil {
+		return Job{}, err
+	}
+
+	if params.ConfigurationRef == nil {
+		return Job{}, ErrorConfigurationRefRequired
+	}
+
+	params.Configuration = schema.BookingStatusRequestParams_Configuration{}
+
+	now := t.now().UTC()
+	job := Job{
+		Id:            uuid.New().String(),
+		Platform:      platform,
+		Operation:     operation,
+		CallbackUrl:   callbackUrl,
+		ClientId:      clientId,
+		CorrelationId: correlationId,
+		Params:        params,
+		State:         StatePolling,
+		Status:        schema.BookingStatusResponseStatusPENDING,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		NextAt:        now.Add(t.options.PollInterval),
+	}
+
+	return job, t.store.Save(ctx, job)
+}
+
+// TrackBooking tracks PENDING bookings with a callback url, nil trackers
+// track nothing
+func (t *Tracker) TrackBooking(ctx context.Context, platform string, clientId string, correlationId string, params schema.BookingRequestParams, response schema.BookingResponse, logger *zerolog.Logger) {
+	if t == nil || response.Status != schema.BookingResponseStatusPENDING || params.StatusCallbackUrl == nil {
+		return
+	}
+
+	bookingDateTime := t.now().UTC()
+	if params.BookingDateTime != nil {
+		bookingDateTime = *params.BookingDateTime
+	}
+
+	statusParams := schema.BookingStatusRequestParams{
+		BookingDateTime:          bookingDateTime,
+		BrokerReference:          params.BrokerReference,
+		ConfigurationRef:         params.ConfigurationRef,
+		Contact:                  &schema.Contact{Email: params.Customer.Email},
+		ModuleId:                 params.ModuleId,
+		ReservNumber:             params.ReservNumber,
+		SupplierBookingReference: converting.Unwrap(response.SupplierBookingReference),
+		Timeouts:                 params.Timeouts,
+	}
+
+	t.track(ctx, platform, clientId, OperationBooking, *params.StatusCallbackUrl, correlationId, statusParams, logger)
+}
+
+// TrackModify tracks PENDING modifications with a callback url, nil
+// trackers track nothing
+func (t *Tracker) TrackModify(ctx context.Context, platform string, clientId string, correlationId string, params schema.ModifyRequestParams, response schema.ModifyResponse, logger *zerolog.Logger) {
+	if t == nil || converting.Unwrap(response.Status) != schema.ModifyResponseStatusPENDING || params.StatusCallbackUrl == nil {
+		return
+	}
+
+	reference := params.SupplierBookingReference
+	if response.SupplierBookingReference != nil {
+		reference = *response.SupplierBookingReference
+	}
+
+	statusParams := schema.BookingStatusRequestParams{
+		BookingDateTime:          t.now().UTC(),
+		BrokerReference:          params.BrokerReference,
+		ConfigurationRef:         params.ConfigurationRef,
+		Contact:                  &schema.Contact{Email: params.Customer.Email},
+		ModuleId:                 params.ModuleId,
+		ReservNumber:             params.ReservNumber,
+		SupplierBookingReference: reference,
+		Timeouts:                 params.Timeouts,
+	}
+
+	t.track(ctx, platform, clientId, OperationModify, *params.StatusCallbackUrl, correlationId, statusParams, logger)
+}
+
+// track logs failures, the booking itself succeeded and is answered anyway
+func (t *Tracker) track(ctx context.Context, platform string, clientId string, operation string, callbackUrl string, correlationId string, params schema.BookingStatusRequestParams, logger *zerolog.Logger) {
+	job, err := t.Track(ctx, platform, clientId, operation, callbackUrl, correlationId, params)
+	if errors.Is(err, ErrorConfigurationRefRequired) {
+		logger.Warn().Str("reservNumber", params.ReservNumber).Msg("Not tracking pending booking with inline configuration")
+		return
+	}
+
+	if err != nil {
+		logger.Err(err).Str("reservNumber", params.ReservNumber).Msg("Failed tracking pending booking")
+		return
+	}
+
+	logger.Info().Str("trackingId", job.Id).Str("reservNumber", params.ReservNumber).Msg("Tracking pending booking")
+}
@@ -0,0 +1,337 @@
+package tracking_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/signing"
+	"bitbucket.org/crgw/supplier-hub/internal/tracking"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "callback-secret"
+
+var log = zerolog.Nop()
+
+// platform answers the statuses in order, the last one repeatedly
+type platform struct {
+	statuses []schema.BookingStatusResponse
+	polls    int
+	params   schema.BookingStatusRequestParams
+}
+
+func (p *platform) GetBookingStatus(ctx context.Context, params schema.BookingStatusRequestParams, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
+	p.params = params
+
+	status := p.statuses[len(p.statuses)-1]
+	if p.polls < len(p.statuses) {
+		status = p.statuses[p.polls]
+	}
+
+	p.polls++
+
+	return status, nil
+}
+
+type platforms map[string]any
+
+func (p platforms) GetPlatform(name string) (any, error) {
+	if platform, ok := p[name]; ok {
+		return platform, nil
+	}
+
+	return nil, errors.New("unknown platform")
+}
+
+// receiver accepts callbacks with a valid signature after failing the
+// first failures
+type receiver struct {
+	failures  int
+	callbacks []tracking.Callback
+	sync.Mutex
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	signature := signing.Sign(secret, req.Method, req.URL.RequestURI(), req.Header.Get(signing.TimestampHeader), body)
+
+	if signature != req.Header.Get(signing.SignatureHeader) || req.Header.Get(tracking.JobIdHeader) == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	var callback tracking.Callback
+	_ = json.Unmarshal(body, &callback)
+	r.callbacks = append(r.callbacks, callback)
+}
+
+func options() config.Tracking {
+	return config.Tracking{
+		Enabled:         true,
+		CallbackSecret:  secret,
+		PollInterval:    time.Millisecond,
+		MaxPollInterval: time.Millisecond,
+		MaxAge:          time.Hour,
+		MaxDeliveries:   3,
+		// the receiver listens on the loopback address
+		AllowPrivateCallbacks: true,
+	}
+}
+
+func status(status schema.BookingStatusResponseStatus, errs ...schema.SupplierResponseError) schema.BookingStatusResponse {
+	return schema.BookingStatusResponse{Status: status, Errors: &errs}
+}
+
+func booking(callbackUrl string) (schema.BookingRequestParams, schema.BookingResponse) {
+	params := schema.BookingRequestParams{
+		ReservNumber:      "R123",
+		BrokerReference:   "B123",
+		Customer:          schema.Customer{Email: "mock@example.com"},
+		StatusCallbackUrl: converting.PointerToValue(callbackUrl),
+		ConfigurationRef:  converting.PointerToValue("hertz-main"),
+	}
+
+	// the stored configuration was merged by the credentials middleware
+	_ = params.Configuration.UnmarshalJSON([]byte(`{"password":"secret"}`))
+
+	response := schema.BookingResponse{
+		Status:                   schema.BookingResponseStatusPENDING,
+		SupplierBookingReference: converting.PointerToValue("S123"),
+	}
+
+	return params, response
+}
+
+// runUntil processes due jobs until the tracker is idle
+func runUntil(t *testing.T, tracker *tracking.Tracker) {
+	for i := 0; i < 20; i++ {
+		time.Sleep(2 * time.Millisecond)
+		tracker.RunDue(context.Background(), &log)
+	}
+}
+
+// statusParams reference the stored configuration of newCredentialStore
+func statusParams(reservNumber string) schema.BookingStatusRequestParams {
+	return schema.BookingStatusRequestParams{
+		ReservNumber:     reservNumber,
+		Contact:          &schema.Contact{Email: "mock@example.com"},
+		ConfigurationRef: converting.PointerToValue("hertz-main"),
+	}
+}
+
+func newCredentialStore(t *testing.T, client redis.UniversalClient) *credentials.Store {
+	store, err := credentials.NewStore(credentials.NewRedisBackend(client), []byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, err)
+
+	err = store.Put(context.Background(), "hertz-main", credentials.Record{
+		Platform:      "hertz",
+		Configuration: json.RawMessage(`{"password":"secret"}`),
+	})
+	assert.Nil(t, err)
+
+	return store
+}
+
+func setup(t *testing.T, p *platform, o config.Tracking) (*tracking.Tracker, *tracking.Store, *receiver, *httptest.Server) {
+	redisServer := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	store := tracking.NewStore(client)
+
+	r := &receiver{}
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	return tracking.New(store, o, platforms{"hertz": p}, newCredentialStore(t, client)), store, r, server
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should poll pending bookings and deliver the final status", func(t *testing.T) {
+		p := &platform{statuses: []schema.BookingStatusResponse{
+			status(schema.BookingStatusResponseStatusPENDING),
+			status(schema.BookingStatusResponseStatusFAILED, schema.NewTimeoutError("timed out")),
+			status(schema.BookingStatusResponseStatusOK),
+		}}
+		tracker, _, r, server := setup(t, p, options())
+
+		params, response := booking(server.URL + "/callback?source=hub")
+		tracker.TrackBooking(ctx, "hertz", "", "correlation", params, response, &log)
+
+		runUntil(t, tracker)
+
+		assert.Equal(t, 3, p.polls)
+
+		configuration, _ := p.params.Configuration.MarshalJSON()
+		assert.JSONEq(t, `{"password":"secret"}`, string(configuration))
+		assert.Equal(t, []tracking.Callback{{
+			Platform:                 "hertz",
+			Operation:                tracking.OperationBooking,
+			ReservNumber:             "R123",
+			SupplierBookingReference: "S123",
+			Status:                   schema.BookingStatusResponseStatusOK,
+			Final:                    true,
+			Id:                       r.callbacks[0].Id,
+		}}, r.callbacks)
+	})
+
+	t.Run("should deliver supplier failures as final", func(t *testing.T) {
+		p := &platform{statuses: []schema.BookingStatusResponse{
+			status(schema.BookingStatusResponseStatusFAILED, schema.NewSupplierError("not found")),
+		}}
+		tracker, _, r, server := setup(t, p, options())
+
+		params, response := booking(server.URL)
+		tracker.TrackBooking(ctx, "hertz", "", "", params, response, &log)
+
+		runUntil(t, tracker)
+
+		assert.Equal(t, 1, p.polls)
+		assert.Len(t, r.callbacks, 1)
+		assert.Equal(t, schema.BookingStatusResponseStatusFAILED, r.callbacks[0].Status)
+		assert.Equal(t, "not found", r.callbacks[0].Errors[0].Message)
+	})
+
+	t.Run("should deliver the last status as not final after the max age", func(t *testing.T) {
+		o := options()
+		o.MaxAge = time.Millisecond
+		p := &platform{statuses: []schema.BookingStatusResponse{status(schema.BookingStatusResponseStatusPENDING)}}
+		tracker, _, r, server := setup(t, p, o)
+
+		params, response := booking(server.URL)
+		tracker.TrackBooking(ctx, "hertz", "", "", params, response, &log)
+
+		runUntil(t, tracker)
+
+		assert.Equal(t, 1, p.polls)
+		assert.Len(t, r.callbacks, 1)
+		assert.Equal(t, schema.BookingStatusResponseStatusPENDING, r.callbacks[0].Status)
+		assert.False(t, r.callbacks[0].Final)
+	})
+
+	t.Run("should retry callbacks and give up after the max deliveries", func(t *testing.T) {
+		p := &platform{statuses: []schema.BookingStatusResponse{status(schema.BookingStatusResponseStatusOK)}}
+		tracker, store, r, server := setup(t, p, options())
+		r.failures = 2
+
+		job, err := tracker.Track(ctx, "hertz", "", tracking.OperationBooking, server.URL, "", statusParams("R1"))
+		assert.Nil(t, err)
+
+		runUntil(t, tracker)
+
+		assert.Len(t, r.callbacks, 1)
+
+		job, _ = store.Get(ctx, job.Id)
+		assert.Equal(t, tracking.StateDelivered, job.State)
+		assert.Equal(t, 3, job.Deliveries)
+
+		r.failures = 3
+
+		job, _ = tracker.Track(ctx, "hertz", "", tracking.OperationBooking, server.URL, "", statusParams("R2"))
+
+		runUntil(t, tracker)
+
+		job, _ = store.Get(ctx, job.Id)
+		assert.Equal(t, tracking.StateFailed, job.State)
+		assert.Equal(t, "callback returned status code 502", job.LastError)
+		assert.Len(t, r.callbacks, 1)
+	})
+
+	t.Run("should continue persisted jobs with another tracker", func(t *testing.T) {
+		p := &platform{statuses: []schema.BookingStatusResponse{status(schema.BookingStatusResponseStatusCANCELLED)}}
+		client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+		credentialStore := newCredentialStore(t, client)
+		store := tracking.NewStore(client)
+
+		r := &receiver{}
+		server := httptest.NewServer(r)
+		t.Cleanup(server.Close)
+
+		tracker := tracking.New(store, options(), platforms{"hertz": p}, credentialStore)
+		job, _ := tracker.Track(ctx, "hertz", "", tracking.OperationModify, server.URL, "", statusParams("R1"))
+
+		restarted := tracking.New(store, options(), platforms{"hertz": p}, credentialStore)
+		runUntil(t, restarted)
+
+		assert.Len(t, r.callbacks, 1)
+		assert.Equal(t, job.Id, r.callbacks[0].Id)
+		assert.Equal(t, tracking.OperationModify, r.callbacks[0].Operation)
+	})
+
+	t.Run("should only track pending bookings with a valid callback url", func(t *testing.T) {
+		tracker, _, _, _ := setup(t, &platform{}, options())
+
+		_, err := tracker.Track(ctx, "hertz", "", tracking.OperationBooking, "/callback", "", statusParams("R1"))
+		assert.ErrorIs(t, err, tracking.ErrorInvalidCallbackUrl)
+
+		var disabled *tracking.Tracker
+		params, response := booking("http://localhost/callback")
+		disabled.TrackBooking(ctx, "hertz", "", "", params, response, &log)
+
+		assert.Equal(t, 0, tracker.RunDue(ctx, &log))
+	})
+	t.Run("should not deliver callbacks to private addresses", func(t *testing.T) {
+		o := options()
+		o.AllowPrivateCallbacks = false
+		o.MaxDeliveries = 1
+		p := &platform{statuses: []schema.BookingStatusResponse{status(schema.BookingStatusResponseStatusOK)}}
+		tracker, store, r, server := setup(t, p, o)
+
+		job, err := tracker.Track(ctx, "hertz", "", tracking.OperationBooking, server.URL, "", statusParams("R1"))
+		assert.Nil(t, err)
+
+		runUntil(t, tracker)
+
+		job, _ = store.Get(ctx, job.Id)
+		assert.Equal(t, tracking.StateFailed, job.State)
+		assert.Contains(t, job.LastError, tracking.ErrorPrivateCallbackAddress.Error())
+		assert.Empty(t, r.callbacks)
+	})
+
+	t.Run("should not keep supplier configuration", func(t *testing.T) {
+		tracker, store, _, server := setup(t, &platform{}, options())
+
+		params, response := booking(server.URL)
+		params.ConfigurationRef = nil
+
+		tracker.TrackBooking(ctx, "hertz", "", "", params, response, &log)
+		assert.Equal(t, 0, tracker.RunDue(ctx, &log))
+
+		_, err := tracker.Track(ctx, "hertz", "", tracking.OperationBooking, server.URL, "", schema.BookingStatusRequestParams{ReservNumber: "R1"})
+		assert.ErrorIs(t, err, tracking.ErrorConfigurationRefRequired)
+
+		resolved := statusParams("R1")
+		_ = resolved.Configuration.UnmarshalJSON([]byte(`{"password":"secret"}`))
+
+		job, err := tracker.Track(ctx, "hertz", "", tracking.OperationBooking, server.URL, "", resolved)
+		assert.Nil(t, err)
+
+		job, _ = store.Get(ctx, job.Id)
+		content, _ := json.Marshal(job)
+		assert.NotContains(t, string(content), "secret")
+		assert.Equal(t, "hertz-main", converting.Unwrap(job.Params.ConfigurationRef))
+	})
+}
@@ -0,0 +1,248 @@
+package tracking
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/interfaces"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/signing"
+	"github.com/rs/zerolog"
+)
+
+const callbackTimeout = 10 * time.Second
+
+type platforms interface {
+	GetPlatform(string) (any, error)
+}
+
+type Tracker struct {
+	store       *Store
+	options     config.Tracking
+	platforms   platforms
+	credentials *credentials.Store
+	client      *http.Client
+	now         func() time.Time
+}
+
+// New polls platforms of the factory, configuration references are resolved
+// with the credential store for each poll
+func New(store *Store, o config.Tracking, platforms platforms, credentialStore *credentials.Store) *Tracker {
+	return &Tracker{
+		store:       store,
+		options:     o,
+		platforms:   platforms,
+		credentials: credentialStore,
+		client:      callbackClient(o.AllowPrivateCallbacks),
+		now:         time.Now,
+	}
+}
+
+// callbackClient checks the address of each connection, callback urls come
+// from requests and their hosts may resolve to anything
+func callbackClient(allowPrivate bool) *http.Client {
+	if allowPrivate {
+		return &http.Client{Timeout: callbackTimeout}
+	}
+
+	dialer := &net.Dialer{Control: refusePrivate}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// a proxy would connect on behalf of the hub without the check
+	transport.Proxy = nil
+
+	return &http.Client{Timeout: callbackTimeout, Transport: transport}
+}
+
+// refusePrivate runs after name resolution, for the address actually dialed
+func refusePrivate(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+		return ErrorPrivateCallbackAddress
+	}
+
+	return nil
+}
+
+// Run processes due jobs until the context ends or shutdown starts, jobs
+// are registered with the lifecycle tracker so shutdown waits for them
+func (t *Tracker) Run(ctx context.Context, lifecycleTracker *lifecycle.Tracker, logger *zerolog.Logger) {
+	t.store.queue.Run(ctx, t.process, lifecycleTracker, logger)
+}
+
+// RunDue processes the jobs due now and returns how many
+func (t *Tracker) RunDue(ctx context.Context, logger *zerolog.Logger) int {
+	return t.store.queue.RunDue(ctx, t.process, logger)
+}
+
+func (t *Tracker) process(ctx context.Context, job Job, logger *zerolog.Logger) {
+	jobLogger := logger.With().
+		Str("trackingId", job.Id).
+		Str("platform", job.Platform).
+		Str("reservNumber", job.Params.ReservNumber).
+		Logger()
+
+	switch job.State {
+	case StatePolling:
+		t.poll(ctx, &job, &jobLogger)
+	case StateDelivering:
+		t.deliver(ctx, &job, &jobLogger)
+	}
+
+	job.UpdatedAt = t.now().UTC()
+
+	err := t.store.Save(ctx, job)
+	if err != nil {
+		jobLogger.Err(err).Msg("Failed saving tracking job")
+	}
+}
+
+func (t *Tracker) poll(ctx context.Context, job *Job, logger *zerolog.Logger) {
+	now := t.now().UTC()
+	job.Polls++
+
+	response, err := t.bookingStatus(ctx, *job, logger)
+	if err != nil {
+		logger.Err(err).Msg("Failed polling booking status")
+	} else {
+		job.Status = response.Status
+		job.Errors = converting.Unwrap(response.Errors)
+	}
+
+	switch {
+	case err == nil && final(response):
+		job.Final = true
+	case now.Sub(job.CreatedAt) < t.options.MaxAge:
+		job.NextAt = now.Add(backoff(t.options, job.Polls))
+		return
+	}
+
+	job.State = StateDelivering
+	job.NextAt = now
+}
+
+func (t *Tracker) bookingStatus(ctx context.Context, job Job, logger *zerolog.Logger) (schema.BookingStatusResponse, error) {
+	platform, err := t.platforms.GetPlatform(job.Platform)
+	if err != nil {
+		return schema.BookingStatusResponse{}, err
+	}
+
+	platformWithBookingStatus, ok := platform.(interfaces.WithBookingStatus)
+	if !ok {
+		return schema.BookingStatusResponse{}, fmt.Errorf("%s: booking status not implemented", job.Platform)
+	}
+
+	body, err := json.Marshal(job.Params)
+	if err != nil {
+		return schema.BookingStatusResponse{}, err
+	}
+
+	body, err = t.credentials.Resolve(ctx, job.Platform, job.ClientId, body)
+	if err != nil {
+		return schema.BookingStatusResponse{}, err
+	}
+
+	var params schema.BookingStatusRequestParams
+
+	err = json.Unmarshal(body, &params)
+	if err != nil {
+		return schema.BookingStatusResponse{}, err
+	}
+
+	return platformWithBookingStatus.GetBookingStatus(ctx, params, logger)
+}
+
+// final statuses end polling, failures by timeouts and connection errors
+// are polled again
+func final(response schema.BookingStatusResponse) bool {
+	switch response.Status {
+	case schema.BookingStatusResponseStatusPENDING:
+		return false
+	case schema.BookingStatusResponseStatusFAILED:
+		for _, e := range converting.Unwrap(response.Errors) {
+			if e.Code == schema.SupplierError {
+				return true
+			}
+		}
+
+		return len(converting.Unwrap(response.Errors)) == 0
+	}
+
+	return true
+}
+
+func (t *Tracker) deliver(ctx context.Context, job *Job, logger *zerolog.Logger) {
+	job.Deliveries++
+
+	err := t.post(ctx, *job)
+	if err == nil {
+		job.State = StateDelivered
+		job.LastError = ""
+		logger.Info().Str("status", string(job.Status)).Msg("Delivered booking status")
+		return
+	}
+
+	job.LastError = err.Error()
+
+	if job.Deliveries >= t.options.MaxDeliveries {
+		job.State = StateFailed
+		logger.Error().Err(err).Int("deliveries", job.Deliveries).Msg("Gave up delivering booking status")
+		return
+	}
+
+	job.NextAt = t.now().UTC().Add(backoff(t.options, job.Deliveries))
+}
+
+// post signs the callback like a request authenticated with HMAC
+func (t *Tracker) post(ctx context.Context, job Job) error {
+	body, err := json.Marshal(job.callback())
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, job.CallbackUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(t.now().Unix(), 10)
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(JobIdHeader, job.Id)
+	request.Header.Set(signing.TimestampHeader, timestamp)
+	request.Header.Set(signing.SignatureHeader, signing.Sign(t.options.CallbackSecret, http.MethodPost, request.URL.RequestURI(), timestamp, body))
+
+	if job.CorrelationId != "" {
+		request.Header.Set("x-correlation-id", job.CorrelationId)
+	}
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("callback returned status code %d", response.StatusCode)
+	}
+
+	return nil
+}
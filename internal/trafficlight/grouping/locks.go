@@ -0,0 +1,62 @@
+package grouping
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// heldLocks are grouping locks acquired by this process. Locks left behind on
+// shutdown would make other instances wait until the lock expires.
+var heldLocks = struct {
+	keys map[string]struct{}
+	sync.Mutex
+}{
+	keys: make(map[string]struct{}),
+}
+
+func lockHeld(cacheKey string) {
+	heldLocks.Lock()
+	heldLocks.keys[cacheKey] = struct{}{}
+	heldLocks.Unlock()
+}
+
+func lockReleased(cacheKey string) {
+	heldLocks.Lock()
+	delete(heldLocks.keys, cacheKey)
+	heldLocks.Unlock()
+}
+
+// ReleaseHeldLocks deletes all locks still held by this process and returns their keys
+func ReleaseHeldLocks(ctx context.Context, redisClient redis.UniversalClient) ([]string, error) {
+	heldLocks.Lock()
+	defer heldLocks.Unlock()
+
+	keys := make([]string, 0, len(heldLocks.keys))
+	for key := range heldLocks.keys {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	if len(keys) == 0 {
+		return keys, nil
+	}
+
+	// keys are deleted one by one, they may live in different cluster slots
+	pipe := redisClient.Pipeline()
+	for _, key := range keys {
+		pipe.Del(ctx, key)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return keys, err
+	}
+
+	heldLocks.keys = make(map[string]struct{})
+
+	return keys, nil
+}
@@ -30,11 +30,15 @@ type RequestManager interface {
 
 type MiddlewareOptions struct {
 	CreateManager func(
-		redis *redis.Client,
+		redis redis.UniversalClient,
 		log *zerolog.Logger,
 		cacheKey string,
 	) RequestManager
-	RedisClient *redis.Client
+	RedisClient redis.UniversalClient
+	// Available is optional, grouping is skipped while it reports false
+	Available func() bool
+	// ExposeRole answers with RoleHeader, it is left out otherwise
+	ExposeRole bool
 }
 
 func Middleware(o MiddlewareOptions) gin.HandlerFunc {
@@ -48,6 +52,12 @@ func Middleware(o MiddlewareOptions) gin.HandlerFunc {
 			return
 		}
 
+		if o.Available != nil && !o.Available() {
+			log.Warn().Str("label", "cache").Msg("TrafficLight redis unavailable, grouping skipped")
+			c.Next()
+			return
+		}
+
 		params := c.MustGet(platformMiddleware.ParamsKey).(*schema.RatesRequestParams)
 
 		cacheKey := service.TrafficLightGroupingCacheKey(c.Request.Context(), *params, log)
@@ -58,6 +68,10 @@ func Middleware(o MiddlewareOptions) gin.HandlerFunc {
 			bodyWriter := &bodyLogWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
 			c.Writer = bodyWriter
 
+			if o.ExposeRole {
+				c.Header(RoleHeader, RoleLead)
+			}
+
 			// expects rates handler to be called
 			c.Next()
 
@@ -86,6 +100,10 @@ func Middleware(o MiddlewareOptions) gin.HandlerFunc {
 				return
 			}
 
+			if !o.ExposeRole {
+				delete(response.Headers, http.CanonicalHeaderKey(RoleHeader))
+			}
+
 			for key, values := range response.Headers {
 				for _, value := range values {
 					c.Writer.Header().Add(key, value)
@@ -47,7 +47,7 @@ func TestGroupingMiddleware(t *testing.T) {
 		redisClient, _ := redismock.NewClientMock()
 
 		createManager := func(
-			redis *redis.Client,
+			redis redis.UniversalClient,
 			log *zerolog.Logger,
 			cacheKey string,
 		) grouping.RequestManager {
@@ -79,7 +79,7 @@ func TestGroupingMiddleware(t *testing.T) {
 		}
 
 		router.POST("/rates", grouping.Middleware(
-			grouping.MiddlewareOptions{CreateManager: createManager, RedisClient: redisClient},
+			grouping.MiddlewareOptions{CreateManager: createManager, RedisClient: redisClient, ExposeRole: true},
 		), handleRates)
 
 		reader := bytes.NewReader([]byte(""))
@@ -90,13 +90,14 @@ func TestGroupingMiddleware(t *testing.T) {
 		router.ServeHTTP(response, request)
 
 		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, grouping.RoleLead, response.Header().Get(grouping.RoleHeader))
 	})
 
 	t.Run("should provide from manager and not call the next handler", func(t *testing.T) {
 		redisClient, _ := redismock.NewClientMock()
 
 		createManager := func(
-			redis *redis.Client,
+			redis redis.UniversalClient,
 			log *zerolog.Logger,
 			cacheKey string,
 		) grouping.RequestManager {
@@ -104,7 +105,11 @@ func TestGroupingMiddleware(t *testing.T) {
 
 			return &groupingManagerMock{
 				handleRequestMock: func(ctx context.Context, requester func() (*grouping.Response, error)) (*grouping.Response, error) {
-					return &grouping.Response{Code: http.StatusOK, Body: "response from cache"}, nil
+					return &grouping.Response{
+						Code:    http.StatusOK,
+						Body:    "response from cache",
+						Headers: map[string][]string{http.CanonicalHeaderKey(grouping.RoleHeader): {grouping.RoleHit}},
+					}, nil
 				},
 			}
 		}
@@ -131,5 +136,49 @@ func TestGroupingMiddleware(t *testing.T) {
 		assert.NoError(t, err)
 
 		router.ServeHTTP(response, request)
+
+		assert.Equal(t, "response from cache", response.Body.String())
+		assert.Empty(t, response.Header().Get(grouping.RoleHeader))
+	})
+
+	t.Run("should skip grouping while redis is unavailable", func(t *testing.T) {
+		redisClient, _ := redismock.NewClientMock()
+
+		createManager := func(
+			redis redis.UniversalClient,
+			log *zerolog.Logger,
+			cacheKey string,
+		) grouping.RequestManager {
+			assert.Fail(t, "Should not group requests")
+			return nil
+		}
+
+		response := httptest.NewRecorder()
+
+		router := gin.Default()
+
+		router.Use(middleware.CorrelationId)
+		router.Use(middleware.RegisterLogger(&log))
+
+		router.Use(m.PreparePlatform(&factoryMock{}))
+		router.Use(m.PrepareParams(schema.RatesRequestParams{}))
+
+		router.POST("/rates", grouping.Middleware(
+			grouping.MiddlewareOptions{
+				CreateManager: createManager,
+				RedisClient:   redisClient,
+				Available:     func() bool { return false },
+			},
+		), func(c *gin.Context) {
+			c.String(http.StatusOK, "response from supplier")
+		})
+
+		request, err := http.NewRequest(http.MethodPost, "/rates", bytes.NewReader([]byte("")))
+		assert.NoError(t, err)
+
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "response from supplier", response.Body.String())
 	})
 }
@@ -3,9 +3,11 @@ package grouping
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"time"
 
 	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/converting"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
 	"github.com/google/uuid"
@@ -13,6 +15,17 @@ import (
 	"github.com/rs/zerolog"
 )
 
+const (
+	// RoleHeader tells how grouping answered a rates request
+	RoleHeader = "x-trafficlight-grouping"
+	// RoleLead requested the supplier and stored the response
+	RoleLead = "lead"
+	// RoleHit found a stored response right away
+	RoleHit = "hit"
+	// RoleWait found the stored response after waiting for the lead
+	RoleWait = "wait"
+)
+
 type Response struct {
 	Code    int
 	Headers map[string][]string
@@ -76,7 +89,7 @@ func (m *requestManager) requestSupplierAndStore(
 	return response, err
 }
 
-func (m *requestManager) requestOrWait(ctx context.Context, requester func() (*Response, error)) (*Response, error) {
+func (m *requestManager) requestOrWait(ctx context.Context, requester func() (*Response, error), waited bool) (*Response, error) {
 	select {
 	case <-ctx.Done():
 		return nil, context.Canceled
@@ -112,6 +125,14 @@ func (m *requestManager) requestOrWait(ctx context.Context, requester func() (*R
 
 		response.Headers["x-trafficlight-grouping-hit"] = []string{"hit"}
 
+		role := RoleHit
+		if waited {
+			role = RoleWait
+		}
+
+		// replaces the role stored with the response of the lead
+		response.Headers[http.CanonicalHeaderKey(RoleHeader)] = []string{role}
+
 		return &Response{
 			Code:    response.Code,
 			Body:    response.Body,
@@ -127,17 +148,17 @@ func (m *requestManager) requestOrWait(ctx context.Context, requester func() (*R
 
 	time.Sleep(400 * time.Millisecond)
 
-	return m.requestOrWait(ctx, requester)
+	return m.requestOrWait(ctx, requester, true)
 }
 
 func (m *requestManager) HandleRequest(ctx context.Context, requester func() (*Response, error)) (*Response, error) {
 	m.slowLog.Start("grouping:HandleRequest")
 	defer m.slowLog.Stop("grouping:HandleRequest")
-	return m.requestOrWait(ctx, requester)
+	return m.requestOrWait(ctx, requester, false)
 }
 
 func NewRequestManager(
-	redis *redis.Client,
+	redis redis.UniversalClient,
 	log *zerolog.Logger,
 	cacheKey string,
 ) RequestManager {
@@ -150,6 +171,7 @@ func NewRequestManager(
 		cacheKey:   cacheKey,
 		cache: &storage{
 			redis:   redis,
+			cache:   caching.NewRedisCache(redis),
 			log:     &logWithGroupingId,
 			slowLog: slowLog,
 		},
@@ -114,6 +114,7 @@ func TestGroupingManager(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, "response body from cache", response.Body)
 		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, []string{RoleHit}, response.Headers[http.CanonicalHeaderKey(RoleHeader)])
 	})
 
 	t.Run("should wait for other process to finish requesting", func(t *testing.T) {
@@ -146,6 +147,7 @@ func TestGroupingManager(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, response.Code)
 		assert.Equal(t, "response body from cache", response.Body)
+		assert.Equal(t, []string{RoleWait}, response.Headers[http.CanonicalHeaderKey(RoleHeader)])
 	})
 
 	t.Run("should start waiting on the cache, but acquires lock while doing it", func(t *testing.T) {
@@ -3,11 +3,11 @@ package grouping
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
 
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/slowlog"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
@@ -67,6 +67,42 @@ func TestCacheReleaseLock(t *testing.T) {
 	})
 }
 
+func TestReleaseHeldLocks(t *testing.T) {
+	redisClient, redisMock := redismock.NewClientMock()
+
+	storage := storage{
+		redis: redisClient,
+	}
+
+	t.Run("should release locks held on shutdown", func(t *testing.T) {
+		heldLocks.keys = make(map[string]struct{})
+
+		redisMock.ExpectSetNX("held-a", "", 1*time.Minute).SetVal(true)
+		redisMock.ExpectSetNX("held-b", "", 1*time.Minute).SetVal(true)
+		redisMock.ExpectSetNX("foreign", "", 1*time.Minute).SetVal(false)
+		redisMock.ExpectSetNX("released", "", 1*time.Minute).SetVal(true)
+		redisMock.ExpectDel("released").SetVal(1)
+
+		storage.AcquireLock(context.Background(), "held-a")
+		storage.AcquireLock(context.Background(), "held-b")
+		storage.AcquireLock(context.Background(), "foreign")
+		storage.AcquireLock(context.Background(), "released")
+		storage.ReleaseLock(context.Background(), "released")
+
+		redisMock.ExpectDel("held-a").SetVal(1)
+		redisMock.ExpectDel("held-b").SetVal(1)
+
+		keys, err := ReleaseHeldLocks(context.Background(), redisClient)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"held-a", "held-b"}, keys)
+		assert.Nil(t, redisMock.ExpectationsWereMet())
+
+		keys, _ = ReleaseHeldLocks(context.Background(), redisClient)
+		assert.Empty(t, keys)
+	})
+}
+
 func TestCacheFetchResponse(t *testing.T) {
 	out := &bytes.Buffer{}
 	log := zerolog.New(out)
@@ -75,16 +111,16 @@ func TestCacheFetchResponse(t *testing.T) {
 
 	storage := storage{
 		redis:   redisClient,
+		cache:   caching.NewRedisCache(redisClient),
 		log:     &log,
 		slowLog: slowLog,
 	}
 
 	t.Run("should fetch body from cache", func(t *testing.T) {
-		bytes, _ := json.Marshal(CachedValue{
+		compressed, _ := caching.Encode(caching.Deflate, CachedValue{
 			Code: http.StatusOK,
 			Body: "body",
 		})
-		compressed, _ := deflate(bytes)
 
 		redisMock.ExpectGet("responseKey").SetVal(string(compressed))
 		response, err := storage.FetchResponse(context.TODO(), "responseKey")
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"bitbucket.org/crgw/supplier-hub/internal/audit"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/web/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// auditedOperations change bookings at the supplier
+var auditedOperations = map[string]bool{
+	"booking": true,
+	"modify":  true,
+	"cancel":  true,
+}
+
+// auditWriter keeps a copy of the response, it runs inside SupplierHistory so
+// supplier requests are recorded before history modes rewrite them
+type auditWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// references are read from the request and the response, the response wins
+type references struct {
+	ReservNumber             string `json:"reservNumber"`
+	SupplierBookingReference string `json:"supplierBookingReference"`
+}
+
+// AuditLog appends booking, modify and cancel requests with their responses
+// to the audit log, nil logs record nothing. Failures are logged, the
+// response is not affected.
+func AuditLog(auditLog *audit.Log) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		scope, ok := platformScope(c)
+		if auditLog == nil || !ok || batchRoute(c) {
+			return
+		}
+
+		platform, operation, _ := strings.Cut(scope, ":")
+		if !auditedOperations[operation] {
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err != nil {
+			return
+		}
+
+		original := c.Writer
+		writer := &auditWriter{ResponseWriter: original}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = original
+
+		record := audit.Record{
+			Platform:      platform,
+			Operation:     operation,
+			CorrelationId: c.GetString("correlationId"),
+			StatusCode:    writer.Status(),
+			Request:       audit.Redact(body),
+			Response:      audit.Redact(writer.body.Bytes()),
+			StartedAt:     c.GetTime("requestStartTime").UTC(),
+		}
+
+		record.Duration = CurrentTimeFunc().Sub(record.StartedAt).Milliseconds()
+
+		if principal, ok := auth.FromContext(c); ok {
+			record.ClientId = principal.ClientId
+		}
+
+		var request, response references
+		_ = json.Unmarshal(body, &request)
+		_ = json.Unmarshal(writer.body.Bytes(), &response)
+
+		record.ReservNumber = request.ReservNumber
+		record.SupplierBookingReference = request.SupplierBookingReference
+
+		if response.SupplierBookingReference != "" {
+			record.SupplierBookingReference = response.SupplierBookingReference
+		}
+
+		err = auditLog.Append(c.Request.Context(), record)
+		if err != nil {
+			log := c.MustGet("logger").(*zerolog.Logger)
+			log.Err(err).Msg("Failed appending to the audit log")
+		}
+	}
+}
+
+// auditLog creates the configured log, nil when auditing is disabled
+func auditLog(cfg *config.Config) (*audit.Log, error) {
+	switch cfg.Audit.Backend {
+	case "":
+		return nil, nil
+	case config.BackendFile:
+		backend, err := audit.NewFileBackend(cfg.Audit.Path, int64(cfg.Audit.MaxFileSize))
+		if err != nil {
+			return nil, err
+		}
+
+		return audit.NewLog(backend), nil
+	default:
+		return nil, audit.ErrorUnknownBackend
+	}
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+)
+
+const (
+	ApiKeyHeader string = "x-api-key"
+
+	MethodApiKey = "api-key"
+)
+
+type apiKeyEntry struct {
+	digest []byte
+	client config.AuthClient
+}
+
+type apiKeyAuthenticator struct {
+	entries []apiKeyEntry
+}
+
+// NewApiKeyAuthenticator matches the x-api-key header against the key digests of the clients
+func NewApiKeyAuthenticator(clients []config.AuthClient) (Authenticator, error) {
+	a := &apiKeyAuthenticator{}
+
+	for _, client := range clients {
+		for _, digest := range client.ApiKeySha256 {
+			decoded, err := hex.DecodeString(strings.TrimSpace(digest))
+			if err != nil || len(decoded) != sha256.Size {
+				return nil, ErrorInvalid
+			}
+
+			a.entries = append(a.entries, apiKeyEntry{
+				digest: decoded,
+				client: client,
+			})
+		}
+	}
+
+	return a, nil
+}
+
+func (a *apiKeyAuthenticator) Authenticate(request *http.Request, body []byte) (*Principal, error) {
+	key := request.Header.Get(ApiKeyHeader)
+	if key == "" {
+		return nil, ErrorNoCredentials
+	}
+
+	digest := sha256.Sum256([]byte(key))
+
+	for _, entry := range a.entries {
+		if subtle.ConstantTimeCompare(entry.digest, digest[:]) == 1 {
+			return &Principal{
+				ClientId: entry.client.Id,
+				Method:   MethodApiKey,
+				Scopes:   entry.client.Scopes,
+			}, nil
+		}
+	}
+
+	return nil, ErrorInvalid
+}
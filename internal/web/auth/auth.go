@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const (
+	PrincipalKey string = "principal"
+
+	// ScopeAdmin grants pprof and admin routes, it is never matched by wildcards
+	ScopeAdmin = config.ScopeAdmin
+)
+
+var (
+	// ErrorNoCredentials is returned by authenticators when the request
+	// does not carry their kind of credentials
+	ErrorNoCredentials   = errors.New("no credentials")
+	ErrorInvalid         = errors.New("invalid credentials")
+	ErrorForbidden       = errors.New("missing scope")
+	errorUnauthenticated = errors.New("authentication required")
+)
+
+// Principal is the authenticated caller
+type Principal struct {
+	ClientId string
+	Method   string
+	Scopes   []string
+}
+
+// Authenticator checks one kind of credentials, the body is already read
+type Authenticator interface {
+	Authenticate(request *http.Request, body []byte) (*Principal, error)
+}
+
+// Requirement tells the middleware what a route needs, an empty scope
+// only requires an authenticated caller
+type Requirement struct {
+	Public bool
+	Scope  string
+}
+
+func matches(granted string, required string) bool {
+	if granted == "*" || granted == required {
+		return true
+	}
+
+	return false
+}
+
+// Allows matches the required scope against granted scopes,
+// platform and operation may be granted with the * wildcard
+func (p *Principal) Allows(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+
+		if scope == ScopeAdmin || granted == ScopeAdmin {
+			continue
+		}
+
+		grantedPlatform, grantedOperation, _ := strings.Cut(granted, ":")
+		platform, operation, _ := strings.Cut(scope, ":")
+
+		if matches(grantedPlatform, platform) && matches(grantedOperation, operation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func FromContext(ctx *gin.Context) (*Principal, bool) {
+	principal, ok := ctx.Get(PrincipalKey)
+	if !ok {
+		return nil, false
+	}
+
+	return principal.(*Principal), true
+}
+
+// Middleware tries the authenticators in order, the first one finding its kind
+// of credentials decides. The principal is added to the context and the logger,
+// the client id is also kept as "clientId" like the correlation id.
+func Middleware(requirement func(ctx *gin.Context) Requirement, authenticators ...Authenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		required := requirement(ctx)
+		if required.Public {
+			return
+		}
+
+		var body []byte
+		if ctx.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(ctx.Request.Body)
+			if err != nil {
+				middleware.HandleError(ctx, http.StatusBadRequest, "Failed reading request body", err)
+				ctx.Abort()
+				return
+			}
+
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		var principal *Principal
+
+		for _, authenticator := range authenticators {
+			found, err := authenticator.Authenticate(ctx.Request, body)
+			if errors.Is(err, ErrorNoCredentials) {
+				continue
+			}
+
+			if err != nil {
+				ctx.Header("WWW-Authenticate", "Bearer")
+				middleware.HandleError(ctx, http.StatusUnauthorized, "Invalid credentials", err)
+				ctx.Abort()
+				return
+			}
+
+			principal = found
+			break
+		}
+
+		if principal == nil {
+			ctx.Header("WWW-Authenticate", "Bearer")
+			middleware.HandleError(ctx, http.StatusUnauthorized, "Authentication required", errorUnauthenticated)
+			ctx.Abort()
+			return
+		}
+
+		if required.Scope != "" && !principal.Allows(required.Scope) {
+			middleware.HandleError(ctx, http.StatusForbidden, "Missing scope "+required.Scope, ErrorForbidden)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(PrincipalKey, principal)
+		ctx.Set("clientId", principal.ClientId)
+
+		if logger, ok := ctx.Get("logger"); ok {
+			requestLogger := logger.(*zerolog.Logger).
+				With().
+				Str("clientId", principal.ClientId).
+				Logger()
+
+			ctx.Set("logger", &requestLogger)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/signing"
+	"bitbucket.org/crgw/supplier-hub/internal/web/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func digest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+var clients = []config.AuthClient{
+	{
+		Id:           "rates-only",
+		ApiKeySha256: []string{digest("rates-key")},
+		Scopes:       []string{"*:rates"},
+	},
+	{
+		Id:         "hertz-booking",
+		HmacSecret: "hmac-secret",
+		Scopes:     []string{"hertz:*"},
+	},
+}
+
+func requirement(ctx *gin.Context) auth.Requirement {
+	switch ctx.FullPath() {
+	case "/status":
+		return auth.Requirement{Public: true}
+	case "/debug/pprof":
+		return auth.Requirement{Scope: auth.ScopeAdmin}
+	default:
+		return auth.Requirement{Scope: ctx.Param("platform") + ":" + strings.TrimPrefix(ctx.FullPath(), "/:platform/")}
+	}
+}
+
+// handledHeader is set by the handler, rejected requests must not reach it
+const handledHeader = "x-handled"
+
+func assertRejected(t *testing.T, response *httptest.ResponseRecorder, status int) {
+	assert.Equal(t, status, response.Code)
+	assert.Empty(t, response.Header().Get(handledHeader))
+}
+
+func setupRouter(t *testing.T, authenticators ...auth.Authenticator) *gin.Engine {
+	router := gin.New()
+	router.Use(auth.Middleware(requirement, authenticators...))
+
+	handler := func(ctx *gin.Context) {
+		if principal, ok := auth.FromContext(ctx); ok {
+			assert.Equal(t, principal.ClientId, ctx.GetString("clientId"))
+		}
+
+		ctx.Header(handledHeader, "true")
+		ctx.String(http.StatusOK, ctx.GetString("clientId"))
+	}
+
+	router.GET("/status", handler)
+	router.GET("/debug/pprof", handler)
+	router.POST("/:platform/rates", handler)
+	router.POST("/:platform/booking", handler)
+
+	return router
+}
+
+func serve(router *gin.Engine, request *http.Request) *httptest.ResponseRecorder {
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestScopes(t *testing.T) {
+	t.Run("should match wildcards per segment", func(t *testing.T) {
+		principal := auth.Principal{Scopes: []string{"*:rates", "hertz:*"}}
+
+		assert.True(t, principal.Allows("rently:rates"))
+		assert.True(t, principal.Allows("hertz:cancel"))
+		assert.False(t, principal.Allows("rently:booking"))
+		assert.False(t, principal.Allows(auth.ScopeAdmin))
+	})
+
+	t.Run("should not grant admin with wildcards", func(t *testing.T) {
+		principal := auth.Principal{Scopes: []string{"*:*"}}
+		assert.False(t, principal.Allows(auth.ScopeAdmin))
+
+		principal = auth.Principal{Scopes: []string{auth.ScopeAdmin}}
+		assert.True(t, principal.Allows(auth.ScopeAdmin))
+		assert.False(t, principal.Allows("hertz:rates"))
+	})
+}
+
+func TestApiKey(t *testing.T) {
+	apiKeys, err := auth.NewApiKeyAuthenticator(clients)
+	assert.Nil(t, err)
+
+	router := setupRouter(t, apiKeys)
+
+	t.Run("should leave public routes open", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/status", nil)
+		assert.Equal(t, http.StatusOK, serve(router, request).Code)
+	})
+
+	t.Run("should require credentials", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/hertz/rates", nil)
+		assertRejected(t, serve(router, request), http.StatusUnauthorized)
+	})
+
+	t.Run("should reject unknown keys", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/hertz/rates", nil)
+		request.Header.Set(auth.ApiKeyHeader, "unknown")
+		assertRejected(t, serve(router, request), http.StatusUnauthorized)
+	})
+
+	t.Run("should authorize by scope", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/hertz/rates", nil)
+		request.Header.Set(auth.ApiKeyHeader, "rates-key")
+
+		response := serve(router, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "rates-only", response.Body.String())
+
+		request, _ = http.NewRequest(http.MethodPost, "/hertz/booking", nil)
+		request.Header.Set(auth.ApiKeyHeader, "rates-key")
+		assertRejected(t, serve(router, request), http.StatusForbidden)
+
+		request, _ = http.NewRequest(http.MethodGet, "/debug/pprof", nil)
+		request.Header.Set(auth.ApiKeyHeader, "rates-key")
+		assertRejected(t, serve(router, request), http.StatusForbidden)
+	})
+}
+
+func TestHmac(t *testing.T) {
+	router := setupRouter(t, auth.NewHmacAuthenticator(clients, time.Minute))
+
+	signed := func(body string, timestamp time.Time, secret string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "/hertz/booking?trace=1", strings.NewReader(body))
+
+		unix := strconv.FormatInt(timestamp.Unix(), 10)
+		request.Header.Set(auth.ClientIdHeader, "hertz-booking")
+		request.Header.Set(signing.TimestampHeader, unix)
+		request.Header.Set(signing.SignatureHeader, signing.Sign(secret, http.MethodPost, "/hertz/booking?trace=1", unix, []byte(body)))
+
+		return request
+	}
+
+	t.Run("should accept signed requests and keep the body", func(t *testing.T) {
+		var received string
+
+		router := gin.New()
+		router.Use(auth.Middleware(requirement, auth.NewHmacAuthenticator(clients, time.Minute)))
+		router.POST("/:platform/booking", func(ctx *gin.Context) {
+			body, _ := ctx.GetRawData()
+			received = string(body)
+		})
+
+		response := serve(router, signed(`{"moduleId":1}`, time.Now(), "hmac-secret"))
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `{"moduleId":1}`, received)
+	})
+
+	t.Run("should reject wrong signatures", func(t *testing.T) {
+		response := serve(router, signed(`{"moduleId":1}`, time.Now(), "other-secret"))
+		assertRejected(t, response, http.StatusUnauthorized)
+	})
+
+	t.Run("should reject stale timestamps", func(t *testing.T) {
+		response := serve(router, signed(`{"moduleId":1}`, time.Now().Add(-time.Hour), "hmac-secret"))
+		assertRejected(t, response, http.StatusUnauthorized)
+	})
+}
+
+func TestJwt(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	jwks, _ := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kid": "main",
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		}},
+	})
+
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	assert.Nil(t, os.WriteFile(jwksPath, jwks, 0o600))
+
+	authenticator, err := auth.NewJwtAuthenticator(config.Jwt{
+		JwksPath: jwksPath,
+		Issuer:   "https://issuer.example",
+	})
+	assert.Nil(t, err)
+
+	router := setupRouter(t, authenticator)
+
+	token := func(claims jwt.MapClaims, kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+
+		signed, _ := token.SignedString(privateKey)
+		return signed
+	}
+
+	bearer := func(token string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "/rently/booking", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		return request
+	}
+
+	t.Run("should accept tokens signed by a known key", func(t *testing.T) {
+		response := serve(router, bearer(token(jwt.MapClaims{
+			"iss":   "https://issuer.example",
+			"sub":   "broker",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": "rently:rates rently:booking",
+		}, "main")))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "broker", response.Body.String())
+	})
+
+	t.Run("should reject expired tokens and tokens without expiry", func(t *testing.T) {
+		response := serve(router, bearer(token(jwt.MapClaims{
+			"iss":   "https://issuer.example",
+			"exp":   time.Now().Add(-time.Hour).Unix(),
+			"scope": "rently:booking",
+		}, "main")))
+		assertRejected(t, response, http.StatusUnauthorized)
+
+		response = serve(router, bearer(token(jwt.MapClaims{
+			"iss":   "https://issuer.example",
+			"scope": "rently:booking",
+		}, "main")))
+		assertRejected(t, response, http.StatusUnauthorized)
+	})
+
+	t.Run("should reject unknown keys and issuers", func(t *testing.T) {
+		response := serve(router, bearer(token(jwt.MapClaims{
+			"iss": "https://issuer.example",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}, "other")))
+		assertRejected(t, response, http.StatusUnauthorized)
+
+		response = serve(router, bearer(token(jwt.MapClaims{
+			"iss": "https://other.example",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}, "main")))
+		assertRejected(t, response, http.StatusUnauthorized)
+	})
+
+	t.Run("should authorize token scopes", func(t *testing.T) {
+		response := serve(router, bearer(token(jwt.MapClaims{
+			"iss": "https://issuer.example",
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"scp": []string{"rently:rates"},
+		}, "main")))
+
+		assertRejected(t, response, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/signing"
+)
+
+const (
+	ClientIdHeader string = "x-client-id"
+
+	MethodHmac = "hmac"
+
+	defaultMaxSkew = 5 * time.Minute
+)
+
+type hmacAuthenticator struct {
+	clients map[string]config.AuthClient
+	maxSkew time.Duration
+	now     func() time.Time
+}
+
+// NewHmacAuthenticator verifies requests signed with the client secret,
+// see signing.Sign for the signed content. Timestamps older than maxSkew are rejected.
+func NewHmacAuthenticator(clients []config.AuthClient, maxSkew time.Duration) Authenticator {
+	if maxSkew <= 0 {
+		maxSkew = defaultMaxSkew
+	}
+
+	a := &hmacAuthenticator{
+		clients: make(map[string]config.AuthClient),
+		maxSkew: maxSkew,
+		now:     time.Now,
+	}
+
+	for _, client := range clients {
+		if client.HmacSecret != "" {
+			a.clients[client.Id] = client
+		}
+	}
+
+	return a
+}
+
+func (a *hmacAuthenticator) Authenticate(request *http.Request, body []byte) (*Principal, error) {
+	signature := request.Header.Get(signing.SignatureHeader)
+	if signature == "" {
+		return nil, ErrorNoCredentials
+	}
+
+	client, ok := a.clients[request.Header.Get(ClientIdHeader)]
+	if !ok {
+		return nil, ErrorInvalid
+	}
+
+	timestamp := request.Header.Get(signing.TimestampHeader)
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timestamp", ErrorInvalid)
+	}
+
+	skew := a.now().Sub(time.Unix(seconds, 0))
+	if skew > a.maxSkew || skew < -a.maxSkew {
+		return nil, fmt.Errorf("%w: timestamp out of range", ErrorInvalid)
+	}
+
+	expected := signing.Sign(client.HmacSecret, request.Method, request.URL.RequestURI(), timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, fmt.Errorf("%w: signature", ErrorInvalid)
+	}
+
+	return &Principal{
+		ClientId: client.Id,
+		Method:   MethodHmac,
+		Scopes:   client.Scopes,
+	}, nil
+}
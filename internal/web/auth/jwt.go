@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	MethodJwt = "jwt"
+
+	jwtLeeway = 30 * time.Second
+)
+
+var errorUnknownKey = errors.New("unknown jwt key id")
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	ClientId string   `json:"client_id"`
+	Scope    string   `json:"scope"`
+	Scp      []string `json:"scp"`
+}
+
+type jwtAuthenticator struct {
+	keys    map[string]any
+	options []jwt.ParserOption
+}
+
+func decodeInt(value string) (*big.Int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(decoded), nil
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+// NewJwtAuthenticator loads the signing keys once, RS* and ES* tokens with
+// an expiry are accepted. Scopes come from the space separated scope claim or scp.
+func NewJwtAuthenticator(o config.Jwt) (Authenticator, error) {
+	content, err := os.ReadFile(o.JwksPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	err = json.Unmarshal(content, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", o.JwksPath, err)
+	}
+
+	a := &jwtAuthenticator{
+		keys: make(map[string]any),
+		options: []jwt.ParserOption{
+			jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+			jwt.WithLeeway(jwtLeeway),
+		},
+	}
+
+	for _, key := range jwks.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %s: %w", o.JwksPath, key.Kid, err)
+		}
+
+		a.keys[key.Kid] = publicKey
+	}
+
+	if o.Issuer != "" {
+		a.options = append(a.options, jwt.WithIssuer(o.Issuer))
+	}
+
+	if o.Audience != "" {
+		a.options = append(a.options, jwt.WithAudience(o.Audience))
+	}
+
+	return a, nil
+}
+
+func (a *jwtAuthenticator) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := a.keys[kid]
+	if !ok {
+		return nil, errorUnknownKey
+	}
+
+	return key, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(request *http.Request, body []byte) (*Principal, error) {
+	header := request.Header.Get("Authorization")
+
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, ErrorNoCredentials
+	}
+
+	raw := strings.TrimPrefix(header, "Bearer ")
+
+	var claims jwtClaims
+
+	_, err := jwt.ParseWithClaims(strings.TrimSpace(raw), &claims, a.key, a.options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorInvalid, err)
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: token without expiry", ErrorInvalid)
+	}
+
+	principal := &Principal{
+		ClientId: claims.ClientId,
+		Method:   MethodJwt,
+		Scopes:   claims.Scp,
+	}
+
+	if principal.ClientId == "" {
+		principal.ClientId = claims.Subject
+	}
+
+	if claims.Scope != "" {
+		principal.Scopes = append(principal.Scopes, strings.Fields(claims.Scope)...)
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,75 @@
+package web
+
+import (
+	"strings"
+
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/web/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// platformScope returns <platform>:<operation> for platform routes
+func platformScope(ctx *gin.Context) (string, bool) {
+	path := ctx.FullPath()
+	if !strings.HasPrefix(path, "/:platform/") {
+		return "", false
+	}
+
+	// batches need the scope of the operation they run
+	operation := strings.TrimSuffix(strings.TrimPrefix(path, "/:platform/"), "/batch")
+
+	return ctx.Param("platform") + ":" + operation, true
+}
+
+// batchRoute runs an operation for many items
+func batchRoute(ctx *gin.Context) bool {
+	return strings.HasPrefix(ctx.FullPath(), "/:platform/") && strings.HasSuffix(ctx.FullPath(), "/batch")
+}
+
+// requirement maps routes to scopes, platform routes need <platform>:<operation>.
+// Unknown routes need authentication so they can not be probed anonymously.
+func requirement(ctx *gin.Context) auth.Requirement {
+	path := ctx.FullPath()
+
+	if scope, ok := platformScope(ctx); ok {
+		return auth.Requirement{Scope: scope}
+	}
+
+	switch {
+	case path == "/status" || strings.HasPrefix(path, "/health/"):
+		return auth.Requirement{Public: true}
+	case path == "/metrics" || strings.HasPrefix(path, "/debug/") || strings.HasPrefix(path, "/admin/"):
+		return auth.Requirement{Scope: auth.ScopeAdmin}
+	default:
+		return auth.Requirement{}
+	}
+}
+
+// Authentication checks api keys, signed requests and bearer tokens,
+// all routes are open while auth is disabled
+func Authentication(cfg config.Auth) (func(c *gin.Context), error) {
+	if !cfg.Enabled {
+		return func(c *gin.Context) {}, nil
+	}
+
+	apiKeys, err := auth.NewApiKeyAuthenticator(cfg.Clients)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticators := []auth.Authenticator{
+		apiKeys,
+		auth.NewHmacAuthenticator(cfg.Clients, cfg.HmacMaxSkew),
+	}
+
+	if cfg.Jwt.JwksPath != "" {
+		jwt, err := auth.NewJwtAuthenticator(cfg.Jwt)
+		if err != nil {
+			return nil, err
+		}
+
+		authenticators = append(authenticators, jwt)
+	}
+
+	return auth.Middleware(requirement, authenticators...), nil
+}
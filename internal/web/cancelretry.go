@@ -0,0 +1,32 @@
+package web
+
+import (
+	"bitbucket.org/crgw/supplier-hub/internal/cancelretry"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"bitbucket.org/crgw/supplier-hub/internal/history"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/factory"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+)
+
+// cancelRetryStore creates the store of cancel retries, nil when retries are disabled
+func cancelRetryStore(cfg *config.Config, redisFactory *redisfactory.Factory) (*cancelretry.Store, error) {
+	if !cfg.CancelRetry.Enabled {
+		return nil, nil
+	}
+
+	client, err := redisFactory.Client(redisfactory.CancelRetry)
+	if err != nil {
+		return nil, err
+	}
+
+	return cancelretry.NewStore(client), nil
+}
+
+func cancelRetrier(cfg *config.Config, store *cancelretry.Store, platformFactory *factory.Factory, credentialStore *credentials.Store, historyStore *history.Store) *cancelretry.Retrier {
+	if store == nil {
+		return nil
+	}
+
+	return cancelretry.New(store, cfg.CancelRetry, platformFactory, credentialStore, historyStore)
+}
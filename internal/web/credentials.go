@@ -0,0 +1,97 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"github.com/gin-gonic/gin"
+)
+
+// ResolveConfiguration merges stored supplier configuration into platform requests
+// before they are validated, a nil store rejects requests with a configurationRef.
+// Only configuration stored for the authenticated client is resolved.
+func ResolveConfiguration(store *credentials.Store) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodPost || !strings.HasPrefix(c.FullPath(), "/:platform/") {
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			middleware.HandleError(c, http.StatusBadRequest, "Failed reading request body", err)
+			c.Abort()
+			return
+		}
+
+		resolve := store.Resolve
+		if batchRoute(c) {
+			resolve = store.ResolveItems
+		}
+
+		resolved, err := resolve(c.Request.Context(), c.Param("platform"), c.GetString("clientId"), body)
+		if errors.Is(err, credentials.ErrorClientMismatch) {
+			middleware.HandleError(c, http.StatusForbidden, err.Error(), err)
+			c.Abort()
+			return
+		}
+
+		if err != nil {
+			if errors.Is(err, credentials.ErrorNotFound) ||
+				errors.Is(err, credentials.ErrorInvalidRef) ||
+				errors.Is(err, credentials.ErrorPlatformMismatch) ||
+				errors.Is(err, credentials.ErrorNotAnObject) ||
+				errors.Is(err, credentials.ErrorDisabled) {
+				middleware.HandleError(c, http.StatusBadRequest, err.Error(), err)
+				c.Abort()
+				return
+			}
+
+			middleware.HandleError(c, http.StatusInternalServerError, "Failed resolving configuration reference", err)
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(resolved))
+		c.Request.ContentLength = int64(len(resolved))
+	}
+}
+
+// credentialStore creates the configured store, nil when references are disabled
+func credentialStore(cfg *config.Config, redisFactory *redisfactory.Factory) (*credentials.Store, error) {
+	var backend credentials.Backend
+
+	switch cfg.Credentials.Backend {
+	case "":
+		return nil, nil
+	case config.BackendFile:
+		fileBackend, err := credentials.NewFileBackend(cfg.Credentials.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		backend = fileBackend
+	case config.BackendRedis:
+		client, err := redisFactory.Client(redisfactory.Credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		backend = credentials.NewRedisBackend(client)
+	default:
+		return nil, credentials.ErrorUnknownBackend
+	}
+
+	key, err := cfg.Credentials.DecodeKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewStore(backend, key)
+}
@@ -0,0 +1,53 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/requesting"
+	"github.com/gin-gonic/gin"
+)
+
+// InjectFaults attaches the active faults and the ones named by the
+// requesting.FaultHeader header to platform requests, the supplier
+// requests they make are broken accordingly. It is not registered in production.
+func InjectFaults(faults config.Faults) func(c *gin.Context) {
+	available := faults.Available()
+
+	return func(c *gin.Context) {
+		if _, ok := platformScope(c); !ok {
+			return
+		}
+
+		names := append([]string{}, faults.Active...)
+
+		for _, name := range strings.Split(c.GetHeader(requesting.FaultHeader), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+
+		if len(names) == 0 {
+			return
+		}
+
+		selected := make([]requesting.Fault, 0, len(names))
+
+		for _, name := range names {
+			fault, ok := available[name]
+			if !ok {
+				err := fmt.Errorf("%w %q", requesting.ErrorUnknownFault, name)
+				middleware.HandleError(c, http.StatusBadRequest, err.Error(), err)
+				c.Abort()
+				return
+			}
+
+			selected = append(selected, fault)
+		}
+
+		c.Request = c.Request.WithContext(requesting.WithFaults(c.Request.Context(), c.Param("platform"), selected))
+	}
+}
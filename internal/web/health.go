@@ -0,0 +1,50 @@
+package web
+
+import (
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/health"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/client/spit"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/client/userservice"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+)
+
+// healthChecker checks dependencies shared by all platforms
+func healthChecker(cfg *config.Config, redisFactory *redisfactory.Factory, tracker *lifecycle.Tracker) (*health.Checker, error) {
+	optional := make(map[string]bool)
+	for _, name := range cfg.Health.Optional {
+		optional[name] = true
+	}
+
+	checks := []health.Check{
+		{
+			Name:  redisfactory.Trafficlight,
+			Probe: health.RedisProbe(redisFactory, redisfactory.Trafficlight),
+		},
+		{
+			Name:  redisfactory.ResponsesCache,
+			Probe: health.RedisProbe(redisFactory, redisfactory.ResponsesCache),
+		},
+		{
+			Name:  "userservice",
+			Probe: health.HttpProbe(userservice.BaseURL(cfg.Services.UserServiceOptions()...)),
+		},
+		{
+			Name:  "spit",
+			Probe: health.HttpProbe(spit.BaseURL(cfg.Services.SpitOptions()...)),
+		},
+	}
+
+	for i := range checks {
+		checks[i].Required = !optional[checks[i].Name]
+	}
+
+	supplierChecks, err := health.SupplierChecks(cfg.Health.SupplierProbes)
+	if err != nil {
+		return nil, err
+	}
+
+	return health.NewChecker(0, checks...).
+		WithSupplierChecks(supplierChecks...).
+		WithDraining(tracker.Draining), nil
+}
@@ -0,0 +1,245 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/history"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/schema/har"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"bitbucket.org/crgw/supplier-hub/internal/web/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// historyWriter holds the response back so supplierRequests can be rewritten,
+// it reports the response as written so grouping does not write it again
+type historyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *historyWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *historyWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *historyWriter) WriteHeaderNow() {}
+
+func (w *historyWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+func (w *historyWriter) Size() int {
+	if w.body.Len() == 0 {
+		return -1
+	}
+
+	return w.body.Len()
+}
+
+// acceptsHar negotiates HAR output, JSON stays the default for */* and missing Accept headers
+func acceptsHar(c *gin.Context) bool {
+	return c.NegotiateFormat(gin.MIMEJSON, har.MimeType) == har.MimeType
+}
+
+func historyMode(c *gin.Context, fallback schema.HistoryMode) (schema.HistoryMode, error) {
+	value := c.GetHeader(history.ModeHeader)
+	if value == "" {
+		value = c.Query(history.ModeQuery)
+	}
+
+	return history.ParseMode(value, fallback)
+}
+
+// OmitTimings leaves durations and start times out of the supplier requests of
+// platform responses, it is registered in test mode to keep them deterministic
+func OmitTimings(c *gin.Context) {
+	c.Request = c.Request.WithContext(schema.WithoutTimings(c.Request.Context()))
+}
+
+// SupplierHistory applies the requested history mode to platform responses,
+// full responses are passed through untouched. Callers accepting HAR get
+// the supplier requests as HTTP archive instead of the response. Streamed
+// batch responses are left alone.
+func SupplierHistory(store *history.Store, fallback schema.HistoryMode) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		scope, ok := platformScope(c)
+		if !ok || batchRoute(c) {
+			return
+		}
+
+		mode, err := historyMode(c, fallback)
+		if err != nil {
+			middleware.HandleError(c, http.StatusBadRequest, err.Error(), err)
+			c.Abort()
+			return
+		}
+
+		archive := acceptsHar(c)
+		if archive {
+			mode = schema.HistoryModeFull
+		}
+
+		if mode == schema.HistoryModeFull && !archive {
+			return
+		}
+
+		if mode == schema.HistoryModeOffload && store == nil {
+			middleware.HandleError(c, http.StatusBadRequest, history.ErrorDisabled.Error(), history.ErrorDisabled)
+			c.Abort()
+			return
+		}
+
+		original := c.Writer
+		writer := &historyWriter{ResponseWriter: original}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = original
+
+		var body []byte
+
+		if archive {
+			body, err = archiveHistory(c, writer.body.Bytes())
+		} else {
+			body, err = rewriteHistory(c, store, mode, scope, writer.body.Bytes())
+		}
+
+		if err != nil {
+			log := c.MustGet("logger").(*zerolog.Logger)
+			log.Err(err).Msg("Failed applying history mode, full history returned")
+			body = writer.body.Bytes()
+		}
+
+		c.Writer.Header().Del("Content-Length")
+		c.Writer.WriteHeaderNow()
+		_, _ = c.Writer.Write(body)
+	}
+}
+
+// archiveHistory converts the supplier requests of the response, responses
+// without them, errors for example, are returned as they are
+func archiveHistory(c *gin.Context, body []byte) ([]byte, error) {
+	requests, err := har.Parse(body)
+	if err != nil {
+		return body, nil
+	}
+
+	c.Writer.Header().Set("Content-Type", har.MimeType)
+
+	return json.Marshal(har.FromSupplierRequests(requests))
+}
+
+func rewriteHistory(c *gin.Context, store *history.Store, mode schema.HistoryMode, scope string, body []byte) ([]byte, error) {
+	var response map[string]json.RawMessage
+
+	if json.Unmarshal(body, &response) != nil || response["supplierRequests"] == nil {
+		return body, nil
+	}
+
+	var requests schema.SupplierRequests
+
+	err := json.Unmarshal(response["supplierRequests"], &requests)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(response, "supplierRequests")
+
+	switch mode {
+	case schema.HistoryModeMetadata:
+		response["supplierRequests"], err = json.Marshal(history.Metadata(requests))
+
+	case schema.HistoryModeOffload:
+		platform, operation, _ := strings.Cut(scope, ":")
+
+		record := history.Record{
+			History: schema.SupplierHistory{
+				Platform:         platform,
+				Operation:        operation,
+				SupplierRequests: requests,
+			},
+		}
+
+		if correlationId := c.GetString("correlationId"); correlationId != "" {
+			record.History.CorrelationId = &correlationId
+		}
+
+		if principal, ok := auth.FromContext(c); ok {
+			record.ClientId = principal.ClientId
+		}
+
+		var id string
+
+		id, err = store.Put(c.Request.Context(), record)
+		if err == nil {
+			response["historyId"], err = json.Marshal(id)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(response)
+}
+
+// getHistory returns offloaded history to the client that created it
+func getHistory(store *history.Store) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		record, err := store.Get(c.Request.Context(), c.Param("id"))
+
+		if principal, ok := auth.FromContext(c); ok && err == nil && record.ClientId != principal.ClientId {
+			err = history.ErrorNotFound
+		}
+
+		switch {
+		case err == nil && acceptsHar(c):
+			archive, _ := json.Marshal(har.FromSupplierRequests(record.History.SupplierRequests))
+			c.Data(http.StatusOK, har.MimeType, archive)
+		case err == nil:
+			c.JSON(http.StatusOK, record.History)
+		case errors.Is(err, history.ErrorNotFound) || errors.Is(err, history.ErrorInvalidId):
+			middleware.HandleError(c, http.StatusNotFound, "History not found", err)
+		case errors.Is(err, history.ErrorDisabled):
+			middleware.HandleError(c, http.StatusNotFound, err.Error(), err)
+		default:
+			middleware.HandleError(c, http.StatusInternalServerError, "Failed loading history", err)
+		}
+	}
+}
+
+// historyStore creates the configured store, nil when offloading is disabled
+func historyStore(cfg *config.Config, redisFactory *redisfactory.Factory) (*history.Store, error) {
+	switch cfg.History.Backend {
+	case "":
+		return nil, nil
+	case config.BackendFile:
+		backend, err := history.NewFileBackend(cfg.History.Path, cfg.History.Retention)
+		if err != nil {
+			return nil, err
+		}
+
+		return history.NewStore(backend), nil
+	case config.BackendRedis:
+		client, err := redisFactory.Client(redisfactory.History)
+		if err != nil {
+			return nil, err
+		}
+
+		return history.NewStore(history.NewRedisBackend(client, cfg.History.Retention)), nil
+	default:
+		return nil, history.ErrorUnknownBackend
+	}
+}
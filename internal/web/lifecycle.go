@@ -0,0 +1,14 @@
+package web
+
+import (
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"github.com/gin-gonic/gin"
+)
+
+// Lifecycle middleware adds the tracker to the request context,
+// platforms use it to track work that outlives the handler
+func Lifecycle(tracker *lifecycle.Tracker) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(lifecycle.WithTracker(c.Request.Context(), tracker))
+	}
+}
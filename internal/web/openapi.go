@@ -17,19 +17,26 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func OpenapiValidator() gin.HandlerFunc {
+// OpenapiValidator validates requests by the specification, the remote
+// platforms of the configuration are accepted next to the platforms in it
+func OpenapiValidator(remotePlatforms []string) gin.HandlerFunc {
 	spec, err := spec.GetSwagger() // auto-generated by oapi-codegen
 	if err != nil {
 		panic(err)
 	}
 
+	platform := spec.Components.Parameters["requiredPlatformInPath"].Value.Schema.Value
+	for _, name := range remotePlatforms {
+		platform.Enum = append(platform.Enum, name)
+	}
+
 	err = spec.Validate(context.Background())
 	if err != nil {
 		panic(err)
 	}
 
 	return func(c *gin.Context) {
-		if strings.HasPrefix(c.Request.URL.Path, "/debug") {
+		if strings.HasPrefix(c.Request.URL.Path, "/debug") || strings.HasPrefix(c.Request.URL.Path, "/admin") || c.Request.URL.Path == "/metrics" {
 			return
 		}
 
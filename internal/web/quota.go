@@ -0,0 +1,82 @@
+package web
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"bitbucket.org/crgw/service-helpers/middleware"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/quota"
+	"bitbucket.org/crgw/supplier-hub/internal/web/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// anonymousClient shares one quota between callers while auth is disabled
+const anonymousClient = "anonymous"
+
+type platforms interface {
+	GetPlatform(string) (any, error)
+}
+
+// Quota admits platform requests within the limits of the calling client,
+// rejected requests get 429 with Retry-After in seconds. Batches are not
+// admitted themselves, their items wait for the quota. Unknown platforms are
+// left to PreparePlatform so they do not add limiter state.
+func Quota(limiter *quota.Limiter, platforms platforms) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if limiter == nil {
+			return
+		}
+
+		scope, ok := platformScope(c)
+		if !ok {
+			return
+		}
+
+		if _, err := platforms.GetPlatform(c.Param("platform")); err != nil {
+			return
+		}
+
+		client := anonymousClient
+		if principal, ok := auth.FromContext(c); ok {
+			client = principal.ClientId
+		}
+
+		c.Set(quota.ContextKey, quota.Acquire(func(scope string) (func(), error) {
+			return limiter.Acquire(client, scope)
+		}))
+
+		// batches acquire per item
+		if batchRoute(c) {
+			return
+		}
+
+		release, err := limiter.Acquire(client, scope)
+		if err != nil {
+			var rejection *quota.Rejection
+			if errors.As(err, &rejection) {
+				retryAfter := math.Max(1, math.Ceil(rejection.RetryAfter.Seconds()))
+				c.Header("Retry-After", strconv.Itoa(int(retryAfter)))
+			}
+
+			middleware.HandleError(c, http.StatusTooManyRequests, "Quota exceeded", err)
+			c.Abort()
+			return
+		}
+
+		defer release()
+
+		c.Next()
+	}
+}
+
+// QuotaMetrics serves the limiter state to Prometheus
+func QuotaMetrics(limiter *quota.Limiter) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4")
+		c.Status(http.StatusOK)
+
+		_ = limiter.WriteMetrics(c.Writer)
+	}
+}
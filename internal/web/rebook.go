@@ -0,0 +1,29 @@
+package web
+
+import (
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/rebook"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+)
+
+// rebookStore creates the store of rebookings, nil when rebooking is disabled
+func rebookStore(cfg *config.Config, redisFactory *redisfactory.Factory) (*rebook.Store, error) {
+	if !cfg.Rebook.Enabled {
+		return nil, nil
+	}
+
+	client, err := redisFactory.Client(redisfactory.Rebook)
+	if err != nil {
+		return nil, err
+	}
+
+	return rebook.NewStore(client, cfg.Rebook.Retention), nil
+}
+
+func rebooker(store *rebook.Store) *rebook.Rebooker {
+	if store == nil {
+		return nil
+	}
+
+	return rebook.New(store)
+}
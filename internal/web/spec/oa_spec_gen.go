@@ -18,120 +18,161 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+w9/VPbSLL/ypT2qjapEgSS3btdfnMMJL4N4AVv9t0Cb2uQ2vZcJI12ZgT45fG/v5ov",
-	"SZZ6bAMhL6nLTwme7+6e/p7WxyjheckLKJSM9j5GJRU0BwXC/CXgr4oJSMcZVVMu8lExpmquW1KQiWCl",
-	"YryI9iLfTkb7URwx/VOpO8ZRQXPQf7kOUVzPGe0pUUEcyWQOOdVzQlHl0d55NAeh/ieKo1LwKVM5vU3n",
-	"KoqjK84/sGKWcD0LLRYCCmXmK1S2iC7jKGfFOyhmeoO7caQWpV5ZKsGKWXR3d+eXMicbzOCUFjMwZxa8",
-	"BKEYmJYko1La/7op+NW/IVHRXRwlPIVWg587jnJ62/qdFQpmIEyDBgbWwBUdLs9WN97F/ZUHxeIUCjXk",
-	"xZTNKkEt4Lt4OKvKMmMgiCwhYVOWkAafZMoFoVlGSi7Vll6AaFSAVJLAbQKlIg7C2+TkGoRgKRBWEOnn",
-	"zHlaZbA8jeCVgu54i7oWSGnJfoFFf7uDkpEPsIjiPkD9qoOS/SayFSelJSOVyPpz3LVJ7bw7Yew3dYkA",
-	"+7U9x5DnXym8p1OWMarAU1gH7L6ZGHpGoF9SKW+4SJGb7lueBGfNHMeGbwRn0GwlJjdzlswJk6SSkBpA",
-	"TVmmQM9FeKXINcxZkoHEFlIgVX+BCUilAd+CyhXnGdBCj6kkiALd2G++5d5kWDUjywa2yxjsgGUFyR7c",
-	"KkFPxCEgjI1eU5bRK5YxZe7i3wRMo73ouxeNBHjhOOQLM8+gPaDF/QI4AT0mSFJMjookq1JAiGokCXON",
-	"+gYIqnD4M3lEi5QqLhboJHndio3+q6KFcmdfHvqrb4kRRm1/6RGK/jWuRZaBVxRHGvCX62igDaIVuDy1",
-	"3GKsOYpE0MlExgoYhkSSYw77VMGEWZrVEpiqaC9KqYItxXIUU1eCfwBxClMQUCTI2YdUEC2MaEbeUAU3",
-	"dOE50Qs7loh6MDJ/QoWnmTFd5FCosWB2nVUkaToNcl4VasUsWkMxfw0roXewuO+0PM9rXWhZL6ik4jkI",
-	"hJ+SHKSkMyBTwXPiOxLFa2aO8gVEvehIG1osTqbR3vnqI7zV+tKypLqL151aq1ZH9Hb/7eR+I0Oycd04",
-	"VIFZN+jU6HadMZcWUkrQRK3D7tD302NaCFw5xve7i6NU8PJkOl03xF3V14IWyfx3pub6xv3BC9BzGL4o",
-	"B0V6CJaSmAJ7oTeAc4uhNxRDhaCGI08zNpurY96nyUPTQooqv8Korx5q2+8/3ConI4SZH1m1xVgCiNpb",
-	"aKZ1CHBEy/7YE9NKpgAkp2WEXJGSJR9+Kx+FD2FY1z5dyP4GHFtLdSO2fQESxHUIamHGSOxAQ8EroCpr",
-	"jialmgtezebrjnqGDGnNdEoVrGDltfDWMrdh25aR6d+kV1pXbffMab+jwkoYx7022TY2VBM6y4FXau0t",
-	"mfh+d3HktL2htt/6J31vW4kx75b0bLmQCvK1mpujvIYntLhQZ/EQ+Dv00xe1LSbVumJdwdACz2VbXZAl",
-	"LySi+IEQXMhNMeLnObCjNJoVVRUCUrcwce2NNnTyi1aFBqN3B5oJjA+O90fHbxC9qIFTfYb1lNrcqBU6",
-	"hp93nyq6idmWUkUJvdJGg5pDvQaV5J9nJ8fETrxNJnMmbV8miVRcQEpumJo3A4qUaDUeUj3Wok1U9tp3",
-	"TMOEFglksbY22HRhRrpZLDxraxFjgzV9+T4bI9f179klFocr1NEz02ONUvrlKp3fVKt7qFYbalZOsXqo",
-	"LvCZhOmn5i33l00BH0BvYxvIh+4Fu7eY8Nf4CxcWcTQcHA8P3ulf/z8Ex+dgrEMjAb5ajup2TyXmGbVn",
-	"I8I0k2fXNKvA+naKGZCX2jbe/fE5yha+seovj1U/wOz6T+bwta3wGFbvsbs5i/cM5XPz9t+pNGqzZQmZ",
-	"RZuskgSknFbZdoDbXz4V/+1z2uaidEjQNhBWTHkvjAI5ZUgY42DL/B43fBjcD6sNSNvrMrA9783qOB1d",
-	"C3Zj/SjCjNc8oZphm9s0s7cJ5dott2hnOt8SRyVVCoT+8b/PB1t/XH58dfc31AlEheMKrsldVRNBMl7Y",
-	"Ceo8dy5aokdtk0GW8RtIiZERco/skC1yWGUZKQWUdBGTXbKljSfFqP8tMhFXlmua2jVBVvv/HVzNVJUo",
-	"/ivPLLtvbbcVHPB0hwG6Zh7rAd2O4S65/BvgtlZaBhNKGS2fZcf9P0MAuy/YNQii2zBIBAjaL0JgU8qO",
-	"oykTUuFBuno604fgYbE4yujaCXSX4PhyzotVg207MlCAZKnmsUNeFQoLJTkw1h2tLW86k9HZSTDGpZjK",
-	"sFiR+Xkde2gg2oINslt/8rhGjkY3RjwHt0wqVsyc8PmEumVNSKvpYiXOwwjFMLZCjfC6g+312dWFzY27",
-	"LrhxnHUDr6E4LkZpzMphG4lt+mHRUKMLDFdHdGv3mCZ4woqUJVQTFLmZL1oLMUkKrrD1WhSjlzuyYbJN",
-	"VvQRtUcs2kFM0zMI90DgHI97DwNMAG612nPPsKMPf4+KU6pgZYQcxWb+iLh4Tm9/DYbGj6yYJT54TvjU",
-	"I8BEsTAxg6dIHIf4OF284wnN0J2XdAEpoYpojboq0f2XD4gebxTUfz8cR3H0fjiJ4ujg1zGqr1aF1Sj8",
-	"mBIESY1GUIJXE9ZnBCi7sJMrPh3EnKtHG21kt6CHETViEn8tKUyHZqFCTQS9hiwDMRZ8JmiO6WeHXJDD",
-	"yTHRclKxKQNnjfC8pMWCUCl5wqjyvnrddjg5fnEEWlDIOStHaDpTbwv2P2eQKC422oVbpV5m3wmnRud9",
-	"73RereKawZIMbF4H8cuTw2wBgnwXk5db5LtdMsyqK2JlYUxekS3ylivImu71dsl32Klomk7o7YQP+TUI",
-	"OgOJcTbbZNi+JQKbaaXord4hTVNIieIGkjWV+qB2XyvqBK2vPrk68jUIdtdnMINChb2jdGaTWhFvWzKH",
-	"fbiqZhmfIeeCIiUpKMoySJtLmvHZTE+rzVuDx1TPMLMXrr9GVl0FfTUN1eHhFVHuM2mU5OP8CpuAi5Kb",
-	"KLPvt2q6EjV3ZaUV+7HgaZUoEhLAU4AxXWhJbzhjZqQaTVOmJ6LaCmzR3OZk27ntYLgMVYQKfVEKraUL",
-	"Lauy7IYujOTSWyCZ3YMxHEEoqv81mjwDuU0ObmleZrBHPpKLaDA6HZ+cTsjw5Hh4cHY2OjkmpwfDk/cH",
-	"p/+6iPbI+UV0dnARxeQiOhtcRJfkDosMTssxXejdtQ7fEauqTpk0/HBaZdmWtatJCjSTsb7bqZYAuWZE",
-	"+oD6pk/1kbvHeqZEBc8JF7qFFPyGPJvSTMLzbbIPU1plZjHzEyq8zXYLfuPC80ZMm4QNm1Dd2/kNFx9k",
-	"YN/bZDQlJqnc7HdCb8mz94PJ8x7PcsF6y7s23WhXGoRuiu9IVM2IwyaJk+01Mw7qf/5gWox6rkQSP4zc",
-	"sCwjV7CURrl0SvRM9zHByc2cE2df8JsChNYEW7Fy3D90652IElUtHWT0VMJ3JHBbQqKltUlCMTrMEo52",
-	"dwJq5DG/WSHVatavpdoz+Vwv6gGo/6wvszYruumoJjyvG+f0GjQZXQGRoBqiz2lq9ZzR5Gz4vHWxzy+i",
-	"f1xE8UX0w08X0eW9BGXnSOFr0SaOPk1Y0reqD71tZ0MvKdE853o+3CQc++Yg1y0Fv138W77lJQL7UaGZ",
-	"CaQm6Xyse/7zjGhrHQSanK1B/mtFM61GIVdM3xHStD/Ix1NTuHfsWBq0ozaZ8oiWpVNjQsKlN0c32cz7",
-	"l/wecjvnNmma3C2rk0o1QVrxrv+rBzR8zU9DTYKTU64W1rHaljUX0WByEe1dRPsHhjRHB+av1/avM/vX",
-	"/i8XESphJBSpVmOSxWuWZayYWU6oybPlaeqT6JxJ4uKA0lwevWthw4Lezx2TKzun5wyma6GWs2w8ANAE",
-	"27ZTF4r0Pa+SOUZERmXiwlx314lABvmSAoZ4iNe+bhiMR+teN9TXiWZeG1oxYeoVphJEoi3KmdPKQSqW",
-	"G8MmocLyeQMoXilCzeC0scX8LNvkXGtrf/q//yzyK3H553nNAf7UDPJyD1n1otrZeQVki4AjpY8X0e6P",
-	"P+z8tPPn7t9//vtPOxfR3ssfUaJRNEGyVK0wJZaayJDnOZNSc5mwaqjo7cFtknmuOPS6FDI5vdVWZlZJ",
-	"dg01X0S1r/OLaDCwqtXr1/dl025HPrt3lZ6olrb0tCpha8O8Quh/NHkx4ZVYBWqDnXElSi4hiDzfjjlH",
-	"JOwzAYk6gyxbIbdsJ6J7mZjQUjqdy49zKqvR8YyW5u9uKcBwiC1Sm2R2JjcCvczXCZIhSjOW0lrIESol",
-	"mxWQkquF1UKwIzol6/c5CBiolkzAs09vdD9Clef02FW5hiINuWbfmzbrj83YByB/HMTk8DAmf5xqePwx",
-	"wffoJxzlJRcKU8XMbsj1g6YvgvsM09a15bg+hukkCR4uHE0buTG2ccCYHHjeN+GKZtazh6Qt93T/jgzZ",
-	"vv+LrRZ+MENf213e1dZx3NdqQjAePcgywpUWRTnQwiWK2s560/oAfj/fS2OEuTyAml3148c0qcOw3ktp",
-	"ljCJBVfMuM59SGlKbzVAeJZNBfqUKY4MKhDG2Hvk1KzsB2Hg6nJTmqYCsATugWvAfVq66WVwEDEetZcr",
-	"xr5aM/YV6qZAXebDpZdkbR8JdqM9uQSDiklQg+1HJHuB+5d44P6TZgG4fHib9YPJwH3Byy0+nZIr14U8",
-	"s/aVJukpgHzeImCEJAJh6xpypWA5FYv7hK/tmxwkt8P8vk1+d7aTzR3Q5tMcCpKzRHBttmhFq+UJ12O0",
-	"moRdSOQ8DE1KHw0mgyAeA6DPqGKqQsnKt8T93IyMF7PQsLoJGWfM+fDbGR8qEktvaFbnZuSsWDmlHXyv",
-	"KfHoU00soXQCzueaTrl97NXXGDifj20MCm8uoWDF7C2vBHKOE9tK5qY53uwFmBtkPMqYdwDPfxiH0h5K",
-	"LpWWPKh5b9qCLEjQG/whxYlgM1bQrGVl6H4xIoWc2xtLzFetRKH1QsXaZ+1hmFgx0SyajQqpRJXo7WIu",
-	"IduJsHYvzGxTVKjTJiUxgGVPrQbLWt8wVpkPXK6mWqlQv9+Z/jlIs8vK5wZa55QmJqmAPHv/++FzskXI",
-	"yWRApKJFSkVK1u6yflAfWuqerNPPtzHzxF9SO/gkdYaMJ9hV+tm6pyy9NGRewLc0ZCwN+dHpsiuTWlv4",
-	"eqq8Vk95Miw3ZJNpYpTytia+KUOvLQOEmz9N3usRy4BiRW5Spi99Ar+59IWuu9Z5v303UhVMyXYe7y95",
-	"ZKdHbQTvPt9345FskNrx/wvLuE03eJEzzQu05WGc83MqZsshDacHdCIaLRkHgvF0zaFyCxRiO5ujtU+2",
-	"b7I3NIuPYvceeGx6BvI/MpYzhVW1+M031SumlbCmp8kK6fslUBQa98dja0E8+eu6L6lqwjdG/a1qwreq",
-	"Cf95VROe7C3gtxoKX1INhUe9qbp/eQUvgL9VV/giqys4rH151RV6s7b9OZpubJzP1kcss4VJPTeRrThK",
-	"6cJY4FCk0V708tXejz8bAkj3m4YjXqi5/+NfQEW093Ln1Y7JfzM/53UHzuf11LyEoi69qahQ0V608/e9",
-	"nZ0ojm4APpil/xFHCzfjy527fjlHv13ME2Ym6NLEDtkicA1ikdJFbCMckmyRhGZQpLROmW7cns/Od7d+",
-	"vvzf892Xl+c7+n+vznd2L5//DX/XVKS2dmkzfMeO2rX/vDzf2Xp1+XzvfGfrRzsf6hR28H305s93tv5x",
-	"GdyqQxy2Cs0yYtAmsXUsQjtgqo/5cgV0LHn0MtWqLCMGz93d/3z58Yc7fLbpEt5bzuHPfCpD0yFPbOCN",
-	"nyX3+xEK6nevL8qGpOL7b0oli0+Fr478bfZhoORBYm8Q5ixrVZVyjzb7AjAHNedp+JWna8fkSKkm/ANg",
-	"rxJKRZRpWqdS1LPXk2mh3X7ugSSzsQQItSFjI2BazzM7rK6eYpng4+h2a8a33I+nvCpSSA8zTlU3vLZZ",
-	"KCf8XNTtAEVO0Bz9VK88+vCYQaH2K7UIVJbVzUS32xwCPfPYV3HGU84Zng9vNDdJQo+G8ARx+95Djwmm",
-	"KaYgFSsCUPJZCzSHPbLPs4yKmEzmgk3VIg7ngHxLuX6alOv16cnfS/KOShWKjzx9/nG7REawZnJ9+VYV",
-	"T/4SU16/uPxJvGizeyNqr4yiYgbKHs0wsZgwS6JEjyZQXDPBCyOZIJM1r7NppoqT0j4vcZZg7xiKV8HU",
-	"/8+QTxeoP10DL1yIeqPEshDbxHK8nLXDBRnZ4ttGdPzgAcoF9qzuYeWwnZhYZt+YSNTXpB/Y61+lJj/M",
-	"jY0/QXkF6r4psLm7sv4KwepncqsrdS8/8jfeflok84NAistAL9C6H99LF6N26Tnuee+Jy0Zx2atMEl7a",
-	"5LVsQWR1lTOlfHC3dgMVAKm0md7GjveRssb/tXla7bcyUE/p1k8NKbe/ghEidVeyi3qysRdGrsg5fPwH",
-	"NFpm5dL3M5rf+5/PWBUi6r3le0CAIpy5Zb2ahBbpCy6Im7tOZyB6UDC15nERgeaOI3bw548ZrIsTHLe0",
-	"rbURg3XKTCf7Uc9qGZg2Z71vLXrz+h5pkYre+ud3PYL7dDXBGt93C1ZLjnC/D7T6i5U+G3mvwwIy5My2",
-	"EHy/YV6R6UAGihz6vCIvNbbJEZMVqcrUCNvvlzOVvrdio6RCGl+z7tPDhzaxea55TKkW1mPp64g8xtu+",
-	"SV6YpebGVpZInljnhE52fo/M/33zEuvBx368R3pV8tagTm2pE7K05Hbnh5RIoCKZt3wGm6a8OEqJ1iVy",
-	"tb7UgpIgjjiUwBEp+bUUvTBpFEIfczATYEypYaD2jO9IqO/pRYwPE421mc40gxjTxUmxj2rOX8Infz7f",
-	"V3VC8MXpqC3YNqwJ5BjHo1P5P4UwS+9dRsNrNxt9vaZJtkxXlb0I5xT8R4BUtU7bLQpnW/S+0qaa8xPA",
-	"fthkX3frraSLoFlPTCtypDnQFIQMD/QdkB2FQgV+aDhUUGHcZWJ8PZAGPwCHgMQqBPeGiR32EKC4kSug",
-	"YiPSOKevx9dpAJt8LfAM/47I8lHLJqCz0jruh4B6qq37/bK1tEMpknoalMr7LR12w2cdnnBMhYpnsD3b",
-	"tmpVXJtfsZe2MQGVPG8JSK9/ubdAjcel/t9WDXSbRhD5qtdRHP1VcaOhN7nDcUQrNUeFq+jdxA1sKt/b",
-	"mkNdsl09wXJ3H/tsV1bqgJ4q0LzKaqgtlw25obJx97TfVq3ge6tIsq3BoriUxNTSUNwkbTRueQEJsGvv",
-	"lu9nHm2kj3apE/ENoLbDptJqqchIi9aGvCjAaK12ujhydqL/0w+0f1+ikStFWYY9r3MNmHchVHDRV2Jc",
-	"H131/ZKQohSwtfqZ9EyqBm3Ojrs32to4WYG8QDZdv2JbQoVYmDSrnknAWmO7mrr5RO1S+Yv+9MYT2UzX",
-	"KWzhvarB6habXatJyy2BVlMLv9Mz950VJNcbk5DwIpW9EhusFQ9T3D3qIbSQN+0YR7t6v2WQ91s18V8L",
-	"eOCiqY3OYXfDhu3s+3FtkuWr9mG434N3EXpUuvLo7vXogxdd8XZl5br1uIcvbQXo/ZbtPc6/z5Id5uSx",
-	"jnEl73joP5VIBJMBZWtg2mpzoRW3Drwcd8/5B0oJdlWh0BjUvQhtugWd53WfzQuo1g/ye+UsKujBzBnF",
-	"G77HN/VN/6pgZDfrnFGoRBlNGxZaCn7NUrDcxPiVbAxB2ZeAhb6EJOFVli49FJSc2LAEnUEyh+SD5gu6",
-	"BxR6kpQ8487fUpCrBXEhisEMyBEt6AzE8x6fzrGchiEVpP0xXLMd51b1VeV//rn1FnL3pzj8ae91c7Pi",
-	"3nN3hbDJndHLoYUlqYSHfED1is3OKqYSiiZHvOU3JKfFglwxLRtdPzJlpuwUejPaQuBT5Y/HUcpRbaIJ",
-	"JZgOwevaFhKaXibBwr9+CtOtpbr98Pt+FEeD3/HvVTyo2nPvbQtWhMCov1OAjZWkNY9eKsjWH173avsQ",
-	"QQmuf9hnIE3L28WVME7FgwwSJVgSxdEbYz29XaSCz0zG3lGVKUYO7VwHak4LnqHQm1M5eH2GiG3uavj6",
-	"tCFTdk93xVIy9CxMYPWl8HkoM0VVLF8OlA6bU/lmvPHOdFf0g9U5nQHqGP3t9F37pVs9nRmB3YMPsMjQ",
-	"uii/uAa0tHfz/nMV5fhnojYeCdmbigpaKAh8q9uW/eUll2DKM0FGZs0IbB+48e7ZQfALDw/gamX9jY9e",
-	"AUP9O1LVQgJVKxmM6bAJ15M5zbJNeKrpeH+uGnopctZ9ITJ4Pxi9G7x+dxDF0cnxn6cHv/52cDZZ/b2d",
-	"B71xCmQ5FdLVcVvPcNq92yeoFNeml2YvR7SoNiminrh3Q53y6cEPsN2Zh8pTwzbclztqcGzNqyvzIklI",
-	"u+fd7Z3tHZ9eTksW7UWvzE/Gezw3WHnh2rb/7T6JNgNDiVohMbr2KI32ojegTkooBiXTRmrUOHfMHC93",
-	"djoVM0whP6urv/ATW+JHMizuekUmzVrjETGL3RmM5zkVC7sTstQcR4rOpAmoWHl8qQe8aOgudKCaAh91",
-	"lmXVrVl1rRncP7XbUP+8jRsVO+nHMqNKW/13L9pVyjmWdTgUYGwZ2grnLQPG9njdBPua8Gkoi6np8sKT",
-	"9thtaVSMqZpHd5e1O/G181VvDOANUpmWc+fu7u7uHonUjdZ06QgIIutXcPZrYh2M6lbZ5ZsOsR6VQdRu",
-	"NRQWwLC2QVoIboing2fdb+nzml8JsrFP+n4elHe+QroK8dhNNhBfekvYu9dh9DceqgDarTMKXGJl+Hqb",
-	"fl/J9ca+NfrEqO58jRDj0vUnApc+HdhFtsVHp9b4WjQvOcZwTB+CSubE6mK9oor9e/4G1LtW4xeN70CV",
-	"oydGeb9WD4L1BobLeD5kRboE/fU4dmG5IILt2+3gDbbNX8kNxgrBPDE6O0/fEVw6AIumSxujptV/juc+",
-	"d7f2LONoPTUew3ZJpkb8967sqQvzftHIRd4tPDFulxNBEdT2kwI72F2FBQzFd3f/FwAA//8uNr7qbZIA",
-	"AA==",
+	"H4sIAAAAAAAC/+y9a3fbtrIw/FewuPdaTdZL35J279bfFF8anSa2a7vpOY39dsEkJGGHBFgAtK2Tx//9",
+	"WYMLSZEDSXactnniT7aI+2AwM5gbPiaZLCspmDA62f2YVFTRkhmm7C/F/qi5YvlJQc1EqnIsTqiZQUnO",
+	"dKZ4ZbgUyW4Sysl4P0kTDp8qqJgmgpYMfvkKSdr0mewaVbM00dmMlRT6ZKIuk933yYwp879JmlRKTrgp",
+	"6W0+M0maXEn5gYtpJqEXKuaKCWP7E6aYJ2kiDU3SRFORX8nb5DJNSi7eMDGFCe+kiZlXMBNtFBfT5O7u",
+	"LgxtVzqaslMqpgz+r5SsmDKc2ZKsoFq7f30X8uo/LDPJXZpkMmedgtB3mpT0tvOdC8OmTNkCLvACaeje",
+	"Ym9N4V06HHkk5qdMmD0pJnxaK+o2or8vZ3VVFZwpoiuW8QnPSLu/ZCIVoUVBKqnNBgxAYGuYNpqw24xV",
+	"hniIb5Lja6YUzxnhgujQZynzumCL3ShZG9Zvn6Q9kNKK/8Tmw+mOKk4+MNjLAUDDqKOK/6KKJSulFSe1",
+	"KoZ93HVR732/wzRM6hIB9iu3jj1ZfqHwnkx4walhAcN6YA/FxOIzAn1aFGFpetj+lJlaCWJmjFyzGc8K",
+	"pomc2EmGyeuUMJrNiKHTKcvJDTczwo1uylPChTaM5tBwwgvDYGhyNW9qHNGyM7UrKQtGBcytolrfSJUj",
+	"VCmUfBZ8SpOFqcV7ABKYkpsZz2aEa1JrlttNbJcpa9NADhvIMG2GA5wzbQApcLDUmimBTuyXUHLvI1K3",
+	"LasWtovY1QPLkuN0cGsUPVaHDCG69Jrygl7xghtLJ/6p2CTZTf6x1XKrLU+9t2w/o26DDmWO7AmDNlF0",
+	"53ossqLOGYJUY024L4TTqajB4c/1WypyaqSao52UTSnW+o+aCsMN0vTnUJIiTMR9GSAKfE0b9mrhlaTJ",
+	"IetuTwQHuiBaspenjpKdALXTyHZyVXDB9mLs0hOufWrYOXc4C9ICNcluklPDNgwv0Z26UvIDU6dswhQT",
+	"GbL2PaoIMEpakB+pYTd0HqjklmtLVNMY6T+jKuDMCZ2XTJgTxTO2CiVtpVEpa2GW9DIW/tderWAG8/t2",
+	"K8uykdsWZZZaG1kyhdB6UjKt6ZSRiZIlCRWJkQ2lRekCIvr0OCEV8+NJsvt++RJeg2y3yEXv0lWrBjHw",
+	"Lb3df31+v5Yxvr2qHSpcrWp0auXQ+7U5NvR+DU5ZKQ27X5szJxH3Gl2mMdq4sLMpKeHIMCJLbgyw7hkT",
+	"i1VO2QT4mnbi+O3GVFrpZkN/4NWGtN3TYqOSXBimnNTfx59TNhmianOqu8i5ODTRRipHiEH6mNVXlrPC",
+	"/xktCuCtWcGZMGmoeU2Lmmli6AdGKsUyltsR5HW/a8vjjGEKpvL/vx9t/EY3/nd744fN3zcuP+6kOy++",
+	"v/snSjGkMIpmZtVZ3gv1oE3nuC5tE+rdpUmuZHU8maxq4gnzK0VFNvuVmxnQ19+kYNCH5YJ6JPJD5ugG",
+	"N6zUq7ocsu+WPlClqOW/k4JPZ+ZIDrf10JYQUZdXGK1pmrry+zd3YvIYYd1vbYm7oyIXMAEs6pCxt7Qa",
+	"tj22pWTCGClplSAEseLZh1+qT9oPZRnVPp2jQjaUkRwKsekrppm6jkEtzgaJa+iOUxyq2lBT6z1aFFc0",
+	"+4CKzKcsY/waDteMkQkXtCCuFcj0JwdH++OjH8Oo2tERXw4Hwc6Fa8IEvSpYTqjIbUf+jtSyaR2O8iIl",
+	"sFeKPlFJSUXnhaS5JlQxovlUhNsH9H1r5QptaFnZ8W43oAY1tWJkxmjOFMC6kURqxZddA+C6YWZK1tPZ",
+	"Kiw4Q5p0ejqlhi2RaUJrK3y2gHEcHb7pALVl0z3zV9SxcAv0bHydaWNNgQbwksnarCQg56HeXZr4a88e",
+	"KFmGK33nSonVwSxchvVcG1auvML4Q9mSyw6B7g0eA3/vaA1lzg797lCfDjguu3KyrqTQyI2HKSWVXncH",
+	"Qj8HrtVdmsw4HIs5RvfG9lYNCC8nEzgNLG8BGXQQKVH2Gu+4qa9IfK/2mpkSzRj58eCcbPnPWx95fhen",
+	"FsOZeCj4c9+5kxz/BBeS0fjNARBnTyyQ20m7SQ1AVx+TltItkfRDv/vU0HUUOzk1lNAruLoDZMMYVJP/",
+	"Ojs+Iq7jTXI+49rV5Q3hsvSnaSByApdpIHnaEzBVO5rWUx5lVGSsSGEz+GRuW/pePB0Ne4mxpwa5Q521",
+	"Mc3XH2gH3B4uuRSe2RqvqMlmK+6HjdyxDF06qGp1SSCYtsQPYwpJei+Jxs13capOlzt2vexsb1sNbvjZ",
+	"F3t6EHJjrwsgXRcGucmheozX5+cnASpQYwEFF6FFbmRd5CDBU6FvWMA/VIawNAhj7FRLYTVzlANvbvSW",
+	"0BOcAEpUIGyYOkXk7BZRz0nNDXcddzk9F8RB7i8Qc1SHPt8DY3yjz0ae+ohlAZoG5UyPP0WnsBITV5zS",
+	"v6+W5kkX8aSLeNJFoLqINVURXhPx0Mvz5759fiah7/43loiJZDCxNW4NfXK68vLQ4zZPV4g1rhBpsjc6",
+	"2jt4A1//iuvEnyFu79l7wRfLvP3sqcYs6m5tRNli8sySTGd3E1NGXgA53vnuOUqTnqSCJ6ngSSr4K6WC",
+	"B6jEv2ZholFWfopUEXY3Lk0EdvFViBGKmVVTAYPGVMlagGLDqDl8p17ZVjhk8nqPqzmhxMOSSHscBcts",
+	"DQu0zswdEQrMyyjOnAniUc0b95GbfqXaU53OunSdZUzrSV1sRiSpy88l2wylmJYs9Q68KyBcTOTAtY2V",
+	"lCO2qIMN+71jvGH+w3LNiqt1GZlesOv2VXSuBMOy0Ipwi8wZBTS3tGvqaBdKoDvuIL3uQsmA9F9+fIlT",
+	"+5IqT4N9kSeM1nPOep+co05D3jWFQKtNMioKedMwp12yTTbIYQ1eiIpVdJ6SHbIB6mrDafiWWM0pLwGn",
+	"dqza1P2/jd8f4dz8d1k4Uaoz3Y5TVMA7DNANqV4N6K5fbXfvsxa4nZEWwYRiRsd633N7miKA3VccWDmU",
+	"YZCIIHQYhLB1MTtNJlxpgzsnNt3ZOgR3B0yTgq7soKBL2lczKZY1duW4HpZbuWdP1sKoeRSMTUVnPbGV",
+	"yfjsOOrbZ7gpMB85+3kVeWgh2oENMtuw8rTZHDrFkefglmvDxdSz+ke8tzWItBwvlu55fEOxHVsitAVJ",
+	"zdX604Wz9bU2fXDje9Z3OI35r2KYxh0fdh6obT3MC9QKFXvLPVkbg6S1A3GR84wCQpGb2bwzENdESION",
+	"18EYGO6tcw9cZ8TgSfgJg/Y2pq0ZhXvEYRi3k+1FiAD4y2t9T3fL4PY7FuAgsNQzGN3N8hP8gUt6+3PU",
+	"JfitY7MkOA2DAOs3IGpHw13Dj2J0nM7fyIwW6MwrOgeB1hC4v9QVOv/qAV6zazkzv9s7SdLk3d55kiYH",
+	"P5+g8motnEQR2lRMgR9Vktr/nJiw2hPauIE9Xwlu8HZdA9zobnYHehhSv2a0MLO9Gcs+xGzAjVkWodUG",
+	"FxTfuAIQhUpeFFyzTIq8gwqtFNiuEbH75lwwre39x7tsURIakJxVTORuHE1yeSPQvW9vJGEDLJLY+iuh",
+	"3qh5m4/tquPgPGWVVJioDmC2/9E8507vc7JQY7lmsL9V2D3mcZbrZ4ovcaCg/FICkQ7tQMKcK3rNioKp",
+	"EyWnipaYRH8oFTk8PyIgWRk+4d6xEPaFijmhWsuMU9P15zs8P9p6ywCx9YxXYzTwZzAF988Zy4xUa83C",
+	"j9IMs+/FmfaW9M7fkuBSZBtrMnIRECQMTw6LOVPkHyl5sUH+sUP2ivqKOOkpJS/JBnktDSva6s10yT+w",
+	"VdE8P6e353IP1IR0yjTGC12RFRQcEriYJENvYYY0B22MkRaSDV0L7jODEfsOv1ePLsB+CaKgrzOaMmHi",
+	"tipqi1HbRzZj++yqnhZyiqyLCaCypud1U8jpFLrlYiLtPubQw9QduOEYRX0V1aW2WIc2lara59peq47K",
+	"K6wDqSpp3VBDvWXdVVgHQtdwFTxRMq8zQ2Ii24SxEzoH2dDy0mK+jIqvj7a9084slaHG6uoyKeBeB6yO",
+	"FsUNnVtZB6ZACjcHq2pgylD4C6tXnOlNcnBLy6pgu+QjuUhG49OT49Nzsnd8tHdwdjY+PiKnB3vH7w5O",
+	"/+ci2SXvL5Kzg4skJRfJ2egiuSR3mPfepDqhc5hdZ/E9Qcw0wYWWHk7qothwmhiSM1roFM52zgxTJRAi",
+	"WKB11GZsuKxnRtXsOZEKSoiQN+TZhBaaPd8k+2xC68IOZj+hLN9OV8gb779rBTvr7E5v0ZnfSPVBR+a9",
+	"ScYTAvNJ7XzP6S159m50/nxAs7w3r6Nd6060zw1iJyVUJCbUXHKJ9dJgQ4yjN4awMGCjjViVhWbkhhcF",
+	"WLa6AYcLq0TXdB+lDbmZSeJvpPJGMNUoxRsOjsW2B6W/Ri8jHjLQVfCnA8mgYhlwa+ulbmWYhT3a2Y5c",
+	"PI7kzRKu1rEJ5uyZfg6DBgDCz+YwC2kGgZtWCw+FM3ptbXhXjGhmWqQvae7knPH52d7zzsF+f5H8+yJJ",
+	"L5Jvv79ILu/FKHtLih+LLnIMccKhvhN96C3TKCpUSpYS+sOVCCehOEp1KyVv5//Rr2WFwH4sgJiw3IaO",
+	"n0DN/zojoN9xcRODzgDkP9e0ADEKczOFbtryB2kFGwwPqkCHg67VOl2+pVXlxZgYcxn00VtG6LGZQ+n6",
+	"3CRtkT9lTfglIGTr/goNWroWuqE2AsILV3Oniu/ymotkdH6R7F4k+wcWNccH9tcr9+vM/dr/6SJBOYxm",
+	"IgcxJpu/4tYu7SghoGdHNzlEUfBv914Z2h4emLWq7Y0hWEZScuX6DJTBG+MXPeEDANBQ1O59kon8nayz",
+	"GVMRkUkqe9x9JcIKVi4IYIhNYWUegNHJeFUegOY40SJIQ0s6zH0VUjGVMWHs6QZaw7Thpb3YgBXD0vnW",
+	"0do27hhNQy+b5D1Ia7+H37+L8kpd/v6+oQC/A4G83EVGvai3t18yskGYR6WPF8nOd99uf7/9+86/fvjX",
+	"99sXye6L71CkMTRDIvwcMyUOm8ieLEuuNVCZuGho6O3BbVYEqrgXZCmkc3oLt8yi1vyaNXQRlb7eXySj",
+	"kROtXr26L5n2Mzpk2CQW5USzMKXPKxJ2JixrBP/H51vnslbLQG1356RWldQsunmhHOmg1myfK5aZM1YU",
+	"S/iWq0SglrUiLoS8+BgWL7JaGc9KaeHsVopZCrFBWicb25NvgR7m6wwJIaMFz2nD5AjVPgrwau6kEGyJ",
+	"Xsj6dcYUG5kOT8DD026gHmhAPaXHjso1E3lMmf/OljkNfsE/MPLbQUoOD1Py2ynA47dzfI6hw3EZtF19",
+	"UczOhlw/qHsRnWcct64dxQ1Wb89JcAPzeNLyjRNnOU7JQaB959LQwumCkbjGgezf4yGb989t0tkfVOnm",
+	"/Eze4uE48mboxmJJQ/AHSR2/815gtxuh9kZwa3HhpsS7hoWvf9RMzVvV3aa9HRHQcOWaXMmcM52Skhlq",
+	"I8ykKObAo+ynmYRBXSgQHDjDSy6mOm0camAEpl2PYTzqfGBqJTRp3XhabbtwRs0wYpIm0DxJE98pqn6H",
+	"K2vQUvasZI2EFXX+GBUFkQa4eMmo8HFwrjLsNwArwPIbbe+v/cCvobMGzRqfh7AwOwSsTF5xa6cK9tsJ",
+	"vU3SxMiimCg0X0qaWCxGeMogk0o7cmiEYVqfEdE8VwwLjh35AlwdCEUvoo2IVUa+WNL25Yq2L7G2GWqf",
+	"2ltIV9OpjR6mgC5RC34WFf6H5v+Bl8yLiE/kY7rc+Fhj59CIiQ/7SlYbcjIhV74KeeaupoDSE8b08w4C",
+	"IygR8RFpIFcpXlI1v4+viEsFgThS2e+b5Fd/7Vx0cCt5piTc+EBG7RgRoA1ImNiBRNbD0Zjb8eh8FN3H",
+	"COgLaripUbQKJYgJrJBiGmvWFCHtrCYknrIh2GXVQuqG5Y5QJRdLu+Ti3l3ipt4GWWK+O1LOAE+lyzEy",
+	"FLaknJ04gy9eXDHBxfS1rLGUcceulMxs8Zphur6RVcZjihXc2egk5mNUSW2A86CaEVsWJUGK3uBx4seK",
+	"T13mi+aC5vjkkAt5iwHqnt3xylvNVNzVttsMYyvWEEiLsdBG1dZnFdOmuUqEd2vhTqbKnLbe1pFdDthq",
+	"dxlENXuhDV4Cy7FWG1RlCjFQLIqzi3L7GgL7hGbWg4c8e/fr4XOyQcjx+QjEJpFTlZOVs/QDxlNX3JN0",
+	"hv7WJp54ujYPn6xxRwsIi2FGoASrwn+zNU3OnxadIQV7CtL5ooJ0vvZ4mk+O+1iIzuicxq8iQCMQOR0X",
+	"UXTrQdhsdkdDvJbsEPrCBIfPE8/wlheMYgmlcw78JWO/eLe03g4EG1WoRmrBje7GZ/xUJq579DoajFz7",
+	"vj3i5deY537ihXROQVslB7YDl1woyGZUTRcNj17k7Nkd23ErprjMVyyqdEAhrrJdWndl+9YrD6SJJPUZ",
+	"z05szYhfX8FLbjDXtV9CUTNiXiunIIJOEe0huoVWSfmpuU0/e/KLv1MW0CeZ4EkmeJIJnrKAPmUBfcoC",
+	"+lVmAf1siUSecoL+nXKCflKI/up0oUHyfEr185QtdFW20ED7/nbZQge9dhX4gMTOJyaxLwJVxdwG9lkv",
+	"kDSB+CxQuTKRJ7vJi5e73/1gESDfbwveSmFm4cf/MKqS3RfbL7etr7j9XDYVpJw1XcuKiebxKUOVSXaT",
+	"7X/tboMD6g1jH+zQ/06Tue/xxfbd8AGjMF3M9GE76OMERMyza6bmOYTLW3uzJhsgoDLQNYeAtFbYfPZ+",
+	"Z+OHy//zfufF5ftt+O/l++2dy+f/xKPGRe5e72qbb7tWO+7Pi/fbGy8vn+++3974zvWHWgE9fD958u+3",
+	"N/59GZ2q3zhsFHB2tdumsXFsSR9MzTJfLIGOQ4/+iNb5we5zf/Y/XH789g7vbbKw7x1r4J+8KovTMdMb",
+	"XuLR/X6Igl5wmoOyJqqE+utiyfyx9qvH/Nt5WCgFkLgThFlHBvfoR4u1k5OGr2poSy2FB/vTi+3tl1vb",
+	"3zW+/l5Zo4cPe/3lYV9MXLNCYv5dPpDcydywfMezqoJyQW7LgsBMlPN05oJQcnY8OiGhv4XYgNuy6HB/",
+	"90tLWiWXT4FCT4FCf0qg0MyY6rW/DD48NmDUNCM2hbe/XtqAfaCZjf/igls/HIyRNcYDHlwkx+ejiBP/",
+	"U+DRqsCjru0oGn/UTWH3VwYgPU7A0SOE3ViklGqcYwCQtcrci48A3JPjsxBwkRIprKmHND0Qnq/t7WPo",
+	"aTswtrDHDQSR1B9wDEQ3lmN5H5uwVMuw3BGGa5Y2xZxkitlIcUuPmt2zIRYZrc3M/udCLrJaM+V+Ox2B",
+	"+7rVfvYf2naf9JLlkggWA9Y+7IlJ+z2sN4ghzoncPj8pm8BdLsUmOZLGOzpPCCsr3O3T0NtRUSw5Ui0Z",
+	"j4ko4XnRhmKhW/o3DCqRdTTC9HOHbbQO7nppBIKjyU5l6FzY7SF0j5YuAuI3L4/8dn5fQECIYDD6ryAC",
+	"Xo58F1qs9ORfIFcRkb7fKQIQW0KoMYpf2dwWvVOwyD02t7e/H4jn12xm00ThTDgMIScg9P/+rlP59OeI",
+	"d5vL3blOX65mtKNTptfp5ZTpJV2YtXsxkY7u8O3pEn7EwtAyE5erE3iOtmxoeEOaMmH2azOPPH0MxSSv",
+	"zdyFx/g9bjrDrO0VFfOImNPpwleMuip2OgIjILs1kf4yV7puv3wFxNA2WkYjap0zfRNMuxw6eMqmY+/H",
+	"3XvoxW0gNHEM5VvyzO6WvU58T545gvU8WTMxE8fv750n1nxKy6E23YXLxHNg+nKM+VbmXH5gmFagMsTY",
+	"olXzb3pvOgMLQDcZ1nBiUEioLfU2r06YwuIBaLpYVFh1bPSAIbIWOcsPC0lNPx5iPd/7eDJNPwN0c6L+",
+	"F4+lZXkYQdhvCMLESTgTbkoXCTRMr8Lx3C9WpNMkllINT4bizja0iYbk50wbLiJQChF6tGS7ZF8WBVUp",
+	"OZ8pPjHzNB7v+KQ1+Dxag9U34m80eUO1iVHzz59rY+GuG3tJvzl8y57U/zumd/jb5QrAn/L3GTTdkTHN",
+	"pcsTsZRwh6IEWoOKlCspLGdihW6dI+zNy0hSNTcydBl/5SUE7rZ4PFIDvF9ClaV3mCjpi5HNa7FMLhrn",
+	"zmUMWMe3pHODuH8gcd3Ov2pPi2cTi+QbY4lwTIaRGMOj1MZC+7bpIySfplN2SsX0Hm5gI99iRUq4ZV30",
+	"UyBDS+sYdRCJSRzBAJ3z8Y32QUU+ntInPw1ip1fUc02CG2IxJ7q+6jqfhl0kgrFcE9PY4YMjYes8s/4l",
+	"9+kBmqcHaJ4eoHkc59jcEq6G2iwhbP5pKBqIhCOPeklKgIJqvWC+6fq3R7z6S3rb+d5xAuAC/S4BjfNI",
+	"ROfKHAAPcfONB1Y7BziwPWxJRXzfTbQhgUbRyNdP86ttKTritfDne96u8rY96sjWK/1uV4muveQE0Ktj",
+	"V0naekIlP766R9YCQ2+DanGAcI/3GlHrJtmB1YLPZJgH+hKCkzVQR8e4+BPze3QQe7dmmK+tQEaGHIYw",
+	"3yATbJK3XNekrnIYj3yzGDj8jSPhFVXaegJCnQH8gY4DxXd2jkCz/99yzFwnZtwdpVYto5EY8h64vZj2",
+	"DdL/N22Cuwfvwac7Ly4L7B41sYihjmWofv2wJ4yqbNZRT61r8fRom6wK8m7mluLnAd849LQhshKiBQ0L",
+	"AfyrCmrAw1u7TI/+USxlOyI0p5VhyqGYE5vtd+1fyQoKZB/2viBNgPdr75NVrkh1Q1VHD+PHILXIZsDp",
+	"c8QrytaI+2k438++a4brFlEvpoRtTje7pt1mIYNguQDb3paFKeGbMJSKv5SE7jb4UAGujaaKWdXJXuQl",
+	"jlCR0FAzCBnBsetEsYpaC8UJnR+LffSm3M0NG1NXLdNSra3EoRWPKXHiWoy48uKBaoMIfHE86oo2a76Q",
+	"4mp/eq6lxxBn8nuniA/y7aokKfliNox8WUr3eGzWVwFS01lt/4ksVwLzavpNPwfs99r0OP23BPJ5VI1H",
+	"bCmypFnrt4c3DBWQGcVMg6Fp3DRYqyLmUMNynLLcoSBxIuK9YeKaPQQovuUSqPg4QBT9m/ZN2A7ikj3o",
+	"EdXArBBFLAd27RqxZJOMDZkywZwDSyOigaNd0eaTEDn5wFilO5GIIcNuwxZTojMmqOLSOcIYxadT6+R7",
+	"FR4y8xdErzE5Gx3tvzr+7w0fkJQ2Hw5OT49P25/n47cHx7+c21mEb0fH7p3ytKuknEj/oIiVsXKqZ7YN",
+	"mAh1PZnw2wE/XpJNo73MNpWGRqEm29EOmpOJYbkWzrzKlDVwzxu4pz4ZXOoMgQu7sEkO/qghPNS2d9cW",
+	"wuwnZZ/X8emQfSUQqhl++44L6+2iG0ywHiOuu4X1/2vF8vsMHGCB0bAgQPiMoRjXUIq5B1jxJ0ObYu/g",
+	"AqAN+L0sijNTDICP3YjhEROg7O5S1U0xekNDpFk3yHU510Vm7VcbcTCB5ceOdFhYU8eL2+4AS7XMIzhA",
+	"ZUm3432s4aM/z2/X3Uynu+LuviADL0Ohju/KEI2q1ptlqWlg6P/Sn3vo6bIztJ/fcNg8Spz362bF6yYh",
+	"DFwUSsmzdtvThkqkYftTwkz2vHNbCBoBR2Da93ra/zYaDuRiIGErnP9cmvxRS6uwaglmmoCfLXrTUAOx",
+	"ZA0VY6ht2w94+PIOFquHwK3uE0qrjrefsjvewda15gm/W4KS3QOD7qUm9tEMI30y4eCToFxeAO+TMIzZ",
+	"XktD0sdORFWOqtbWFd0XnPk7uLbXPPTtuksTrzYNP0ND9/sSddsxlBdYMlhfgLQpY29xhkc6V7uWhXpZ",
+	"7NYYUUUOk3Fxbdpt82rOe29bd0+WbF4kD8HwabaMKjW3MeID/QjvtO2LSTMwsS68c4FwNKjTdtd7wSKY",
+	"lKPPWKx3rM47Wnr02bR4Vll73nvvO/YnQXjHGcjIIGRRoW+YQul0FnEwXjpq8879AwfNnRSGnQ1b4BPF",
+	"gzRYLpuHpX4PnkUsBfLSpbtGDx90icC+dNym3cOHdgz0fsMOsvDfZ8gecQq7jlGloAofHAuaKa4jN8+R",
+	"LVtwNfZSfyTPuVcNjxoffzTnua/VCQWI25KbOuu/rdukjx+8W1GzAcy8hnDN7PH26ds/ajZ2k/XmEZSj",
+	"jNtgZFIpec1z5qiJtXQ4k7pxeWsFHELwwCvyhbS2WhJnpadTZp8vBboANUIyn2fSK58FXGi9xX40ZeQt",
+	"FXTKrKt3zzGb3uJZjGjn9W07HW9ldLib7P7wQ/cy930k0/Y6fXNx7777TNg6DsNw6AuSVDsH1nu+jnzF",
+	"p2c1NxlFPUPhRYoS9ARXHHijr0cmvHmDYnAyukzgsTLvpEkuUWmivZfbCtHj2mUSgC/n0TehQxe2Wkd0",
+	"+/ZXuACOft1HpbIHPQQ+SJiGpcy34u+EsbWFpBWZ1GpWrF481OoaVJhREj7sc6Ztyev5lbJX1YOCZUbx",
+	"LEmTH+3t6fU8V3LKBKQWrQvDyaHr68DMqJAFCr0Z1aNXZwjblkx399OFt0JVNLSa6hFXmVy3H8qtH5Kj",
+	"y5E3wmZU/3iy9sygKtYLL+mUoVaiX07fLLhche5sC+wcfGDzAn3F4ydfgI1ftilkl2FOyDTr3HNY8WNN",
+	"FRUGVdaNtY+ilJXUzL7DxAoybVtg88Av74EcxJx1H/Lme2UdGdGXCuE78gaDZtQsJTC2wjpUT5e0KNah",
+	"qbbi/alqLM3VWT+91ejdaPxm9OrNQZImx0e/nx78/MvB2fnS/Fa4uNE3U4M/CFPh3Rw/19RGnF/NFyz7",
+	"oB2FepoBP2+fM9CEGiI/S6q6iL+50P71uNXUr1u7C87aSLgHZkDaqKjXeew/8+nfes/8+y0c8nFoDxdO",
+	"mKDhxhrq2jee6qukCXlNdpOdze3N7ZCoh1Y82U1e2k/WrjezKLI1s4/NbxXAzXY/Jj48u1ErguYYPro3",
+	"6d84nqe6oSIvtrd7T03YxwPdtWHrP9pdqd05HAqyLbIODVuV8Uqo3mG8Q8HS23zv+sE1oXZtdxZxSniv",
+	"BoybzBD4LDxJpFMN2+Hli0uoHCCjGM3nq0FzaqsBZBt3HHDARvNTN94ni55VNJsFcbBS8sqxdGhmn+cK",
+	"WNJuOJS3cB0kmr78xI1a7jvu1lxJhYJ/1M33kbOKiZyJjPtQ77qC/f1u++WfNx9kNvZVwFzeCAQ3YNM5",
+	"IIfTuXTa2BtHHGe6nm7LcMbVW4UvrbWjvf7QxSfTLILAcW7xwwpeLZXxGegaOJZcvGFiamZd81NDn+6H",
+	"NTOq/r/hTsXocPNinXZpaUZA/a8Z2dl84R+u62pWnD0SCO4os85BzlCM+kA9HHP6ZjSMlgwWEDOW3aXJ",
+	"t9vfYmniPwh5I4hUoMiwaDhrthZBvyWOlzHM8zR+M6w+hnmQG3FUcdB8fiolX0mE7VgnY2IHG65yoTiy",
+	"rJY/xBbUiDV/Bldai/W4xsP1to4K2Eo/Bmy62+roZCuJxfHtWUufJrRjtFwEjLMFvmpKe2QGOxFtla1A",
+	"PIJ1cyxOgMI44uDclrw3yKNQ7yZrajca7e7u7u4ro0UNHNyaMeTyVYiuM6tRWMQyKNX9C4JHtoBeUXTb",
+	"aLE+gnWW9bVI1yJ0D/egnp9oczq/BAR0k31Cw0VorIGMGMWz2LKQhXhA/9ZFya0rarLZSsRcHIfpYC+z",
+	"KSdA5g7JImyBv0mDgZ42SU98hFrwC7Jp3I1itHSCl2A3NjwoZ+HZF5tW2XclVW4tdmxus6cUzLBNcrb0",
+	"vV5oCqkCUgJOAS6Rh6sxYSabsdxegm1uMwuDzTUO2yuo+EWdODvjTzx2txsi//QpwLajkozoo7HHEbs1",
+	"gBMo9jvVeA8t1zoArX00gvK2nDAf0xyXA2y9L0QO8Lmkvnb6G8AQJ7xnjvtP6sLbxAsfSryIg7aon+1y",
+	"JeotmIpx7DsE4kScdnLwKPZQIJgy86ZT+LfGwcgrlV8hGg5fCEQwsanUw71D3nXBXQvvvPNcFOncWxVt",
+	"iNGvDiL+d+c1l7SjYm7drV33rfun7YjQKeXC2tCy9rTIIm/dAeGDYDekM44/cyEEf0H1rRiA3uWC2CQe",
+	"ibh9UoHYsHtVV8YZRPjEb4yfBWASFzXThBtImasloRPDwhML1i3IcBBFZG02ybfbP8BkOs+9crAMWXcJ",
+	"Lkil5NQ+o06Fnyd3bnrdWbg23VcfkOUKacgEpKih9OGA+oWwF+yZva/wXPfefEEOtT9pqq3SPdq2lHv7",
+	"5n0YS+MIhJ/vU4vI3Uc420vsgJ+ceq/cvzXCITl2vkJ8Wwy1x5Tz/Q3vM5NlmIGh3d3d/x0AFs7qRm/Q",
+	"AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,23 @@
+package web
+
+import (
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/credentials"
+	"bitbucket.org/crgw/supplier-hub/internal/platform/factory"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
+	"bitbucket.org/crgw/supplier-hub/internal/tracking"
+)
+
+// statusTracker creates the tracker of pending bookings, nil when tracking is disabled
+func statusTracker(cfg *config.Config, redisFactory *redisfactory.Factory, platformFactory *factory.Factory, credentialStore *credentials.Store) (*tracking.Tracker, error) {
+	if !cfg.Tracking.Enabled {
+		return nil, nil
+	}
+
+	client, err := redisFactory.Client(redisfactory.Tracking)
+	if err != nil {
+		return nil, err
+	}
+
+	return tracking.New(tracking.NewStore(client), cfg.Tracking, platformFactory, credentialStore), nil
+}
@@ -1,43 +1,147 @@
 package web
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"time"
 
+	"bitbucket.org/crgw/supplier-hub/internal/admin"
+	"bitbucket.org/crgw/supplier-hub/internal/config"
+	"bitbucket.org/crgw/supplier-hub/internal/health"
 	"bitbucket.org/crgw/supplier-hub/internal/platform"
 	"bitbucket.org/crgw/supplier-hub/internal/platform/factory"
+	"bitbucket.org/crgw/supplier-hub/internal/schema"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/caching"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/lifecycle"
+	"bitbucket.org/crgw/supplier-hub/internal/tools/quota"
 	"bitbucket.org/crgw/supplier-hub/internal/tools/redisfactory"
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 )
 
-func SetupRouter(log *zerolog.Logger, redisFactory *redisfactory.Factory) *gin.Engine {
-	var (
-		startTime       = time.Now()
-		openApiLocation = os.Getenv("OPENAPI_LOCATION")
-	)
+// Workers run in the background until their context ends
+type Workers []func(ctx context.Context)
+
+// Start runs each worker in its own goroutine
+func (w Workers) Start(ctx context.Context) {
+	for _, worker := range w {
+		go worker(ctx)
+	}
+}
+
+// SetupRouter builds the routers and the background workers of the
+// configuration, the workers are started by the caller
+func SetupRouter(
+	log *zerolog.Logger,
+	cfg *config.Config,
+	redisFactory *redisfactory.Factory,
+	tracker *lifecycle.Tracker,
+) (router *gin.Engine, adminRouter *gin.Engine, workers Workers, err error) {
+	startTime := time.Now()
+
+	openApiContent, _ := os.ReadFile(cfg.Server.OpenApiLocation)
+
+	responsesCacheClient, err := redisFactory.ResponsesCacheClient()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	responsesCache, err := caching.NewFromOptions(cfg.Cache.Options(), responsesCacheClient, func() bool {
+		return redisFactory.Available(redisfactory.ResponsesCache)
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	credentialStore, err := credentialStore(cfg, redisFactory)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	historyStore, err := historyStore(cfg, redisFactory)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	auditLog, err := auditLog(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	rebookStore, err := rebookStore(cfg, redisFactory)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	platformFactory := factory.NewFactory(cfg, redisFactory, responsesCache)
+
+	statusTracker, err := statusTracker(cfg, redisFactory, platformFactory, credentialStore)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if statusTracker != nil {
+		workers = append(workers, func(ctx context.Context) {
+			statusTracker.Run(ctx, tracker, log)
+		})
+	}
+
+	cancelRetryStore, err := cancelRetryStore(cfg, redisFactory)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	if openApiLocation == "" {
-		openApiLocation = "./api/openapi.json"
+	cancelRetrier := cancelRetrier(cfg, cancelRetryStore, platformFactory, credentialStore, historyStore)
+	if cancelRetrier != nil {
+		workers = append(workers, func(ctx context.Context) {
+			cancelRetrier.Run(ctx, tracker, log)
+		})
 	}
 
-	openApiContent, _ := os.ReadFile(openApiLocation)
+	checker, err := healthChecker(cfg, redisFactory, tracker)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	authentication, err := Authentication(cfg.Auth)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	router := gin.New()
+	var limiter *quota.Limiter
+	if cfg.Quota.Enabled {
+		limiter = quota.New(cfg.Quota)
+	}
 
-	if os.Getenv("ENV") == "production" {
+	if cfg.Production() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	router = gin.New()
+
 	router.
 		Use(StartRequest).
 		Use(CorrelationId).
 		Use(RegisterLogger(log)).
 		Use(TraceLog).
 		Use(PanicRecovery).
-		Use(OpenapiValidator())
+		Use(authentication).
+		Use(Quota(limiter, platformFactory)).
+		Use(Lifecycle(tracker)).
+		Use(ResolveConfiguration(credentialStore)).
+		Use(OpenapiValidator(cfg.Remote.Names())).
+		Use(SupplierHistory(historyStore, schema.HistoryMode(cfg.History.DefaultMode))).
+		Use(AuditLog(auditLog))
+
+	if !cfg.Production() {
+		router.Use(InjectFaults(cfg.Faults))
+	}
+
+	if cfg.Test {
+		router.Use(OmitTimings)
+	}
 
 	router.GET("/status", func(c *gin.Context) {
 		response := struct {
@@ -49,18 +153,61 @@ func SetupRouter(log *zerolog.Logger, redisFactory *redisfactory.Factory) *gin.E
 		c.JSON(http.StatusOK, response)
 	})
 
+	health.RegisterRoutes(router, checker)
+
+	router.GET("/history/:id", getHistory(historyStore))
+
 	router.GET("/openapi.json", func(c *gin.Context) {
 		c.Header("Content-Type", "application/json")
 		c.String(http.StatusOK, string(openApiContent))
 	})
 
-	pprof.Register(router)
+	// the admin listener is expected to be reachable from the internal network
+	// only, on the public listener pprof and metrics need the admin api key
+	// like the admin routes, also while auth is disabled
+	adminRoutes := router
+	debugRoutes := router.Group("", admin.Authenticate(cfg.Admin.ApiKey))
+	if cfg.Server.AdminPort != 0 {
+		adminRouter = gin.New()
+
+		adminRouter.
+			Use(StartRequest).
+			Use(CorrelationId).
+			Use(RegisterLogger(log)).
+			Use(TraceLog).
+			Use(PanicRecovery)
+
+		adminRoutes = adminRouter
+		debugRoutes = &adminRouter.RouterGroup
+	}
+
+	pprof.RouteRegister(debugRoutes)
 
-	platform.RegisterRoutes(
+	if limiter != nil {
+		debugRoutes.GET("/metrics", QuotaMetrics(limiter))
+	}
+
+	admin.RegisterRoutes(adminRoutes, admin.Options{
+		ApiKey:       cfg.Admin.ApiKey,
+		RedisFactory: redisFactory,
+		Credentials:  credentialStore,
+		Rebook:       rebookStore,
+		CancelRetry:  cancelRetryStore,
+		Audit:        auditLog,
+	})
+
+	err = platform.RegisterRoutes(
 		router,
-		factory.NewFactory(redisFactory),
+		platformFactory,
 		redisFactory,
+		rebooker(rebookStore),
+		statusTracker,
+		cancelRetrier,
+		cfg.Test || cfg.Trafficlight.ExposeRole,
 	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	return router
+	return router, adminRouter, workers, nil
 }